@@ -22,6 +22,7 @@ import (
 	cty "github.com/33cn/chain33/system/dapp/coins/types"
 	"github.com/33cn/chain33/types"
 	ttypes "github.com/33cn/plugin/plugin/consensus/dpos/types"
+	"github.com/33cn/plugin/plugin/consensus/signer"
 
 	dty "github.com/33cn/plugin/plugin/dapp/dposvote/types"
 	"github.com/golang/protobuf/proto"
@@ -55,6 +56,9 @@ var (
 	blockNumToUpdateDelegate int64 = 20000
 	registTopNHeightLimit    int64 = 100
 	updateTopNHeightLimit    int64 = 200
+	remoteSigner             string
+	signerKey                = "validator"
+	signerTLS                *signer.TLSConfig
 )
 
 func init() {
@@ -98,6 +102,11 @@ type subConfig struct {
 	BlockNumToUpdateDelegate  int64    `json:"blockNumToUpdateDelegate"`
 	RegistTopNHeightLimit     int64    `json:"registTopNHeightLimit"`
 	UpdateTopNHeightLimit     int64    `json:"updateTopNHeightLimit"`
+	RemoteSigner              string   `json:"remoteSigner"`
+	SignerKey                 string   `json:"signerKey"`
+	SignerTLSCert             string   `json:"signerTLSCert"`
+	SignerTLSKey              string   `json:"signerTLSKey"`
+	SignerTLSCA               string   `json:"signerTLSCA"`
 }
 
 func (client *Client) applyConfig(sub []byte) {
@@ -175,6 +184,14 @@ func (client *Client) applyConfig(sub []byte) {
 	if subcfg.UpdateTopNHeightLimit > 0 {
 		updateTopNHeightLimit = subcfg.UpdateTopNHeightLimit
 	}
+
+	remoteSigner = subcfg.RemoteSigner
+	if subcfg.SignerKey != "" {
+		signerKey = subcfg.SignerKey
+	}
+	if subcfg.SignerTLSCert != "" {
+		signerTLS = &signer.TLSConfig{CertFile: subcfg.SignerTLSCert, KeyFile: subcfg.SignerTLSKey, CAFile: subcfg.SignerTLSCA}
+	}
 }
 
 // New ...
@@ -212,30 +229,41 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 		return nil
 	}
 
-	privValidator := ttypes.LoadOrGenPrivValidatorFS("./priv_validator.json")
-	if privValidator == nil {
-		dposlog.Error("NewDPosClient create priv_validator file failed")
-		//return nil
-	}
-
 	ttypes.InitMessageMap()
 
-	pubkey := privValidator.GetPubKey().KeyString()
 	c := drivers.NewBaseClient(cfg)
 	client := &Client{
-		BaseClient:    c,
-		genesisDoc:    genDoc,
-		privValidator: privValidator,
-		privKey:       priv,
-		pubKey:        pubkey,
-		crypto:        cr,
-		stopC:         make(chan struct{}, 1),
-		isDelegator:   false,
-		testFlag:      false,
+		BaseClient:  c,
+		genesisDoc:  genDoc,
+		privKey:     priv,
+		crypto:      cr,
+		stopC:       make(chan struct{}, 1),
+		isDelegator: false,
+		testFlag:    false,
 	}
 	c.SetChild(client)
 
 	client.applyConfig(sub)
+
+	//配置了remoteSigner时使用远程签名服务, 否则使用本地的priv_validator.json
+	var privValidator ttypes.PrivValidator
+	if remoteSigner != "" {
+		dposlog.Info("NewDPosClient use remote signer", "addr", remoteSigner, "key", signerKey)
+		privValidator, err = ttypes.NewRemotePrivValidator(remoteSigner, signerKey, signerTLS)
+		if err != nil {
+			dposlog.Error("NewDPosClient connect remote signer failed", "err", err)
+			return nil
+		}
+	} else {
+		privValidatorFS := ttypes.LoadOrGenPrivValidatorFS("./priv_validator.json")
+		if privValidatorFS == nil {
+			dposlog.Error("NewDPosClient create priv_validator file failed")
+			//return nil
+		}
+		privValidator = privValidatorFS
+	}
+	client.privValidator = privValidator
+	client.pubKey = privValidator.GetPubKey().KeyString()
 	return client
 }
 
@@ -208,6 +208,10 @@ func checkVrf(cs *ConsensusState) {
 		info := cs.GetVrfInfoByCircle(task.Cycle, VrfQueryTypeRP)
 		if info != nil && len(info.M) > 0 && (len(info.R) == 0 || len(info.P) == 0) {
 			hash, proof := cs.VrfEvaluate(info.M)
+			if len(proof) == 0 {
+				dposlog.Error("VrfEvaluate failed", "cycle", task.Cycle)
+				return
+			}
 
 			vrfRP := &dty.DposVrfRPRegist{
 				Pubkey: strings.ToUpper(hex.EncodeToString(cs.privValidator.GetPubKey().Bytes())),
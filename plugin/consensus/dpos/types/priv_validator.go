@@ -345,6 +345,10 @@ func (pv *PrivValidatorImp) VrfProof(pubkey []byte, input []byte, hash [32]byte,
 	pv.mtx.Lock()
 	defer pv.mtx.Unlock()
 
+	return vrfProof(pubkey, input, hash, proof)
+}
+
+func vrfProof(pubkey []byte, input []byte, hash [32]byte, proof []byte) bool {
 	pubKey, err := secp256k1.ParsePubKey(pubkey, secp256k1.S256())
 	if err != nil {
 		return false
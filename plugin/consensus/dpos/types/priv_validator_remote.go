@@ -0,0 +1,153 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/consensus/signer"
+)
+
+var remoteLog = log15.New("module", "dpos-remote-signer")
+
+// RemotePrivValidator implements PrivValidator using an external signer process,
+// the private key never leaves the signer.
+type RemotePrivValidator struct {
+	Address []byte
+	PubKey  crypto.PubKey
+
+	client *signer.Client
+	mtx    sync.Mutex
+}
+
+// NewRemotePrivValidator connects to the signer at addr and loads the pubkey of keyName,
+// tlsCfg is required when the signer listens on tcp.
+func NewRemotePrivValidator(addr, keyName string, tlsCfg *signer.TLSConfig) (*RemotePrivValidator, error) {
+	client, err := signer.NewClient(addr, keyName, tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+	_, pub, err := client.GetPubKey()
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	pubKey, err := ConsensusCrypto.PubKeyFromBytes(pub)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return &RemotePrivValidator{
+		Address: address.PubKeyToAddress(pubKey.Bytes()).Hash160[:],
+		PubKey:  pubKey,
+		client:  client,
+	}, nil
+}
+
+// GetAddress returns the address of the validator.
+// Implements PrivValidator.
+func (pv *RemotePrivValidator) GetAddress() []byte {
+	return pv.Address
+}
+
+// GetPubKey returns the public key of the validator.
+// Implements PrivValidator.
+func (pv *RemotePrivValidator) GetPubKey() crypto.PubKey {
+	return pv.PubKey
+}
+
+// 签名服务按照投票的周期检查重复签名, 其他消息不能是投票和通知
+func (pv *RemotePrivValidator) sign(msg []byte, msgType int32) (crypto.Signature, error) {
+	sigBytes, err := pv.client.Sign(msg, msgType)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := ConsensusCrypto.SignatureFromBytes(sigBytes)
+	if err != nil {
+		return nil, err
+	}
+	if !pv.PubKey.VerifyBytes(msg, sig) {
+		return nil, errors.New("remote signer returned an invalid signature")
+	}
+	return sig, nil
+}
+
+// SignVote signs a canonical representation of the vote, along with the
+// chainID. Implements PrivValidator.
+func (pv *RemotePrivValidator) SignVote(chainID string, vote *Vote) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	signature, err := pv.sign(SignBytes(chainID, vote), signer.MsgDposVote)
+	if err != nil {
+		return errors.New(Fmt("Error signing vote: %v", err))
+	}
+	vote.Signature = signature.Bytes()
+	return nil
+}
+
+// SignNotify signs a canonical representation of the notify, along with the
+// chainID. Implements PrivValidator.
+func (pv *RemotePrivValidator) SignNotify(chainID string, notify *Notify) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	signature, err := pv.sign(SignBytes(chainID, notify), signer.MsgDposNotify)
+	if err != nil {
+		return errors.New(Fmt("Error signing notify: %v", err))
+	}
+	notify.Signature = signature.Bytes()
+	return nil
+}
+
+// SignMsg signs a msg.
+func (pv *RemotePrivValidator) SignMsg(msg []byte) (sig crypto.Signature, err error) {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	return pv.sign(msg, signer.MsgOther)
+}
+
+// SignTx signs a tx the same way as types.Transaction.Sign, Implements PrivValidator.
+func (pv *RemotePrivValidator) SignTx(tx *types.Transaction) {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	tx.Signature = nil
+	sig, err := pv.sign(types.Encode(tx), signer.MsgOther)
+	if err != nil {
+		remoteLog.Error("SignTx failed", "err", err)
+		return
+	}
+	tx.Signature = &types.Signature{
+		Ty:        types.SECP256K1,
+		Pubkey:    pv.PubKey.Bytes(),
+		Signature: sig.Bytes(),
+	}
+}
+
+// VrfEvaluate use input to generate hash & proof, the proof is empty if the signer fails.
+func (pv *RemotePrivValidator) VrfEvaluate(input []byte) (hash [32]byte, proof []byte) {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	vrfHash, vrfProofBytes, err := pv.client.VrfEvaluate(input)
+	if err != nil || len(vrfHash) != len(hash) {
+		remoteLog.Error("VrfEvaluate failed", "err", err)
+		return hash, nil
+	}
+	copy(hash[:], vrfHash)
+	return hash, vrfProofBytes
+}
+
+// VrfProof check the vrf.
+func (pv *RemotePrivValidator) VrfProof(pubkey []byte, input []byte, hash [32]byte, proof []byte) bool {
+	return vrfProof(pubkey, input, hash, proof)
+}
+
+// String returns a string representation of the RemotePrivValidator.
+func (pv *RemotePrivValidator) String() string {
+	return Fmt("RemotePrivValidator{%v}", pv.GetAddress())
+}
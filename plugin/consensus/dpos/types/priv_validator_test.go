@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"testing"
@@ -15,6 +17,7 @@ import (
 	"github.com/33cn/chain33/common/address"
 	"github.com/33cn/chain33/common/crypto"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/consensus/signer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -346,3 +349,61 @@ func testOneKey(priv, pub, addr string, t *testing.T) {
 	fmt.Println(fmt.Sprintf("%x", bAddr))
 	assert.True(t, addr == fmt.Sprintf("%X", bAddr))
 }
+
+func TestRemotePrivValidator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dpossigner")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ks := signer.NewKeystore(dir)
+	priv, err := hex.DecodeString("5A6A14DA6F5A42835E529D75D87CC8904544F59EEE5387A37D87EEAD194D7EB2")
+	require.Nil(t, err)
+	require.Nil(t, ks.ImportKey("validator", types.GetSignName("", types.SECP256K1), priv, []byte("123456")))
+	server, err := signer.NewServer(ks, []byte("123456"), nil)
+	require.Nil(t, err)
+	addr := "unix://" + filepath.Join(dir, "signer.sock")
+	lis, err := server.Listen(addr)
+	require.Nil(t, err)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	privValidator, err := NewRemotePrivValidator(addr, "validator", nil)
+	require.Nil(t, err)
+	assert.Equal(t, strAddr, strings.ToUpper(hex.EncodeToString(privValidator.GetAddress())))
+	assert.Equal(t, strPubkey, strings.ToUpper(hex.EncodeToString(privValidator.GetPubKey().Bytes())))
+
+	sig, err := privValidator.SignMsg([]byte("asdfadsasf"))
+	require.Nil(t, err)
+	assert.True(t, privValidator.GetPubKey().VerifyBytes([]byte("asdfadsasf"), sig))
+
+	tx := &types.Transaction{Execer: []byte("dpos")}
+	privValidator.SignTx(tx)
+	require.NotNil(t, tx.Signature)
+	assert.True(t, types.SECP256K1 == tx.Signature.Ty)
+	assert.True(t, bytes.Equal(privValidator.PubKey.Bytes(), tx.Signature.Pubkey))
+
+	input := []byte("abcdefghijklmn")
+	hash, proof := privValidator.VrfEvaluate(input)
+	assert.True(t, 0 < len(proof))
+	assert.True(t, privValidator.VrfProof(privValidator.PubKey.Bytes(), input, hash, proof))
+
+	//同一个周期内只能投给同一个节点, 投票时间不同时可以重新签名
+	newVote := func(votedIndex int32, timestamp int64) *Vote {
+		return &Vote{DPosVote: &DPosVote{
+			VoteItem:         &VoteItem{VotedNodeIndex: votedIndex, PeriodStart: 20000, PeriodStop: 21000, Height: 100},
+			VoteTimestamp:    timestamp,
+			VoterNodeAddress: privValidator.GetAddress(),
+		}}
+	}
+	vote := newVote(0, 1)
+	require.Nil(t, privValidator.SignVote("test", vote))
+	require.Nil(t, vote.Verify("test", privValidator.PubKey))
+	require.Nil(t, privValidator.SignVote("test", newVote(0, 2)))
+	assert.NotNil(t, privValidator.SignVote("test", newVote(1, 3)))
+	notify := &Notify{DPosNotify: &DPosNotify{Vote: vote.VoteItem, HeightStop: 110, NotifyTimestamp: 4, NotifyNodeAddress: privValidator.GetAddress()}}
+	require.Nil(t, privValidator.SignNotify("test", notify))
+	require.Nil(t, notify.Verify("test", privValidator.PubKey))
+	//投票和通知不能作为普通消息签名
+	_, err = privValidator.SignMsg(SignBytes("test", newVote(1, 5)))
+	assert.NotNil(t, err)
+}
@@ -17,6 +17,7 @@ import (
 	drivers "github.com/33cn/chain33/system/consensus"
 	cty "github.com/33cn/chain33/system/dapp/coins/types"
 	"github.com/33cn/chain33/types"
+	"github.com/coreos/etcd/raft/raftpb"
 	"github.com/coreos/etcd/snap"
 )
 
@@ -38,6 +39,10 @@ type Client struct {
 	once        sync.Once
 	blockInfo   *BlockInfo
 	mtx         sync.Mutex
+
+	confChangeC  chan<- raftpb.ConfChange
+	confAppliedC <-chan struct{}
+	confApplied  func() confChangePos
 }
 
 // NewBlockstore create Raft Client
@@ -102,6 +107,7 @@ func (client *Client) SetQueueClient(c queue.Client) {
 	go client.EventLoop()
 	go client.readCommits(client.commitC, client.errorC)
 	go client.pollingTask()
+	go client.confChangeRoutine()
 }
 
 // Close method
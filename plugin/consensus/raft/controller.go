@@ -42,6 +42,7 @@ type subConfig struct {
 	WriteBlockSeconds  int64  `json:"writeBlockSeconds"`
 	HeartbeatTick      int32  `json:"heartbeatTick"`
 	EmptyBlockInterval int64  `json:"emptyBlockInterval"`
+	EnableRaftAPI      bool   `json:"enableRaftAPI"`
 }
 
 func init() {
@@ -106,10 +107,16 @@ func NewRaftCluster(cfg *types.Consensus, sub []byte) queue.Module {
 	proposeC := make(chan BlockInfo)
 	confChangeC = make(chan raftpb.ConfChange)
 	node, commitC, errorC, snapshotterReady, validatorC := NewRaftNode(ctx, int(subcfg.NodeID), subcfg.IsNewJoinNode, peers, readOnlyPeers, addPeers, getSnapshot, proposeC, confChangeC)
-	//启动raft删除节点操作监听
-	go serveHTTPRaftAPI(ctx, int(subcfg.RaftAPIPort), confChangeC, errorC)
+	//启动raft删除节点操作监听, 该接口没有权限控制, 成员变更应通过raftnode交易提交
+	if subcfg.EnableRaftAPI {
+		rlog.Warn("The raft http api is unauthenticated, use raftnode transactions to change membership instead")
+		go serveHTTPRaftAPI(ctx, int(subcfg.RaftAPIPort), confChangeC, errorC)
+	}
 	// 监听commit channel,取block
 	b = NewBlockstore(ctx, cfg, <-snapshotterReady, proposeC, commitC, errorC, validatorC, stop)
+	b.confChangeC = confChangeC
+	b.confAppliedC = node.confAppliedC
+	b.confApplied = node.ConfApplied
 	node.SetClient(b)
 	return b
 }
@@ -0,0 +1,210 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package raft
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/33cn/chain33/types"
+	rt "github.com/33cn/plugin/plugin/dapp/raftnode/types"
+	"github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	// 等待成员变更被raft执行的超时时间, 一次只能执行一个成员变更
+	confChangeTimeout = 10 * time.Second
+	// 每次扫描的成员变更记录数量
+	confChangeScanCount int32 = 20
+	zeroHash            [32]byte
+)
+
+// confChangePos 成员变更记录在链上的位置, 按照区块高度和交易序号排序
+type confChangePos struct {
+	Height int64 `json:"height"`
+	Index  int32 `json:"index"`
+}
+
+func (pos confChangePos) after(other confChangePos) bool {
+	return pos.Height > other.Height || (pos.Height == other.Height && pos.Index > other.Index)
+}
+
+// confChangeContext 通过raft提交的成员变更附带链上记录的位置,
+// 每个节点执行时记录已经执行的位置, 新的leader和重启的节点从该位置之后继续执行
+type confChangeContext struct {
+	URL string `json:"url,omitempty"`
+	confChangePos
+}
+
+// parseConfChangeContext 解析成员变更的附加信息, 配置文件和http接口提交的成员变更只有地址
+func parseConfChangeContext(data []byte) (url string, pos *confChangePos) {
+	if len(data) == 0 || data[0] != '{' {
+		return string(data), nil
+	}
+	var ctx confChangeContext
+	if err := json.Unmarshal(data, &ctx); err != nil {
+		return string(data), nil
+	}
+	return ctx.URL, &ctx.confChangePos
+}
+
+// toConfChange 把链上的成员变更转换成raft的ConfChange, 提升learner就是以voter身份再次添加
+func toConfChange(record *rt.RaftConfChangeRecord) (raftpb.ConfChange, bool) {
+	cc := record.GetConfChange()
+	ctx := confChangeContext{confChangePos: confChangePos{Height: record.GetHeight(), Index: record.GetIndex()}}
+	var ty raftpb.ConfChangeType
+	switch cc.GetType() {
+	case rt.RaftConfChangeAddNode:
+		ty, ctx.URL = raftpb.ConfChangeAddNode, cc.GetUrl()
+	case rt.RaftConfChangeAddLearnerNode:
+		ty, ctx.URL = raftpb.ConfChangeAddLearnerNode, cc.GetUrl()
+	case rt.RaftConfChangePromoteLearner:
+		ty = raftpb.ConfChangeAddNode
+	case rt.RaftConfChangeRemoveNode:
+		ty = raftpb.ConfChangeRemoveNode
+	default:
+		return raftpb.ConfChange{}, false
+	}
+	data, err := json.Marshal(&ctx)
+	if err != nil {
+		return raftpb.ConfChange{}, false
+	}
+	return raftpb.ConfChange{Type: ty, NodeID: cc.GetNodeID(), Context: data}, true
+}
+
+// QueryConfChangeHistory 查询pos之后由管理员提交的成员变更
+func (client *Client) QueryConfChangeHistory(pos confChangePos) (*rt.RaftConfChangeRecords, error) {
+	req := &rt.ReqRaftConfChangeHistory{Height: pos.Height, Index: pos.Index, Count: confChangeScanCount, Direction: 1}
+	param, err := proto.Marshal(req)
+	if err != nil {
+		rlog.Error("QueryConfChangeHistory marshal", "err", err)
+		return nil, types.ErrInvalidParam
+	}
+	msg := client.GetQueueClient().NewMessage("execs", types.EventBlockChainQuery,
+		&types.ChainExecutor{Driver: rt.RaftNodeX, FuncName: "GetConfChangeHistory", StateHash: zeroHash[:], Param: param})
+	err = client.GetQueueClient().Send(msg, true)
+	if err != nil {
+		rlog.Error("QueryConfChangeHistory send", "err", err)
+		return nil, err
+	}
+	msg, err = client.GetQueueClient().Wait(msg)
+	if err != nil {
+		return nil, err
+	}
+	return msg.GetData().(types.Message).(*rt.RaftConfChangeRecords), nil
+}
+
+// confChangeRoutine leader定时扫描已经执行的位置之后的成员变更, 依次提交给raft执行
+func (client *Client) confChangeRoutine() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-client.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if mux.Load().(bool) {
+			client.applyConfChanges()
+		}
+	}
+}
+
+// applyConfChanges 每次等待上一个成员变更被raft执行, 超时或者不再是leader时等待下一次扫描
+func (client *Client) applyConfChanges() {
+	records, err := client.QueryConfChangeHistory(client.confApplied())
+	if err != nil {
+		if err != types.ErrNotFound {
+			rlog.Error("applyConfChanges QueryConfChangeHistory fail", "err", err)
+		}
+		return
+	}
+	for _, record := range records.GetRecords() {
+		if !mux.Load().(bool) {
+			rlog.Info("Not the Leader node anymore, stop applying conf change")
+			return
+		}
+		cc, ok := toConfChange(record)
+		if !ok {
+			rlog.Error("Unknown conf change type", "txHash", record.GetTxHash(), "type", record.GetConfChange().GetType())
+			continue
+		}
+		rlog.Info("Apply conf change", "type", rt.ConfChangeNames[record.GetConfChange().GetType()], "nodeID", cc.NodeID,
+			"operator", record.GetOperator(), "height", record.GetHeight(), "txHash", record.GetTxHash())
+		//清除之前的执行通知
+		select {
+		case <-client.confAppliedC:
+		default:
+		}
+		select {
+		case client.confChangeC <- cc:
+		case <-client.ctx.Done():
+			return
+		}
+		select {
+		case <-client.confAppliedC:
+		case <-time.After(confChangeTimeout):
+			rlog.Error("Wait conf change applied timeout", "txHash", record.GetTxHash())
+			return
+		case <-client.ctx.Done():
+			return
+		}
+	}
+}
+
+// ConfApplied 返回已经执行的最后一个链上成员变更的位置
+func (rc *raftNode) ConfApplied() confChangePos {
+	rc.confMtx.Lock()
+	defer rc.confMtx.Unlock()
+	return rc.confApplied
+}
+
+func (rc *raftNode) loadConfApplied() {
+	data, err := ioutil.ReadFile(rc.confFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			rlog.Error("loadConfApplied read fail", "file", rc.confFile, "err", err)
+		}
+		return
+	}
+	rc.confMtx.Lock()
+	defer rc.confMtx.Unlock()
+	if err := json.Unmarshal(data, &rc.confApplied); err != nil {
+		rlog.Error("loadConfApplied unmarshal fail", "file", rc.confFile, "err", err)
+	}
+}
+
+// checkConfChange 在raft提交成员变更之后调用, 记录链上成员变更的执行位置,
+// 已经执行过的链上成员变更(超时后重复提交或者leader切换)被取消, 返回false
+func (rc *raftNode) checkConfChange(cc *raftpb.ConfChange) bool {
+	url, pos := parseConfChangeContext(cc.Context)
+	cc.Context = []byte(url)
+	if pos == nil {
+		return true
+	}
+	rc.confMtx.Lock()
+	defer rc.confMtx.Unlock()
+	if !pos.after(rc.confApplied) {
+		rlog.Info("Cancel applied conf change", "nodeID", cc.NodeID, "height", pos.Height, "index", pos.Index)
+		cc.NodeID = raft.None
+		return false
+	}
+	rc.confApplied = *pos
+	data, err := json.Marshal(pos)
+	if err == nil {
+		tmp := rc.confFile + ".tmp"
+		if err = ioutil.WriteFile(tmp, data, 0600); err == nil {
+			err = os.Rename(tmp, rc.confFile)
+		}
+	}
+	if err != nil {
+		rlog.Error("checkConfChange save applied position fail", "file", rc.confFile, "err", err)
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package raft
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/33cn/chain33/queue"
+	drivers "github.com/33cn/chain33/system/consensus"
+	"github.com/33cn/chain33/types"
+	rt "github.com/33cn/plugin/plugin/dapp/raftnode/types"
+	"github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveConfChangeHistory 模拟raftnode执行器按照位置查询成员变更记录
+func serveConfChangeHistory(q queue.Queue, records []*rt.RaftConfChangeRecord) {
+	cli := q.Client()
+	cli.Sub("execs")
+	for msg := range cli.Recv() {
+		var req rt.ReqRaftConfChangeHistory
+		if err := types.Decode(msg.GetData().(*types.ChainExecutor).Param, &req); err != nil {
+			msg.Reply(cli.NewMessage("", types.EventBlockChainQuery, err))
+			continue
+		}
+		pos := confChangePos{Height: req.Height, Index: req.Index}
+		reply := &rt.RaftConfChangeRecords{}
+		for _, record := range records {
+			if (confChangePos{Height: record.Height, Index: record.Index}).after(pos) {
+				reply.Records = append(reply.Records, record)
+			}
+		}
+		if len(reply.Records) == 0 {
+			msg.Reply(cli.NewMessage("", types.EventBlockChainQuery, types.ErrNotFound))
+			continue
+		}
+		msg.Reply(cli.NewMessage("", types.EventBlockChainQuery, reply))
+	}
+}
+
+// newTestConfClient 创建只用于执行成员变更的client, raft提交成员变更的过程由commit模拟
+func newTestConfClient(ctx context.Context, q queue.Queue, rc *raftNode, commit func(raftpb.ConfChange)) *Client {
+	confChangeC := make(chan raftpb.ConfChange)
+	client := &Client{
+		BaseClient:   drivers.NewBaseClient(&types.Consensus{Name: "raft"}),
+		ctx:          ctx,
+		confChangeC:  confChangeC,
+		confAppliedC: rc.confAppliedC,
+		confApplied:  rc.ConfApplied,
+	}
+	client.InitClient(q.Client(), func() {})
+	go func() {
+		for {
+			select {
+			case cc := <-confChangeC:
+				commit(cc)
+				rc.confAppliedC <- struct{}{}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return client
+}
+
+func TestConfChangeLeaderSwitch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "raftconf")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	go q.Start()
+	defer q.Close()
+	records := []*rt.RaftConfChangeRecord{
+		{ConfChange: &rt.RaftConfChange{Type: rt.RaftConfChangeAddNode, NodeID: 4, Url: "http://127.0.0.1:9024"}, Height: 5, Index: 1},
+		{ConfChange: &rt.RaftConfChange{Type: rt.RaftConfChangeRemoveNode, NodeID: 2}, Height: 7},
+	}
+	go serveConfChangeHistory(q, records)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	defer mux.Store(false)
+	confFile := filepath.Join(dir, "confchange")
+
+	//旧leader提交第一个成员变更之后失去leader身份, 第二个成员变更没有执行
+	var committed []raftpb.ConfChange
+	rcA := &raftNode{confFile: confFile, confAppliedC: make(chan struct{}, 1)}
+	clientA := newTestConfClient(ctx, q, rcA, func(cc raftpb.ConfChange) {
+		require.True(t, rcA.checkConfChange(&cc))
+		committed = append(committed, cc)
+		mux.Store(false)
+	})
+	mux.Store(true)
+	clientA.applyConfChanges()
+	require.Equal(t, 1, len(committed))
+	assert.Equal(t, raftpb.ConfChangeAddNode, committed[0].Type)
+	assert.Equal(t, "http://127.0.0.1:9024", string(committed[0].Context))
+	assert.Equal(t, confChangePos{Height: 5, Index: 1}, rcA.ConfApplied())
+
+	//新leader重启后从保存的位置继续执行
+	rcB := &raftNode{confFile: confFile, confAppliedC: make(chan struct{}, 1)}
+	rcB.loadConfApplied()
+	assert.Equal(t, confChangePos{Height: 5, Index: 1}, rcB.ConfApplied())
+	var stale raftpb.ConfChange
+	clientB := newTestConfClient(ctx, q, rcB, func(cc raftpb.ConfChange) {
+		stale = cc
+		require.True(t, rcB.checkConfChange(&cc))
+		committed = append(committed, cc)
+	})
+	mux.Store(true)
+	clientB.applyConfChanges()
+	require.Equal(t, 2, len(committed))
+	assert.Equal(t, raftpb.ConfChangeRemoveNode, committed[1].Type)
+	assert.Equal(t, uint64(2), committed[1].NodeID)
+	assert.Equal(t, confChangePos{Height: 7}, rcB.ConfApplied())
+
+	//已经执行过的成员变更再次提交时被取消
+	assert.False(t, rcB.checkConfChange(&stale))
+	assert.Equal(t, uint64(raft.None), stale.NodeID)
+	clientB.applyConfChanges()
+	assert.Equal(t, 2, len(committed))
+
+	//配置文件和http接口提交的成员变更不受影响
+	cc := raftpb.ConfChange{Type: raftpb.ConfChangeAddLearnerNode, NodeID: 5, Context: []byte("http://127.0.0.1:9025")}
+	assert.True(t, rcB.checkConfChange(&cc))
+	assert.Equal(t, "http://127.0.0.1:9025", string(cc.Context))
+}
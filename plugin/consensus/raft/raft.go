@@ -63,6 +63,12 @@ type raftNode struct {
 	validatorC chan bool
 	//用于判断该节点是否重启过
 	restartC chan struct{}
+	//成员变更被执行的通知
+	confAppliedC chan struct{}
+	//已经执行的链上成员变更的位置, 保存在confFile中
+	confFile    string
+	confMtx     sync.Mutex
+	confApplied confChangePos
 }
 
 type Node struct {
@@ -98,6 +104,8 @@ func NewRaftNode(ctx context.Context, id int, join bool, peers []string, readOnl
 		validatorC:       make(chan bool),
 		snapshotterReady: make(chan *snap.Snapshotter, 1),
 		restartC:         make(chan struct{}, 1),
+		confAppliedC:     make(chan struct{}, 1),
+		confFile:         fmt.Sprintf("chain33_raft-%d%sconfchange", id, string(os.PathSeparator)),
 		ctx:              ctx,
 	}
 	go rc.startRaft()
@@ -117,6 +125,7 @@ func (rc *raftNode) startRaft() {
 
 	rc.snapshotter = snap.New(rc.snapdir)
 	rc.snapshotterReady <- rc.snapshotter
+	rc.loadConfApplied()
 
 	oldwal := wal.Exist(rc.waldir)
 	rc.wal = rc.replayWAL()
@@ -531,23 +540,34 @@ func (rc *raftNode) publishEntries(ents []raftpb.Entry) bool {
 		case raftpb.EntryConfChange:
 			var cc raftpb.ConfChange
 			cc.Unmarshal(ents[i].Data)
+			//重复提交的链上成员变更被取消, 只更新raft的ConfState
+			apply := rc.checkConfChange(&cc)
 			rc.confState = *rc.node.ApplyConfChange(cc)
-			switch cc.Type {
-			case raftpb.ConfChangeAddNode:
-				if len(cc.Context) > 0 {
-					rc.transport.AddPeer(typec.ID(cc.NodeID), []string{string(cc.Context)})
-				}
-			case raftpb.ConfChangeRemoveNode:
-				if cc.NodeID == uint64(rc.id) {
-					rlog.Info("I've been removed from the cluster! Shutting down.")
-					return false
-				}
-				rc.transport.RemovePeer(typec.ID(cc.NodeID))
-			case raftpb.ConfChangeAddLearnerNode:
-				if len(cc.Context) > 0 {
-					rc.transport.AddPeer(typec.ID(cc.NodeID), []string{string(cc.Context)})
+			if apply {
+				switch cc.Type {
+				case raftpb.ConfChangeAddNode:
+					if len(cc.Context) > 0 {
+						rc.transport.AddPeer(typec.ID(cc.NodeID), []string{string(cc.Context)})
+					}
+				case raftpb.ConfChangeRemoveNode:
+					if cc.NodeID == uint64(rc.id) {
+						rlog.Info("I've been removed from the cluster! Shutting down.")
+						return false
+					}
+					//移除不存在的节点时transport会panic
+					if rc.transport.Get(typec.ID(cc.NodeID)) != nil {
+						rc.transport.RemovePeer(typec.ID(cc.NodeID))
+					}
+				case raftpb.ConfChangeAddLearnerNode:
+					if len(cc.Context) > 0 {
+						rc.transport.AddPeer(typec.ID(cc.NodeID), []string{string(cc.Context)})
+					}
+					isReady = true
 				}
-				isReady = true
+			}
+			select {
+			case rc.confAppliedC <- struct{}{}:
+			default:
 			}
 
 		}
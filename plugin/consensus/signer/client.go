@@ -0,0 +1,98 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	stypes "github.com/33cn/plugin/plugin/consensus/signer/types"
+	"google.golang.org/grpc"
+)
+
+const requestTimeout = 3 * time.Second
+
+// Client 远程签名服务的客户端, 一个客户端对应签名服务中的一个私钥
+type Client struct {
+	conn    *grpc.ClientConn
+	signer  stypes.SignerClient
+	keyName string
+}
+
+// NewClient 连接签名服务, addr的格式和Listen相同,
+// tcp地址必须配置tlsCfg, unix socket通过文件权限限制访问, 也可以使用tls
+func NewClient(addr, keyName string, tlsCfg *TLSConfig) (*Client, error) {
+	var opts []grpc.DialOption
+	isUnix := strings.HasPrefix(addr, unixPrefix)
+	if isUnix {
+		path := strings.TrimPrefix(addr, unixPrefix)
+		opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		}))
+	}
+	switch {
+	case tlsCfg != nil:
+		host, _, _ := net.SplitHostPort(addr)
+		creds, err := tlsCfg.clientCredentials(host)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	case isUnix:
+		opts = append(opts, grpc.WithInsecure())
+	default:
+		return nil, ErrInsecureListen
+	}
+	conn, err := grpc.Dial(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, signer: stypes.NewSignerClient(conn), keyName: keyName}, nil
+}
+
+// GetPubKey 返回私钥的签名类型和公钥
+func (c *Client) GetPubKey() (string, []byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	reply, err := c.signer.GetPubKey(ctx, &stypes.ReqSignerPubKey{KeyName: c.keyName})
+	if err != nil {
+		return "", nil, err
+	}
+	return reply.GetKeyType(), reply.GetPubKey(), nil
+}
+
+// Sign 签名, msgType为签名内容的类型, 共识消息由签名服务做重复签名检查
+func (c *Client) Sign(signBytes []byte, msgType int32) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	reply, err := c.signer.Sign(ctx, &stypes.ReqSignerSign{
+		KeyName:   c.keyName,
+		SignBytes: signBytes,
+		MsgType:   msgType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetSignature(), nil
+}
+
+// VrfEvaluate 计算vrf的hash和proof
+func (c *Client) VrfEvaluate(input []byte) ([]byte, []byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	reply, err := c.signer.VrfEvaluate(ctx, &stypes.ReqSignerVrf{KeyName: c.keyName, Input: input})
+	if err != nil {
+		return nil, nil, err
+	}
+	return reply.GetHash(), reply.GetProof(), nil
+}
+
+// Close 关闭连接
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
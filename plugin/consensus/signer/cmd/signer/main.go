@@ -0,0 +1,184 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// signer 是tendermint和dpos验证者的参考签名服务, 私钥加密保存在keystore中,
+// 共识节点通过remoteSigner配置连接签名服务, 本地不需要保存明文私钥
+//
+//   生成私钥:  signer -keystore ./keystore -gen validator -type ed25519
+//   迁移私钥:  signer -keystore ./keystore -import validator -from priv_validator.json
+//   启动服务:  signer -keystore ./keystore -addr unix:///var/run/chain33-signer.sock
+//   tcp服务:   signer -keystore ./keystore -addr 10.0.0.2:8805 -tlscert signer.crt -tlskey signer.key -tlsca ca.crt
+//
+// 使用tcp时必须配置双向tls, 共识节点使用同一个CA签发的客户端证书连接
+//
+// 密码从环境变量CHAIN33_SIGNER_PASSWORD读取, 没有设置时从终端输入
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"syscall"
+
+	log "github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/plugin/plugin/consensus/signer"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const passwordEnv = "CHAIN33_SIGNER_PASSWORD"
+
+var (
+	slog         = log.New("module", "signer")
+	keystorePath = flag.String("keystore", "keystore", "keystore directory")
+	listenAddr   = flag.String("addr", "unix://chain33-signer.sock", "listen address, unix:///path/to/socket or ip:port, ip:port requires tls")
+	tlsCert      = flag.String("tlscert", "", "server certificate for mutual tls")
+	tlsKey       = flag.String("tlskey", "", "server private key for mutual tls")
+	tlsCA        = flag.String("tlsca", "", "CA certificate to verify the consensus node certificates")
+	genName      = flag.String("gen", "", "generate a new key with the name")
+	keyType      = flag.String("type", "ed25519", "key type of the generated key, ed25519 for tendermint, secp256k1 for dpos")
+	importName   = flag.String("import", "", "import the key from a priv_validator.json with the name")
+	importFrom   = flag.String("from", "priv_validator.json", "priv_validator.json to import")
+	list         = flag.Bool("list", false, "list keys in the keystore")
+)
+
+// privValidatorFS priv_validator.json中的私钥部分
+type privValidatorFS struct {
+	PrivKey struct {
+		Kind string `json:"type"`
+		Data string `json:"data"`
+	} `json:"priv_key"`
+}
+
+func main() {
+	flag.Parse()
+	ks := signer.NewKeystore(*keystorePath)
+	var err error
+	switch {
+	case *list:
+		err = listKeys(ks)
+	case *genName != "":
+		err = genKey(ks)
+	case *importName != "":
+		err = importKey(ks)
+	default:
+		err = serve(ks)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func listKeys(ks *signer.Keystore) error {
+	names, err := ks.List()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		fmt.Println(name)
+	}
+	return nil
+}
+
+func genKey(ks *signer.Keystore) error {
+	password, err := readNewPassword()
+	if err != nil {
+		return err
+	}
+	pub, err := ks.GenKey(*genName, *keyType, password)
+	if err != nil {
+		return err
+	}
+	fmt.Println("pubkey:", pub.KeyString())
+	return nil
+}
+
+func importKey(ks *signer.Keystore) error {
+	data, err := ioutil.ReadFile(*importFrom)
+	if err != nil {
+		return err
+	}
+	privVal := &privValidatorFS{}
+	if err := json.Unmarshal(data, privVal); err != nil {
+		return err
+	}
+	priv, err := hex.DecodeString(privVal.PrivKey.Data)
+	if err != nil {
+		return err
+	}
+	password, err := readNewPassword()
+	if err != nil {
+		return err
+	}
+	if err := ks.ImportKey(*importName, privVal.PrivKey.Kind, priv, password); err != nil {
+		return err
+	}
+	fmt.Printf("key imported, remove the plaintext %s from the consensus host\n", *importFrom)
+	return nil
+}
+
+func serve(ks *signer.Keystore) error {
+	password, err := readPassword("Password: ")
+	if err != nil {
+		return err
+	}
+	var tlsCfg *signer.TLSConfig
+	if *tlsCert != "" {
+		tlsCfg = &signer.TLSConfig{CertFile: *tlsCert, KeyFile: *tlsKey, CAFile: *tlsCA}
+	}
+	server, err := signer.NewServer(ks, password, tlsCfg)
+	if err != nil {
+		return err
+	}
+	lis, err := server.Listen(*listenAddr)
+	if err != nil {
+		return err
+	}
+	slog.Info("signer started", "addr", *listenAddr, "keystore", *keystorePath)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		s := <-interrupt
+		slog.Info("signer got signal", "signal", s)
+		server.Stop()
+	}()
+	return server.Serve(lis)
+}
+
+func readPassword(prompt string) ([]byte, error) {
+	if password := os.Getenv(passwordEnv); password != "" {
+		return []byte(password), nil
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	password, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	return password, err
+}
+
+func readNewPassword() ([]byte, error) {
+	password, err := readPassword("New password: ")
+	if err != nil {
+		return nil, err
+	}
+	if len(password) == 0 {
+		return nil, fmt.Errorf("password is empty")
+	}
+	if os.Getenv(passwordEnv) != "" {
+		return password, nil
+	}
+	confirm, err := readPassword("Repeat password: ")
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(password, confirm) {
+		return nil, fmt.Errorf("passwords do not match")
+	}
+	return password, nil
+}
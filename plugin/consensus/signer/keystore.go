@@ -0,0 +1,242 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/33cn/chain33/common/crypto"
+	_ "github.com/33cn/chain33/system/crypto/init" //注册签名类型
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	keyFileSuffix = ".key"
+	scryptKeyLen  = 32
+)
+
+// scrypt参数, 测试时可以调小
+var (
+	scryptN = 1 << 18
+	scryptR = 8
+	scryptP = 1
+)
+
+// keystore error defines
+var (
+	ErrKeyExists     = errors.New("ErrKeyExists")
+	ErrKeyNotFound   = errors.New("ErrKeyNotFound")
+	ErrWrongPassword = errors.New("ErrWrongPassword")
+	ErrInvalidName   = errors.New("ErrInvalidName")
+)
+
+// keyFile 加密保存的私钥文件, 私钥使用scrypt从密码派生的密钥做aes-gcm加密
+type keyFile struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	PubKey     string `json:"pub_key"`
+	Salt       string `json:"salt"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Nonce      string `json:"nonce"`
+	CipherText string `json:"cipher_text"`
+}
+
+// Keystore 验证者私钥的加密存储, 每个私钥保存为目录下的一个文件
+type Keystore struct {
+	dir string
+}
+
+// NewKeystore returns a keystore saving keys in dir
+func NewKeystore(dir string) *Keystore {
+	return &Keystore{dir: dir}
+}
+
+func (ks *Keystore) keyPath(name string) string {
+	return filepath.Join(ks.dir, name+keyFileSuffix)
+}
+
+func checkKeyName(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\.`) {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+// GenKey 生成新的私钥并加密保存, keyType为chain33的签名类型名称, 如ed25519, secp256k1
+func (ks *Keystore) GenKey(name, keyType string, password []byte) (crypto.PubKey, error) {
+	cr, err := crypto.New(keyType)
+	if err != nil {
+		return nil, err
+	}
+	priv, err := cr.GenKey()
+	if err != nil {
+		return nil, err
+	}
+	err = ks.ImportKey(name, keyType, priv.Bytes(), password)
+	if err != nil {
+		return nil, err
+	}
+	return priv.PubKey(), nil
+}
+
+// ImportKey 导入已有的私钥, 同名的私钥已经存在时返回ErrKeyExists
+func (ks *Keystore) ImportKey(name, keyType string, privKey []byte, password []byte) error {
+	if err := checkKeyName(name); err != nil {
+		return err
+	}
+	cr, err := crypto.New(keyType)
+	if err != nil {
+		return err
+	}
+	priv, err := cr.PrivKeyFromBytes(privKey)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(ks.keyPath(name)); err == nil {
+		return ErrKeyExists
+	}
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	gcm, err := newGCM(password, salt, scryptN, scryptR, scryptP)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	kf := &keyFile{
+		Name:       name,
+		Type:       keyType,
+		PubKey:     hex.EncodeToString(priv.PubKey().Bytes()),
+		Salt:       hex.EncodeToString(salt),
+		N:          scryptN,
+		R:          scryptR,
+		P:          scryptP,
+		Nonce:      hex.EncodeToString(nonce),
+		CipherText: hex.EncodeToString(gcm.Seal(nil, nonce, priv.Bytes(), []byte(name))),
+	}
+	data, err := json.MarshalIndent(kf, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(ks.dir, 0700); err != nil {
+		return err
+	}
+	return writeFileAtomic(ks.keyPath(name), data)
+}
+
+// LoadKey 使用密码解密私钥, 返回私钥和签名类型
+func (ks *Keystore) LoadKey(name string, password []byte) (crypto.PrivKey, string, error) {
+	if err := checkKeyName(name); err != nil {
+		return nil, "", err
+	}
+	data, err := ioutil.ReadFile(ks.keyPath(name))
+	if os.IsNotExist(err) {
+		return nil, "", ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	kf := &keyFile{}
+	if err := json.Unmarshal(data, kf); err != nil {
+		return nil, "", err
+	}
+	salt, err := hex.DecodeString(kf.Salt)
+	if err != nil {
+		return nil, "", err
+	}
+	nonce, err := hex.DecodeString(kf.Nonce)
+	if err != nil {
+		return nil, "", err
+	}
+	cipherText, err := hex.DecodeString(kf.CipherText)
+	if err != nil {
+		return nil, "", err
+	}
+	gcm, err := newGCM(password, salt, kf.N, kf.R, kf.P)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, "", fmt.Errorf("invalid nonce size %d", len(nonce))
+	}
+	plain, err := gcm.Open(nil, nonce, cipherText, []byte(kf.Name))
+	if err != nil {
+		return nil, "", ErrWrongPassword
+	}
+	cr, err := crypto.New(kf.Type)
+	if err != nil {
+		return nil, "", err
+	}
+	priv, err := cr.PrivKeyFromBytes(plain)
+	if err != nil {
+		return nil, "", err
+	}
+	return priv, kf.Type, nil
+}
+
+// List 返回keystore中所有私钥的名称
+func (ks *Keystore) List() ([]string, error) {
+	files, err := ioutil.ReadDir(ks.dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), keyFileSuffix) {
+			names = append(names, strings.TrimSuffix(f.Name(), keyFileSuffix))
+		}
+	}
+	return names, nil
+}
+
+func newGCM(password, salt []byte, n, r, p int) (cipher.AEAD, error) {
+	key, err := scrypt.Key(password, salt, n, r, p, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// 先写临时文件再改名, 避免写到一半时崩溃损坏文件
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,188 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// 签名内容的类型, 共识消息的高度/轮次/步骤由签名服务从签名内容中解析,
+// 不信任客户端传入的高度, 防止客户端绕过重复签名检查
+const (
+	// MsgOther 心跳, 节点地址, 交易等非共识消息, 签名内容不能是共识消息
+	MsgOther = int32(iota)
+	// MsgTendermintProposal tendermint的CanonicalJSONOnceProposal
+	MsgTendermintProposal
+	// MsgTendermintVote tendermint的CanonicalJSONOnceVote
+	MsgTendermintVote
+	// MsgDposVote dpos的CanonicalJSONOnceVote
+	MsgDposVote
+	// MsgDposNotify dpos的CanonicalJSONOnceNotify
+	MsgDposNotify
+)
+
+// 共识消息对应的步骤, 和tendermint的stepPropose/stepPrevote/stepPrecommit一致
+const (
+	stepPropose   = 1
+	stepPrevote   = 2
+	stepPrecommit = 3
+	// dpos每个周期先投票再通知投票结果
+	stepDposVote   = 1
+	stepDposNotify = 2
+)
+
+// message error defines
+var (
+	ErrInvalidSignBytes = errors.New("ErrInvalidSignBytes")
+	ErrConsensusMsgType = errors.New("ErrConsensusMsgType")
+)
+
+// 以下结构和tendermint, dpos中签名使用的json格式保持一致,
+// 签名内容必须是这些结构的规范编码, 否则验证签名时也无法通过
+type tendermintBlockID struct {
+	Hash        []byte                  `json:"hash,omitempty"`
+	PartsHeader tendermintPartSetHeader `json:"parts,omitempty"`
+}
+
+type tendermintPartSetHeader struct {
+	Hash  []byte `json:"hash"`
+	Total int    `json:"total"`
+}
+
+type tendermintOnceProposal struct {
+	ChainID  string `json:"chain_id"`
+	Proposal struct {
+		BlockBytes []byte            `json:"block_parts_header"`
+		Height     int64             `json:"height"`
+		POLBlockID tendermintBlockID `json:"pol_block_id"`
+		POLRound   int               `json:"pol_round"`
+		Round      int               `json:"round"`
+		Timestamp  string            `json:"timestamp"`
+	} `json:"proposal"`
+}
+
+type tendermintOnceVote struct {
+	ChainID string `json:"chain_id"`
+	Vote    struct {
+		BlockID   tendermintBlockID `json:"block_id"`
+		Height    int64             `json:"height"`
+		Round     int               `json:"round"`
+		Timestamp string            `json:"timestamp"`
+		Type      byte              `json:"type"`
+	} `json:"vote"`
+}
+
+type dposVoteItem struct {
+	VotedNodeIndex   int32  `json:"votedNodeIndex,omitempty"`
+	VotedNodeAddress []byte `json:"votedNodeAddress,omitempty"`
+	CycleStart       int64  `json:"cycleStart,omitempty"`
+	CycleStop        int64  `json:"cycleStop,omitempty"`
+	PeriodStart      int64  `json:"periodStart,omitempty"`
+	PeriodStop       int64  `json:"periodStop,omitempty"`
+	Height           int64  `json:"height,omitempty"`
+	VoteID           []byte `json:"voteID,omitempty"`
+}
+
+type dposOnceVote struct {
+	ChainID string `json:"chain_id"`
+	Vote    struct {
+		VoteItem         *dposVoteItem `json:"vote,omitempty"`
+		VoteTimestamp    int64         `json:"voteTimestamp,omitempty"`
+		VoterNodeIndex   int32         `json:"voterNodeIndex,omitempty"`
+		VoterNodeAddress []byte        `json:"voterNodeAddress,omitempty"`
+	} `json:"vote"`
+}
+
+type dposOnceNotify struct {
+	ChainID string `json:"chain_id"`
+	Notify  struct {
+		VoteItem        *dposVoteItem `json:"vote,omitempty"`
+		HeightStop      int64         `json:"heightStop,omitempty"`
+		NotifyTimestamp int64         `json:"notifyTimestamp,omitempty"`
+	} `json:"vote"`
+}
+
+// signHRS 从共识消息中解析的高度/轮次/步骤,
+// conflictKey相同的两个消息只有时间戳不同, 允许重新签名
+type signHRS struct {
+	height      int64
+	round       int32
+	step        int32
+	conflictKey []byte
+}
+
+// decodeCanonical 解码之后重新编码必须和原内容一致
+func decodeCanonical(data []byte, v interface{}) bool {
+	if err := json.Unmarshal(data, v); err != nil {
+		return false
+	}
+	canonical, err := json.Marshal(v)
+	return err == nil && bytes.Equal(canonical, data)
+}
+
+func parseConsensusMsg(msgType int32, data []byte) (*signHRS, bool) {
+	switch msgType {
+	case MsgTendermintProposal:
+		var p tendermintOnceProposal
+		if !decodeCanonical(data, &p) {
+			return nil, false
+		}
+		return &signHRS{height: p.Proposal.Height, round: int32(p.Proposal.Round), step: stepPropose, conflictKey: data}, true
+	case MsgTendermintVote:
+		var v tendermintOnceVote
+		if !decodeCanonical(data, &v) {
+			return nil, false
+		}
+		hrs := &signHRS{height: v.Vote.Height, round: int32(v.Vote.Round), conflictKey: data}
+		switch v.Vote.Type {
+		case 1:
+			hrs.step = stepPrevote
+		case 2:
+			hrs.step = stepPrecommit
+		default:
+			return nil, false
+		}
+		return hrs, true
+	case MsgDposVote:
+		var v dposOnceVote
+		if !decodeCanonical(data, &v) || v.Vote.VoteItem == nil {
+			return nil, false
+		}
+		return dposHRS(v.Vote.VoteItem, stepDposVote), true
+	case MsgDposNotify:
+		var n dposOnceNotify
+		if !decodeCanonical(data, &n) || n.Notify.VoteItem == nil {
+			return nil, false
+		}
+		return dposHRS(n.Notify.VoteItem, stepDposNotify), true
+	}
+	return nil, false
+}
+
+// dpos以周期开始时间作为高度, 同一个周期只能对同一个投票内容签名, 投票时间可以不同
+func dposHRS(item *dposVoteItem, step int32) *signHRS {
+	key, _ := json.Marshal(item)
+	return &signHRS{height: item.PeriodStart, step: step, conflictKey: key}
+}
+
+// parseSignBytes 解析签名内容, 非共识消息返回nil,
+// 共识消息必须按照声明的类型解码成功, 非共识消息不能被解码成任何一种共识消息
+func parseSignBytes(msgType int32, data []byte) (*signHRS, error) {
+	if msgType != MsgOther {
+		hrs, ok := parseConsensusMsg(msgType, data)
+		if !ok {
+			return nil, ErrInvalidSignBytes
+		}
+		return hrs, nil
+	}
+	for _, ty := range []int32{MsgTendermintProposal, MsgTendermintVote, MsgDposVote, MsgDposNotify} {
+		if _, ok := parseConsensusMsg(ty, data); ok {
+			return nil, ErrConsensusMsgType
+		}
+	}
+	return nil, nil
+}
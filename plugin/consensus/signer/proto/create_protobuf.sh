@@ -0,0 +1,2 @@
+#!/bin/sh
+protoc --go_out=plugins=grpc:../types ./*.proto --proto_path=.
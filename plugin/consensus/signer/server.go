@@ -0,0 +1,268 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/33cn/chain33/common/crypto"
+	log "github.com/33cn/chain33/common/log/log15"
+	vrf "github.com/33cn/chain33/common/vrf/secp256k1"
+	"github.com/33cn/chain33/types"
+	stypes "github.com/33cn/plugin/plugin/consensus/signer/types"
+	secp256k1 "github.com/btcsuite/btcd/btcec"
+	"google.golang.org/grpc"
+)
+
+var slog = log.New("module", "signer")
+
+const (
+	unixPrefix      = "unix://"
+	stateFileSuffix = ".state"
+)
+
+// server error defines
+var (
+	ErrHeightRegression = errors.New("ErrHeightRegression")
+	ErrRoundRegression  = errors.New("ErrRoundRegression")
+	ErrStepRegression   = errors.New("ErrStepRegression")
+	ErrConflictingData  = errors.New("ErrConflictingData")
+	ErrVrfNotSupported  = errors.New("ErrVrfNotSupported")
+	ErrInsecureListen   = errors.New("ErrInsecureListen")
+)
+
+// lastSignState 最后一次签名的高度/轮次/步骤, 签名之前先写入文件
+type lastSignState struct {
+	Height      int64  `json:"height"`
+	Round       int32  `json:"round"`
+	Step        int32  `json:"step"`
+	Signature   []byte `json:"signature,omitempty"`
+	SignBytes   []byte `json:"sign_bytes,omitempty"`
+	ConflictKey []byte `json:"conflict_key,omitempty"`
+}
+
+type signerKey struct {
+	priv      crypto.PrivKey
+	keyType   string
+	state     lastSignState
+	statePath string
+}
+
+// Server 远程签名服务, 私钥只在签名进程中解密,
+// 对共识消息做高度/轮次/步骤检查, 防止验证者重复签名
+type Server struct {
+	mtx    sync.Mutex
+	keys   map[string]*signerKey
+	srv    *grpc.Server
+	useTLS bool
+}
+
+// NewServer 使用密码解密keystore中的所有私钥, 并加载对应的签名状态,
+// tlsCfg不为空时要求客户端提供由CA签发的证书
+func NewServer(ks *Keystore, password []byte, tlsCfg *TLSConfig) (*Server, error) {
+	names, err := ks.List()
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, ErrKeyNotFound
+	}
+	s := &Server{keys: make(map[string]*signerKey)}
+	for _, name := range names {
+		priv, keyType, err := ks.LoadKey(name, password)
+		if err != nil {
+			return nil, fmt.Errorf("load key %s: %v", name, err)
+		}
+		key := &signerKey{
+			priv:      priv,
+			keyType:   keyType,
+			statePath: filepath.Join(ks.dir, name+stateFileSuffix),
+		}
+		data, err := ioutil.ReadFile(key.statePath)
+		if err == nil {
+			err = json.Unmarshal(data, &key.state)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("load sign state %s: %v", name, err)
+		}
+		slog.Info("NewServer load key", "name", name, "type", keyType, "height", key.state.Height,
+			"round", key.state.Round, "step", key.state.Step)
+		s.keys[name] = key
+	}
+	var opts []grpc.ServerOption
+	if tlsCfg != nil {
+		creds, err := tlsCfg.serverCredentials()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(creds))
+		s.useTLS = true
+	}
+	s.srv = grpc.NewServer(opts...)
+	stypes.RegisterSignerServer(s.srv, s)
+	return s, nil
+}
+
+// Listen 监听签名服务地址, unix://开头的地址使用unix socket,
+// 其他的使用tcp, tcp只有在配置了双向tls时才允许
+func (s *Server) Listen(addr string) (net.Listener, error) {
+	if !strings.HasPrefix(addr, unixPrefix) {
+		if !s.useTLS {
+			return nil, ErrInsecureListen
+		}
+		return net.Listen("tcp", addr)
+	}
+	path := strings.TrimPrefix(addr, unixPrefix)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	//只允许同一个用户的进程访问
+	if err := os.Chmod(path, 0600); err != nil {
+		lis.Close()
+		return nil, err
+	}
+	return lis, nil
+}
+
+// Serve 处理签名请求, 直到Stop被调用
+func (s *Server) Serve(lis net.Listener) error {
+	return s.srv.Serve(lis)
+}
+
+// Stop 停止签名服务
+func (s *Server) Stop() {
+	s.srv.Stop()
+}
+
+func (s *Server) getKey(name string) (*signerKey, error) {
+	key, ok := s.keys[name]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return key, nil
+}
+
+// GetPubKey 返回私钥的签名类型和公钥
+func (s *Server) GetPubKey(ctx context.Context, req *stypes.ReqSignerPubKey) (*stypes.ReplySignerPubKey, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	key, err := s.getKey(req.GetKeyName())
+	if err != nil {
+		return nil, err
+	}
+	return &stypes.ReplySignerPubKey{KeyType: key.keyType, PubKey: key.priv.PubKey().Bytes()}, nil
+}
+
+// Sign 签名, 共识消息的高度/轮次/步骤从签名内容中解析,
+// 同一高度/轮次/步骤只允许对相同的内容重复签名, 非共识消息不做检查
+func (s *Server) Sign(ctx context.Context, req *stypes.ReqSignerSign) (*stypes.ReplySignerSign, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	key, err := s.getKey(req.GetKeyName())
+	if err != nil {
+		return nil, err
+	}
+	hrs, err := parseSignBytes(req.GetMsgType(), req.GetSignBytes())
+	if err != nil {
+		slog.Error("Sign refused invalid sign bytes", "key", req.GetKeyName(), "msgType", req.GetMsgType(), "err", err)
+		return nil, err
+	}
+	if hrs == nil {
+		return &stypes.ReplySignerSign{Signature: key.priv.Sign(req.GetSignBytes()).Bytes()}, nil
+	}
+
+	sameHRS, err := key.checkHRS(hrs.height, hrs.round, hrs.step)
+	if err != nil {
+		slog.Error("Sign refused", "key", req.GetKeyName(), "height", hrs.height, "round", hrs.round,
+			"step", hrs.step, "err", err)
+		return nil, err
+	}
+	if sameHRS {
+		if bytes.Equal(req.GetSignBytes(), key.state.SignBytes) {
+			return &stypes.ReplySignerSign{Signature: key.state.Signature}, nil
+		}
+		//只有时间戳不同时重新签名
+		if !bytes.Equal(hrs.conflictKey, key.state.ConflictKey) {
+			slog.Error("Sign refused conflicting data", "key", req.GetKeyName(), "height", hrs.height,
+				"round", hrs.round, "step", hrs.step)
+			return nil, ErrConflictingData
+		}
+	}
+
+	sig := key.priv.Sign(req.GetSignBytes()).Bytes()
+	state := lastSignState{
+		Height:      hrs.height,
+		Round:       hrs.round,
+		Step:        hrs.step,
+		Signature:   sig,
+		SignBytes:   req.GetSignBytes(),
+		ConflictKey: hrs.conflictKey,
+	}
+	//先持久化签名状态, 保存失败时不返回签名
+	data, err := json.Marshal(&state)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(key.statePath, data); err != nil {
+		slog.Error("Sign save state failed", "key", req.GetKeyName(), "err", err)
+		return nil, err
+	}
+	key.state = state
+	return &stypes.ReplySignerSign{Signature: sig}, nil
+}
+
+// VrfEvaluate 使用secp256k1私钥计算vrf的hash和proof
+func (s *Server) VrfEvaluate(ctx context.Context, req *stypes.ReqSignerVrf) (*stypes.ReplySignerVrf, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	key, err := s.getKey(req.GetKeyName())
+	if err != nil {
+		return nil, err
+	}
+	if key.keyType != types.GetSignName("", types.SECP256K1) {
+		return nil, ErrVrfNotSupported
+	}
+	privKey, _ := secp256k1.PrivKeyFromBytes(secp256k1.S256(), key.priv.Bytes())
+	vrfPriv := &vrf.PrivateKey{PrivateKey: (*ecdsa.PrivateKey)(privKey)}
+	hash, proof := vrfPriv.Evaluate(req.GetInput())
+	return &stypes.ReplySignerVrf{Hash: hash[:], Proof: proof}, nil
+}
+
+// 返回true表示和上次签名的高度/轮次/步骤相同
+func (key *signerKey) checkHRS(height int64, round int32, step int32) (bool, error) {
+	last := key.state
+	if last.Height > height {
+		return false, ErrHeightRegression
+	}
+	if last.Height == height {
+		if last.Round > round {
+			return false, ErrRoundRegression
+		}
+		if last.Round == round {
+			if last.Step > step {
+				return false, ErrStepRegression
+			}
+			if last.Step == step {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,319 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	stypes "github.com/33cn/plugin/plugin/consensus/signer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	scryptN = 1 << 10
+}
+
+func TestKeystore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signerkeystore")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ks := NewKeystore(dir)
+	password := []byte("123456")
+	pub, err := ks.GenKey("validator", types.GetSignName("", types.ED25519), password)
+	require.Nil(t, err)
+	_, err = ks.GenKey("validator", types.GetSignName("", types.ED25519), password)
+	assert.Equal(t, ErrKeyExists, err)
+	_, err = ks.GenKey("../validator", types.GetSignName("", types.ED25519), password)
+	assert.Equal(t, ErrInvalidName, err)
+
+	priv, keyType, err := ks.LoadKey("validator", password)
+	require.Nil(t, err)
+	assert.Equal(t, types.GetSignName("", types.ED25519), keyType)
+	assert.Equal(t, pub.Bytes(), priv.PubKey().Bytes())
+	_, _, err = ks.LoadKey("validator", []byte("654321"))
+	assert.Equal(t, ErrWrongPassword, err)
+	_, _, err = ks.LoadKey("dpos", password)
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	//私钥文件中不能有明文私钥
+	data, err := ioutil.ReadFile(filepath.Join(dir, "validator.key"))
+	require.Nil(t, err)
+	assert.NotContains(t, string(data), hex.EncodeToString(priv.Bytes()))
+
+	names, err := ks.List()
+	require.Nil(t, err)
+	assert.Equal(t, []string{"validator"}, names)
+}
+
+func tendermintVoteBytes(t *testing.T, height int64, round int, typ byte, hash string) []byte {
+	var v tendermintOnceVote
+	v.ChainID = "test"
+	v.Vote.BlockID.Hash = []byte(hash)
+	v.Vote.Height, v.Vote.Round, v.Vote.Type = height, round, typ
+	data, err := json.Marshal(&v)
+	require.Nil(t, err)
+	return data
+}
+
+func tendermintProposalBytes(t *testing.T, height int64, round int) []byte {
+	var p tendermintOnceProposal
+	p.ChainID = "test"
+	p.Proposal.Height, p.Proposal.Round, p.Proposal.POLRound = height, round, -1
+	data, err := json.Marshal(&p)
+	require.Nil(t, err)
+	return data
+}
+
+func dposVoteBytes(t *testing.T, periodStart int64, votedIndex int32, timestamp int64) []byte {
+	var v dposOnceVote
+	v.ChainID = "test"
+	v.Vote.VoteItem = &dposVoteItem{VotedNodeIndex: votedIndex, PeriodStart: periodStart, PeriodStop: periodStart + 3}
+	v.Vote.VoteTimestamp = timestamp
+	data, err := json.Marshal(&v)
+	require.Nil(t, err)
+	return data
+}
+
+func TestServerSign(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signerserver")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ks := NewKeystore(dir)
+	password := []byte("123456")
+	pub, err := ks.GenKey("validator", types.GetSignName("", types.ED25519), password)
+	require.Nil(t, err)
+	_, err = NewServer(ks, []byte("654321"), nil)
+	assert.NotNil(t, err)
+	server, err := NewServer(ks, password, nil)
+	require.Nil(t, err)
+
+	ctx := context.Background()
+	sign := func(data []byte, msgType int32) ([]byte, error) {
+		reply, err := server.Sign(ctx, &stypes.ReqSignerSign{KeyName: "validator", SignBytes: data, MsgType: msgType})
+		if err != nil {
+			return nil, err
+		}
+		return reply.Signature, nil
+	}
+	prevote := tendermintVoteBytes(t, 10, 1, 1, "hashA")
+	sigBytes, err := sign(prevote, MsgTendermintVote)
+	require.Nil(t, err)
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	require.Nil(t, err)
+	sig, err := cr.SignatureFromBytes(sigBytes)
+	require.Nil(t, err)
+	assert.True(t, pub.VerifyBytes(prevote, sig))
+
+	//相同内容可以重复签名, 不同内容拒绝签名
+	again, err := sign(prevote, MsgTendermintVote)
+	require.Nil(t, err)
+	assert.Equal(t, sigBytes, again)
+	_, err = sign(tendermintVoteBytes(t, 10, 1, 1, "hashB"), MsgTendermintVote)
+	assert.Equal(t, ErrConflictingData, err)
+
+	_, err = sign(tendermintVoteBytes(t, 9, 1, 1, "hashA"), MsgTendermintVote)
+	assert.Equal(t, ErrHeightRegression, err)
+	_, err = sign(tendermintVoteBytes(t, 10, 0, 2, "hashA"), MsgTendermintVote)
+	assert.Equal(t, ErrRoundRegression, err)
+	_, err = sign(tendermintProposalBytes(t, 10, 1), MsgTendermintProposal)
+	assert.Equal(t, ErrStepRegression, err)
+	_, err = sign(tendermintVoteBytes(t, 10, 1, 2, "hashA"), MsgTendermintVote)
+	assert.Nil(t, err)
+
+	//非共识消息不检查高度, 但不能用来签名共识消息
+	_, err = sign([]byte("heartbeat"), MsgOther)
+	assert.Nil(t, err)
+	_, err = sign(tendermintVoteBytes(t, 10, 1, 1, "hashB"), MsgOther)
+	assert.Equal(t, ErrConsensusMsgType, err)
+	_, err = sign([]byte("vote"), MsgTendermintVote)
+	assert.Equal(t, ErrInvalidSignBytes, err)
+	_, err = sign(append(tendermintVoteBytes(t, 11, 0, 1, "hashB"), ' '), MsgTendermintVote)
+	assert.Equal(t, ErrInvalidSignBytes, err)
+	_, err = server.VrfEvaluate(ctx, &stypes.ReqSignerVrf{KeyName: "validator", Input: []byte("input")})
+	assert.Equal(t, ErrVrfNotSupported, err)
+
+	//重启后签名状态不丢失
+	server, err = NewServer(ks, password, nil)
+	require.Nil(t, err)
+	_, err = sign(prevote, MsgTendermintVote)
+	assert.Equal(t, ErrStepRegression, err)
+}
+
+func TestServerSignDpos(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signerdpos")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ks := NewKeystore(dir)
+	password := []byte("123456")
+	_, err = ks.GenKey("dpos", types.GetSignName("", types.SECP256K1), password)
+	require.Nil(t, err)
+	server, err := NewServer(ks, password, nil)
+	require.Nil(t, err)
+	ctx := context.Background()
+	sign := func(data []byte, msgType int32) error {
+		_, err := server.Sign(ctx, &stypes.ReqSignerSign{KeyName: "dpos", SignBytes: data, MsgType: msgType})
+		return err
+	}
+
+	//同一个周期只能投给同一个节点, 投票时间可以不同
+	assert.Nil(t, sign(dposVoteBytes(t, 100, 1, 101), MsgDposVote))
+	assert.Nil(t, sign(dposVoteBytes(t, 100, 1, 102), MsgDposVote))
+	assert.Equal(t, ErrConflictingData, sign(dposVoteBytes(t, 100, 2, 103), MsgDposVote))
+	assert.Equal(t, ErrConsensusMsgType, sign(dposVoteBytes(t, 100, 2, 103), MsgOther))
+
+	var n dposOnceNotify
+	n.ChainID = "test"
+	n.Notify.VoteItem = &dposVoteItem{VotedNodeIndex: 1, PeriodStart: 100, PeriodStop: 103}
+	n.Notify.HeightStop = 20
+	notify, err := json.Marshal(&n)
+	require.Nil(t, err)
+	assert.Nil(t, sign(notify, MsgDposNotify))
+	assert.Equal(t, ErrStepRegression, sign(dposVoteBytes(t, 100, 1, 104), MsgDposVote))
+	assert.Nil(t, sign(dposVoteBytes(t, 104, 2, 105), MsgDposVote))
+	assert.Equal(t, ErrHeightRegression, sign(dposVoteBytes(t, 100, 1, 106), MsgDposVote))
+}
+
+func TestClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signerclient")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ks := NewKeystore(dir)
+	password := []byte("123456")
+	pub, err := ks.GenKey("dpos", types.GetSignName("", types.SECP256K1), password)
+	require.Nil(t, err)
+	server, err := NewServer(ks, password, nil)
+	require.Nil(t, err)
+	//没有tls时只允许unix socket
+	_, err = server.Listen("127.0.0.1:0")
+	assert.Equal(t, ErrInsecureListen, err)
+	_, err = NewClient("127.0.0.1:8805", "dpos", nil)
+	assert.Equal(t, ErrInsecureListen, err)
+	addr := unixPrefix + filepath.Join(dir, "signer.sock")
+	lis, err := server.Listen(addr)
+	require.Nil(t, err)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := NewClient(addr, "dpos", nil)
+	require.Nil(t, err)
+	defer client.Close()
+	keyType, pubKey, err := client.GetPubKey()
+	require.Nil(t, err)
+	assert.Equal(t, types.GetSignName("", types.SECP256K1), keyType)
+	assert.Equal(t, pub.Bytes(), pubKey)
+
+	_, err = client.Sign(dposVoteBytes(t, 100, 1, 101), MsgDposVote)
+	require.Nil(t, err)
+	_, err = client.Sign(dposVoteBytes(t, 100, 2, 101), MsgDposVote)
+	assert.NotNil(t, err)
+	_, err = client.Sign([]byte("tx"), MsgOther)
+	require.Nil(t, err)
+	hash, proof, err := client.VrfEvaluate([]byte("input"))
+	require.Nil(t, err)
+	assert.Equal(t, 32, len(hash))
+	assert.NotEmpty(t, proof)
+
+	unknown, err := NewClient(addr, "unknown", nil)
+	require.Nil(t, err)
+	defer unknown.Close()
+	_, _, err = unknown.GetPubKey()
+	assert.NotNil(t, err)
+}
+
+// writeTestCert 生成由parent签发的证书, parent为空时生成自签名的CA
+func writeTestCert(t *testing.T, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		tmpl.IsCA, tmpl.BasicConstraintsValid = true, true
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dir, name+".crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dir, name+".key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	cert, err := x509.ParseCertificate(der)
+	require.Nil(t, err)
+	return cert, key
+}
+
+func TestTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signertls")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	certDir := filepath.Join(dir, "certs")
+	require.Nil(t, os.Mkdir(certDir, 0700))
+	ca, caKey := writeTestCert(t, certDir, "ca", nil, nil)
+	writeTestCert(t, certDir, "server", ca, caKey)
+	writeTestCert(t, certDir, "client", ca, caKey)
+	other, otherKey := writeTestCert(t, certDir, "other", nil, nil)
+	writeTestCert(t, certDir, "stranger", other, otherKey)
+	tlsCfg := func(name string) *TLSConfig {
+		return &TLSConfig{
+			CertFile: filepath.Join(certDir, name+".crt"),
+			KeyFile:  filepath.Join(certDir, name+".key"),
+			CAFile:   filepath.Join(certDir, "ca.crt"),
+		}
+	}
+
+	ks := NewKeystore(filepath.Join(dir, "keystore"))
+	password := []byte("123456")
+	pub, err := ks.GenKey("validator", types.GetSignName("", types.ED25519), password)
+	require.Nil(t, err)
+	server, err := NewServer(ks, password, tlsCfg("server"))
+	require.Nil(t, err)
+	lis, err := server.Listen("127.0.0.1:0")
+	require.Nil(t, err)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := NewClient(lis.Addr().String(), "validator", tlsCfg("client"))
+	require.Nil(t, err)
+	defer client.Close()
+	_, pubKey, err := client.GetPubKey()
+	require.Nil(t, err)
+	assert.Equal(t, pub.Bytes(), pubKey)
+
+	//其他CA签发的客户端证书被拒绝
+	stranger, err := NewClient(lis.Addr().String(), "validator", tlsCfg("stranger"))
+	require.Nil(t, err)
+	defer stranger.Close()
+	_, _, err = stranger.GetPubKey()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,72 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// ErrInvalidCA CA证书文件中没有可用的证书
+var ErrInvalidCA = errors.New("ErrInvalidCA")
+
+// TLSConfig 签名服务和共识节点之间的双向tls配置, 文件都是pem格式,
+// CAFile用于校验对端的证书, 签名服务只接受由该CA签发的客户端证书
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	CAFile   string
+	// 客户端校验签名服务证书时使用的名称, 为空时使用地址中的主机名
+	ServerName string
+}
+
+func (c *TLSConfig) load() (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	data, err := ioutil.ReadFile(c.CAFile)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return tls.Certificate{}, nil, ErrInvalidCA
+	}
+	return cert, pool, nil
+}
+
+func (c *TLSConfig) serverCredentials() (credentials.TransportCredentials, error) {
+	cert, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+func (c *TLSConfig) clientCredentials(serverName string) (credentials.TransportCredentials, error) {
+	cert, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	if c.ServerName != "" {
+		serverName = c.ServerName
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
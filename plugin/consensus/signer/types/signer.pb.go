@@ -0,0 +1,494 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: signer.proto
+
+package types
+
+import (
+	context "context"
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ReqSignerPubKey 查询签名私钥对应的公钥
+type ReqSignerPubKey struct {
+	KeyName              string   `protobuf:"bytes,1,opt,name=keyName,proto3" json:"keyName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqSignerPubKey) Reset()         { *m = ReqSignerPubKey{} }
+func (m *ReqSignerPubKey) String() string { return proto.CompactTextString(m) }
+func (*ReqSignerPubKey) ProtoMessage()    {}
+func (*ReqSignerPubKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_df2490657d73dbfd, []int{0}
+}
+
+func (m *ReqSignerPubKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqSignerPubKey.Unmarshal(m, b)
+}
+func (m *ReqSignerPubKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqSignerPubKey.Marshal(b, m, deterministic)
+}
+func (m *ReqSignerPubKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqSignerPubKey.Merge(m, src)
+}
+func (m *ReqSignerPubKey) XXX_Size() int {
+	return xxx_messageInfo_ReqSignerPubKey.Size(m)
+}
+func (m *ReqSignerPubKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqSignerPubKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqSignerPubKey proto.InternalMessageInfo
+
+func (m *ReqSignerPubKey) GetKeyName() string {
+	if m != nil {
+		return m.KeyName
+	}
+	return ""
+}
+
+type ReplySignerPubKey struct {
+	KeyType              string   `protobuf:"bytes,1,opt,name=keyType,proto3" json:"keyType,omitempty"`
+	PubKey               []byte   `protobuf:"bytes,2,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplySignerPubKey) Reset()         { *m = ReplySignerPubKey{} }
+func (m *ReplySignerPubKey) String() string { return proto.CompactTextString(m) }
+func (*ReplySignerPubKey) ProtoMessage()    {}
+func (*ReplySignerPubKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_df2490657d73dbfd, []int{1}
+}
+
+func (m *ReplySignerPubKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplySignerPubKey.Unmarshal(m, b)
+}
+func (m *ReplySignerPubKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplySignerPubKey.Marshal(b, m, deterministic)
+}
+func (m *ReplySignerPubKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplySignerPubKey.Merge(m, src)
+}
+func (m *ReplySignerPubKey) XXX_Size() int {
+	return xxx_messageInfo_ReplySignerPubKey.Size(m)
+}
+func (m *ReplySignerPubKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplySignerPubKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplySignerPubKey proto.InternalMessageInfo
+
+func (m *ReplySignerPubKey) GetKeyType() string {
+	if m != nil {
+		return m.KeyType
+	}
+	return ""
+}
+
+func (m *ReplySignerPubKey) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+// ReqSignerSign 签名请求, msgType为签名内容的类型,
+// 共识消息的高度/轮次/步骤由签名服务从签名内容中解析, 用于重复签名检查
+type ReqSignerSign struct {
+	KeyName              string   `protobuf:"bytes,1,opt,name=keyName,proto3" json:"keyName,omitempty"`
+	SignBytes            []byte   `protobuf:"bytes,2,opt,name=signBytes,proto3" json:"signBytes,omitempty"`
+	MsgType              int32    `protobuf:"varint,6,opt,name=msgType,proto3" json:"msgType,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqSignerSign) Reset()         { *m = ReqSignerSign{} }
+func (m *ReqSignerSign) String() string { return proto.CompactTextString(m) }
+func (*ReqSignerSign) ProtoMessage()    {}
+func (*ReqSignerSign) Descriptor() ([]byte, []int) {
+	return fileDescriptor_df2490657d73dbfd, []int{2}
+}
+
+func (m *ReqSignerSign) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqSignerSign.Unmarshal(m, b)
+}
+func (m *ReqSignerSign) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqSignerSign.Marshal(b, m, deterministic)
+}
+func (m *ReqSignerSign) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqSignerSign.Merge(m, src)
+}
+func (m *ReqSignerSign) XXX_Size() int {
+	return xxx_messageInfo_ReqSignerSign.Size(m)
+}
+func (m *ReqSignerSign) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqSignerSign.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqSignerSign proto.InternalMessageInfo
+
+func (m *ReqSignerSign) GetKeyName() string {
+	if m != nil {
+		return m.KeyName
+	}
+	return ""
+}
+
+func (m *ReqSignerSign) GetSignBytes() []byte {
+	if m != nil {
+		return m.SignBytes
+	}
+	return nil
+}
+
+func (m *ReqSignerSign) GetMsgType() int32 {
+	if m != nil {
+		return m.MsgType
+	}
+	return 0
+}
+
+type ReplySignerSign struct {
+	Signature            []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplySignerSign) Reset()         { *m = ReplySignerSign{} }
+func (m *ReplySignerSign) String() string { return proto.CompactTextString(m) }
+func (*ReplySignerSign) ProtoMessage()    {}
+func (*ReplySignerSign) Descriptor() ([]byte, []int) {
+	return fileDescriptor_df2490657d73dbfd, []int{3}
+}
+
+func (m *ReplySignerSign) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplySignerSign.Unmarshal(m, b)
+}
+func (m *ReplySignerSign) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplySignerSign.Marshal(b, m, deterministic)
+}
+func (m *ReplySignerSign) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplySignerSign.Merge(m, src)
+}
+func (m *ReplySignerSign) XXX_Size() int {
+	return xxx_messageInfo_ReplySignerSign.Size(m)
+}
+func (m *ReplySignerSign) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplySignerSign.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplySignerSign proto.InternalMessageInfo
+
+func (m *ReplySignerSign) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// ReqSignerVrf vrf计算请求, 只支持secp256k1私钥
+type ReqSignerVrf struct {
+	KeyName              string   `protobuf:"bytes,1,opt,name=keyName,proto3" json:"keyName,omitempty"`
+	Input                []byte   `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqSignerVrf) Reset()         { *m = ReqSignerVrf{} }
+func (m *ReqSignerVrf) String() string { return proto.CompactTextString(m) }
+func (*ReqSignerVrf) ProtoMessage()    {}
+func (*ReqSignerVrf) Descriptor() ([]byte, []int) {
+	return fileDescriptor_df2490657d73dbfd, []int{4}
+}
+
+func (m *ReqSignerVrf) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqSignerVrf.Unmarshal(m, b)
+}
+func (m *ReqSignerVrf) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqSignerVrf.Marshal(b, m, deterministic)
+}
+func (m *ReqSignerVrf) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqSignerVrf.Merge(m, src)
+}
+func (m *ReqSignerVrf) XXX_Size() int {
+	return xxx_messageInfo_ReqSignerVrf.Size(m)
+}
+func (m *ReqSignerVrf) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqSignerVrf.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqSignerVrf proto.InternalMessageInfo
+
+func (m *ReqSignerVrf) GetKeyName() string {
+	if m != nil {
+		return m.KeyName
+	}
+	return ""
+}
+
+func (m *ReqSignerVrf) GetInput() []byte {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+type ReplySignerVrf struct {
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Proof                []byte   `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplySignerVrf) Reset()         { *m = ReplySignerVrf{} }
+func (m *ReplySignerVrf) String() string { return proto.CompactTextString(m) }
+func (*ReplySignerVrf) ProtoMessage()    {}
+func (*ReplySignerVrf) Descriptor() ([]byte, []int) {
+	return fileDescriptor_df2490657d73dbfd, []int{5}
+}
+
+func (m *ReplySignerVrf) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplySignerVrf.Unmarshal(m, b)
+}
+func (m *ReplySignerVrf) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplySignerVrf.Marshal(b, m, deterministic)
+}
+func (m *ReplySignerVrf) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplySignerVrf.Merge(m, src)
+}
+func (m *ReplySignerVrf) XXX_Size() int {
+	return xxx_messageInfo_ReplySignerVrf.Size(m)
+}
+func (m *ReplySignerVrf) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplySignerVrf.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplySignerVrf proto.InternalMessageInfo
+
+func (m *ReplySignerVrf) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *ReplySignerVrf) GetProof() []byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ReqSignerPubKey)(nil), "types.ReqSignerPubKey")
+	proto.RegisterType((*ReplySignerPubKey)(nil), "types.ReplySignerPubKey")
+	proto.RegisterType((*ReqSignerSign)(nil), "types.ReqSignerSign")
+	proto.RegisterType((*ReplySignerSign)(nil), "types.ReplySignerSign")
+	proto.RegisterType((*ReqSignerVrf)(nil), "types.ReqSignerVrf")
+	proto.RegisterType((*ReplySignerVrf)(nil), "types.ReplySignerVrf")
+}
+
+func init() {
+	proto.RegisterFile("signer.proto", fileDescriptor_df2490657d73dbfd)
+}
+
+var fileDescriptor_df2490657d73dbfd = []byte{
+	// 322 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x52, 0xd1, 0x4a, 0xc3, 0x30,
+	0x14, 0x5d, 0x35, 0xad, 0xdb, 0xb5, 0xba, 0x19, 0xe7, 0x28, 0xc3, 0x87, 0x91, 0xa7, 0x81, 0x30,
+	0x41, 0xc1, 0x07, 0x05, 0x05, 0x61, 0x08, 0x13, 0x44, 0xa2, 0xf4, 0xbd, 0x83, 0x74, 0x1b, 0x6e,
+	0x6b, 0x4c, 0x52, 0x21, 0x3f, 0xe7, 0xb7, 0x49, 0xb2, 0xb4, 0x2b, 0x9d, 0xfa, 0x72, 0xe9, 0xb9,
+	0xbd, 0xe7, 0x9e, 0x93, 0x9c, 0x40, 0x28, 0x17, 0xb3, 0x35, 0x13, 0x23, 0x2e, 0x32, 0x95, 0x61,
+	0x5f, 0x69, 0xce, 0x24, 0xb9, 0x80, 0x36, 0x65, 0x9f, 0x6f, 0xf6, 0xcf, 0x6b, 0x3e, 0x7d, 0x66,
+	0x1a, 0x47, 0x70, 0xf0, 0xc1, 0xf4, 0x4b, 0xb2, 0x62, 0x91, 0x37, 0xf0, 0x86, 0x2d, 0x5a, 0x40,
+	0x32, 0x86, 0x13, 0xca, 0xf8, 0x52, 0xff, 0x32, 0xfe, 0xae, 0x79, 0x75, 0xdc, 0x40, 0xdc, 0x83,
+	0x80, 0xdb, 0x99, 0x68, 0x6f, 0xe0, 0x0d, 0x43, 0xea, 0x10, 0x91, 0x70, 0x54, 0x6a, 0x9a, 0xfa,
+	0xb7, 0x22, 0x3e, 0x87, 0x96, 0x71, 0xfd, 0xa8, 0x15, 0x93, 0x6e, 0xcb, 0xb6, 0x61, 0x78, 0x2b,
+	0x39, 0xb3, 0xd2, 0xc1, 0xc0, 0x1b, 0xfa, 0xb4, 0x80, 0x13, 0xd4, 0xdc, 0xef, 0xa0, 0x09, 0x6a,
+	0xa2, 0x8e, 0x3f, 0x41, 0x4d, 0xbf, 0x13, 0x90, 0x4b, 0x68, 0x57, 0xbc, 0x5b, 0x59, 0xb7, 0x3c,
+	0x51, 0xb9, 0xd8, 0x08, 0x87, 0x74, 0xdb, 0x20, 0xf7, 0x10, 0x96, 0x2e, 0x63, 0x91, 0xfe, 0x63,
+	0xb2, 0x0b, 0xfe, 0x62, 0xcd, 0x73, 0xe5, 0x0c, 0x6e, 0x00, 0xb9, 0x85, 0xe3, 0x8a, 0xa0, 0xd9,
+	0x80, 0x01, 0xcd, 0x13, 0x39, 0x77, 0x52, 0xf6, 0xdb, 0x70, 0xb9, 0xc8, 0xb2, 0xb4, 0xe0, 0x5a,
+	0x70, 0xf5, 0xed, 0x41, 0xb0, 0x49, 0x0b, 0x3f, 0x40, 0xeb, 0x89, 0x29, 0x77, 0xd7, 0xbd, 0x91,
+	0x4d, 0x6d, 0x54, 0x8b, 0xac, 0x1f, 0x95, 0xfd, 0x5a, 0x3a, 0xa4, 0x81, 0x6f, 0x00, 0xd9, 0xd3,
+	0x76, 0xeb, 0x5c, 0x53, 0xfb, 0xbd, 0x5d, 0xa6, 0xa9, 0xa4, 0x81, 0xef, 0xe0, 0x30, 0x16, 0xe9,
+	0xf8, 0x2b, 0x59, 0xe6, 0x89, 0x62, 0xf8, 0xb4, 0x4e, 0x8f, 0x45, 0xda, 0x3f, 0xdb, 0x65, 0xc7,
+	0x22, 0x25, 0x8d, 0x69, 0x60, 0x1f, 0xd9, 0xf5, 0xcf, 0x00, 0x0c, 0x5c, 0xca, 0xa7, 0x74, 0x02,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// SignerClient is the client API for Signer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SignerClient interface {
+	GetPubKey(ctx context.Context, in *ReqSignerPubKey, opts ...grpc.CallOption) (*ReplySignerPubKey, error)
+	Sign(ctx context.Context, in *ReqSignerSign, opts ...grpc.CallOption) (*ReplySignerSign, error)
+	VrfEvaluate(ctx context.Context, in *ReqSignerVrf, opts ...grpc.CallOption) (*ReplySignerVrf, error)
+}
+
+type signerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSignerClient(cc grpc.ClientConnInterface) SignerClient {
+	return &signerClient{cc}
+}
+
+func (c *signerClient) GetPubKey(ctx context.Context, in *ReqSignerPubKey, opts ...grpc.CallOption) (*ReplySignerPubKey, error) {
+	out := new(ReplySignerPubKey)
+	err := c.cc.Invoke(ctx, "/types.signer/GetPubKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) Sign(ctx context.Context, in *ReqSignerSign, opts ...grpc.CallOption) (*ReplySignerSign, error) {
+	out := new(ReplySignerSign)
+	err := c.cc.Invoke(ctx, "/types.signer/Sign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) VrfEvaluate(ctx context.Context, in *ReqSignerVrf, opts ...grpc.CallOption) (*ReplySignerVrf, error) {
+	out := new(ReplySignerVrf)
+	err := c.cc.Invoke(ctx, "/types.signer/VrfEvaluate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SignerServer is the server API for Signer service.
+type SignerServer interface {
+	GetPubKey(context.Context, *ReqSignerPubKey) (*ReplySignerPubKey, error)
+	Sign(context.Context, *ReqSignerSign) (*ReplySignerSign, error)
+	VrfEvaluate(context.Context, *ReqSignerVrf) (*ReplySignerVrf, error)
+}
+
+// UnimplementedSignerServer can be embedded to have forward compatible implementations.
+type UnimplementedSignerServer struct {
+}
+
+func (*UnimplementedSignerServer) GetPubKey(ctx context.Context, req *ReqSignerPubKey) (*ReplySignerPubKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPubKey not implemented")
+}
+func (*UnimplementedSignerServer) Sign(ctx context.Context, req *ReqSignerSign) (*ReplySignerSign, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sign not implemented")
+}
+func (*UnimplementedSignerServer) VrfEvaluate(ctx context.Context, req *ReqSignerVrf) (*ReplySignerVrf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VrfEvaluate not implemented")
+}
+
+func RegisterSignerServer(s *grpc.Server, srv SignerServer) {
+	s.RegisterService(&_Signer_serviceDesc, srv)
+}
+
+func _Signer_GetPubKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqSignerPubKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).GetPubKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.signer/GetPubKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).GetPubKey(ctx, req.(*ReqSignerPubKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqSignerSign)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.signer/Sign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).Sign(ctx, req.(*ReqSignerSign))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_VrfEvaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqSignerVrf)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).VrfEvaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.signer/VrfEvaluate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).VrfEvaluate(ctx, req.(*ReqSignerVrf))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Signer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.signer",
+	HandlerType: (*SignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPubKey",
+			Handler:    _Signer_GetPubKey_Handler,
+		},
+		{
+			MethodName: "Sign",
+			Handler:    _Signer_Sign_Handler,
+		},
+		{
+			MethodName: "VrfEvaluate",
+			Handler:    _Signer_VrfEvaluate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "signer.proto",
+}
@@ -92,6 +92,17 @@ type ConsensusState struct {
 	txsAvailable      chan int64
 	begCons           time.Time
 	ProposalBlockHash []byte
+
+	// write-ahead log, replayed on restart
+	wal *ConsensusWAL
+	// our own signed votes, proposal and proposal block at the current height,
+	// restored from the wal so that we never sign a conflicting one after restart
+	signedVotes      map[string]*tmtypes.Vote
+	ownProposal      *tmtypes.Proposal
+	ownProposalBlock *tmtypes.TendermintBlock
+
+	// evidence of double signing, gossiped and included in proposed blocks
+	evpool *EvidencePool
 }
 
 // NewConsensusState returns a new ConsensusState.
@@ -106,6 +117,7 @@ func NewConsensusState(client *Client, state State, blockExec *BlockExecutor) *C
 		quit:         make(chan struct{}),
 		txsAvailable: make(chan int64, 1),
 		begCons:      time.Time{},
+		signedVotes:  make(map[string]*tmtypes.Vote),
 	}
 	atomic.CompareAndSwapUint32(&cs.status, 0, 0)
 	// set function defaults (may be overwritten before calling Start)
@@ -169,6 +181,20 @@ func (cs *ConsensusState) SetPrivValidator(priv ttypes.PrivValidator) {
 	cs.privValidator = priv
 }
 
+// SetWAL sets the write-ahead log. It must be called before Start.
+func (cs *ConsensusState) SetWAL(wal *ConsensusWAL) {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+	cs.wal = wal
+}
+
+// SetEvidencePool sets the evidence pool. It must be called before Start.
+func (cs *ConsensusState) SetEvidencePool(evpool *EvidencePool) {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+	cs.evpool = evpool
+}
+
 // SetTimeoutTicker sets the local timer. It may be useful to overwrite for testing.
 func (cs *ConsensusState) SetTimeoutTicker(timeoutTicker TimeoutTicker) {
 	cs.mtx.Lock()
@@ -191,6 +217,10 @@ func (cs *ConsensusState) Start() {
 	if atomic.CompareAndSwapUint32(&cs.status, 0, 1) {
 		cs.timeoutTicker.Start()
 
+		// replay the wal before receiving new messages, so that we are back
+		// to the height/round/step we were in before the restart
+		cs.catchupReplay(cs.GetRoundState().Height)
+
 		go cs.checkTxsAvailable()
 		// now start the receiveRoutine
 		go cs.receiveRoutine(0)
@@ -329,6 +359,9 @@ func (cs *ConsensusState) updateToState(state State) {
 	cs.LastCommit = lastPrecommits
 	cs.LastValidators = state.LastValidators
 	cs.begCons = time.Time{}
+	cs.signedVotes = make(map[string]*tmtypes.Vote)
+	cs.ownProposal = nil
+	cs.ownProposalBlock = nil
 
 	cs.state = state
 
@@ -371,22 +404,31 @@ func (cs *ConsensusState) receiveRoutine(maxSteps int) {
 
 		select {
 		case height := <-cs.txsAvailable:
+			cs.writeWAL(rs.Height, &WALMessage{TypeID: walTxsAvailableID, TxsHeight: height}, false)
 			cs.handleTxsAvailable(height)
 		case mi = <-cs.peerMsgQueue:
+			cs.writeWAL(rs.Height, &WALMessage{TypeID: mi.TypeID, Msg: mi.Msg}, false)
 			// handles proposals, block parts, votes
 			// may generate internal events (votes, complete proposals, 2/3 majorities)
 			cs.handleMsg(mi)
 		case mi = <-cs.internalMsgQueue:
+			// our own messages must hit the disk before they can be sent to peers,
+			// never send a vote we may forget after a crash
+			if err := cs.writeWAL(rs.Height, &WALMessage{TypeID: mi.TypeID, Own: true, Msg: mi.Msg}, true); err != nil {
+				tendermintlog.Error("Drop internal msg that failed to write wal", "type", mi.TypeID, "err", err)
+				continue
+			}
 			// handles proposals, block parts, votes
 			cs.handleMsg(mi)
 		case ti := <-cs.timeoutTicker.Chan(): // tockChan:
+			cs.writeWAL(rs.Height, &WALMessage{TypeID: walTimeoutID, Timeout: &ti}, false)
 			// if the timeout is relevant to the rs
 			// go to the next step
 			cs.handleTimeout(ti, rs)
 		case <-cs.quit:
 			// NOTE: the internalMsgQueue may have signed messages from our
 			// priv_val that haven't hit the WAL, but its ok because
-			// priv_val tracks LastSig
+			// they are never sent to peers before written to the WAL
 			return
 		}
 	}
@@ -429,6 +471,11 @@ func (cs *ConsensusState) handleMsg(mi MsgInfo) {
 		// TODO: If rs.Height == vote.Height && rs.Round < vote.Round,
 		// the peer is sending us CatchupCommit precommits.
 		// We could make note of this and help filter in broadcastHasVoteMessage().
+	case *tmtypes.DuplicateVoteEvidence:
+		err = cs.addEvidence(&ttypes.DuplicateVoteEvidence{DuplicateVoteEvidence: msg})
+		if err != nil && err != ttypes.ErrEvidenceExists {
+			tendermintlog.Error("handleMsg evidence failed", "peerip", peerIP, "err", err)
+		}
 	default:
 		tendermintlog.Error("Unknown msg type", msg.String(), "peerid", peerID, "peerip", peerIP)
 	}
@@ -662,6 +709,17 @@ func (cs *ConsensusState) isProposer() bool {
 }
 
 func (cs *ConsensusState) defaultDecideProposal(height int64, round int) {
+	// We have signed a proposal for this round before restart, resend it.
+	// Never sign another block for the same round.
+	if cs.ownProposal != nil && cs.ownProposal.Height == height && int(cs.ownProposal.Round) == round {
+		tendermintlog.Info("Resend proposal signed before restart", "height", height, "round", round)
+		cs.sendInternalMessage(MsgInfo{ttypes.ProposalID, cs.ownProposal, cs.ourID, ""})
+		ownBlock := &ttypes.TendermintBlock{TendermintBlock: cs.ownProposalBlock}
+		if ownBlock.HashesTo(cs.ownProposal.Blockhash) {
+			cs.sendInternalMessage(MsgInfo{ttypes.ProposalBlockID, cs.ownProposalBlock, cs.ourID, ""})
+		}
+		return
+	}
 
 	var block *ttypes.TendermintBlock
 
@@ -687,6 +745,8 @@ func (cs *ConsensusState) defaultDecideProposal(height int64, round int) {
 	propBlockID := tmtypes.BlockID{Hash: block.Hash()}
 	proposal := ttypes.NewProposal(height, round, block.Hash(), cs.ValidRound, propBlockID)
 	if err := cs.privValidator.SignProposal(cs.state.ChainID, proposal); err == nil {
+		cs.ownProposal = &proposal.Proposal
+		cs.ownProposalBlock = block.TendermintBlock
 		// send proposal and block on internal msg queue
 		cs.sendInternalMessage(MsgInfo{ttypes.ProposalID, &proposal.Proposal, cs.ourID, ""})
 		cs.sendInternalMessage(MsgInfo{ttypes.ProposalBlockID, block.TendermintBlock, cs.ourID, ""})
@@ -741,7 +801,7 @@ func (cs *ConsensusState) createProposalBlock() (block *ttypes.TendermintBlock)
 	}
 
 	proposerAddr := cs.privValidator.GetAddress()
-	block = cs.state.MakeBlock(cs.Height, int64(cs.Round), pblock, commit, proposerAddr)
+	block = cs.state.MakeBlock(cs.Height, int64(cs.Round), pblock, commit, cs.pendingEvidence(), proposerAddr)
 	baseTx := cs.createBaseTx(block.TendermintBlock)
 	if baseTx == nil {
 		tendermintlog.Error("createProposalBlock createBaseTx fail")
@@ -765,6 +825,39 @@ func (cs *ConsensusState) createProposalBlock() (block *ttypes.TendermintBlock)
 	return block
 }
 
+// addEvidence verifies the evidence and gossips it if it's new
+func (cs *ConsensusState) addEvidence(ev *ttypes.DuplicateVoteEvidence) error {
+	if cs.evpool == nil {
+		return nil
+	}
+	if err := verifyEvidence(cs.blockExec.db, cs.state, ev); err != nil {
+		return err
+	}
+	if err := cs.evpool.AddEvidence(ev); err != nil {
+		return err
+	}
+	tendermintlog.Info("Found double sign evidence", "evidence", ev)
+	cs.broadcastChannel <- MsgInfo{TypeID: ttypes.EvidenceID, Msg: ev.DuplicateVoteEvidence, PeerID: cs.ourID, PeerIP: ""}
+	return nil
+}
+
+// pendingEvidence returns the evidence still valid for the block we are proposing
+func (cs *ConsensusState) pendingEvidence() []*tmtypes.DuplicateVoteEvidence {
+	if cs.evpool == nil {
+		return nil
+	}
+	var evidence []*tmtypes.DuplicateVoteEvidence
+	for _, item := range cs.evpool.PendingEvidence(maxEvidencePerBlock) {
+		ev := &ttypes.DuplicateVoteEvidence{DuplicateVoteEvidence: item}
+		if err := verifyEvidence(cs.blockExec.db, cs.state, ev); err != nil {
+			tendermintlog.Info("Skip pending evidence", "evidence", ev, "err", err)
+			continue
+		}
+		evidence = append(evidence, item)
+	}
+	return evidence
+}
+
 func (cs *ConsensusState) createBaseTx(block *tmtypes.TendermintBlock) *types.Transaction {
 	var state *tmtypes.State
 	if cs.Height == 1 {
@@ -1143,6 +1236,22 @@ func (cs *ConsensusState) finalizeCommit(height int64) {
 		panic(fmt.Sprintf("finalizeCommit SaveSeenCommit fail: %v", err))
 	}
 	tendermintlog.Info(fmt.Sprintf("Save consensus state. Current: %v/%v/%v", cs.Height, cs.CommitRound, cs.Step), "cost", types.Since(cs.begCons))
+	if cs.evpool != nil {
+		// evidence older than MaxAge of the next height can't be committed anymore
+		minHeight := int64(0)
+		if maxAge := stateCopy.ConsensusParams.EvidenceParams.MaxAge; maxAge > 0 {
+			minHeight = height + 1 - maxAge
+		}
+		if err := cs.evpool.Update(block.Evidence, minHeight); err != nil {
+			tendermintlog.Error("finalizeCommit update evidence pool fail", "height", height, "err", err)
+		}
+	}
+	// the state is saved, messages of this height are no longer needed
+	if cs.wal != nil {
+		if err := cs.wal.Prune(height + 1); err != nil {
+			tendermintlog.Error("finalizeCommit prune wal fail", "height", height, "err", err)
+		}
+	}
 
 	// NewHeightStep!
 	cs.updateToState(stateCopy)
@@ -1288,11 +1397,15 @@ func (cs *ConsensusState) tryAddVote(voteRaw *tmtypes.Vote, peerID string, peerI
 		// If it's otherwise invalid, punish peer.
 		if err == ErrVoteHeightMismatch {
 			return err
-		} else if err == ttypes.ErrVoteConflict {
+		} else if voteErr, ok := err.(*ttypes.ErrVoteConflictingVotes); ok {
 			if bytes.Equal(vote.ValidatorAddress, cs.privValidator.GetAddress()) {
 				tendermintlog.Error("Found conflicting vote from ourselves. Did you unsafe_reset a validator?", "height", vote.Height, "round", vote.Round, "type", vote.Type)
 				return err
 			}
+			if err := cs.addEvidence(voteErr.DuplicateVoteEvidence); err != nil && err != ttypes.ErrEvidenceExists {
+				tendermintlog.Error("Add conflicting vote evidence fail", "evidence", voteErr.DuplicateVoteEvidence, "err", err)
+			}
+			return err
 		} else {
 			// Probably an invalid signature / Bad peer.
 			// Seems this can also err sometimes with "Unexpected step" - perhaps not from a bad peer ?
@@ -1467,6 +1580,15 @@ func (cs *ConsensusState) addVote(vote *ttypes.Vote, peerID string, peerIP strin
 }
 
 func (cs *ConsensusState) signVote(voteType byte, hash []byte) (*ttypes.Vote, error) {
+	// We have signed a vote of this type for this round, maybe before restart.
+	// Resend the same vote, or refuse to sign a conflicting one.
+	key := signedVoteKey(cs.Height, cs.Round, voteType)
+	if prev, ok := cs.signedVotes[key]; ok {
+		if bytes.Equal(prev.GetBlockID().GetHash(), hash) {
+			return &ttypes.Vote{Vote: prev}, nil
+		}
+		return nil, fmt.Errorf("already signed a vote for %X at %v/%v/%v", ttypes.Fingerprint(prev.GetBlockID().GetHash()), cs.Height, cs.Round, voteType)
+	}
 
 	addr := cs.privValidator.GetAddress()
 	valIndex, _ := cs.Validators.GetByAddress(addr)
@@ -1487,9 +1609,16 @@ func (cs *ConsensusState) signVote(voteType byte, hash []byte) (*ttypes.Vote, er
 	beg := time.Now()
 	err := cs.privValidator.SignVote(cs.state.ChainID, vote)
 	tendermintlog.Debug("signVote", "height", cs.Height, "cost", types.Since(beg))
+	if err == nil {
+		cs.signedVotes[key] = vote.Vote
+	}
 	return vote, err
 }
 
+func signedVoteKey(height int64, round int, voteType byte) string {
+	return fmt.Sprintf("%v/%v/%v", height, round, voteType)
+}
+
 // sign the vote and publish on internalMsgQueue
 func (cs *ConsensusState) signAddVote(voteType byte, hash []byte) *ttypes.Vote {
 	// if we don't have a key or we're not in the validator set, do nothing
@@ -0,0 +1,126 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tendermint
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
+)
+
+// 每个区块最多包含的作恶证据
+const maxEvidencePerBlock = 10
+
+var (
+	evidencePendingPrefix   = []byte("EVP:")
+	evidenceCommittedPrefix = []byte("EVC:")
+)
+
+// EvidencePool 保存验证过的作恶证据, 直到被打包进区块,
+// 待打包和已打包的证据都写入db, 重启后不会丢失证据, 也不会重复打包
+type EvidencePool struct {
+	mtx sync.Mutex
+	db  dbm.DB
+}
+
+// NewEvidencePool returns a new EvidencePool with the given DB
+func NewEvidencePool(db dbm.DB) *EvidencePool {
+	return &EvidencePool{db: db}
+}
+
+// 按证据高度排序, 先打包较早的证据
+func calcEvidenceKey(prefix []byte, ev *ttypes.DuplicateVoteEvidence) []byte {
+	return []byte(fmt.Sprintf("%s%020d:%X", prefix, ev.Height(), ev.Hash()))
+}
+
+func (evpool *EvidencePool) has(key []byte) bool {
+	value, err := evpool.db.Get(key)
+	return err == nil && len(value) > 0
+}
+
+// AddEvidence 加入已验证的证据, 已经存在或者已经打包的证据返回ErrEvidenceExists
+func (evpool *EvidencePool) AddEvidence(ev *ttypes.DuplicateVoteEvidence) error {
+	evpool.mtx.Lock()
+	defer evpool.mtx.Unlock()
+	pendingKey := calcEvidenceKey(evidencePendingPrefix, ev)
+	if evpool.has(pendingKey) || evpool.has(calcEvidenceKey(evidenceCommittedPrefix, ev)) {
+		return ttypes.ErrEvidenceExists
+	}
+	return evpool.db.SetSync(pendingKey, types.Encode(ev.DuplicateVoteEvidence))
+}
+
+// IsCommitted 证据是否已经打包
+func (evpool *EvidencePool) IsCommitted(ev *ttypes.DuplicateVoteEvidence) bool {
+	evpool.mtx.Lock()
+	defer evpool.mtx.Unlock()
+	return evpool.has(calcEvidenceKey(evidenceCommittedPrefix, ev))
+}
+
+// PendingEvidence 返回最多max个待打包的证据
+func (evpool *EvidencePool) PendingEvidence(max int) []*tmtypes.DuplicateVoteEvidence {
+	evpool.mtx.Lock()
+	defer evpool.mtx.Unlock()
+	var evidence []*tmtypes.DuplicateVoteEvidence
+	it := evpool.db.Iterator(evidencePendingPrefix, nil, false)
+	defer it.Close()
+	for it.Rewind(); it.Valid() && len(evidence) < max; it.Next() {
+		ev := &tmtypes.DuplicateVoteEvidence{}
+		if err := types.Decode(it.ValueCopy(), ev); err != nil {
+			tendermintlog.Error("PendingEvidence decode fail", "key", string(it.Key()), "err", err)
+			continue
+		}
+		evidence = append(evidence, ev)
+	}
+	return evidence
+}
+
+// Update 标记区块中打包的证据, 删除低于minHeight的过期证据
+func (evpool *EvidencePool) Update(evidence []*tmtypes.DuplicateVoteEvidence, minHeight int64) error {
+	evpool.mtx.Lock()
+	defer evpool.mtx.Unlock()
+	batch := evpool.db.NewBatch(true)
+	for _, item := range evidence {
+		ev := &ttypes.DuplicateVoteEvidence{DuplicateVoteEvidence: item}
+		batch.Delete(calcEvidenceKey(evidencePendingPrefix, ev))
+		batch.Set(calcEvidenceKey(evidenceCommittedPrefix, ev), []byte{1})
+	}
+	for _, prefix := range [][]byte{evidencePendingPrefix, evidenceCommittedPrefix} {
+		it := evpool.db.Iterator(prefix, []byte(fmt.Sprintf("%s%020d", prefix, minHeight)), false)
+		for it.Rewind(); it.Valid(); it.Next() {
+			batch.Delete(it.Key())
+		}
+		it.Close()
+	}
+	return batch.Write()
+}
+
+// verifyEvidence 检查证据没有过期, 并且是该高度的验证者签名的
+func verifyEvidence(stateDB *CSStateDB, s State, ev *ttypes.DuplicateVoteEvidence) error {
+	height := s.LastBlockHeight + 1
+	if ev.Height() > height {
+		return fmt.Errorf("%v: evidence height %v is higher than %v", ttypes.ErrEvidenceInvalid, ev.Height(), height)
+	}
+	maxAge := s.ConsensusParams.EvidenceParams.MaxAge
+	if maxAge > 0 && ev.Height() < height-maxAge {
+		return ttypes.ErrEvidenceTooOld
+	}
+	valSet, err := stateDB.validatorsAt(s, ev.Height())
+	if err != nil {
+		return err
+	}
+	_, val := valSet.GetByAddress(ev.Address())
+	if val == nil {
+		return ttypes.ErrEvidenceNotFound
+	}
+	if !bytes.Equal(val.PubKey, ev.PubKey) {
+		return fmt.Errorf("%v: pubkey does not match the validator", ttypes.ErrEvidenceInvalid)
+	}
+	return ev.Verify(s.ChainID, ttypes.ConsensusCrypto)
+}
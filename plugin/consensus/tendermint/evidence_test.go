@@ -0,0 +1,148 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tendermint
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/33cn/chain33/common/crypto"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signTestVote(t *testing.T, pv *ttypes.PrivValidatorImp, height int64, hash []byte) *ttypes.Vote {
+	vote := &ttypes.Vote{Vote: &tmtypes.Vote{
+		ValidatorAddress: pv.GetAddress(),
+		ValidatorIndex:   0,
+		Height:           height,
+		Round:            0,
+		Type:             uint32(ttypes.VoteTypePrevote),
+		BlockID:          &tmtypes.BlockID{Hash: hash},
+	}}
+	require.Nil(t, pv.SignVote("test", vote))
+	return vote
+}
+
+func makeTestEvidence(t *testing.T, pv *ttypes.PrivValidatorImp, vals *ttypes.ValidatorSet, height int64) *ttypes.DuplicateVoteEvidence {
+	voteSet := ttypes.NewVoteSet("test", height, 0, ttypes.VoteTypePrevote, vals)
+	added, err := voteSet.AddVote(signTestVote(t, pv, height, []byte("hashB")))
+	require.Nil(t, err)
+	require.True(t, added)
+	_, err = voteSet.AddVote(signTestVote(t, pv, height, []byte("hashA")))
+	conflict, ok := err.(*ttypes.ErrVoteConflictingVotes)
+	require.True(t, ok)
+	return conflict.DuplicateVoteEvidence
+}
+
+func TestDuplicateVoteEvidence(t *testing.T) {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	require.Nil(t, err)
+	ttypes.ConsensusCrypto = cr
+	pv := ttypes.GenPrivValidatorImp("")
+	other := ttypes.GenPrivValidatorImp("")
+	vals := ttypes.NewValidatorSet([]*ttypes.Validator{ttypes.NewValidator(pv.GetPubKey(), 10)})
+
+	ev := makeTestEvidence(t, pv, vals, 5)
+	assert.Equal(t, []byte("hashA"), ev.VoteA.BlockID.Hash)
+	assert.Equal(t, []byte("hashB"), ev.VoteB.BlockID.Hash)
+	require.Nil(t, ev.ValidateBasic())
+
+	s := State{ChainID: "test", LastBlockHeight: 5, Validators: vals, LastValidators: vals}
+	s.ConsensusParams = *ttypes.DefaultConsensusParams()
+	s.ConsensusParams.EvidenceParams.MaxAge = 3
+	assert.Nil(t, verifyEvidence(nil, s, ev))
+
+	//签名被篡改
+	tampered := &ttypes.DuplicateVoteEvidence{DuplicateVoteEvidence: &tmtypes.DuplicateVoteEvidence{
+		PubKey: ev.PubKey,
+		VoteA:  ev.VoteA,
+		VoteB:  &tmtypes.Vote{},
+	}}
+	*tampered.VoteB = *ev.VoteB
+	tampered.VoteB.Signature = ev.VoteA.Signature
+	assert.Equal(t, ttypes.ErrVoteInvalidSignature, verifyEvidence(nil, s, tampered))
+
+	//不是当前的验证者
+	s.Validators = ttypes.NewValidatorSet([]*ttypes.Validator{ttypes.NewValidator(other.GetPubKey(), 10)})
+	s.LastBlockHeight = 4
+	assert.Equal(t, ttypes.ErrEvidenceNotFound, verifyEvidence(nil, s, ev))
+
+	//超过最大有效期
+	s.LastBlockHeight = 9
+	assert.Equal(t, ttypes.ErrEvidenceTooOld, verifyEvidence(nil, s, ev))
+
+	//证据高度高于下一个区块
+	s.LastBlockHeight = 3
+	assert.NotNil(t, verifyEvidence(nil, s, ev))
+}
+
+func TestEvidencePool(t *testing.T) {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	require.Nil(t, err)
+	ttypes.ConsensusCrypto = cr
+	dir, err := ioutil.TempDir("", "tendermintevidence")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	pv := ttypes.GenPrivValidatorImp("")
+	vals := ttypes.NewValidatorSet([]*ttypes.Validator{ttypes.NewValidator(pv.GetPubKey(), 10)})
+	ev1 := makeTestEvidence(t, pv, vals, 2)
+	ev2 := makeTestEvidence(t, pv, vals, 5)
+
+	db := dbm.NewDB("evidence", "leveldb", dir, 0)
+	defer db.Close()
+	evpool := NewEvidencePool(db)
+	require.Nil(t, evpool.AddEvidence(ev2))
+	require.Nil(t, evpool.AddEvidence(ev1))
+	assert.Equal(t, ttypes.ErrEvidenceExists, evpool.AddEvidence(ev1))
+
+	pending := evpool.PendingEvidence(maxEvidencePerBlock)
+	require.Equal(t, 2, len(pending))
+	assert.Equal(t, ev1.Hash(), (&ttypes.DuplicateVoteEvidence{DuplicateVoteEvidence: pending[0]}).Hash())
+	assert.Equal(t, 1, len(evpool.PendingEvidence(1)))
+
+	//打包后不再重复加入
+	require.Nil(t, evpool.Update([]*tmtypes.DuplicateVoteEvidence{ev1.DuplicateVoteEvidence}, 0))
+	assert.True(t, evpool.IsCommitted(ev1))
+	assert.Equal(t, ttypes.ErrEvidenceExists, evpool.AddEvidence(ev1))
+	assert.Equal(t, 1, len(evpool.PendingEvidence(maxEvidencePerBlock)))
+
+	//删除过期的证据
+	require.Nil(t, evpool.Update(nil, 3))
+	assert.False(t, evpool.IsCommitted(ev1))
+	pending = evpool.PendingEvidence(maxEvidencePerBlock)
+	require.Equal(t, 1, len(pending))
+	assert.Equal(t, ev2.Hash(), (&ttypes.DuplicateVoteEvidence{DuplicateVoteEvidence: pending[0]}).Hash())
+}
+
+func TestBlockEvidenceHash(t *testing.T) {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	require.Nil(t, err)
+	ttypes.ConsensusCrypto = cr
+	pv := ttypes.GenPrivValidatorImp("")
+	vals := ttypes.NewValidatorSet([]*ttypes.Validator{ttypes.NewValidator(pv.GetPubKey(), 10)})
+	ev := makeTestEvidence(t, pv, vals, 1)
+
+	evidence := []*tmtypes.DuplicateVoteEvidence{ev.DuplicateVoteEvidence}
+	block := ttypes.MakeBlock(1, 0, &types.Block{}, &tmtypes.TendermintCommit{}, evidence)
+	assert.Equal(t, ttypes.EvidenceHash(evidence), block.Header.EvidenceHash)
+	require.Nil(t, block.ValidateBasic())
+
+	//证据包含在区块哈希中
+	empty := ttypes.MakeBlock(1, 0, &types.Block{}, &tmtypes.TendermintCommit{}, nil)
+	assert.Nil(t, empty.Header.EvidenceHash)
+	block.Header.ValidatorsHash = vals.Hash()
+	empty.Header.ValidatorsHash = vals.Hash()
+	assert.NotEqual(t, empty.Hash(), block.Hash())
+
+	block.Evidence = nil
+	assert.NotNil(t, block.ValidateBasic())
+}
@@ -238,5 +238,12 @@ func validateBlock(stateDB *CSStateDB, s State, b *ttypes.TendermintBlock) error
 		}
 	}
 
+	// Validate block evidence.
+	for _, item := range b.Evidence {
+		if err := verifyEvidence(stateDB, s, &ttypes.DuplicateVoteEvidence{DuplicateVoteEvidence: item}); err != nil {
+			return fmt.Errorf("Invalid evidence: %v", err)
+		}
+	}
+
 	return nil
 }
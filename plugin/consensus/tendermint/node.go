@@ -123,6 +123,11 @@ type Node struct {
 	dialing      *IP2IPPort
 	reconnecting *IP2IPPort
 
+	addrBook      *ValidatorAddrBook
+	addrChannel   chan MsgInfo
+	privValidator ttypes.PrivValidator
+	advertiseAddr string
+
 	seeds    []string
 	protocol string
 	lAddr    string
@@ -151,6 +156,8 @@ func NewNode(seeds []string, protocol string, lAddr string, privKey crypto.PrivK
 		ID:               ID(hex.EncodeToString(address)),
 		dialing:          NewMutexMap(),
 		reconnecting:     NewMutexMap(),
+		addrBook:         NewValidatorAddrBook(),
+		addrChannel:      make(chan MsgInfo, maxAddrChannelSize),
 		broadcastChannel: make(chan MsgInfo, maxSendQueueSize),
 		state:            state,
 		localIPs:         make(map[string]net.IP),
@@ -213,6 +220,7 @@ func (node *Node) Start() {
 
 		go node.StartConsensusRoutine()
 		go node.BroadcastRoutine()
+		go node.validatorAddrRoutine()
 	}
 }
 
@@ -227,7 +235,7 @@ func (node *Node) DialPeerWithAddress(addr string) error {
 func (node *Node) addOutboundPeerWithConfig(addr string) error {
 	tendermintlog.Info("Dialing peer", "address", addr)
 
-	peerConn, err := newOutboundPeerConn(addr, node.privKey, node.StopPeerForError, node.state)
+	peerConn, err := newOutboundPeerConn(addr, node.privKey, node.StopPeerForError, node.state, true)
 	if err != nil {
 		go node.reconnectToPeer(addr)
 		return err
@@ -424,6 +432,7 @@ func (node *Node) addPeer(pc *peerConn) error {
 	// All good. Start peer
 	if node.IsRunning() {
 		pc.SetTransferChannel(node.state.peerMsgQueue)
+		pc.SetAddrChannel(node.addrChannel)
 		if err = node.startInitPeer(pc); err != nil {
 			return err
 		}
@@ -437,6 +446,7 @@ func (node *Node) addPeer(pc *peerConn) error {
 	}
 
 	tendermintlog.Info("Added peer", "peer", pc.ip)
+	node.sendValidatorAddrs(pc)
 	return nil
 }
 
@@ -637,13 +647,13 @@ func dial(addr string) (net.Conn, error) {
 	return conn, nil
 }
 
-func newOutboundPeerConn(addr string, ourNodePrivKey crypto.PrivKey, onPeerError func(Peer, interface{}), state *ConsensusState) (*peerConn, error) {
+func newOutboundPeerConn(addr string, ourNodePrivKey crypto.PrivKey, onPeerError func(Peer, interface{}), state *ConsensusState, persistent bool) (*peerConn, error) {
 	conn, err := dial(addr)
 	if err != nil {
 		return &peerConn{}, fmt.Errorf("Error creating peer:%v", err)
 	}
 
-	pc, err := newPeerConn(conn, true, true, ourNodePrivKey, onPeerError, state)
+	pc, err := newPeerConn(conn, true, persistent, ourNodePrivKey, onPeerError, state)
 	if err != nil {
 		if cerr := conn.Close(); cerr != nil {
 			return &peerConn{}, fmt.Errorf("newPeerConn failed:%v, connection close failed:%v", err, cerr)
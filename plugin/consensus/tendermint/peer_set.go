@@ -91,6 +91,7 @@ type peerConn struct {
 	waitQuit   sync.WaitGroup
 
 	transferChannel chan MsgInfo
+	addrChannel     chan MsgInfo
 
 	sendBuffer []byte
 
@@ -150,6 +151,18 @@ func (ps *PeerSet) Has(peerKey ID) bool {
 	return ok
 }
 
+// Get looks up a peer by the provided peerKey. Returns nil if peer is not
+// found.
+func (ps *PeerSet) Get(peerKey ID) Peer {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	item, ok := ps.lookup[peerKey]
+	if ok {
+		return item.peer
+	}
+	return nil
+}
+
 // HasIP returns true if the set contains the peer referred to by this IP
 // address, otherwise false.
 func (ps *PeerSet) HasIP(peerIP net.IP) bool {
@@ -265,6 +278,11 @@ func (pc *peerConn) SetTransferChannel(transferChannel chan MsgInfo) {
 	pc.transferChannel = transferChannel
 }
 
+// SetAddrChannel 设置验证者地址消息的接收通道
+func (pc *peerConn) SetAddrChannel(addrChannel chan MsgInfo) {
+	pc.addrChannel = addrChannel
+}
+
 func (pc *peerConn) CloseConn() {
 	err := pc.conn.Close() // nolint: errcheck
 	if err != nil {
@@ -563,7 +581,7 @@ FOR_LOOP:
 					continue
 				}
 				if pc.transferChannel != nil && (pkt.TypeID == ttypes.ProposalID || pkt.TypeID == ttypes.VoteID ||
-					pkt.TypeID == ttypes.ProposalBlockID) {
+					pkt.TypeID == ttypes.ProposalBlockID || pkt.TypeID == ttypes.EvidenceID) {
 					pc.transferChannel <- MsgInfo{pkt.TypeID, realMsg.(proto.Message), pc.ID(), pc.ip.String()}
 					if pkt.TypeID == ttypes.ProposalID {
 						proposal := realMsg.(*tmtypes.Proposal)
@@ -580,6 +598,14 @@ FOR_LOOP:
 					}
 				} else if pkt.TypeID == ttypes.ProposalHeartbeatID {
 					pc.heartbeatQueue <- realMsg.(*tmtypes.Heartbeat)
+				} else if pkt.TypeID == ttypes.ValidatorAddressID {
+					if pc.addrChannel != nil {
+						select {
+						case pc.addrChannel <- MsgInfo{pkt.TypeID, realMsg.(proto.Message), pc.ID(), pc.ip.String()}:
+						default:
+							tendermintlog.Debug("Drop validator address, channel is full", "peerip", pc.ip.String())
+						}
+					}
 				} else {
 					pc.updateStateQueue <- MsgInfo{pkt.TypeID, realMsg.(proto.Message), pc.ID(), pc.ip.String()}
 				}
@@ -0,0 +1,76 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tendermint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/consensus/signer"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemotePrivValidator(t *testing.T) {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	require.Nil(t, err)
+	ttypes.ConsensusCrypto = cr
+	dir, err := ioutil.TempDir("", "tendermintsigner")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ks := signer.NewKeystore(dir)
+	pub, err := ks.GenKey("validator", types.GetSignName("", types.ED25519), []byte("123456"))
+	require.Nil(t, err)
+	server, err := signer.NewServer(ks, []byte("123456"), nil)
+	require.Nil(t, err)
+	addr := "unix://" + filepath.Join(dir, "signer.sock")
+	lis, err := server.Listen(addr)
+	require.Nil(t, err)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	pv, err := ttypes.NewRemotePrivValidator(addr, "validator", nil)
+	require.Nil(t, err)
+	assert.Equal(t, pub.Bytes(), pv.GetPubKey().Bytes())
+	assert.Equal(t, ttypes.GenAddressByPubKey(pub), pv.GetAddress())
+
+	newVote := func(hash []byte) *ttypes.Vote {
+		return &ttypes.Vote{Vote: &tmtypes.Vote{
+			ValidatorAddress: pv.GetAddress(),
+			Height:           5,
+			Round:            1,
+			Type:             uint32(ttypes.VoteTypePrevote),
+			BlockID:          &tmtypes.BlockID{Hash: hash},
+		}}
+	}
+	vote := newVote([]byte("hashA"))
+	require.Nil(t, pv.SignVote("test", vote))
+	require.Nil(t, vote.Verify("test", pv.GetPubKey()))
+	assert.Equal(t, int64(5), pv.GetLastHeight())
+	assert.Equal(t, 1, pv.GetLastRound())
+
+	//同一高度/轮次对不同的区块投票被签名服务拒绝
+	assert.NotNil(t, pv.SignVote("test", newVote([]byte("hashB"))))
+	proposal := &ttypes.Proposal{Proposal: tmtypes.Proposal{Height: 5, Round: 1, POLRound: -1, POLBlockID: &tmtypes.BlockID{}, Blockhash: []byte("hashA")}}
+	assert.NotNil(t, pv.SignProposal("test", proposal))
+	heartbeat := &ttypes.Heartbeat{Heartbeat: &tmtypes.Heartbeat{ValidatorAddress: pv.GetAddress(), Height: 5}}
+	assert.Nil(t, pv.SignHeartbeat("test", heartbeat))
+
+	//节点重启后重置本地高度也不能重复签名
+	pv, err = ttypes.NewRemotePrivValidator(addr, "validator", nil)
+	require.Nil(t, err)
+	pv.ResetLastHeight(4)
+	assert.NotNil(t, pv.SignVote("test", newVote([]byte("hashB"))))
+	again := newVote([]byte("hashA"))
+	require.Nil(t, pv.SignVote("test", again))
+	assert.Equal(t, vote.Signature, again.Signature)
+}
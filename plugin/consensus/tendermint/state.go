@@ -114,10 +114,10 @@ func (s State) GetValidators() (last *ttypes.ValidatorSet, current *ttypes.Valid
 //------------------------------------------------------------------------
 // Create a block from the latest state
 
-// MakeBlock builds a block with the given txs and commit from the current state.
-func (s State) MakeBlock(height int64, round int64, pblock *types.Block, commit *tmtypes.TendermintCommit, proposerAddr []byte) *ttypes.TendermintBlock {
+// MakeBlock builds a block with the given txs, commit and evidence from the current state.
+func (s State) MakeBlock(height int64, round int64, pblock *types.Block, commit *tmtypes.TendermintCommit, evidence []*tmtypes.DuplicateVoteEvidence, proposerAddr []byte) *ttypes.TendermintBlock {
 	// build base block
-	block := ttypes.MakeBlock(height, round, pblock, commit)
+	block := ttypes.MakeBlock(height, round, pblock, commit, evidence)
 
 	// fill header with state data
 	block.Header.ChainID = s.ChainID
@@ -312,6 +312,24 @@ func (csdb *CSStateDB) LoadValidators(height int64) (*ttypes.ValidatorSet, error
 	return load.Validators.Copy(), nil
 }
 
+// validatorsAt returns the validator set voting at height, s is the state until the working height-1
+func (csdb *CSStateDB) validatorsAt(s State, height int64) (*ttypes.ValidatorSet, error) {
+	switch height {
+	case s.LastBlockHeight + 1:
+		return s.Validators, nil
+	case s.LastBlockHeight:
+		return s.LastValidators, nil
+	}
+	if height < 1 {
+		return nil, ttypes.ErrHeightLessThanOne
+	}
+	state := csdb.client.LoadBlockState(height)
+	if state == nil {
+		return nil, errors.New("ErrLoadBlockState")
+	}
+	return LoadState(state).Validators, nil
+}
+
 func saveConsensusParams(dest *tmtypes.ConsensusParams, source ttypes.ConsensusParams) {
 	dest.BlockSize.MaxBytes = int32(source.BlockSize.MaxBytes)
 	dest.BlockSize.MaxTxs = int32(source.BlockSize.MaxTxs)
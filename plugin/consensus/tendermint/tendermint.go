@@ -6,6 +6,7 @@ package tendermint
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
@@ -19,6 +20,7 @@ import (
 	cty "github.com/33cn/chain33/system/dapp/coins/types"
 	"github.com/33cn/chain33/types"
 	"github.com/33cn/chain33/util"
+	"github.com/33cn/plugin/plugin/consensus/signer"
 	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
 	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
 	"github.com/golang/protobuf/proto"
@@ -47,6 +49,10 @@ var (
 	peerQueryMaj23SleepDuration int32 = 2000
 	zeroHash                    [32]byte
 	random                      *rand.Rand
+	remoteSigner                string
+	signerKey                   = "validator"
+	signerTLS                   *signer.TLSConfig
+	advertiseAddr               string
 )
 
 func init() {
@@ -87,6 +93,12 @@ type subConfig struct {
 	CreateEmptyBlocksInterval int32    `json:"createEmptyBlocksInterval"`
 	ValidatorNodes            []string `json:"validatorNodes"`
 	FastSync                  bool     `json:"fastSync"`
+	RemoteSigner              string   `json:"remoteSigner"`
+	SignerKey                 string   `json:"signerKey"`
+	SignerTLSCert             string   `json:"signerTLSCert"`
+	SignerTLSKey              string   `json:"signerTLSKey"`
+	SignerTLSCA               string   `json:"signerTLSCA"`
+	AdvertiseAddr             string   `json:"advertiseAddr"`
 }
 
 func (client *Client) applyConfig(sub []byte) {
@@ -133,6 +145,14 @@ func (client *Client) applyConfig(sub []byte) {
 		validatorNodes = subcfg.ValidatorNodes
 	}
 	fastSync = subcfg.FastSync
+	remoteSigner = subcfg.RemoteSigner
+	if subcfg.SignerKey != "" {
+		signerKey = subcfg.SignerKey
+	}
+	if subcfg.SignerTLSCert != "" {
+		signerTLS = &signer.TLSConfig{CertFile: subcfg.SignerTLSCert, KeyFile: subcfg.SignerTLSKey, CAFile: subcfg.SignerTLSCA}
+	}
+	advertiseAddr = subcfg.AdvertiseAddr
 }
 
 // DefaultDBProvider returns a database using the DBBackend and DBDir
@@ -167,33 +187,45 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 		return nil
 	}
 
-	privValidator := ttypes.LoadOrGenPrivValidatorFS("priv_validator.json")
-	if privValidator == nil {
-		tendermintlog.Error("NewTendermintClient create priv_validator file failed")
-		return nil
-	}
-
 	ttypes.InitMessageMap()
 
-	pubkey := privValidator.GetPubKey().KeyString()
 	c := drivers.NewBaseClient(cfg)
 	client := &Client{
-		BaseClient:    c,
-		genesisDoc:    genDoc,
-		privValidator: privValidator,
-		privKey:       priv,
-		pubKey:        pubkey,
-		csStore:       NewConsensusStore(),
-		crypto:        cr,
-		txsAvailable:  make(chan int64, 1),
-		stopC:         make(chan struct{}, 1),
+		BaseClient:   c,
+		genesisDoc:   genDoc,
+		privKey:      priv,
+		csStore:      NewConsensusStore(),
+		crypto:       cr,
+		txsAvailable: make(chan int64, 1),
+		stopC:        make(chan struct{}, 1),
 	}
 	c.SetChild(client)
 
 	client.applyConfig(sub)
+
+	privValidator, err := loadPrivValidator()
+	if err != nil {
+		tendermintlog.Error("NewTendermintClient load priv_validator failed", "err", err)
+		return nil
+	}
+	client.privValidator = privValidator
+	client.pubKey = privValidator.GetPubKey().KeyString()
 	return client
 }
 
+// 配置了remoteSigner时使用远程签名服务, 否则使用本地的priv_validator.json
+func loadPrivValidator() (ttypes.PrivValidator, error) {
+	if remoteSigner != "" {
+		tendermintlog.Info("Use remote signer", "addr", remoteSigner, "key", signerKey)
+		return ttypes.NewRemotePrivValidator(remoteSigner, signerKey, signerTLS)
+	}
+	privValidator := ttypes.LoadOrGenPrivValidatorFS("priv_validator.json")
+	if privValidator == nil {
+		return nil, errors.New("create priv_validator file failed")
+	}
+	return privValidator, nil
+}
+
 // PrivValidator returns the Node's PrivValidator.
 func (client *Client) PrivValidator() ttypes.PrivValidator {
 	return client.privValidator
@@ -306,6 +338,8 @@ OuterLoop:
 
 	// Make ConsensusReactor
 	csState := NewConsensusState(client, state, blockExec)
+	csState.SetWAL(NewConsensusWAL(DefaultDBProvider("wal")))
+	csState.SetEvidencePool(NewEvidencePool(DefaultDBProvider("evidence")))
 	// reset height, round, state begin at newheigt,0,0
 	client.privValidator.ResetLastHeight(state.LastBlockHeight)
 	csState.SetPrivValidator(client.privValidator)
@@ -316,6 +350,8 @@ OuterLoop:
 	protocol, listeningAddress := "tcp", "0.0.0.0:46656"
 	node := NewNode(validatorNodes, protocol, listeningAddress, client.privKey, state.ChainID, tendermintVersion, csState)
 
+	node.SetPrivValidator(client.privValidator, advertiseAddr)
+
 	client.node = node
 	node.Start()
 
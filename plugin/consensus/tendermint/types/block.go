@@ -57,7 +57,7 @@ type TendermintBlock struct {
 
 // MakeBlock returns a new block with an empty header, except what can be computed from itself.
 // It populates the same set of fields validated by ValidateBasic
-func MakeBlock(height int64, round int64, pblock *types.Block, commit *tmtypes.TendermintCommit) *TendermintBlock {
+func MakeBlock(height int64, round int64, pblock *types.Block, commit *tmtypes.TendermintCommit, evidence []*tmtypes.DuplicateVoteEvidence) *TendermintBlock {
 	block := &TendermintBlock{
 		&tmtypes.TendermintBlock{
 			Header: &tmtypes.TendermintBlockHeader{
@@ -68,6 +68,7 @@ func MakeBlock(height int64, round int64, pblock *types.Block, commit *tmtypes.T
 			},
 			Data:       pblock,
 			LastCommit: commit,
+			Evidence:   evidence,
 		},
 	}
 	block.FillHeader()
@@ -112,6 +113,24 @@ func (b *TendermintBlock) ValidateBasic() error {
 		return fmt.Errorf("Wrong Header.LastCommitHash.  Expected %v, got %v", b.Header.LastCommitHash, lastCommit.Hash())
 	}
 
+	evidenceSet := make(map[string]bool)
+	for _, item := range b.Evidence {
+		ev := &DuplicateVoteEvidence{DuplicateVoteEvidence: item}
+		if err := ev.ValidateBasic(); err != nil {
+			return err
+		}
+		if evidenceSet[string(ev.Hash())] {
+			return fmt.Errorf("Duplicate evidence %v", ev)
+		}
+		evidenceSet[string(ev.Hash())] = true
+		if ev.Height() > b.Header.Height {
+			return fmt.Errorf("Evidence height %v is higher than block height %v", ev.Height(), b.Header.Height)
+		}
+	}
+	if !bytes.Equal(b.Header.EvidenceHash, EvidenceHash(b.Evidence)) {
+		return fmt.Errorf("Wrong Header.EvidenceHash.  Expected %X, got %X", EvidenceHash(b.Evidence), b.Header.EvidenceHash)
+	}
+
 	return nil
 }
 
@@ -123,6 +142,9 @@ func (b *TendermintBlock) FillHeader() {
 		}
 		b.Header.LastCommitHash = lastCommit.Hash()
 	}
+	if b.Header.EvidenceHash == nil {
+		b.Header.EvidenceHash = EvidenceHash(b.Evidence)
+	}
 }
 
 // Hash computes and returns the block hash.
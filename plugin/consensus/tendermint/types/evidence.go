@@ -0,0 +1,138 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/common/merkle"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
+)
+
+// evidence error defines
+var (
+	ErrEvidenceInvalid  = errors.New("Invalid evidence")
+	ErrEvidenceTooOld   = errors.New("Evidence is too old")
+	ErrEvidenceExists   = errors.New("Evidence already exists")
+	ErrEvidenceNotFound = errors.New("Evidence validator not found")
+)
+
+// ErrVoteConflictingVotes is returned when a validator signs two different blocks
+// at the same height/round/type, it carries the evidence of the double sign.
+type ErrVoteConflictingVotes struct {
+	*DuplicateVoteEvidence
+}
+
+func (err *ErrVoteConflictingVotes) Error() string {
+	return fmt.Sprintf("%v: %v; New vote: %v", ErrVoteConflict, &Vote{Vote: err.VoteA}, &Vote{Vote: err.VoteB})
+}
+
+// DuplicateVoteEvidence contains evidence a validator signed two conflicting votes.
+type DuplicateVoteEvidence struct {
+	*tmtypes.DuplicateVoteEvidence
+}
+
+// NewDuplicateVoteEvidence orders the two votes by block hash,
+// so that every node makes the same evidence for the same pair of votes.
+func NewDuplicateVoteEvidence(pubKey []byte, vote1, vote2 *Vote) *DuplicateVoteEvidence {
+	voteA, voteB := vote1.Vote, vote2.Vote
+	if bytes.Compare(voteA.BlockID.Hash, voteB.BlockID.Hash) > 0 {
+		voteA, voteB = voteB, voteA
+	}
+	return &DuplicateVoteEvidence{&tmtypes.DuplicateVoteEvidence{
+		PubKey: pubKey,
+		VoteA:  voteA,
+		VoteB:  voteB,
+	}}
+}
+
+// Height returns the height the evidence was created at
+func (dve *DuplicateVoteEvidence) Height() int64 {
+	return dve.VoteA.Height
+}
+
+// Address returns the address of the validator
+func (dve *DuplicateVoteEvidence) Address() []byte {
+	return dve.VoteA.ValidatorAddress
+}
+
+// Hash returns the hash of the evidence
+func (dve *DuplicateVoteEvidence) Hash() []byte {
+	data, err := json.Marshal(dve.DuplicateVoteEvidence)
+	if err != nil {
+		blocklog.Error("evidence hash marshal failed", "err", err)
+		return nil
+	}
+	return crypto.Ripemd160(data)
+}
+
+// String returns a string representation of the evidence
+func (dve *DuplicateVoteEvidence) String() string {
+	return fmt.Sprintf("DuplicateVoteEvidence{%X VoteA: %v, VoteB: %v}",
+		Fingerprint(dve.Address()), &Vote{Vote: dve.VoteA}, &Vote{Vote: dve.VoteB})
+}
+
+// ValidateBasic checks the two votes are for different blocks at the same height/round/type
+func (dve *DuplicateVoteEvidence) ValidateBasic() error {
+	if dve.DuplicateVoteEvidence == nil || len(dve.PubKey) == 0 {
+		return ErrEvidenceInvalid
+	}
+	voteA, voteB := dve.VoteA, dve.VoteB
+	if voteA == nil || voteB == nil || voteA.BlockID == nil || voteB.BlockID == nil || !IsVoteTypeValid(byte(voteA.Type)) {
+		return ErrEvidenceInvalid
+	}
+	if voteA.Height != voteB.Height || voteA.Round != voteB.Round || voteA.Type != voteB.Type {
+		return fmt.Errorf("%v: votes are for different height/round/type, %v/%v/%v vs %v/%v/%v", ErrEvidenceInvalid,
+			voteA.Height, voteA.Round, voteA.Type, voteB.Height, voteB.Round, voteB.Type)
+	}
+	if !bytes.Equal(voteA.ValidatorAddress, voteB.ValidatorAddress) || voteA.ValidatorIndex != voteB.ValidatorIndex {
+		return fmt.Errorf("%v: votes are from different validators", ErrEvidenceInvalid)
+	}
+	if bytes.Compare(voteA.BlockID.Hash, voteB.BlockID.Hash) >= 0 {
+		return fmt.Errorf("%v: votes are for the same block or not ordered by block hash", ErrEvidenceInvalid)
+	}
+	return nil
+}
+
+// Verify checks both votes are signed by the pubkey of the evidence.
+// The crypto is passed in since the valnode executor verifies the evidence as well.
+func (dve *DuplicateVoteEvidence) Verify(chainID string, cr crypto.Crypto) error {
+	if err := dve.ValidateBasic(); err != nil {
+		return err
+	}
+	pubKey, err := cr.PubKeyFromBytes(dve.PubKey)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(GenAddressByPubKey(pubKey), dve.Address()) {
+		return ErrVoteInvalidValidatorAddress
+	}
+	for _, item := range []*tmtypes.Vote{dve.VoteA, dve.VoteB} {
+		sig, err := cr.SignatureFromBytes(item.Signature)
+		if err != nil {
+			return err
+		}
+		if !pubKey.VerifyBytes(SignBytes(chainID, &Vote{Vote: item}), sig) {
+			return ErrVoteInvalidSignature
+		}
+	}
+	return nil
+}
+
+// EvidenceHash returns the merkle root of the evidence list, nil if there is no evidence
+func EvidenceHash(evidence []*tmtypes.DuplicateVoteEvidence) []byte {
+	if len(evidence) == 0 {
+		return nil
+	}
+	bs := make([][]byte, len(evidence))
+	for i, item := range evidence {
+		bs[i] = (&DuplicateVoteEvidence{DuplicateVoteEvidence: item}).Hash()
+	}
+	return merkle.GetMerkleRoot(bs)
+}
@@ -54,6 +54,7 @@ type PrivValidator interface {
 	SignVote(chainID string, vote *Vote) error
 	SignProposal(chainID string, proposal *Proposal) error
 	SignHeartbeat(chainID string, heartbeat *Heartbeat) error
+	SignValidatorAddress(chainID string, addr *ValidatorAddress) error
 
 	GetLastHeight() int64
 	GetLastRound() int
@@ -438,6 +439,19 @@ func (pv *PrivValidatorImp) SignHeartbeat(chainID string, heartbeat *Heartbeat)
 	return err
 }
 
+// SignValidatorAddress signs a canonical representation of the validator address, along with the chainID.
+// Implements PrivValidator.
+func (pv *PrivValidatorImp) SignValidatorAddress(chainID string, addr *ValidatorAddress) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	sig, err := pv.Sign(SignBytes(chainID, addr))
+	if err != nil {
+		return err
+	}
+	addr.Signature = sig.Bytes()
+	return nil
+}
+
 // String returns a string representation of the PrivValidatorImp.
 func (pv *PrivValidatorImp) String() string {
 	return Fmt("PrivValidator{%X LH:%v, LR:%v, LS:%v}", pv.GetAddress(), pv.LastHeight, pv.LastRound, pv.LastStep)
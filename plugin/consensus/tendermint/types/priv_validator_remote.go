@@ -0,0 +1,165 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/plugin/plugin/consensus/signer"
+)
+
+// RemotePrivValidator implements PrivValidator using an external signer process,
+// the private key never leaves the signer, which also persists the last
+// height/round/step and refuses to double sign.
+type RemotePrivValidator struct {
+	Address    []byte
+	PubKey     crypto.PubKey
+	LastHeight int64
+	LastRound  int
+	LastStep   int8
+
+	client *signer.Client
+	mtx    sync.Mutex
+}
+
+// NewRemotePrivValidator connects to the signer at addr and loads the pubkey of keyName,
+// tlsCfg is required when the signer listens on tcp.
+func NewRemotePrivValidator(addr, keyName string, tlsCfg *signer.TLSConfig) (*RemotePrivValidator, error) {
+	client, err := signer.NewClient(addr, keyName, tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+	_, pub, err := client.GetPubKey()
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	pubKey, err := ConsensusCrypto.PubKeyFromBytes(pub)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return &RemotePrivValidator{
+		Address: GenAddressByPubKey(pubKey),
+		PubKey:  pubKey,
+		client:  client,
+	}, nil
+}
+
+// GetAddress returns the address of the validator.
+// Implements PrivValidator.
+func (pv *RemotePrivValidator) GetAddress() []byte {
+	return pv.Address
+}
+
+// GetPubKey returns the public key of the validator.
+// Implements PrivValidator.
+func (pv *RemotePrivValidator) GetPubKey() crypto.PubKey {
+	return pv.PubKey
+}
+
+// sign the signer parses height/round/step from signBytes itself, they are only
+// used here to keep the local copy.
+func (pv *RemotePrivValidator) sign(signBytes []byte, msgType int32, height int64, round int, step int8) ([]byte, error) {
+	sigBytes, err := pv.client.Sign(signBytes, msgType)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := ConsensusCrypto.SignatureFromBytes(sigBytes)
+	if err != nil {
+		return nil, err
+	}
+	if !pv.PubKey.VerifyBytes(signBytes, sig) {
+		return nil, errors.New("remote signer returned an invalid signature")
+	}
+	if step != stepNone {
+		pv.LastHeight = height
+		pv.LastRound = round
+		pv.LastStep = step
+	}
+	return sigBytes, nil
+}
+
+// SignVote signs a canonical representation of the vote, along with the
+// chainID. Implements PrivValidator.
+func (pv *RemotePrivValidator) SignVote(chainID string, vote *Vote) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	sig, err := pv.sign(SignBytes(chainID, vote), signer.MsgTendermintVote, vote.Height, int(vote.Round), voteToStep(vote))
+	if err != nil {
+		return errors.New(Fmt("Error signing vote: %v", err))
+	}
+	vote.Signature = sig
+	return nil
+}
+
+// SignProposal signs a canonical representation of the proposal, along with
+// the chainID. Implements PrivValidator.
+func (pv *RemotePrivValidator) SignProposal(chainID string, proposal *Proposal) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	sig, err := pv.sign(SignBytes(chainID, proposal), signer.MsgTendermintProposal, proposal.Height, int(proposal.Round), stepPropose)
+	if err != nil {
+		return errors.New(Fmt("Error signing proposal: %v", err))
+	}
+	proposal.Signature = sig
+	return nil
+}
+
+// SignHeartbeat signs a canonical representation of the heartbeat, along with the chainID.
+// Implements PrivValidator.
+func (pv *RemotePrivValidator) SignHeartbeat(chainID string, heartbeat *Heartbeat) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	sig, err := pv.sign(SignBytes(chainID, heartbeat), signer.MsgOther, 0, 0, stepNone)
+	if err != nil {
+		return errors.New(Fmt("Error signing heartbeat: %v", err))
+	}
+	heartbeat.Signature = sig
+	return nil
+}
+
+// SignValidatorAddress signs a canonical representation of the validator address, along with the chainID.
+// Implements PrivValidator.
+func (pv *RemotePrivValidator) SignValidatorAddress(chainID string, addr *ValidatorAddress) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	sig, err := pv.sign(SignBytes(chainID, addr), signer.MsgOther, 0, 0, stepNone)
+	if err != nil {
+		return errors.New(Fmt("Error signing validator address: %v", err))
+	}
+	addr.Signature = sig
+	return nil
+}
+
+// GetLastHeight ...
+func (pv *RemotePrivValidator) GetLastHeight() int64 {
+	return pv.LastHeight
+}
+
+// GetLastRound ...
+func (pv *RemotePrivValidator) GetLastRound() int {
+	return pv.LastRound
+}
+
+// GetLastStep ...
+func (pv *RemotePrivValidator) GetLastStep() int8 {
+	return pv.LastStep
+}
+
+// ResetLastHeight only resets the local copy, the signer keeps its own
+// height/round/step so that restarting the node can not make it double sign.
+func (pv *RemotePrivValidator) ResetLastHeight(height int64) {
+	pv.LastHeight = height
+	pv.LastRound = 0
+	pv.LastStep = 0
+}
+
+// String returns a string representation of the RemotePrivValidator.
+func (pv *RemotePrivValidator) String() string {
+	return Fmt("RemotePrivValidator{%X LH:%v, LR:%v, LS:%v}", pv.GetAddress(), pv.LastHeight, pv.LastRound, pv.LastStep)
+}
@@ -42,6 +42,8 @@ const (
 	ProposalHeartbeatID = byte(0x08)
 	ProposalBlockID     = byte(0x09)
 	ValidBlockID        = byte(0x0a)
+	EvidenceID          = byte(0x0b)
+	ValidatorAddressID  = byte(0x0c)
 
 	PacketTypePing = byte(0xff)
 	PacketTypePong = byte(0xfe)
@@ -60,6 +62,8 @@ func InitMessageMap() {
 		ProposalHeartbeatID: reflect.TypeOf(tmtypes.Heartbeat{}),
 		ProposalBlockID:     reflect.TypeOf(tmtypes.TendermintBlock{}),
 		ValidBlockID:        reflect.TypeOf(tmtypes.ValidBlockMsg{}),
+		EvidenceID:          reflect.TypeOf(tmtypes.DuplicateVoteEvidence{}),
+		ValidatorAddressID:  reflect.TypeOf(tmtypes.ValidatorAddress{}),
 	}
 }
 
@@ -280,6 +284,15 @@ type CanonicalJSONOnceHeartbeat struct {
 	Heartbeat CanonicalJSONHeartbeat `json:"heartbeat"`
 }
 
+// CanonicalJSONOnceValidatorAddress ...
+type CanonicalJSONOnceValidatorAddress struct {
+	ChainID   string `json:"chain_id"`
+	PubKey    []byte `json:"pub_key"`
+	NodeID    string `json:"node_id"`
+	Address   string `json:"address"`
+	Timestamp int64  `json:"timestamp"`
+}
+
 // Canonicalize the structs
 
 // CanonicalBlockID ...
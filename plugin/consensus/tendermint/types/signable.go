@@ -120,6 +120,50 @@ func (heartbeat *Heartbeat) WriteSignBytes(chainID string, w io.Writer, n *int,
 	*err = writeErr
 }
 
+// ValidatorAddress is the consensus listen address announced by a validator,
+// it is signed by the validator key so that it can be relayed by any peer.
+type ValidatorAddress struct {
+	*tmtypes.ValidatorAddress
+}
+
+// WriteSignBytes writes the ValidatorAddress for signing.
+func (addr *ValidatorAddress) WriteSignBytes(chainID string, w io.Writer, n *int, err *error) {
+	if *err != nil {
+		return
+	}
+	canonical := CanonicalJSONOnceValidatorAddress{
+		ChainID:   chainID,
+		PubKey:    addr.PubKey,
+		NodeID:    addr.NodeID,
+		Address:   addr.Address,
+		Timestamp: addr.Timestamp,
+	}
+	byteAddr, e := json.Marshal(&canonical)
+	if e != nil {
+		*err = e
+		return
+	}
+	number, writeErr := w.Write(byteAddr)
+	*n = number
+	*err = writeErr
+}
+
+// Verify checks the ValidatorAddress is signed by its pubkey.
+func (addr *ValidatorAddress) Verify(chainID string) error {
+	pubKey, err := ConsensusCrypto.PubKeyFromBytes(addr.PubKey)
+	if err != nil {
+		return err
+	}
+	sig, err := ConsensusCrypto.SignatureFromBytes(addr.Signature)
+	if err != nil {
+		return err
+	}
+	if !pubKey.VerifyBytes(SignBytes(chainID, addr), sig) {
+		return ErrVoteInvalidSignature
+	}
+	return nil
+}
+
 // Types of votes
 // TODO Make a new type "VoteType"
 const (
@@ -127,7 +127,7 @@ func (voteSet *VoteSet) Size() int {
 //    UnexpectedStep | InvalidIndex | InvalidAddress |
 //    InvalidSignature | InvalidBlockHash | ConflictingVotes ]
 // Duplicate votes return added=false, err=nil.
-// Conflicting votes return added=*, err=ErrVoteConflictingVotes.
+// Conflicting votes return added=*, err=*ErrVoteConflictingVotes with the evidence.
 // NOTE: vote should not be mutated after adding.
 // NOTE: VoteSet must not be nil
 // NOTE: Vote must not be nil
@@ -200,7 +200,7 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 	// Add vote and get conflicting vote if any
 	added, conflicting := voteSet.addVerifiedVote(vote, blockKey, val.VotingPower)
 	if conflicting != nil {
-		return added, errors.Wrapf(ErrVoteConflict, "Conflicting vote: %v; New vote: %v", conflicting, vote)
+		return added, &ErrVoteConflictingVotes{NewDuplicateVoteEvidence(val.PubKey, conflicting, vote)}
 	}
 	if !added {
 		PanicSanity("Expected to add non-conflicting vote")
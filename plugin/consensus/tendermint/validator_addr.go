@@ -0,0 +1,240 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tendermint
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
+)
+
+const (
+	// 检查验证者连接的间隔
+	validatorAddrInterval = 30 * time.Second
+	// 重新签名并广播本节点地址的间隔, 也是允许的最大时钟偏差
+	validatorAddrRefresh = 10 * time.Minute
+	maxAddrChannelSize   = 100
+)
+
+// error defines
+var (
+	ErrInvalidValidatorAddr = errors.New("ErrInvalidValidatorAddr")
+	ErrNotValidator         = errors.New("ErrNotValidator")
+	ErrValidatorAddrExpired = errors.New("ErrValidatorAddrExpired")
+	ErrValidatorAddrFuture  = errors.New("ErrValidatorAddrFuture")
+)
+
+// ValidatorAddrBook 保存当前验证者签名公布的共识监听地址, 每个验证者只保留最新的一条
+type ValidatorAddrBook struct {
+	mtx   sync.Mutex
+	addrs map[string]*tmtypes.ValidatorAddress
+}
+
+// NewValidatorAddrBook method
+func NewValidatorAddrBook() *ValidatorAddrBook {
+	return &ValidatorAddrBook{
+		addrs: make(map[string]*tmtypes.ValidatorAddress),
+	}
+}
+
+// Add 保存比已有记录更新的地址, 返回是否保存
+func (book *ValidatorAddrBook) Add(addr *tmtypes.ValidatorAddress) bool {
+	book.mtx.Lock()
+	defer book.mtx.Unlock()
+	key := hex.EncodeToString(addr.PubKey)
+	if old, ok := book.addrs[key]; ok && old.Timestamp >= addr.Timestamp {
+		return false
+	}
+	book.addrs[key] = addr
+	return true
+}
+
+// Get 获取验证者的地址
+func (book *ValidatorAddrBook) Get(pubKey []byte) *tmtypes.ValidatorAddress {
+	book.mtx.Lock()
+	defer book.mtx.Unlock()
+	return book.addrs[hex.EncodeToString(pubKey)]
+}
+
+// List 获取所有验证者的地址
+func (book *ValidatorAddrBook) List() []*tmtypes.ValidatorAddress {
+	book.mtx.Lock()
+	defer book.mtx.Unlock()
+	addrs := make([]*tmtypes.ValidatorAddress, 0, len(book.addrs))
+	for _, addr := range book.addrs {
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// Prune 删除已经不在验证者集合中的地址, 返回被删除的地址
+func (book *ValidatorAddrBook) Prune(validators []*ttypes.Validator) []*tmtypes.ValidatorAddress {
+	book.mtx.Lock()
+	defer book.mtx.Unlock()
+	current := make(map[string]bool, len(validators))
+	for _, val := range validators {
+		current[hex.EncodeToString(val.PubKey)] = true
+	}
+	var removed []*tmtypes.ValidatorAddress
+	for key, addr := range book.addrs {
+		if !current[key] {
+			removed = append(removed, addr)
+			delete(book.addrs, key)
+		}
+	}
+	return removed
+}
+
+func hasValidator(validators []*ttypes.Validator, pubKey []byte) bool {
+	for _, val := range validators {
+		if bytes.Equal(val.PubKey, pubKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetPrivValidator 设置本节点的验证者私钥和对外公布的共识监听地址,
+// advertiseAddr为空时不公布本节点的地址, 但仍然会连接其他验证者公布的地址
+func (node *Node) SetPrivValidator(privValidator ttypes.PrivValidator, advertiseAddr string) {
+	node.privValidator = privValidator
+	node.advertiseAddr = advertiseAddr
+}
+
+func (node *Node) validatorAddrRoutine() {
+	ticker := time.NewTicker(validatorAddrInterval)
+	defer ticker.Stop()
+	node.refreshValidatorAddrs()
+	for {
+		select {
+		case msg := <-node.addrChannel:
+			if err := node.handleValidatorAddr(msg); err != nil {
+				tendermintlog.Debug("Ignore validator address", "peerip", msg.PeerIP, "err", err)
+			}
+		case <-ticker.C:
+			if !node.IsRunning() {
+				tendermintlog.Info("validatorAddrRoutine quit")
+				return
+			}
+			node.refreshValidatorAddrs()
+		}
+	}
+}
+
+// refreshValidatorAddrs 按照当前的验证者集合更新连接: 公布本节点地址, 断开被移除的验证者, 连接新的验证者
+func (node *Node) refreshValidatorAddrs() {
+	_, validators := node.state.GetValidators()
+	if node.privValidator != nil && node.advertiseAddr != "" {
+		pubKey := node.privValidator.GetPubKey().Bytes()
+		own := node.addrBook.Get(pubKey)
+		if hasValidator(validators, pubKey) && (own == nil || time.Since(time.Unix(0, own.Timestamp)) >= validatorAddrRefresh) {
+			addr := &ttypes.ValidatorAddress{ValidatorAddress: &tmtypes.ValidatorAddress{
+				PubKey:    pubKey,
+				NodeID:    string(node.ID),
+				Address:   node.advertiseAddr,
+				Timestamp: time.Now().UnixNano(),
+			}}
+			if err := node.privValidator.SignValidatorAddress(node.Network, addr); err != nil {
+				tendermintlog.Error("SignValidatorAddress failed", "err", err)
+			} else {
+				tendermintlog.Info("Advertise validator address", "address", node.advertiseAddr)
+				node.addrBook.Add(addr.ValidatorAddress)
+				node.broadcastValidatorAddr(addr.ValidatorAddress, "")
+			}
+		}
+	}
+
+	for _, addr := range node.addrBook.Prune(validators) {
+		peer := node.peerSet.Get(ID(addr.NodeID))
+		if peer != nil && !peer.IsPersistent() {
+			tendermintlog.Info("Stop peer of removed validator", "peer", addr.Address, "pubkey", hex.EncodeToString(addr.PubKey))
+			node.stopAndRemovePeer(peer, ErrNotValidator)
+		}
+	}
+	for _, addr := range node.addrBook.List() {
+		node.dialValidator(addr)
+	}
+}
+
+// handleValidatorAddr 校验其他节点转发的验证者地址, 保存最新的地址并继续转发
+func (node *Node) handleValidatorAddr(msg MsgInfo) error {
+	addr, ok := msg.Msg.(*tmtypes.ValidatorAddress)
+	if !ok {
+		return ErrInvalidValidatorAddr
+	}
+	if node.privValidator != nil && bytes.Equal(addr.PubKey, node.privValidator.GetPubKey().Bytes()) {
+		return nil
+	}
+	if old := node.addrBook.Get(addr.PubKey); old != nil && old.Timestamp >= addr.Timestamp {
+		return ErrValidatorAddrExpired
+	}
+	if addr.Timestamp > time.Now().Add(validatorAddrRefresh).UnixNano() {
+		return ErrValidatorAddrFuture
+	}
+	_, validators := node.state.GetValidators()
+	if !hasValidator(validators, addr.PubKey) {
+		return ErrNotValidator
+	}
+	if err := (&ttypes.ValidatorAddress{ValidatorAddress: addr}).Verify(node.Network); err != nil {
+		return err
+	}
+	if !node.addrBook.Add(addr) {
+		return ErrValidatorAddrExpired
+	}
+	tendermintlog.Info("Receive validator address", "address", addr.Address, "pubkey", hex.EncodeToString(addr.PubKey), "peerip", msg.PeerIP)
+	node.broadcastValidatorAddr(addr, msg.PeerID)
+	node.dialValidator(addr)
+	return nil
+}
+
+func (node *Node) broadcastValidatorAddr(addr *tmtypes.ValidatorAddress, except ID) {
+	for _, peer := range node.peerSet.List() {
+		if peer.ID() == except {
+			continue
+		}
+		peer.TrySend(MsgInfo{TypeID: ttypes.ValidatorAddressID, Msg: addr, PeerID: peer.ID()})
+	}
+}
+
+// sendValidatorAddrs 把已知的验证者地址发送给新连接的节点
+func (node *Node) sendValidatorAddrs(peer Peer) {
+	for _, addr := range node.addrBook.List() {
+		peer.TrySend(MsgInfo{TypeID: ttypes.ValidatorAddressID, Msg: addr, PeerID: peer.ID()})
+	}
+}
+
+// dialValidator 连接还没有连接的验证者, 失败时不重连, 等待下一次检查
+func (node *Node) dialValidator(addr *tmtypes.ValidatorAddress) {
+	if !node.IsRunning() || ID(addr.NodeID) == node.ID || node.peerSet.Has(ID(addr.NodeID)) {
+		return
+	}
+	host, _ := splitHostPort(addr.Address)
+	if ip := net.ParseIP(host); ip != nil && node.peerSet.HasIP(ip) {
+		return
+	}
+	if node.dialing.Has(host) || node.reconnecting.Has(host) {
+		return
+	}
+	node.dialing.Set(host, addr.Address)
+	go func() {
+		defer node.dialing.Delete(host)
+		tendermintlog.Info("Dialing validator", "address", addr.Address)
+		peerConn, err := newOutboundPeerConn(addr.Address, node.privKey, node.StopPeerForError, node.state, false)
+		if err != nil {
+			tendermintlog.Debug("Error dialing validator", "address", addr.Address, "err", err)
+			return
+		}
+		if err := node.addPeer(peerConn); err != nil {
+			peerConn.CloseConn()
+			tendermintlog.Debug("Error adding validator peer", "address", addr.Address, "err", err)
+		}
+	}()
+}
@@ -0,0 +1,77 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tendermint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signTestValidatorAddr(t *testing.T, pv ttypes.PrivValidator, nodeID, address string, timestamp int64) *tmtypes.ValidatorAddress {
+	addr := &ttypes.ValidatorAddress{ValidatorAddress: &tmtypes.ValidatorAddress{
+		PubKey:    pv.GetPubKey().Bytes(),
+		NodeID:    nodeID,
+		Address:   address,
+		Timestamp: timestamp,
+	}}
+	require.Nil(t, pv.SignValidatorAddress("chain33-test", addr))
+	return addr.ValidatorAddress
+}
+
+func TestValidatorAddr(t *testing.T) {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	require.Nil(t, err)
+	ttypes.ConsensusCrypto = cr
+
+	pvA := ttypes.GenPrivValidatorImp("")
+	pvB := ttypes.GenPrivValidatorImp("")
+	pvC := ttypes.GenPrivValidatorImp("")
+	vals := []*ttypes.Validator{ttypes.NewValidator(pvA.PubKey, 10), ttypes.NewValidator(pvB.PubKey, 10)}
+	cs := &ConsensusState{state: State{Validators: ttypes.NewValidatorSet(vals)}}
+	priv, err := cr.GenKey()
+	require.Nil(t, err)
+	node := NewNode(nil, "tcp", "127.0.0.1:0", priv, "chain33-test", "0.1.0", cs)
+	node.SetPrivValidator(pvA, "127.0.0.1:46656")
+
+	now := time.Now().UnixNano()
+	addrB := signTestValidatorAddr(t, pvB, "nodeB", "127.0.0.2:46656", now)
+	assert.Nil(t, node.handleValidatorAddr(MsgInfo{Msg: addrB}))
+	assert.Equal(t, addrB, node.addrBook.Get(pvB.PubKey.Bytes()))
+	assert.Equal(t, ErrValidatorAddrExpired, node.handleValidatorAddr(MsgInfo{Msg: addrB}))
+
+	//非验证者, 伪造签名, 时间戳过大的地址都被拒绝
+	addrC := signTestValidatorAddr(t, pvC, "nodeC", "127.0.0.3:46656", now)
+	assert.Equal(t, ErrNotValidator, node.handleValidatorAddr(MsgInfo{Msg: addrC}))
+	forged := *addrB
+	forged.Address = "127.0.0.4:46656"
+	forged.Timestamp = now + 1
+	assert.NotNil(t, node.handleValidatorAddr(MsgInfo{Msg: &forged}))
+	future := signTestValidatorAddr(t, pvB, "nodeB", "127.0.0.2:46656", now+int64(2*validatorAddrRefresh))
+	assert.Equal(t, ErrValidatorAddrFuture, node.handleValidatorAddr(MsgInfo{Msg: future}))
+	assert.Equal(t, "127.0.0.2:46656", node.addrBook.Get(pvB.PubKey.Bytes()).Address)
+
+	//本节点是验证者时签名公布自己的地址
+	node.refreshValidatorAddrs()
+	own := node.addrBook.Get(pvA.PubKey.Bytes())
+	require.NotNil(t, own)
+	assert.Equal(t, string(node.ID), own.NodeID)
+	assert.Equal(t, "127.0.0.1:46656", own.Address)
+	assert.Nil(t, (&ttypes.ValidatorAddress{ValidatorAddress: own}).Verify("chain33-test"))
+	assert.Nil(t, node.handleValidatorAddr(MsgInfo{Msg: signTestValidatorAddr(t, pvA, "old", "127.0.0.9:46656", now+1)}))
+	assert.Equal(t, own, node.addrBook.Get(pvA.PubKey.Bytes()))
+
+	//验证者被移除后删除它的地址
+	cs.state.Validators = ttypes.NewValidatorSet(vals[:1])
+	node.refreshValidatorAddrs()
+	assert.Nil(t, node.addrBook.Get(pvB.PubKey.Bytes()))
+	assert.Equal(t, 1, len(node.addrBook.List()))
+}
@@ -0,0 +1,211 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tendermint
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	dbm "github.com/33cn/chain33/common/db"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// wal中超时和有新交易记录的类型, 不和消息类型冲突
+const (
+	walTimeoutID      = byte(0xf0)
+	walTxsAvailableID = byte(0xf1)
+)
+
+var walPrefix = []byte("WAL:")
+
+// WALMessage wal中的一条记录, 消息, 超时或者有新交易的高度
+type WALMessage struct {
+	TypeID byte
+	//本节点签名的提案, 区块和投票
+	Own       bool
+	Msg       proto.Message
+	Timeout   *timeoutInfo
+	TxsHeight int64
+}
+
+// ConsensusWAL 记录共识过程中收到的提案, 区块, 投票和超时,
+// 节点重启后重放当前高度的记录, 恢复到崩溃前的高度和轮次
+type ConsensusWAL struct {
+	mtx sync.Mutex
+	db  dbm.DB
+	seq int64
+}
+
+// NewConsensusWAL returns a new ConsensusWAL with the given DB
+func NewConsensusWAL(db dbm.DB) *ConsensusWAL {
+	wal := &ConsensusWAL{db: db}
+	it := db.Iterator(walPrefix, nil, true)
+	defer it.Close()
+	if it.Rewind() && it.Valid() {
+		var height int64
+		fmt.Sscanf(string(it.Key()), "WAL:%020d:%020d", &height, &wal.seq)
+	}
+	return wal
+}
+
+// 同一高度的记录按写入顺序排列
+func calcWALKey(height int64, seq int64) []byte {
+	return []byte(fmt.Sprintf("WAL:%020d:%020d", height, seq))
+}
+
+func calcWALHeightPrefix(height int64) []byte {
+	return []byte(fmt.Sprintf("WAL:%020d:", height))
+}
+
+func encodeWALMessage(msg *WALMessage) ([]byte, error) {
+	var data []byte
+	var err error
+	switch msg.TypeID {
+	case walTimeoutID:
+		data, err = json.Marshal(msg.Timeout)
+	case walTxsAvailableID:
+		data, err = json.Marshal(msg.TxsHeight)
+	default:
+		data, err = proto.Marshal(msg.Msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	own := byte(0)
+	if msg.Own {
+		own = 1
+	}
+	return append([]byte{msg.TypeID, own}, data...), nil
+}
+
+func decodeWALMessage(buf []byte) (*WALMessage, error) {
+	if len(buf) < 2 {
+		return nil, fmt.Errorf("invalid wal message length %d", len(buf))
+	}
+	msg := &WALMessage{TypeID: buf[0], Own: buf[1] == 1}
+	switch msg.TypeID {
+	case walTimeoutID:
+		msg.Timeout = &timeoutInfo{}
+		return msg, json.Unmarshal(buf[2:], msg.Timeout)
+	case walTxsAvailableID:
+		return msg, json.Unmarshal(buf[2:], &msg.TxsHeight)
+	}
+	v, ok := ttypes.MsgMap[msg.TypeID]
+	if !ok {
+		return nil, fmt.Errorf("unknown wal message type %v", msg.TypeID)
+	}
+	msg.Msg = reflect.New(v).Interface().(proto.Message)
+	return msg, proto.Unmarshal(buf[2:], msg.Msg)
+}
+
+// Write 写入一条记录, sync为true时等待落盘, 本节点签名的消息需要在发送之前落盘
+func (wal *ConsensusWAL) Write(height int64, msg *WALMessage, sync bool) error {
+	buf, err := encodeWALMessage(msg)
+	if err != nil {
+		return err
+	}
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+	wal.seq++
+	batch := wal.db.NewBatch(sync)
+	batch.Set(calcWALKey(height, wal.seq), buf)
+	return batch.Write()
+}
+
+// Load 按写入顺序读取某个高度的记录, 无法解析的记录忽略
+func (wal *ConsensusWAL) Load(height int64) []*WALMessage {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+	var msgs []*WALMessage
+	it := wal.db.Iterator(calcWALHeightPrefix(height), nil, false)
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		msg, err := decodeWALMessage(it.ValueCopy())
+		if err != nil {
+			tendermintlog.Error("ConsensusWAL Load", "height", height, "err", err)
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// Prune 删除低于height的记录
+func (wal *ConsensusWAL) Prune(height int64) error {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+	batch := wal.db.NewBatch(true)
+	it := wal.db.Iterator(walPrefix, calcWALHeightPrefix(height), false)
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		batch.Delete(it.Key())
+	}
+	return batch.Write()
+}
+
+func (cs *ConsensusState) writeWAL(height int64, msg *WALMessage, sync bool) error {
+	if cs.wal == nil {
+		return nil
+	}
+	err := cs.wal.Write(height, msg, sync)
+	if err != nil {
+		tendermintlog.Error("writeWAL fail", "height", height, "type", msg.TypeID, "err", err)
+	}
+	return err
+}
+
+// restoreOwnMsg remembers what we signed before restart
+func (cs *ConsensusState) restoreOwnMsg(msg proto.Message) {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+	switch msg := msg.(type) {
+	case *tmtypes.Vote:
+		cs.signedVotes[signedVoteKey(msg.Height, int(msg.Round), byte(msg.Type))] = msg
+	case *tmtypes.Proposal:
+		cs.ownProposal = msg
+	case *tmtypes.TendermintBlock:
+		cs.ownProposalBlock = msg
+	}
+}
+
+// catchupReplay replays the wal messages of the height we are working on,
+// it must be called before the receiveRoutine starts
+func (cs *ConsensusState) catchupReplay(height int64) {
+	if cs.wal == nil {
+		return
+	}
+	msgs := cs.wal.Load(height)
+	if len(msgs) == 0 {
+		return
+	}
+	tendermintlog.Info("Replay wal begin", "height", height, "msgs", len(msgs))
+	// restore our own messages first, the replay may sign again before
+	// reaching the record of what we signed
+	for _, msg := range msgs {
+		if msg.Own {
+			cs.restoreOwnMsg(msg.Msg)
+		}
+	}
+	for _, msg := range msgs {
+		switch msg.TypeID {
+		case walTimeoutID:
+			cs.handleTimeout(*msg.Timeout, *cs.GetRoundState())
+		case walTxsAvailableID:
+			cs.handleTxsAvailable(msg.TxsHeight)
+		default:
+			var peerID ID
+			if msg.Own {
+				peerID = cs.ourID
+			}
+			cs.handleMsg(MsgInfo{TypeID: msg.TypeID, Msg: msg.Msg, PeerID: peerID, PeerIP: ""})
+		}
+	}
+	rs := cs.GetRoundState()
+	tendermintlog.Info(fmt.Sprintf("Replay wal done. Current: %v/%v/%v", rs.Height, rs.Round, rs.Step))
+}
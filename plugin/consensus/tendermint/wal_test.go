@@ -0,0 +1,92 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tendermint
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/common/crypto"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensusWAL(t *testing.T) {
+	ttypes.InitMessageMap()
+	dir, err := ioutil.TempDir("", "tendermintwal")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	db := dbm.NewDB("wal", "leveldb", dir, 0)
+	wal := NewConsensusWAL(db)
+	vote := &tmtypes.Vote{Height: 1, Round: 2, Type: uint32(ttypes.VoteTypePrevote), BlockID: &tmtypes.BlockID{Hash: []byte("hash")}}
+	proposal := &tmtypes.Proposal{Height: 1, Round: 2, POLRound: -1, Blockhash: []byte("hash")}
+	ti := &timeoutInfo{Duration: time.Second, Height: 2, Round: 1, Step: ttypes.RoundStepPropose}
+	require.Nil(t, wal.Write(1, &WALMessage{TypeID: ttypes.VoteID, Msg: vote}, false))
+	require.Nil(t, wal.Write(1, &WALMessage{TypeID: ttypes.ProposalID, Own: true, Msg: proposal}, true))
+	require.Nil(t, wal.Write(2, &WALMessage{TypeID: walTimeoutID, Timeout: ti}, false))
+	require.Nil(t, wal.Write(2, &WALMessage{TypeID: walTxsAvailableID, TxsHeight: 2}, false))
+
+	msgs := wal.Load(1)
+	require.Equal(t, 2, len(msgs))
+	assert.Equal(t, ttypes.VoteID, msgs[0].TypeID)
+	assert.False(t, msgs[0].Own)
+	assert.Equal(t, vote.String(), msgs[0].Msg.String())
+	assert.Equal(t, ttypes.ProposalID, msgs[1].TypeID)
+	assert.True(t, msgs[1].Own)
+	assert.Equal(t, proposal.String(), msgs[1].Msg.String())
+
+	require.Nil(t, wal.Prune(2))
+	assert.Equal(t, 0, len(wal.Load(1)))
+	db.Close()
+
+	//重新打开后继续按顺序写入
+	db = dbm.NewDB("wal", "leveldb", dir, 0)
+	defer db.Close()
+	wal = NewConsensusWAL(db)
+	require.Nil(t, wal.Write(2, &WALMessage{TypeID: ttypes.VoteID, Own: true, Msg: vote}, true))
+	msgs = wal.Load(2)
+	require.Equal(t, 3, len(msgs))
+	assert.Equal(t, ti, msgs[0].Timeout)
+	assert.Equal(t, int64(2), msgs[1].TxsHeight)
+	assert.Equal(t, ttypes.VoteID, msgs[2].TypeID)
+}
+
+func TestSignVoteAfterRestart(t *testing.T) {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	require.Nil(t, err)
+	ttypes.ConsensusCrypto = cr
+	pv := ttypes.GenPrivValidatorImp("")
+	vals := ttypes.NewValidatorSet([]*ttypes.Validator{ttypes.NewValidator(pv.GetPubKey(), 10)})
+
+	cs := &ConsensusState{privValidator: pv, signedVotes: make(map[string]*tmtypes.Vote)}
+	cs.state = State{ChainID: "test"}
+	cs.Validators = vals
+	cs.Height = 5
+	cs.Round = 1
+	prevote, err := cs.signVote(ttypes.VoteTypePrevote, []byte("hashA"))
+	require.Nil(t, err)
+
+	//重启后从wal恢复本节点的投票
+	cs.signedVotes = make(map[string]*tmtypes.Vote)
+	cs.restoreOwnMsg(prevote.Vote)
+	vote, err := cs.signVote(ttypes.VoteTypePrevote, []byte("hashA"))
+	require.Nil(t, err)
+	assert.Equal(t, prevote.Signature, vote.Signature)
+	//不能对同一轮次的其他区块投票
+	_, err = cs.signVote(ttypes.VoteTypePrevote, []byte("hashB"))
+	assert.NotNil(t, err)
+	_, err = cs.signVote(ttypes.VoteTypePrecommit, []byte("hashA"))
+	assert.Nil(t, err)
+	cs.Round = 2
+	_, err = cs.signVote(ttypes.VoteTypePrevote, []byte("hashB"))
+	assert.Nil(t, err)
+}
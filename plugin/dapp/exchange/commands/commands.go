@@ -2,6 +2,14 @@
 package commands
 
 import (
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/33cn/chain33/rpc/jsonclient"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	et "github.com/33cn/plugin/plugin/dapp/exchange/types"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +25,391 @@ func Cmd() *cobra.Command {
 		Args:  cobra.MinimumNArgs(1),
 	}
 	cmd.AddCommand(
-	//add sub command
+		LimitOrderCmd(),
+		MarketOrderCmd(),
+		RevokeOrderCmd(),
+		TriggerOrderCmd(),
+		QueryMarketDepthCmd(),
+		QueryOrderCmd(),
+		QueryOrderListCmd(),
+		QueryHistoryOrderListCmd(),
+		QueryCandlesCmd(),
+		QueryTickerCmd(),
 	)
 	return cmd
 }
+
+//交易对相关的参数
+func addAssetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("left_exec", "", "coins", "execer of left asset")
+	cmd.Flags().StringP("left_symbol", "l", "", "symbol of left asset")
+	cmd.MarkFlagRequired("left_symbol")
+	cmd.Flags().StringP("right_exec", "", "token", "execer of right asset")
+	cmd.Flags().StringP("right_symbol", "r", "", "symbol of right asset")
+	cmd.MarkFlagRequired("right_symbol")
+}
+
+func getAssetFlags(cmd *cobra.Command) (left, right *et.Asset) {
+	leftExec, _ := cmd.Flags().GetString("left_exec")
+	leftSymbol, _ := cmd.Flags().GetString("left_symbol")
+	rightExec, _ := cmd.Flags().GetString("right_exec")
+	rightSymbol, _ := cmd.Flags().GetString("right_symbol")
+	return &et.Asset{Execer: leftExec, Symbol: leftSymbol}, &et.Asset{Execer: rightExec, Symbol: rightSymbol}
+}
+
+//价格和数量都按8位小数精度转换为整数
+func toCoinPrecision(value float64) int64 {
+	return int64(math.Round(value * float64(types.Coin)))
+}
+
+func getOp(op string) (int32, error) {
+	switch op {
+	case "buy":
+		return et.OpBuy, nil
+	case "sell":
+		return et.OpSell, nil
+	}
+	return 0, et.ErrAssetOp
+}
+
+func createTx(cmd *cobra.Command, actionName string, payload types.Message) {
+	title, _ := cmd.Flags().GetString("title")
+	cfg := types.GetCliSysParam(title)
+	if cfg == nil {
+		panic(fmt.Sprintln("can not find CliSysParam title", title))
+	}
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	params := &rpctypes.CreateTxIn{
+		Execer:     cfg.ExecName(et.ExchangeX),
+		ActionName: actionName,
+		Payload:    types.MustPBToJSON(payload),
+	}
+	var res string
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.CreateTransaction", params, &res)
+	ctx.RunWithoutMarshal()
+}
+
+func query(cmd *cobra.Command, funcName string, req types.Message, res types.Message) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	paraName, _ := cmd.Flags().GetString("paraName")
+	params := rpctypes.Query4Jrpc{
+		Execer:   paraName + et.ExchangeX,
+		FuncName: funcName,
+		Payload:  types.MustPBToJSON(req),
+	}
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, res)
+	ctx.Run()
+}
+
+// LimitOrderCmd 创建限价挂单交易
+func LimitOrderCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "limit",
+		Short: "create limit order transaction",
+		Run:   limitOrder,
+	}
+	addAssetFlags(cmd)
+	cmd.Flags().Float64P("price", "p", 0, "price of left asset, precision 1e-8")
+	cmd.MarkFlagRequired("price")
+	cmd.Flags().Float64P("amount", "a", 0, "amount of left asset, min 1")
+	cmd.MarkFlagRequired("amount")
+	cmd.Flags().StringP("op", "o", "", "operation, buy or sell")
+	cmd.MarkFlagRequired("op")
+	cmd.Flags().StringP("tif", "", "gtc", "time in force, gtc, ioc or fok")
+	cmd.Flags().BoolP("post_only", "", false, "only make liquidity, fail if the order would match immediately")
+	cmd.Flags().Int64P("expire_height", "", 0, "block height at which the order expires, 0 means never")
+	cmd.Flags().Int64P("expire_time", "", 0, "block time at which the order expires, 0 means never")
+	cmd.Flags().Float64P("trigger", "t", 0, "trigger price of stop limit order, precision 1e-8")
+	return cmd
+}
+
+func getTimeInForce(tif string) (int32, error) {
+	switch tif {
+	case "gtc":
+		return et.TimeInForceGTC, nil
+	case "ioc":
+		return et.TimeInForceIOC, nil
+	case "fok":
+		return et.TimeInForceFOK, nil
+	}
+	return 0, et.ErrTimeInForce
+}
+
+func limitOrder(cmd *cobra.Command, args []string) {
+	left, right := getAssetFlags(cmd)
+	price, _ := cmd.Flags().GetFloat64("price")
+	amount, _ := cmd.Flags().GetFloat64("amount")
+	opStr, _ := cmd.Flags().GetString("op")
+	op, err := getOp(opStr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	tifStr, _ := cmd.Flags().GetString("tif")
+	tif, err := getTimeInForce(tifStr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	postOnly, _ := cmd.Flags().GetBool("post_only")
+	expireHeight, _ := cmd.Flags().GetInt64("expire_height")
+	expireTime, _ := cmd.Flags().GetInt64("expire_time")
+	trigger, _ := cmd.Flags().GetFloat64("trigger")
+	payload := &et.LimitOrder{
+		LeftAsset:    left,
+		RightAsset:   right,
+		Price:        toCoinPrecision(price),
+		Amount:       toCoinPrecision(amount),
+		Op:           op,
+		TimeInForce:  tif,
+		PostOnly:     postOnly,
+		ExpireHeight: expireHeight,
+		ExpireTime:   expireTime,
+		TriggerPrice: toCoinPrecision(trigger),
+	}
+	createTx(cmd, et.NameLimitOrderAction, payload)
+}
+
+// MarketOrderCmd 创建市价委托交易
+func MarketOrderCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "market",
+		Short: "create market order transaction",
+		Run:   marketOrder,
+	}
+	addAssetFlags(cmd)
+	cmd.Flags().Float64P("amount", "a", 0, "amount of left asset, min 1")
+	cmd.MarkFlagRequired("amount")
+	cmd.Flags().StringP("op", "o", "", "operation, buy or sell")
+	cmd.MarkFlagRequired("op")
+	cmd.Flags().Int64P("slippage", "s", 0, "max slippage from the best price, in 1/10000")
+	return cmd
+}
+
+func marketOrder(cmd *cobra.Command, args []string) {
+	left, right := getAssetFlags(cmd)
+	amount, _ := cmd.Flags().GetFloat64("amount")
+	slippage, _ := cmd.Flags().GetInt64("slippage")
+	opStr, _ := cmd.Flags().GetString("op")
+	op, err := getOp(opStr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	payload := &et.MarketOrder{
+		LeftAsset:  left,
+		RightAsset: right,
+		Amount:     toCoinPrecision(amount),
+		Op:         op,
+		Slippage:   slippage,
+	}
+	createTx(cmd, et.NameMarketOrderAction, payload)
+}
+
+// RevokeOrderCmd 创建撤单交易
+func RevokeOrderCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "create revoke order transaction",
+		Run:   revokeOrder,
+	}
+	cmd.Flags().Int64P("orderID", "i", 0, "order id")
+	cmd.MarkFlagRequired("orderID")
+	return cmd
+}
+
+func revokeOrder(cmd *cobra.Command, args []string) {
+	orderID, _ := cmd.Flags().GetInt64("orderID")
+	createTx(cmd, et.NameRevokeOrderAction, &et.RevokeOrder{OrderID: orderID})
+}
+
+// TriggerOrderCmd 创建激活止损限价单的交易
+func TriggerOrderCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trigger",
+		Short: "create transaction to activate a triggered stop limit order",
+		Run:   triggerOrder,
+	}
+	cmd.Flags().Int64P("orderID", "i", 0, "order id")
+	cmd.MarkFlagRequired("orderID")
+	return cmd
+}
+
+func triggerOrder(cmd *cobra.Command, args []string) {
+	orderID, _ := cmd.Flags().GetInt64("orderID")
+	createTx(cmd, et.NameTriggerOrderAction, &et.TriggerOrder{OrderID: orderID})
+}
+
+// QueryMarketDepthCmd 查询市场深度
+func QueryMarketDepthCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "depth",
+		Short: "show market depth of the trading pair",
+		Run:   queryMarketDepth,
+	}
+	addAssetFlags(cmd)
+	cmd.Flags().StringP("op", "o", "", "operation, buy or sell")
+	cmd.MarkFlagRequired("op")
+	cmd.Flags().StringP("primaryKey", "k", "", "primary key of the last page")
+	cmd.Flags().Int32P("count", "c", et.Count, "page size, max 20")
+	return cmd
+}
+
+func queryMarketDepth(cmd *cobra.Command, args []string) {
+	left, right := getAssetFlags(cmd)
+	primaryKey, _ := cmd.Flags().GetString("primaryKey")
+	count, _ := cmd.Flags().GetInt32("count")
+	opStr, _ := cmd.Flags().GetString("op")
+	op, err := getOp(opStr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	req := &et.QueryMarketDepth{
+		LeftAsset:  left,
+		RightAsset: right,
+		Op:         op,
+		PrimaryKey: primaryKey,
+		Count:      count,
+	}
+	var res et.MarketDepthList
+	query(cmd, et.FuncNameQueryMarketDepth, req, &res)
+}
+
+// QueryOrderCmd 根据订单号查询订单
+func QueryOrderCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "order",
+		Short: "show order by order id",
+		Run:   queryOrder,
+	}
+	cmd.Flags().Int64P("orderID", "i", 0, "order id")
+	cmd.MarkFlagRequired("orderID")
+	return cmd
+}
+
+func queryOrder(cmd *cobra.Command, args []string) {
+	orderID, _ := cmd.Flags().GetInt64("orderID")
+	var res et.Order
+	query(cmd, et.FuncNameQueryOrder, &et.QueryOrder{OrderID: orderID}, &res)
+}
+
+// QueryOrderListCmd 根据地址和订单状态查询订单列表
+func QueryOrderListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "orders",
+		Short: "show order list of the address",
+		Run:   queryOrderList,
+	}
+	cmd.Flags().StringP("addr", "a", "", "user address")
+	cmd.MarkFlagRequired("addr")
+	cmd.Flags().Int32P("status", "s", et.Ordered, "order status, 0 ordered, 1 completed, 2 revoked, 3 inactive")
+	cmd.Flags().StringP("primaryKey", "k", "", "primary key of the last page")
+	cmd.Flags().Int32P("count", "c", et.Count, "page size, max 20")
+	cmd.Flags().Int32P("direction", "d", et.ListDESC, "list direction, 0 desc, 1 asc")
+	return cmd
+}
+
+func queryOrderList(cmd *cobra.Command, args []string) {
+	addr, _ := cmd.Flags().GetString("addr")
+	status, _ := cmd.Flags().GetInt32("status")
+	primaryKey, _ := cmd.Flags().GetString("primaryKey")
+	count, _ := cmd.Flags().GetInt32("count")
+	direction, _ := cmd.Flags().GetInt32("direction")
+	req := &et.QueryOrderList{
+		Status:     status,
+		Address:    addr,
+		PrimaryKey: primaryKey,
+		Count:      count,
+		Direction:  direction,
+	}
+	var res et.OrderList
+	query(cmd, et.FuncNameQueryOrderList, req, &res)
+}
+
+// QueryHistoryOrderListCmd 查询交易对的成交记录
+func QueryHistoryOrderListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "show completed orders of the trading pair",
+		Run:   queryHistoryOrderList,
+	}
+	addAssetFlags(cmd)
+	cmd.Flags().StringP("primaryKey", "k", "", "primary key of the last page")
+	cmd.Flags().Int32P("count", "c", et.Count, "page size, max 20")
+	cmd.Flags().Int32P("direction", "d", et.ListDESC, "list direction, 0 desc, 1 asc")
+	return cmd
+}
+
+func queryHistoryOrderList(cmd *cobra.Command, args []string) {
+	left, right := getAssetFlags(cmd)
+	primaryKey, _ := cmd.Flags().GetString("primaryKey")
+	count, _ := cmd.Flags().GetInt32("count")
+	direction, _ := cmd.Flags().GetInt32("direction")
+	req := &et.QueryHistoryOrderList{
+		LeftAsset:  left,
+		RightAsset: right,
+		PrimaryKey: primaryKey,
+		Count:      count,
+		Direction:  direction,
+	}
+	var res et.OrderList
+	query(cmd, et.FuncNameQueryHistoryOrderList, req, &res)
+}
+
+// QueryCandlesCmd 查询交易对的K线
+func QueryCandlesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "candles",
+		Short: "show candles of the trading pair",
+		Run:   queryCandles,
+	}
+	addAssetFlags(cmd)
+	cmd.Flags().StringP("period", "p", et.Period1m, "candle period, 1m, 5m, 1h or 1d")
+	cmd.Flags().StringP("primaryKey", "k", "", "primary key of the last page")
+	cmd.Flags().Int32P("count", "c", et.Count, "page size, max 200")
+	cmd.Flags().Int32P("direction", "d", et.ListDESC, "list direction, 0 desc, 1 asc")
+	return cmd
+}
+
+func queryCandles(cmd *cobra.Command, args []string) {
+	left, right := getAssetFlags(cmd)
+	period, _ := cmd.Flags().GetString("period")
+	primaryKey, _ := cmd.Flags().GetString("primaryKey")
+	count, _ := cmd.Flags().GetInt32("count")
+	direction, _ := cmd.Flags().GetInt32("direction")
+	req := &et.QueryCandles{
+		LeftAsset:  left,
+		RightAsset: right,
+		Period:     period,
+		PrimaryKey: primaryKey,
+		Count:      count,
+		Direction:  direction,
+	}
+	var res et.CandleList
+	query(cmd, et.FuncNameQueryCandles, req, &res)
+}
+
+// QueryTickerCmd 查询交易对的24小时行情
+func QueryTickerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ticker",
+		Short: "show 24h ticker of the trading pair",
+		Run:   queryTicker,
+	}
+	addAssetFlags(cmd)
+	cmd.Flags().Int64P("time", "t", 0, "end time of the 24h window, 0 means the latest trade")
+	return cmd
+}
+
+func queryTicker(cmd *cobra.Command, args []string) {
+	left, right := getAssetFlags(cmd)
+	end, _ := cmd.Flags().GetInt64("time")
+	req := &et.QueryTicker{
+		LeftAsset:  left,
+		RightAsset: right,
+		Time:       end,
+	}
+	var res et.Ticker
+	query(cmd, et.FuncNameQueryTicker, req, &res)
+}
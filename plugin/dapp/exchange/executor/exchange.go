@@ -75,9 +75,35 @@ func (e *exchange) CheckTx(tx *types.Transaction, index int) error {
 		if !CheckOp(op) {
 			return exchangetypes.ErrAssetOp
 		}
+		if !CheckTimeInForce(limitOrder) {
+			return exchangetypes.ErrTimeInForce
+		}
+		if !CheckTriggerPrice(limitOrder.GetTriggerPrice()) {
+			return exchangetypes.ErrAssetPrice
+		}
+		if !CheckExpire(limitOrder) {
+			return exchangetypes.ErrExpire
+		}
 	}
 	if exchange.Ty == exchangetypes.TyMarketOrderAction {
-		return types.ErrActionNotSupport
+		marketOrder := exchange.GetMarketOrder()
+		left := marketOrder.GetLeftAsset()
+		right := marketOrder.GetRightAsset()
+		amount := marketOrder.GetAmount()
+		op := marketOrder.GetOp()
+		slippage := marketOrder.GetSlippage()
+		if !CheckExchangeAsset(left, right) {
+			return exchangetypes.ErrAsset
+		}
+		if !CheckAmount(amount) {
+			return exchangetypes.ErrAssetAmount
+		}
+		if !CheckOp(op) {
+			return exchangetypes.ErrAssetOp
+		}
+		if !CheckSlippage(slippage) {
+			return exchangetypes.ErrSlippage
+		}
 	}
 	return nil
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/33cn/chain33/common/crypto"
 	"github.com/33cn/chain33/queue"
 	et "github.com/33cn/plugin/plugin/dapp/exchange/types"
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -674,6 +675,649 @@ func TestExchange(t *testing.T) {
 
 }
 
+func TestMarketOrder(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	InitExecType()
+	total := 100 * types.Coin
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+
+	accA, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	accA.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[0]})
+	accB, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	accB.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[1]})
+	env := &execEnv{
+		10,
+		1,
+		1539918074,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+
+	//对手盘为空时,市价单无法成交
+	err := Exec_MarketOrder(t, &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrMarketDepth, err)
+
+	/*
+	 用例说明：
+	   1.A挂三个价格不同的卖单,价格分别为1,1.1,2
+	   2.B以20%的滑点市价买入12个,只能和前两档成交10个,剩余2个退回
+	*/
+	for _, price := range []int64{types.Coin, 110000000, 2 * types.Coin} {
+		err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: price, Amount: 5 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+		assert.Equal(t, nil, err)
+	}
+	err = Exec_MarketOrder(t, &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: 12 * types.Coin, Op: et.OpBuy, Slippage: 2000}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+
+	orderList, err := Exec_QueryOrderList(et.Completed, Nodes[1], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	order := orderList.List[0]
+	assert.Equal(t, int32(et.TyMarketOrderAction), order.Ty)
+	assert.Equal(t, 10*types.Coin, order.Executed)
+	assert.Equal(t, 2*types.Coin, order.Balance)
+	assert.Equal(t, int64(105000000), order.AVGPrice)
+
+	acc := accB.LoadExecAccount(Nodes[1], execAddr)
+	assert.Equal(t, total-5*types.Coin-550000000, acc.Balance)
+	assert.Equal(t, int64(0), acc.Frozen)
+	acc = accA.LoadExecAccount(Nodes[1], execAddr)
+	assert.Equal(t, 10*types.Coin, acc.Balance)
+
+	//剩余的卖单深度只有价格为2的一档
+	marketDepthList, err := Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(marketDepthList.List))
+	assert.Equal(t, 2*types.Coin, marketDepthList.List[0].Price)
+	assert.Equal(t, 5*types.Coin, marketDepthList.List[0].Amount)
+
+	//市价单和被撮合的挂单都会出现在成交记录中
+	historyList, err := Exec_QueryHistoryOrder(&et.QueryHistoryOrderList{LeftAsset: left, RightAsset: right}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(historyList.List))
+
+	//B挂买单,A以0滑点市价卖出,只和最优价格成交
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 150000000, Amount: 3 * types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 140000000, Amount: 3 * types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	err = Exec_MarketOrder(t, &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: 5 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	orderList, err = Exec_QueryOrderList(et.Completed, Nodes[0], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	order = orderList.List[0]
+	assert.Equal(t, int32(et.TyMarketOrderAction), order.Ty)
+	assert.Equal(t, 3*types.Coin, order.Executed)
+	assert.Equal(t, int64(150000000), order.AVGPrice)
+	acc = accA.LoadExecAccount(Nodes[0], execAddr)
+	assert.Equal(t, total-15*types.Coin-3*types.Coin, acc.Balance)
+	assert.Equal(t, 5*types.Coin, acc.Frozen)
+
+	//非法的滑点参数
+	_, err = CreateMarketOrder(&et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: types.Coin, Op: et.OpSell, Slippage: et.MaxSlippage + 1}, PrivKeyA)
+	assert.Equal(t, nil, err)
+	err = Exec_MarketOrder(t, &et.MarketOrder{LeftAsset: left, RightAsset: right, Amount: types.Coin, Op: et.OpSell, Slippage: et.MaxSlippage + 1}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrSlippage, err)
+}
+
+func TestExchangeFee(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	InitExecType()
+	total := 100 * types.Coin
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+
+	accA, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	accA.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[0]})
+	accB, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	accB.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[1]})
+	env := &execEnv{
+		10,
+		1,
+		1539918074,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+
+	// set config key, 交易对单独配置的吃单费率优先于全局费率
+	for key, value := range map[string]string{
+		ConfNameFeeAddr:  Nodes[3],
+		ConfNameMakerFee: "10",
+		ConfNameTakerFee: "50",
+		ConfNameTakerFee + "-coins.bty-token.CCNY": "20",
+	} {
+		item := &types.ConfigItem{
+			Key: "mavl-manage-" + key,
+			Value: &types.ConfigItem_Arr{
+				Arr: &types.ArrayConfig{Value: []string{value}},
+			},
+		}
+		stateDB.Set([]byte(item.Key), types.Encode(item))
+	}
+
+	//A挂卖单,B吃单,手续费以各自收到的资产支付
+	err := Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 10 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 10 * types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+
+	acc := accA.LoadExecAccount(Nodes[1], execAddr)
+	assert.Equal(t, 10*types.Coin-2000000, acc.Balance)
+	acc = accA.LoadExecAccount(Nodes[3], execAddr)
+	assert.Equal(t, int64(2000000), acc.Balance)
+	acc = accB.LoadExecAccount(Nodes[0], execAddr)
+	assert.Equal(t, 10*types.Coin-1000000, acc.Balance)
+	acc = accB.LoadExecAccount(Nodes[3], execAddr)
+	assert.Equal(t, int64(1000000), acc.Balance)
+
+	//历史订单中记录累计支付的手续费
+	orderList, err := Exec_QueryOrderList(et.Completed, Nodes[1], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(2000000), orderList.List[0].Fee)
+	orderList, err = Exec_QueryOrderList(et.Completed, Nodes[0], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1000000), orderList.List[0].Fee)
+}
+
+func TestCalcFee(t *testing.T) {
+	assert.Equal(t, int64(100000), CalcFee(types.Coin, 10))
+	assert.Equal(t, int64(0), CalcFee(types.Coin, 0))
+	assert.Equal(t, int64(0), CalcFee(1000, 1))
+}
+
+func TestCalcSlippagePrice(t *testing.T) {
+	assert.Equal(t, int64(120000000), CalcSlippagePrice(et.OpBuy, types.Coin, 2000))
+	assert.Equal(t, int64(80000000), CalcSlippagePrice(et.OpSell, types.Coin, 2000))
+	assert.Equal(t, int64(types.Coin), CalcSlippagePrice(et.OpSell, types.Coin, 0))
+	assert.Equal(t, int64(0), CalcSlippagePrice(et.OpSell, types.Coin, et.MaxSlippage))
+}
+
+func TestCandles(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	InitExecType()
+	total := 100 * types.Coin
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+
+	accA, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	accA.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[0]})
+	accB, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	accB.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[1]})
+	//每个区块时间递增20秒,第一个区块落在整天的起点
+	start := int64(1539907200)
+	env := &execEnv{
+		blockTime:   start - 20,
+		blockHeight: 1,
+		difficulty:  1,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+
+	//没有成交时查询不到行情
+	_, err := Exec_Query(et.FuncNameQueryTicker, &et.QueryTicker{LeftAsset: left, RightAsset: right}, stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+
+	/*
+	 用例说明：
+	   1.A挂卖单5个,价格为1,B买入2个,成交落在第一根1分钟K线中
+	   2.A挂卖单5个,价格为2,B以价格2买入6个,先和价格1成交3个,再和价格2成交3个,成交落在第二根1分钟K线中
+	*/
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 5 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 2 * types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 2 * types.Coin, Amount: 5 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	tx, err := CreateLimitOrder(&et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 2 * types.Coin, Amount: 6 * types.Coin, Op: et.OpBuy}, PrivKeyB)
+	assert.Equal(t, nil, err)
+	err = Exec_Block(t, stateDB, kvdb, env, tx)
+	assert.Equal(t, nil, err)
+
+	msg, err := Exec_Query(et.FuncNameQueryCandles, &et.QueryCandles{LeftAsset: left, RightAsset: right, Period: et.Period1m, Direction: et.ListASC}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	candles := msg.(*et.CandleList).List
+	assert.Equal(t, 2, len(candles))
+	assert.True(t, proto.Equal(&et.Candle{LeftAsset: left, RightAsset: right, Period: et.Period1m, Time: start, Open: types.Coin, High: types.Coin, Low: types.Coin, Close: types.Coin, Volume: 2 * types.Coin, Turnover: 2 * types.Coin}, candles[0]))
+	assert.True(t, proto.Equal(&et.Candle{LeftAsset: left, RightAsset: right, Period: et.Period1m, Time: start + 60, Open: types.Coin, High: 2 * types.Coin, Low: types.Coin, Close: 2 * types.Coin, Volume: 6 * types.Coin, Turnover: 9 * types.Coin}, candles[1]))
+
+	//分页查询
+	msg, err = Exec_Query(et.FuncNameQueryCandles, &et.QueryCandles{LeftAsset: left, RightAsset: right, Period: et.Period1m, Count: 1}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	candleList := msg.(*et.CandleList)
+	assert.Equal(t, start+60, candleList.List[0].Time)
+	msg, err = Exec_Query(et.FuncNameQueryCandles, &et.QueryCandles{LeftAsset: left, RightAsset: right, Period: et.Period1m, Count: 1, PrimaryKey: candleList.PrimaryKey}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, start, msg.(*et.CandleList).List[0].Time)
+
+	//更大的周期聚合了所有成交
+	for _, period := range []string{et.Period5m, et.Period1h, et.Period1d} {
+		msg, err = Exec_Query(et.FuncNameQueryCandles, &et.QueryCandles{LeftAsset: left, RightAsset: right, Period: period}, stateDB, kvdb)
+		assert.Equal(t, nil, err)
+		candles = msg.(*et.CandleList).List
+		assert.Equal(t, 1, len(candles))
+		assert.True(t, proto.Equal(&et.Candle{LeftAsset: left, RightAsset: right, Period: period, Time: start, Open: types.Coin, High: 2 * types.Coin, Low: types.Coin, Close: 2 * types.Coin, Volume: 8 * types.Coin, Turnover: 11 * types.Coin}, candles[0]))
+	}
+
+	msg, err = Exec_Query(et.FuncNameQueryTicker, &et.QueryTicker{LeftAsset: left, RightAsset: right}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.True(t, proto.Equal(&et.Ticker{LeftAsset: left, RightAsset: right, LastPrice: 2 * types.Coin, Open: types.Coin, Change: types.Coin, High: 2 * types.Coin, Low: types.Coin, Volume: 8 * types.Coin, Turnover: 11 * types.Coin, Time: start + 299}, msg))
+	//一天以后没有新的成交,行情只保留最新成交价
+	msg, err = Exec_Query(et.FuncNameQueryTicker, &et.QueryTicker{LeftAsset: left, RightAsset: right, Time: start + et.TickerWindow + 300}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.True(t, proto.Equal(&et.Ticker{LeftAsset: left, RightAsset: right, LastPrice: 2 * types.Coin, Open: 2 * types.Coin, High: 2 * types.Coin, Low: 2 * types.Coin, Time: start + et.TickerWindow + 300}, msg))
+
+	//回滚最后一笔交易,K线恢复到之前的状态
+	err = Exec_DelLocal(tx, kvdb)
+	assert.Equal(t, nil, err)
+	msg, err = Exec_Query(et.FuncNameQueryCandles, &et.QueryCandles{LeftAsset: left, RightAsset: right, Period: et.Period5m}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	candles = msg.(*et.CandleList).List
+	assert.Equal(t, 1, len(candles))
+	assert.True(t, proto.Equal(&et.Candle{LeftAsset: left, RightAsset: right, Period: et.Period5m, Time: start, Open: types.Coin, High: types.Coin, Low: types.Coin, Close: types.Coin, Volume: 2 * types.Coin, Turnover: 2 * types.Coin}, candles[0]))
+	msg, err = Exec_Query(et.FuncNameQueryCandles, &et.QueryCandles{LeftAsset: left, RightAsset: right, Period: et.Period1m}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(msg.(*et.CandleList).List))
+
+	//非法的查询参数
+	_, err = Exec_Query(et.FuncNameQueryCandles, &et.QueryCandles{LeftAsset: left, RightAsset: right, Period: "2m"}, stateDB, kvdb)
+	assert.Equal(t, et.ErrPeriod, err)
+	_, err = Exec_Query(et.FuncNameQueryCandles, &et.QueryCandles{LeftAsset: left, RightAsset: right, Period: et.Period1m, Count: et.MaxCandleCount + 1}, stateDB, kvdb)
+	assert.Equal(t, et.ErrCandleCount, err)
+}
+
+func TestTimeInForce(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	InitExecType()
+	total := 100 * types.Coin
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+
+	accA, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	accA.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[0]})
+	accB, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	accB.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[1]})
+	env := &execEnv{
+		10,
+		1,
+		1539918074,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+
+	//IOC买单成交5个,剩余的3个直接退回,不冻结也不挂单
+	err := Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 5 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 8 * types.Coin, Op: et.OpBuy, TimeInForce: et.TimeInForceIOC}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	orderList, err := Exec_QueryOrderList(et.Completed, Nodes[1], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 5*types.Coin, orderList.List[0].Executed)
+	assert.Equal(t, 3*types.Coin, orderList.List[0].Balance)
+	acc := accB.LoadExecAccount(Nodes[1], execAddr)
+	assert.Equal(t, total-5*types.Coin, acc.Balance)
+	assert.Equal(t, int64(0), acc.Frozen)
+
+	//对手盘为空时IOC订单直接撤销,不影响市场深度
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: types.Coin, Op: et.OpBuy, TimeInForce: et.TimeInForceIOC}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	orderList, err = Exec_QueryOrderList(et.Revoked, Nodes[1], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(orderList.List))
+	_, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpBuy}, stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+
+	//FOK订单全部成交
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 5 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 5 * types.Coin, Op: et.OpBuy, TimeInForce: et.TimeInForceFOK}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	_, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+
+	//只做挂单方的订单会立即成交时交易执行失败,不会成交时正常挂单
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 2 * types.Coin, Amount: 5 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 2 * types.Coin, Amount: types.Coin, Op: et.OpBuy, PostOnly: true}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrPostOnly, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 150000000, Amount: types.Coin, Op: et.OpBuy, PostOnly: true}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	marketDepthList, err := Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpBuy}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(150000000), marketDepthList.List[0].Price)
+
+	//非法的组合
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: types.Coin, Op: et.OpBuy, TimeInForce: et.TimeInForceIOC, PostOnly: true}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrTimeInForce, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: types.Coin, Op: et.OpBuy, TimeInForce: et.TimeInForceFOK, TriggerPrice: types.Coin}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrTimeInForce, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: types.Coin, Op: et.OpBuy, TimeInForce: 3}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrTimeInForce, err)
+
+	//FOK订单不能全部成交时交易执行失败,链上执行失败的交易会整体回滚
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 2 * types.Coin, Amount: 6 * types.Coin, Op: et.OpBuy, TimeInForce: et.TimeInForceFOK}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrFillOrKill, err)
+}
+
+func TestOrderExpire(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	InitExecType()
+	total := 100 * types.Coin
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+
+	accA, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	accA.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[0]})
+	accB, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	accB.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[1]})
+	env := &execEnv{
+		blockTime:   1539918074,
+		blockHeight: 1,
+		difficulty:  1,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+
+	//过期高度必须晚于当前区块
+	err := Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 5 * types.Coin, Op: et.OpSell, ExpireHeight: env.blockHeight + 1}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrExpire, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 5 * types.Coin, Op: et.OpSell, ExpireHeight: -1}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrExpire, err)
+
+	/*
+	 用例说明：
+	   1.A挂卖单,两个区块后过期
+	   2.B在过期前买入2个,正常成交
+	   3.B在过期后买入,A的挂单被撤销并解冻,B的买单挂单
+	*/
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 5 * types.Coin, Op: et.OpSell, ExpireHeight: env.blockHeight + 3}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	orderList, err := Exec_QueryOrderList(et.Ordered, Nodes[0], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	orderID := orderList.List[0].OrderID
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 2 * types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: 2 * types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+
+	order, err := Exec_QueryOrder(orderID, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(et.Revoked), order.Status)
+	assert.Equal(t, 3*types.Coin, order.Balance)
+	acc := accA.LoadExecAccount(Nodes[0], execAddr)
+	assert.Equal(t, total-2*types.Coin, acc.Balance)
+	assert.Equal(t, int64(0), acc.Frozen)
+	_, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+	marketDepthList, err := Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpBuy}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2*types.Coin, marketDepthList.List[0].Amount)
+	orderList, err = Exec_QueryOrderList(et.Revoked, Nodes[0], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, orderID, orderList.List[0].OrderID)
+
+	//按区块时间过期的订单,过期前只有本人可以撤销,过期后任何地址都可以撤销
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 3 * types.Coin, Amount: types.Coin, Op: et.OpSell, ExpireTime: env.blockTime + 40}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	orderList, err = Exec_QueryOrderList(et.Ordered, Nodes[0], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	orderID = orderList.List[0].OrderID
+	acc = accA.LoadExecAccount(Nodes[0], execAddr)
+	assert.Equal(t, types.Coin, acc.Frozen)
+	err = Exec_RevokeOrder(t, orderID, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	acc = accA.LoadExecAccount(Nodes[0], execAddr)
+	assert.Equal(t, int64(0), acc.Frozen)
+	order, err = Exec_QueryOrder(orderID, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(et.Revoked), order.Status)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 3 * types.Coin, Amount: types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	orderList, err = Exec_QueryOrderList(et.Ordered, Nodes[0], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	err = Exec_RevokeOrder(t, orderList.List[0].OrderID, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrAddr, err)
+}
+
+func TestStopLimitOrder(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	InitExecType()
+	total := 100 * types.Coin
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+
+	accA, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	accA.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[0]})
+	accB, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	accB.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: Nodes[1]})
+	env := &execEnv{
+		10,
+		1,
+		1539918074,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+
+	//最新成交价为1
+	err := Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+
+	//B下止损买单,触发价格为2,订单冻结资产等待触发
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 220000000, Amount: 2 * types.Coin, Op: et.OpBuy, TriggerPrice: 2 * types.Coin}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	orderList, err := Exec_QueryOrderList(et.Inactive, Nodes[1], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(orderList.List))
+	orderID := orderList.List[0].OrderID
+	acc := accB.LoadExecAccount(Nodes[1], execAddr)
+	assert.Equal(t, 440000000, int(acc.Frozen))
+	_, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpBuy}, stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+
+	//最新成交价没有达到触发价格,不能激活
+	err = Exec_TriggerOrder(t, orderID, PrivKeyC, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrTrigger, err)
+
+	//成交价涨到2时止损单在同一笔交易中自动激活,没有可以成交的卖单时按限价挂单
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 2 * types.Coin, Amount: types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 2 * types.Coin, Amount: types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	order, err := Exec_QueryOrder(orderID, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(et.Ordered), order.Status)
+	_, err = Exec_QueryOrderList(et.Inactive, Nodes[1], "", stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+	marketDepthList, err := Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpBuy}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(220000000), marketDepthList.List[0].Price)
+	assert.Equal(t, 2*types.Coin, marketDepthList.List[0].Amount)
+	//激活后的止损单以卖单价格成交
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 210000000, Amount: 3 * types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+
+	order, err = Exec_QueryOrder(orderID, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(et.Completed), order.Status)
+	assert.Equal(t, int64(210000000), order.AVGPrice)
+	acc = accB.LoadExecAccount(Nodes[1], execAddr)
+	assert.Equal(t, total-types.Coin-2*types.Coin-420000000, acc.Balance)
+	assert.Equal(t, int64(0), acc.Frozen)
+	_, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpBuy}, stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+	marketDepthList, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, types.Coin, marketDepthList.List[0].Amount)
+	err = Exec_TriggerOrder(t, orderID, PrivKeyC, stateDB, kvdb, env)
+	assert.Equal(t, et.ErrOrderSatus, err)
+
+	//未触发的止损卖单可以撤销,撤销后解冻资产
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: types.Coin, Op: et.OpSell, TriggerPrice: 150000000}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	orderList, err = Exec_QueryOrderList(et.Inactive, Nodes[0], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	orderID = orderList.List[0].OrderID
+	acc = accA.LoadExecAccount(Nodes[0], execAddr)
+	assert.Equal(t, 2*types.Coin, acc.Frozen)
+	err = Exec_RevokeOrder(t, orderID, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	acc = accA.LoadExecAccount(Nodes[0], execAddr)
+	assert.Equal(t, types.Coin, acc.Frozen)
+	_, err = Exec_QueryOrderList(et.Inactive, Nodes[0], "", stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+	orderList, err = Exec_QueryOrderList(et.Revoked, Nodes[0], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, orderID, orderList.List[0].OrderID)
+	marketDepthList, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(marketDepthList.List))
+
+	//下单时已经满足触发条件的止损单按普通限价单处理
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 2 * types.Coin, Amount: types.Coin, Op: et.OpSell, TriggerPrice: 250000000}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	marketDepthList, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(marketDepthList.List))
+	assert.Equal(t, 2*types.Coin, marketDepthList.List[0].Price)
+}
+
+func TestTriggerAndExpireDuringMatch(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	InitExecType()
+	total := 100 * types.Coin
+	dir, stateDB, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, stateDB)
+	execAddr := address.ExecAddress(et.ExchangeX)
+
+	accA, _ := account.NewAccountDB(cfg, "coins", "bty", stateDB)
+	accB, _ := account.NewAccountDB(cfg, "token", "CCNY", stateDB)
+	for _, addr := range Nodes {
+		accA.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: addr})
+		accB.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: addr})
+	}
+	env := &execEnv{
+		blockTime:   1539918074,
+		blockHeight: 1,
+		difficulty:  1,
+	}
+	left := &et.Asset{Symbol: "bty", Execer: "coins"}
+	right := &et.Asset{Execer: "token", Symbol: "CCNY"}
+	queryInactive := func(addr string) int64 {
+		orderList, err := Exec_QueryOrderList(et.Inactive, addr, "", stateDB, kvdb)
+		assert.Equal(t, nil, err)
+		return orderList.List[0].OrderID
+	}
+
+	//最新成交价为1
+	err := Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: types.Coin, Amount: types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+
+	/*
+	 用例说明：
+	   1.C的止损卖单触发价格0.9,D的止损卖单触发价格0.8,B挂买单2个,价格0.85
+	   2.A以0.85卖出1个,成交价触发C的止损单,C以0.8成交后继续触发D的止损单,D的止损单挂单
+	*/
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 80000000, Amount: types.Coin, Op: et.OpSell, TriggerPrice: 90000000}, PrivKeyC, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	orderC := queryInactive(Nodes[2])
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 70000000, Amount: types.Coin, Op: et.OpSell, TriggerPrice: 80000000}, PrivKeyD, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	orderD := queryInactive(Nodes[3])
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 85000000, Amount: 2 * types.Coin, Op: et.OpBuy}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 85000000, Amount: types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+
+	order, err := Exec_QueryOrder(orderC, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(et.Completed), order.Status)
+	assert.Equal(t, int64(80000000), order.AVGPrice)
+	order, err = Exec_QueryOrder(orderD, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(et.Ordered), order.Status)
+	_, err = Exec_QueryOrderList(et.Inactive, Nodes[3], "", stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+	_, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpBuy}, stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+	marketDepthList, err := Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(marketDepthList.List))
+	assert.Equal(t, int64(70000000), marketDepthList.List[0].Price)
+
+	/*
+	 用例说明：
+	   1.A的止损卖单触发价格0.7,限价0.71
+	   2.C以0.72买入2个,和D的挂单以0.7成交1个,剩余部分挂单
+	   3.A的止损单被触发,和C在同一笔交易中挂出的买单以0.71成交,不会出现买卖盘交叉
+	*/
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 71000000, Amount: types.Coin, Op: et.OpSell, TriggerPrice: 70000000}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	orderA := queryInactive(Nodes[0])
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 72000000, Amount: 2 * types.Coin, Op: et.OpBuy}, PrivKeyC, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	order, err = Exec_QueryOrder(orderA, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(et.Completed), order.Status)
+	assert.Equal(t, int64(71000000), order.AVGPrice)
+	orderList, err := Exec_QueryOrderList(et.Completed, Nodes[2], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(orderList.List))
+	_, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpBuy}, stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+	_, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpSell}, stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+
+	/*
+	 用例说明：
+	   1.B的买单按高度过期,C的止损买单按时间过期
+	   2.之后的撮合交易依次撤销过期的订单,解冻剩余资产并从市场深度中删除
+	*/
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 50000000, Amount: types.Coin, Op: et.OpBuy, ExpireHeight: env.blockHeight + 3}, PrivKeyB, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	orderList, err = Exec_QueryOrderList(et.Ordered, Nodes[1], "", stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	orderB := orderList.List[0].OrderID
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 2 * types.Coin, Amount: types.Coin, Op: et.OpBuy, TriggerPrice: 2 * types.Coin, ExpireTime: env.blockTime + 50}, PrivKeyC, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	orderC = queryInactive(Nodes[2])
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 90000000, Amount: types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	order, err = Exec_QueryOrder(orderB, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(et.Revoked), order.Status)
+	acc := accB.LoadExecAccount(Nodes[1], execAddr)
+	assert.Equal(t, int64(0), acc.Frozen)
+	_, err = Exec_QueryMarketDepth(&et.QueryMarketDepth{LeftAsset: left, RightAsset: right, Op: et.OpBuy}, stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+	assert.Equal(t, orderC, queryInactive(Nodes[2]))
+
+	err = Exec_LimitOrder(t, &et.LimitOrder{LeftAsset: left, RightAsset: right, Price: 95000000, Amount: types.Coin, Op: et.OpSell}, PrivKeyA, stateDB, kvdb, env)
+	assert.Equal(t, nil, err)
+	order, err = Exec_QueryOrder(orderC, stateDB, kvdb)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(et.Revoked), order.Status)
+	acc = accB.LoadExecAccount(Nodes[2], execAddr)
+	assert.Equal(t, int64(0), acc.Frozen)
+	_, err = Exec_QueryOrderList(et.Inactive, Nodes[2], "", stateDB, kvdb)
+	assert.Equal(t, types.ErrNotFound, err)
+}
+
 func CreateLimitOrder(limitOrder *et.LimitOrder, privKey string) (tx *types.Transaction, err error) {
 	ety := types.LoadExecutorType(et.ExchangeX)
 	tx, err = ety.Create("LimitOrder", limitOrder)
@@ -692,6 +1336,24 @@ func CreateLimitOrder(limitOrder *et.LimitOrder, privKey string) (tx *types.Tran
 	}
 	return tx, nil
 }
+func CreateMarketOrder(marketOrder *et.MarketOrder, privKey string) (tx *types.Transaction, err error) {
+	ety := types.LoadExecutorType(et.ExchangeX)
+	tx, err = ety.Create("MarketOrder", marketOrder)
+	if err != nil {
+		return nil, err
+	}
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	tx, err = types.FormatTx(cfg, et.ExchangeX, tx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err = signTx(tx, privKey)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
 func CreateRevokeOrder(orderID int64, privKey string) (tx *types.Transaction, err error) {
 	ety := types.LoadExecutorType(et.ExchangeX)
 	tx, err = ety.Create("RevokeOrder", &et.RevokeOrder{OrderID: orderID})
@@ -711,6 +1373,25 @@ func CreateRevokeOrder(orderID int64, privKey string) (tx *types.Transaction, er
 	return tx, nil
 }
 
+func CreateTriggerOrder(orderID int64, privKey string) (tx *types.Transaction, err error) {
+	ety := types.LoadExecutorType(et.ExchangeX)
+	tx, err = ety.Create("TriggerOrder", &et.TriggerOrder{OrderID: orderID})
+	if err != nil {
+		return nil, err
+	}
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	tx, err = types.FormatTx(cfg, et.ExchangeX, tx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err = signTx(tx, privKey)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
 //模拟区块中交易得执行过程
 func Exec_Block(t *testing.T, stateDB db.DB, kvdb db.KVDB, env *execEnv, txs ...*types.Transaction) error {
 	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
@@ -767,6 +1448,14 @@ func Exec_LimitOrder(t *testing.T, limitOrder *et.LimitOrder, privKey string, st
 	return Exec_Block(t, stateDB, kvdb, env, tx)
 }
 
+func Exec_MarketOrder(t *testing.T, marketOrder *et.MarketOrder, privKey string, stateDB db.DB, kvdb db.KVDB, env *execEnv) error {
+	tx, err := CreateMarketOrder(marketOrder, privKey)
+	if err != nil {
+		return err
+	}
+	return Exec_Block(t, stateDB, kvdb, env, tx)
+}
+
 func Exec_RevokeOrder(t *testing.T, orderID int64, privKey string, stateDB db.DB, kvdb db.KVDB, env *execEnv) error {
 	tx, err := CreateRevokeOrder(orderID, privKey)
 	if err != nil {
@@ -775,6 +1464,14 @@ func Exec_RevokeOrder(t *testing.T, orderID int64, privKey string, stateDB db.DB
 	return Exec_Block(t, stateDB, kvdb, env, tx)
 }
 
+func Exec_TriggerOrder(t *testing.T, orderID int64, privKey string, stateDB db.DB, kvdb db.KVDB, env *execEnv) error {
+	tx, err := CreateTriggerOrder(orderID, privKey)
+	if err != nil {
+		return err
+	}
+	return Exec_Block(t, stateDB, kvdb, env, tx)
+}
+
 func Exec_QueryOrderList(status int32, addr string, primaryKey string, stateDB db.KV, kvdb db.KVDB) (*et.OrderList, error) {
 	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
 	cfg.SetTitleOnlyForTest("chain33")
@@ -842,6 +1539,34 @@ func Exec_QueryHistoryOrder(query *et.QueryHistoryOrderList, stateDB db.KV, kvdb
 	msg, err := exec.Query(et.FuncNameQueryHistoryOrderList, types.Encode(query))
 	return msg.(*et.OrderList), err
 }
+
+//模拟区块回滚时交易的本地数据删除
+func Exec_DelLocal(tx *types.Transaction, kvdb db.KVDB) error {
+	exec := NewExchange()
+	exec.SetLocalDB(kvdb)
+	set, err := exec.ExecDelLocal(tx, nil, 0)
+	if err != nil {
+		return err
+	}
+	for _, kv := range set.KV {
+		kvdb.Set(kv.Key, kv.Value)
+	}
+	return nil
+}
+
+func Exec_Query(funcName string, query types.Message, stateDB db.KV, kvdb db.KVDB) (types.Message, error) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	exec := NewExchange()
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	api, _ := client.New(q.Client(), nil)
+	exec.SetAPI(api)
+	exec.SetStateDB(stateDB)
+	exec.SetLocalDB(kvdb)
+	return exec.Query(funcName, types.Encode(query))
+}
+
 func signTx(tx *types.Transaction, hexPrivKey string) (*types.Transaction, error) {
 	signType := types.SECP256K1
 	c, err := crypto.New(types.GetSignName("", signType))
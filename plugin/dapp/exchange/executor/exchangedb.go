@@ -1,8 +1,11 @@
 package executor
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
+	"sort"
+	"strconv"
 
 	"github.com/33cn/chain33/account"
 	"github.com/33cn/chain33/client"
@@ -13,6 +16,17 @@ import (
 	et "github.com/33cn/plugin/plugin/dapp/exchange/types"
 )
 
+var (
+	// ConfNameFeeAddr 手续费收款地址,未配置时不收取手续费
+	ConfNameFeeAddr = et.ExchangeX + "-" + "feeAddr"
+	// ConfNameMakerFee 挂单方手续费费率,追加交易对后缀可以单独配置
+	ConfNameMakerFee = et.ExchangeX + "-" + "makerFee"
+	// ConfNameTakerFee 吃单方手续费费率,追加交易对后缀可以单独配置
+	ConfNameTakerFee = et.ExchangeX + "-" + "takerFee"
+	// DefaultFeeRate 默认手续费费率
+	DefaultFeeRate = int64(0)
+)
+
 // Action action struct
 type Action struct {
 	statedb   dbm.KV
@@ -24,13 +38,62 @@ type Action struct {
 	localDB   dbm.KVDB
 	index     int
 	api       client.QueueProtocolAPI
+	book      *orderBook
 }
 
 func NewAction(e *exchange, tx *types.Transaction, index int) *Action {
 	hash := tx.Hash()
 	fromaddr := tx.From()
-	return &Action{e.GetStateDB(), hash, fromaddr,
-		e.GetBlockTime(), e.GetHeight(), dapp.ExecAddress(string(tx.Execer)), e.GetLocalDB(), index, e.GetAPI()}
+	a := &Action{e.GetStateDB(), hash, fromaddr,
+		e.GetBlockTime(), e.GetHeight(), dapp.ExecAddress(string(tx.Execer)), e.GetLocalDB(), index, e.GetAPI(), nil}
+	a.book = newOrderBook(a.GetIndex())
+	return a
+}
+
+//orderBook 记录同一笔交易中已经更新的订单,本地数据库中的挂单在交易执行之后才会更新,
+//一笔交易撮合多次时(自动激活止损单,撤销过期订单)以这里记录的最新状态为准
+type orderBook struct {
+	//订单号对应的最新状态
+	orders map[int64]*et.Order
+	//本交易中新挂出的订单
+	resting []*et.Order
+	//下一个订单回执使用的索引
+	index int64
+	//本交易中最新的成交价格
+	lastPrice int64
+}
+
+func newOrderBook(index int64) *orderBook {
+	return &orderBook{orders: make(map[int64]*et.Order), index: index}
+}
+
+func (b *orderBook) update(order *et.Order) {
+	b.orders[order.OrderID] = order
+}
+
+//latest 返回订单在本交易中的最新状态
+func (b *orderBook) latest(order *et.Order) *et.Order {
+	if o, ok := b.orders[order.OrderID]; ok {
+		return o
+	}
+	return order
+}
+
+//restingOrders 本交易中新挂出且仍然有效的订单,按价格优先,时间优先排序
+func (b *orderBook) restingOrders(left, right *et.Asset, op int32) []*et.Order {
+	var list []*et.Order
+	for _, order := range b.resting {
+		limitOrder := order.GetLimitOrder()
+		if order.Status != et.Ordered || limitOrder.GetOp() != op ||
+			limitOrder.GetLeftAsset().GetSymbol() != left.GetSymbol() || limitOrder.GetRightAsset().GetSymbol() != right.GetSymbol() {
+			continue
+		}
+		list = append(list, order)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return isBetterPrice(op, list[i].GetLimitOrder().GetPrice(), list[j].GetLimitOrder().GetPrice())
+	})
+	return list
 }
 
 //GetIndex get index
@@ -39,6 +102,12 @@ func (a *Action) GetIndex() int64 {
 	return (a.height*types.MaxTxsPerBlock + int64(a.index)) * 1e4
 }
 
+//生成订单回执日志,同一交易中的多个回执依次占用索引,回执中撮合的订单使用回执索引之后的索引
+func (a *Action) receiptLog(ty int32, re *et.ReceiptExchange) *types.ReceiptLog {
+	a.book.index = re.Index + int64(len(re.MatchOrders)) + 1
+	return &types.ReceiptLog{Ty: ty, Log: types.Encode(re)}
+}
+
 //GetKVSet get kv set
 func (a *Action) GetKVSet(order *et.Order) (kvset []*types.KeyValue) {
 	kvset = append(kvset, &types.KeyValue{Key: calcOrderKey(order.OrderID), Value: types.Encode(order)})
@@ -87,6 +156,46 @@ func CheckAmount(amount int64) bool {
 	return true
 }
 
+//滑点取值范围 0<=slippage<=10000,单位万分比
+func CheckSlippage(slippage int64) bool {
+	return slippage >= 0 && slippage <= et.MaxSlippage
+}
+
+//手续费费率取值范围 0<=rate<=1000,单位万分比
+func CheckFeeRate(rate int64) bool {
+	return rate >= 0 && rate <= et.MaxFeeRate
+}
+
+//成交时效只有GTC,IOC,FOK三种;只做挂单方的订单必须是GTC且不能带触发价格,FOK不能带触发价格
+func CheckTimeInForce(order *et.LimitOrder) bool {
+	tif := order.GetTimeInForce()
+	if tif != et.TimeInForceGTC && tif != et.TimeInForceIOC && tif != et.TimeInForceFOK {
+		return false
+	}
+	if order.GetPostOnly() && (tif != et.TimeInForceGTC || order.GetTriggerPrice() > 0) {
+		return false
+	}
+	return tif != et.TimeInForceFOK || order.GetTriggerPrice() == 0
+}
+
+//触发价格为0表示普通限价单,否则和价格有相同的取值范围
+func CheckTriggerPrice(price int64) bool {
+	return price == 0 || CheckPrice(price)
+}
+
+//过期高度和时间不能为负数,0表示不过期
+func CheckExpire(order *et.LimitOrder) bool {
+	return order.GetExpireHeight() >= 0 && order.GetExpireTime() >= 0
+}
+
+func CheckCandleCount(count int32) bool {
+	return count <= et.MaxCandleCount && count >= 0
+}
+
+func CheckPeriod(period string) bool {
+	return et.GetPeriodSeconds(period) > 0
+}
+
 func CheckDirection(direction int32) bool {
 	if direction == et.ListASC || direction == et.ListDESC {
 		return true
@@ -95,7 +204,7 @@ func CheckDirection(direction int32) bool {
 }
 
 func CheckStatus(status int32) bool {
-	if status == et.Ordered || status == et.Completed || status == et.Revoked {
+	if status == et.Ordered || status == et.Completed || status == et.Revoked || status == et.Inactive {
 		return true
 	}
 	return false
@@ -111,6 +220,35 @@ func CheckExchangeAsset(left, right *et.Asset) bool {
 	}
 	return true
 }
+
+//限价单达到过期高度或者过期时间后视为撤销
+func isOrderExpired(order *et.Order, height, blocktime int64) bool {
+	limitOrder := order.GetLimitOrder()
+	if limitOrder.GetExpireHeight() > 0 && height >= limitOrder.GetExpireHeight() {
+		return true
+	}
+	return limitOrder.GetExpireTime() > 0 && blocktime >= limitOrder.GetExpireTime()
+}
+
+//止损买单在最新成交价涨到触发价格时生效,止损卖单在最新成交价跌到触发价格时生效
+func isTriggered(op int32, triggerPrice, lastPrice int64) bool {
+	if lastPrice == 0 {
+		return false
+	}
+	if op == et.OpBuy {
+		return lastPrice >= triggerPrice
+	}
+	return lastPrice <= triggerPrice
+}
+
+//对手盘中卖单价格低的优先,买单价格高的优先
+func isBetterPrice(op int32, price, other int64) bool {
+	if op == et.OpSell {
+		return price < other
+	}
+	return price > other
+}
+
 func (a *Action) LimitOrder(payload *et.LimitOrder) (*types.Receipt, error) {
 	leftAsset := payload.GetLeftAsset()
 	rightAsset := payload.GetRightAsset()
@@ -128,6 +266,17 @@ func (a *Action) LimitOrder(payload *et.LimitOrder) (*types.Receipt, error) {
 	if !CheckOp(payload.GetOp()) {
 		return nil, et.ErrAssetOp
 	}
+	if !CheckTimeInForce(payload) {
+		return nil, et.ErrTimeInForce
+	}
+	if !CheckTriggerPrice(payload.GetTriggerPrice()) {
+		return nil, et.ErrAssetPrice
+	}
+	//过期高度和时间必须晚于当前区块
+	if !CheckExpire(payload) || (payload.GetExpireHeight() > 0 && payload.GetExpireHeight() <= a.height) ||
+		(payload.GetExpireTime() > 0 && payload.GetExpireTime() <= a.blocktime) {
+		return nil, et.ErrExpire
+	}
 	//TODO 这里symbol
 	cfg := a.api.GetConfig()
 	leftAssetDB, err := account.NewAccountDB(cfg, leftAsset.GetExecer(), leftAsset.GetSymbol(), a.statedb)
@@ -146,8 +295,6 @@ func (a *Action) LimitOrder(payload *et.LimitOrder) (*types.Receipt, error) {
 			elog.Error("limit check right balance", "addr", a.fromaddr, "avail", rightAccount.Balance, "need", amount)
 			return nil, et.ErrAssetBalance
 		}
-		return a.matchLimitOrder(payload, leftAssetDB, rightAssetDB)
-
 	}
 	if payload.GetOp() == et.OpSell {
 		amount := payload.GetAmount()
@@ -156,104 +303,655 @@ func (a *Action) LimitOrder(payload *et.LimitOrder) (*types.Receipt, error) {
 			elog.Error("limit check left balance", "addr", a.fromaddr, "avail", leftAccount.Balance, "need", amount)
 			return nil, et.ErrAssetBalance
 		}
-		return a.matchLimitOrder(payload, leftAssetDB, rightAssetDB)
 	}
-	return nil, fmt.Errorf("unknow op")
+	or := &et.Order{
+		OrderID:    a.GetIndex(),
+		Value:      &et.Order_LimitOrder{LimitOrder: payload},
+		Ty:         et.TyLimitOrderAction,
+		Executed:   0,
+		AVGPrice:   0,
+		Balance:    payload.GetAmount(),
+		Status:     et.Ordered,
+		Addr:       a.fromaddr,
+		UpdateTime: a.blocktime,
+		Index:      a.GetIndex(),
+	}
+	//止损限价单在最新成交价穿过触发价格之前只冻结资产,不参与撮合;下单时已经满足触发条件的直接撮合
+	if payload.GetTriggerPrice() > 0 {
+		lastPrice, err := queryLastPrice(a.localDB, leftAsset, rightAsset)
+		if err != nil && err != types.ErrNotFound {
+			return nil, err
+		}
+		if !isTriggered(payload.GetOp(), payload.GetTriggerPrice(), lastPrice) {
+			receipt, err := a.inactiveOrder(or, leftAssetDB, rightAssetDB)
+			if err != nil {
+				return nil, err
+			}
+			return a.afterMatch(receipt, leftAsset, rightAsset, leftAssetDB, rightAssetDB)
+		}
+	}
+	receipt, err := a.matchLimitOrder(or, et.TyLimitOrderLog, leftAssetDB, rightAssetDB)
+	if err != nil {
+		return nil, err
+	}
+	return a.afterMatch(receipt, leftAsset, rightAsset, leftAssetDB, rightAssetDB)
+}
+
+//MarketOrder 市价委托,按对手盘价格由优到劣依次撮合,直到成交完毕或者超出滑点范围,未成交的部分不会挂单
+func (a *Action) MarketOrder(payload *et.MarketOrder) (*types.Receipt, error) {
+	leftAsset := payload.GetLeftAsset()
+	rightAsset := payload.GetRightAsset()
+	if !CheckExchangeAsset(leftAsset, rightAsset) {
+		return nil, et.ErrAsset
+	}
+	if !CheckAmount(payload.GetAmount()) {
+		return nil, et.ErrAssetAmount
+	}
+	if !CheckOp(payload.GetOp()) {
+		return nil, et.ErrAssetOp
+	}
+	if !CheckSlippage(payload.GetSlippage()) {
+		return nil, et.ErrSlippage
+	}
+	cfg := a.api.GetConfig()
+	leftAssetDB, err := account.NewAccountDB(cfg, leftAsset.GetExecer(), leftAsset.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, err
+	}
+	rightAssetDB, err := account.NewAccountDB(cfg, rightAsset.GetExecer(), rightAsset.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, err
+	}
+	//以对手盘的最优价格为基准，计算滑点范围内可以接受的最差成交价格
+	marketDepthList, err := QueryMarketDepth(a.localDB, leftAsset, rightAsset, a.OpSwap(payload.Op), "", 1)
+	if err == types.ErrNotFound || (err == nil && len(marketDepthList.List) == 0) {
+		return nil, et.ErrMarketDepth
+	}
+	if err != nil {
+		return nil, err
+	}
+	limitPrice := CalcSlippagePrice(payload.Op, marketDepthList.List[0].Price, payload.Slippage)
+	//先检查账户余额,买单按最差成交价格计算所需资产
+	if payload.GetOp() == et.OpBuy {
+		amount := SafeMul(payload.GetAmount(), limitPrice)
+		rightAccount := rightAssetDB.LoadExecAccount(a.fromaddr, a.execaddr)
+		if rightAccount.Balance < amount {
+			elog.Error("market check right balance", "addr", a.fromaddr, "avail", rightAccount.Balance, "need", amount)
+			return nil, et.ErrAssetBalance
+		}
+	}
+	if payload.GetOp() == et.OpSell {
+		amount := payload.GetAmount()
+		leftAccount := leftAssetDB.LoadExecAccount(a.fromaddr, a.execaddr)
+		if leftAccount.Balance < amount {
+			elog.Error("market check left balance", "addr", a.fromaddr, "avail", leftAccount.Balance, "need", amount)
+			return nil, et.ErrAssetBalance
+		}
+	}
+	receipt, err := a.matchMarketOrder(payload, limitPrice, leftAssetDB, rightAssetDB)
+	if err != nil {
+		return nil, err
+	}
+	return a.afterMatch(receipt, leftAsset, rightAsset, leftAssetDB, rightAssetDB)
 }
 
 func (a *Action) RevokeOrder(payload *et.RevokeOrder) (*types.Receipt, error) {
 	var logs []*types.ReceiptLog
 	var kvs []*types.KeyValue
-	order, err := findOrderByOrderID(a.statedb, a.localDB, payload.GetOrderID())
-	if err != nil {
-		return nil, err
-	}
-	if order.Addr != a.fromaddr {
-		elog.Error("RevokeOrder.OrderCheck", "addr", a.fromaddr, "order.addr", order.Addr, "order.status", order.Status)
-		return nil, et.ErrAddr
+	order, err := findOrderByOrderID(a.statedb, a.localDB, payload.GetOrderID())
+	if err != nil {
+		return nil, err
+	}
+	//已经过期的订单任何地址都可以撤销
+	if order.Addr != a.fromaddr && !isOrderExpired(order, a.height, a.blocktime) {
+		elog.Error("RevokeOrder.OrderCheck", "addr", a.fromaddr, "order.addr", order.Addr, "order.status", order.Status)
+		return nil, et.ErrAddr
+	}
+	if order.Status == et.Completed || order.Status == et.Revoked {
+		elog.Error("RevokeOrder.OrderCheck", "addr", a.fromaddr, "order.addr", order.Addr, "order.status", order.Status)
+		return nil, et.ErrOrderSatus
+	}
+	leftAsset := order.GetLimitOrder().GetLeftAsset()
+	rightAsset := order.GetLimitOrder().GetRightAsset()
+
+	cfg := a.api.GetConfig()
+	leftAssetDB, err := account.NewAccountDB(cfg, leftAsset.GetExecer(), leftAsset.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, err
+	}
+	rightAssetDB, err := account.NewAccountDB(cfg, rightAsset.GetExecer(), rightAsset.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := a.unfrozenOrder(order, leftAssetDB, rightAssetDB)
+	if err != nil {
+		return nil, err
+	}
+	logs = append(logs, receipt.Logs...)
+	kvs = append(kvs, receipt.KV...)
+
+	//更新order状态
+	order.Status = et.Revoked
+	order.UpdateTime = a.blocktime
+	kvs = append(kvs, a.GetKVSet(order)...)
+	re := &et.ReceiptExchange{
+		Order: order,
+		Index: a.GetIndex(),
+	}
+	receiptlog := &types.ReceiptLog{Ty: et.TyRevokeOrderLog, Log: types.Encode(re)}
+	logs = append(logs, receiptlog)
+	receipts := &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}
+	return receipts, nil
+
+}
+
+//TriggerOrder 激活最新成交价已经穿过触发价格的止损限价单,任何地址都可以发起,订单以下单地址作为吃单方撮合,
+//撮合时满足触发条件的止损单会自动激活,这里用于激活超出单笔交易自动激活数量的止损单
+func (a *Action) TriggerOrder(payload *et.TriggerOrder) (*types.Receipt, error) {
+	order, err := findOrderByOrderID(a.statedb, a.localDB, payload.GetOrderID())
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != et.Inactive {
+		elog.Error("TriggerOrder.OrderCheck", "addr", a.fromaddr, "order.addr", order.Addr, "order.status", order.Status)
+		return nil, et.ErrOrderSatus
+	}
+	leftAsset := order.GetLimitOrder().GetLeftAsset()
+	rightAsset := order.GetLimitOrder().GetRightAsset()
+	if !isOrderExpired(order, a.height, a.blocktime) {
+		lastPrice, err := queryLastPrice(a.localDB, leftAsset, rightAsset)
+		if err != nil && err != types.ErrNotFound {
+			return nil, err
+		}
+		if !isTriggered(order.GetLimitOrder().GetOp(), order.GetLimitOrder().GetTriggerPrice(), lastPrice) {
+			return nil, et.ErrTrigger
+		}
+	}
+
+	cfg := a.api.GetConfig()
+	leftAssetDB, err := account.NewAccountDB(cfg, leftAsset.GetExecer(), leftAsset.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, err
+	}
+	rightAssetDB, err := account.NewAccountDB(cfg, rightAsset.GetExecer(), rightAsset.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := a.activateStopOrder(order, leftAssetDB, rightAssetDB)
+	if err != nil {
+		return nil, err
+	}
+	return a.afterMatch(receipt, leftAsset, rightAsset, leftAssetDB, rightAssetDB)
+}
+
+//activateStopOrder 激活止损限价单,过期的止损单直接撤销,否则以下单地址作为吃单方按限价单撮合
+func (a *Action) activateStopOrder(order *et.Order, leftAccountDB, rightAccountDB *account.DB) (*types.Receipt, error) {
+	var logs []*types.ReceiptLog
+	var kvs []*types.KeyValue
+	//先解冻下单时冻结的资产,撮合后未成交的部分会重新冻结
+	receipt, err := a.unfrozenOrder(order, leftAccountDB, rightAccountDB)
+	if err != nil {
+		return nil, err
+	}
+	logs = append(logs, receipt.Logs...)
+	kvs = append(kvs, receipt.KV...)
+	order.UpdateTime = a.blocktime
+	order.Index = a.book.index
+
+	//过期的止损单直接撤销
+	if isOrderExpired(order, a.height, a.blocktime) {
+		order.Status = et.Revoked
+		a.book.update(order)
+		kvs = append(kvs, a.GetKVSet(order)...)
+		re := &et.ReceiptExchange{
+			Order: order,
+			Index: order.Index,
+		}
+		logs = append(logs, a.receiptLog(et.TyTriggerOrderLog, re))
+		return &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}, nil
+	}
+
+	order.Status = et.Ordered
+	taker := *a
+	taker.fromaddr = order.Addr
+	receipt, err = taker.matchLimitOrder(order, et.TyTriggerOrderLog, leftAccountDB, rightAccountDB)
+	if err != nil {
+		return nil, err
+	}
+	logs = append(logs, receipt.Logs...)
+	kvs = append(kvs, receipt.KV...)
+	return &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}, nil
+}
+
+//afterMatch 撮合之后依次激活满足触发条件的止损单,再撤销已经过期的订单,回执追加在撮合回执之后
+func (a *Action) afterMatch(receipt *types.Receipt, left, right *et.Asset, leftAccountDB, rightAccountDB *account.DB) (*types.Receipt, error) {
+	trigger, err := a.triggerStopOrders(left, right, leftAccountDB, rightAccountDB)
+	if err != nil {
+		return nil, err
+	}
+	receipt.KV = append(receipt.KV, trigger.KV...)
+	receipt.Logs = append(receipt.Logs, trigger.Logs...)
+	expire, err := a.revokeExpiredOrders()
+	if err != nil {
+		return nil, err
+	}
+	receipt.KV = append(receipt.KV, expire.KV...)
+	receipt.Logs = append(receipt.Logs, expire.Logs...)
+	return receipt, nil
+}
+
+//triggerStopOrders 按本交易最新的成交价格激活止损单,激活后的成交可能继续触发其他止损单
+func (a *Action) triggerStopOrders(left, right *et.Asset, leftAccountDB, rightAccountDB *account.DB) (*types.Receipt, error) {
+	var logs []*types.ReceiptLog
+	var kvs []*types.KeyValue
+	for i := 0; i < et.MaxTriggerCount && a.book.lastPrice > 0; i++ {
+		order, err := a.nextTriggeredOrder(left, right)
+		if err != nil {
+			return nil, err
+		}
+		if order == nil {
+			break
+		}
+		receipt, err := a.activateStopOrder(order, leftAccountDB, rightAccountDB)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, receipt.Logs...)
+		kvs = append(kvs, receipt.KV...)
+	}
+	return &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}, nil
+}
+
+//nextTriggeredOrder 查找满足触发条件的止损单,买单和卖单都满足时先激活先下单的
+func (a *Action) nextTriggeredOrder(left, right *et.Asset) (*et.Order, error) {
+	var next *et.Order
+	for _, op := range []int32{et.OpBuy, et.OpSell} {
+		order, err := a.findTriggeredOrder(left, right, op)
+		if err != nil {
+			return nil, err
+		}
+		if order != nil && (next == nil || order.OrderID < next.OrderID) {
+			next = order
+		}
+	}
+	return next, nil
+}
+
+//止损买单按触发价格由低到高查找,止损卖单按触发价格由高到低查找
+func (a *Action) findTriggeredOrder(left, right *et.Asset, op int32) (*et.Order, error) {
+	table := NewStopOrderTable(a.localDB)
+	prefix := []byte(fmt.Sprintf("%s:%s:%d:", left.GetSymbol(), right.GetSymbol(), op))
+	direction := et.ListASC
+	if op == et.OpSell {
+		direction = et.ListDESC
+	}
+	//本交易中最多激活MaxTriggerCount个止损单,多查询一条就可以跳过本交易中已经激活的止损单
+	rows, err := table.ListIndex("trigger", prefix, nil, et.MaxTriggerCount+1, direction)
+	if err == types.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		elog.Error("findTriggeredOrder.", "left", left, "right", right, "op", op, "err", err.Error())
+		return nil, err
+	}
+	for _, row := range rows {
+		order := a.book.latest(row.Data.(*et.Order))
+		if order.Status != et.Inactive {
+			continue
+		}
+		if !isTriggered(op, order.GetLimitOrder().GetTriggerPrice(), a.book.lastPrice) {
+			return nil, nil
+		}
+		return order, nil
+	}
+	return nil, nil
+}
+
+//revokeExpiredOrders 撤销已经过期的挂单和止损单并解冻剩余资产,分别按过期高度和过期时间由早到晚查找
+func (a *Action) revokeExpiredOrders() (*types.Receipt, error) {
+	var logs []*types.ReceiptLog
+	var kvs []*types.KeyValue
+	table := NewExpireOrderTable(a.localDB)
+	expired := map[string]func(order *et.LimitOrder) bool{
+		"expire_height": func(order *et.LimitOrder) bool { return expireValue(order.GetExpireHeight()) <= a.height },
+		"expire_time":   func(order *et.LimitOrder) bool { return expireValue(order.GetExpireTime()) <= a.blocktime },
+	}
+	count := 0
+	for _, indexName := range []string{"expire_height", "expire_time"} {
+		var primaryKey []byte
+	Loop:
+		for count < et.MaxExpireCount {
+			rows, err := table.ListIndex(indexName, nil, primaryKey, et.Count, et.ListASC)
+			if err == types.ErrNotFound {
+				break
+			}
+			if err != nil {
+				elog.Error("revokeExpiredOrders.", "index", indexName, "err", err.Error())
+				return nil, err
+			}
+			for _, row := range rows {
+				order := a.book.latest(row.Data.(*et.Order))
+				if !expired[indexName](order.GetLimitOrder()) {
+					break Loop
+				}
+				//本交易中已经成交或者撤销的订单
+				if order.Status != et.Ordered && order.Status != et.Inactive {
+					continue
+				}
+				if count >= et.MaxExpireCount {
+					break Loop
+				}
+				receipt, err := a.revokeOrder(order)
+				if err != nil {
+					return nil, err
+				}
+				logs = append(logs, receipt.Logs...)
+				kvs = append(kvs, receipt.KV...)
+				count = count + 1
+			}
+			if len(rows) < int(et.Count) {
+				break
+			}
+			primaryKey = rows[len(rows)-1].Primary
+		}
+	}
+	return &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}, nil
+}
+
+//revokeOrder 撤销订单并解冻剩余资产,订单可以属于任意交易对
+func (a *Action) revokeOrder(order *et.Order) (*types.Receipt, error) {
+	leftAsset := order.GetLimitOrder().GetLeftAsset()
+	rightAsset := order.GetLimitOrder().GetRightAsset()
+	cfg := a.api.GetConfig()
+	leftAssetDB, err := account.NewAccountDB(cfg, leftAsset.GetExecer(), leftAsset.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, err
+	}
+	rightAssetDB, err := account.NewAccountDB(cfg, rightAsset.GetExecer(), rightAsset.GetSymbol(), a.statedb)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := a.unfrozenOrder(order, leftAssetDB, rightAssetDB)
+	if err != nil {
+		return nil, err
+	}
+	order.Status = et.Revoked
+	order.UpdateTime = a.blocktime
+	a.book.update(order)
+	kvs := append(receipt.KV, a.GetKVSet(order)...)
+	re := &et.ReceiptExchange{
+		Order: order,
+		Index: a.book.index,
+	}
+	logs := append(receipt.Logs, a.receiptLog(et.TyRevokeOrderLog, re))
+	return &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}, nil
+}
+
+//止损限价单冻结全部资产后等待触发
+func (a *Action) inactiveOrder(or *et.Order, leftAccountDB, rightAccountDB *account.DB) (*types.Receipt, error) {
+	receipt, err := a.frozenOrder(or, leftAccountDB, rightAccountDB)
+	if err != nil {
+		return nil, err
+	}
+	or.Status = et.Inactive
+	a.book.update(or)
+	kvs := append(receipt.KV, a.GetKVSet(or)...)
+	re := &et.ReceiptExchange{
+		Order: or,
+		Index: or.Index,
+	}
+	logs := append(receipt.Logs, a.receiptLog(et.TyLimitOrderLog, re))
+	return &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}, nil
+}
+
+//冻结限价单未成交部分需要的资产
+func (a *Action) frozenOrder(or *et.Order, leftAccountDB, rightAccountDB *account.DB) (*types.Receipt, error) {
+	payload := or.GetLimitOrder()
+	accountDB := leftAccountDB
+	if payload.GetOp() == et.OpBuy {
+		accountDB = rightAccountDB
+	}
+	amount := CalcActualCost(payload.GetOp(), or.GetBalance(), payload.GetPrice())
+	receipt, err := accountDB.ExecFrozen(or.Addr, a.execaddr, amount)
+	if err != nil {
+		elog.Error("LimitOrder.ExecFrozen", "addr", or.Addr, "amount", amount, "err", err.Error())
+		return nil, err
+	}
+	return receipt, nil
+}
+
+//解冻限价单未成交部分冻结的资产
+func (a *Action) unfrozenOrder(or *et.Order, leftAccountDB, rightAccountDB *account.DB) (*types.Receipt, error) {
+	payload := or.GetLimitOrder()
+	accountDB := leftAccountDB
+	if payload.GetOp() == et.OpBuy {
+		accountDB = rightAccountDB
+	}
+	amount := CalcActualCost(payload.GetOp(), or.GetBalance(), payload.GetPrice())
+	acc := accountDB.LoadExecAccount(or.Addr, a.execaddr)
+	if acc.Frozen < amount {
+		elog.Error("unfrozenOrder check frozen", "addr", or.Addr, "avail", acc.Frozen, "amount", amount)
+		return nil, et.ErrAssetBalance
+	}
+	receipt, err := accountDB.ExecActive(or.Addr, a.execaddr, amount)
+	if err != nil {
+		elog.Error("unfrozenOrder.ExecActive", "addr", or.Addr, "amount", amount, "err", err.Error())
+		return nil, err
+	}
+	return receipt, nil
+}
+
+//撮合时遇到已经过期的挂单,直接撤销并解冻剩余资产,随撮合回执一起更新本地索引
+func (a *Action) revokeExpiredOrder(matchorder *et.Order, re *et.ReceiptExchange, leftAccountDB, rightAccountDB *account.DB) ([]*types.ReceiptLog, []*types.KeyValue, error) {
+	receipt, err := a.unfrozenOrder(matchorder, leftAccountDB, rightAccountDB)
+	if err != nil {
+		return nil, nil, err
+	}
+	matchorder.Status = et.Revoked
+	matchorder.UpdateTime = a.blocktime
+	a.book.update(matchorder)
+	kvs := append(receipt.KV, a.GetKVSet(matchorder)...)
+	re.MatchOrders = append(re.MatchOrders, matchorder)
+	return receipt.Logs, kvs, nil
+}
+
+//walkMatchOrders 按价格优先,时间优先的顺序遍历对手盘挂单,直到价格不再满足cross或者fn要求停止,
+//本交易中已经更新的挂单以最新状态为准,本交易中新挂出的订单排在同价格的已有挂单之后
+func (a *Action) walkMatchOrders(left, right *et.Asset, op int32, cross func(price int64) bool, fn func(matchorder *et.Order) (bool, error)) error {
+	resting := a.book.restingOrders(left, right, op)
+	//遍历价格优于price的新挂单,返回false表示停止遍历
+	walkResting := func(better func(price int64) bool) (bool, error) {
+		for len(resting) > 0 && better(resting[0].GetLimitOrder().GetPrice()) {
+			matchorder := resting[0]
+			resting = resting[1:]
+			if !cross(matchorder.GetLimitOrder().GetPrice()) {
+				return false, nil
+			}
+			if matchorder.Status != et.Ordered {
+				continue
+			}
+			stop, err := fn(matchorder)
+			if stop || err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
+	var priceKey string
+	for {
+		//获取现有市场挂单价格信息
+		marketDepthList, err := QueryMarketDepth(a.localDB, left, right, op, priceKey, et.Count)
+		if err == types.ErrNotFound {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		for _, marketDepth := range marketDepthList.List {
+			next, err := walkResting(func(price int64) bool { return isBetterPrice(op, price, marketDepth.Price) })
+			if !next || err != nil {
+				return err
+			}
+			//市场深度按价格由优到劣排列,之后的价格都不再撮合
+			if !cross(marketDepth.Price) {
+				return nil
+			}
+			//根据价格进行迭代
+			var orderKey string
+			for {
+				orderList, err := findOrderIDListByPrice(a.localDB, left, right, marketDepth.Price, op, et.ListASC, orderKey)
+				if err == types.ErrNotFound {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				for _, matchorder := range orderList.List {
+					matchorder = a.book.latest(matchorder)
+					if matchorder.Status != et.Ordered {
+						continue
+					}
+					stop, err := fn(matchorder)
+					if stop || err != nil {
+						return err
+					}
+				}
+				//查询数据不满足10条说明没有了,跳出循环
+				if orderList.PrimaryKey == "" {
+					break
+				}
+				orderKey = orderList.PrimaryKey
+			}
+		}
+		//查询的数据如果没有primaryKey说明没有后续数据了,跳出循环
+		if marketDepthList.PrimaryKey == "" {
+			break
+		}
+		priceKey = marketDepthList.PrimaryKey
+	}
+	_, err := walkResting(func(int64) bool { return true })
+	return err
+}
+
+//撮合交易逻辑方法
+// 规则：
+//1.买单高于市场价，按价格由低往高撮合。
+//2.卖单低于市场价，按价格由高往低进行撮合。
+//3.价格相同按先进先出的原则进行撮合
+//4.买家获利得原则
+func (a *Action) matchLimitOrder(or *et.Order, logTy int32, leftAccountDB, rightAccountDB *account.DB) (*types.Receipt, error) {
+	var logs []*types.ReceiptLog
+	var kvs []*types.KeyValue
+	var count int
+
+	payload := or.GetLimitOrder()
+	fee := a.getFeeConfig(payload.GetLeftAsset(), payload.GetRightAsset())
+	re := &et.ReceiptExchange{
+		Order:   or,
+		Index:   or.Index,
+		FeeAddr: fee.addr,
 	}
-	if order.Status == et.Completed || order.Status == et.Revoked {
-		elog.Error("RevokeOrder.OrderCheck", "addr", a.fromaddr, "order.addr", order.Addr, "order.status", order.Status)
-		return nil, et.ErrOrderSatus
+	//买单和不高于买价的卖单成交,卖单和不低于卖价的买单成交
+	cross := func(price int64) bool {
+		if payload.Op == et.OpBuy {
+			return price <= payload.GetPrice()
+		}
+		return price >= payload.GetPrice()
 	}
-	leftAsset := order.GetLimitOrder().GetLeftAsset()
-	rightAsset := order.GetLimitOrder().GetRightAsset()
-	price := order.GetLimitOrder().GetPrice()
-	balance := order.GetBalance()
-
-	cfg := a.api.GetConfig()
 
-	if order.GetLimitOrder().GetOp() == et.OpBuy {
-		rightAssetDB, err := account.NewAccountDB(cfg, rightAsset.GetExecer(), rightAsset.GetSymbol(), a.statedb)
-		if err != nil {
-			return nil, err
+	//单笔交易最多撮合100笔历史订单,最大可撮合得深度，系统得自我防护
+	err := a.walkMatchOrders(payload.GetLeftAsset(), payload.GetRightAsset(), a.OpSwap(payload.Op), cross, func(matchorder *et.Order) (bool, error) {
+		//当撮合深度大于等于最大深度时跳出
+		if count >= et.MaxMatchCount {
+			return true, nil
 		}
-		amount := CalcActualCost(et.OpBuy, balance, price)
-		rightAccount := rightAssetDB.LoadExecAccount(a.fromaddr, a.execaddr)
-		if rightAccount.Frozen < amount {
-			elog.Error("revoke check right frozen", "addr", a.fromaddr, "avail", rightAccount.Frozen, "amount", amount)
-			return nil, et.ErrAssetBalance
+		//同地址不能交易
+		if matchorder.Addr == a.fromaddr {
+			return false, nil
 		}
-		receipt, err := rightAssetDB.ExecActive(a.fromaddr, a.execaddr, amount)
-		if err != nil {
-			elog.Error("RevokeOrder.ExecActive", "addr", a.fromaddr, "amount", amount, "err", err.Error())
-			return nil, err
+		//过期的挂单不再成交,直接撤销
+		if isOrderExpired(matchorder, a.height, a.blocktime) {
+			log, kv, err := a.revokeExpiredOrder(matchorder, re, leftAccountDB, rightAccountDB)
+			if err != nil {
+				return true, err
+			}
+			logs = append(logs, log...)
+			kvs = append(kvs, kv...)
+			count = count + 1
+			return false, nil
 		}
-		logs = append(logs, receipt.Logs...)
-		kvs = append(kvs, receipt.KV...)
-	}
-	if order.GetLimitOrder().GetOp() == et.OpSell {
-		leftAssetDB, err := account.NewAccountDB(cfg, leftAsset.GetExecer(), leftAsset.GetSymbol(), a.statedb)
+		//只做挂单方的订单不能主动成交
+		if payload.GetPostOnly() {
+			return true, et.ErrPostOnly
+		}
+		//撮合,指针传递
+		log, kv, err := a.matchModel(leftAccountDB, rightAccountDB, payload, matchorder, or, re, fee) // payload, or redundant
 		if err != nil {
-			return nil, err
+			return true, err
 		}
-		amount := CalcActualCost(et.OpSell, balance, price)
-		leftAccount := leftAssetDB.LoadExecAccount(a.fromaddr, a.execaddr)
-		if leftAccount.Frozen < amount {
-			elog.Error("revoke check left frozen", "addr", a.fromaddr, "avail", leftAccount.Frozen, "amount", amount)
-			return nil, et.ErrAssetBalance
+		logs = append(logs, log...)
+		kvs = append(kvs, kv...)
+		//TODO 这里得逻辑是否需要调整?当匹配的单数过多，会导致receipt日志数量激增，理论上存在日志存储攻击，需要加下最大匹配深度，防止这种攻击发生
+		//撮合深度计数
+		count = count + 1
+		//订单完成,停止撮合，如果没有完成，则继续撮合，直到count等于最大深度
+		return or.Status == et.Completed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	a.book.update(or)
+	if or.Status == et.Completed {
+		logs = append(logs, a.receiptLog(logTy, re))
+		receipts := &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}
+		return receipts, nil
+	}
+
+	switch payload.GetTimeInForce() {
+	case et.TimeInForceFOK:
+		//没有全部成交时交易执行失败,已经发生的撮合随交易一起回滚
+		elog.Error("LimitOrder.FillOrKill", "addr", a.fromaddr, "executed", or.Executed, "amount", payload.GetAmount())
+		return nil, et.ErrFillOrKill
+	case et.TimeInForceIOC:
+		//未成交的部分直接退回,有成交的订单视为完成,完全没有成交的订单视为撤回
+		if or.Executed > 0 {
+			or.Status = et.Completed
+		} else {
+			or.Status = et.Revoked
 		}
-		receipt, err := leftAssetDB.ExecActive(a.fromaddr, a.execaddr, amount)
+	default:
+		//未完成的订单需要冻结剩余未成交的资金,之后的撮合可以和该订单成交
+		receipt, err := a.frozenOrder(or, leftAccountDB, rightAccountDB)
 		if err != nil {
-			elog.Error("RevokeOrder.ExecActive", "addr", a.fromaddr, "amount", amount, "err", err.Error())
 			return nil, err
 		}
 		logs = append(logs, receipt.Logs...)
 		kvs = append(kvs, receipt.KV...)
+		a.book.resting = append(a.book.resting, or)
 	}
-
 	//更新order状态
-	order.Status = et.Revoked
-	order.UpdateTime = a.blocktime
-	kvs = append(kvs, a.GetKVSet(order)...)
-	re := &et.ReceiptExchange{
-		Order: order,
-		Index: a.GetIndex(),
-	}
-	receiptlog := &types.ReceiptLog{Ty: et.TyRevokeOrderLog, Log: types.Encode(re)}
-	logs = append(logs, receiptlog)
+	kvs = append(kvs, a.GetKVSet(or)...)
+	re.Order = or
+	logs = append(logs, a.receiptLog(logTy, re))
 	receipts := &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}
 	return receipts, nil
-
 }
 
-//撮合交易逻辑方法
+//市价单撮合逻辑方法
 // 规则：
-//1.买单高于市场价，按价格由低往高撮合。
-//2.卖单低于市场价，按价格由高往低进行撮合。
-//3.价格相同按先进先出的原则进行撮合
-//4.买家获利得原则
-func (a *Action) matchLimitOrder(payload *et.LimitOrder, leftAccountDB, rightAccountDB *account.DB) (*types.Receipt, error) {
+//1.买单按卖单价格由低往高撮合,卖单按买单价格由高往低撮合,成交价格为挂单价格
+//2.成交价格超出滑点范围,或者达到最大撮合深度时停止撮合
+//3.市价单不冻结资产也不挂单,未成交的部分直接退回给用户
+func (a *Action) matchMarketOrder(payload *et.MarketOrder, limitPrice int64, leftAccountDB, rightAccountDB *account.DB) (*types.Receipt, error) {
 	var logs []*types.ReceiptLog
 	var kvs []*types.KeyValue
-	var orderKey string
-	var priceKey string
 	var count int
 
 	or := &et.Order{
 		OrderID:    a.GetIndex(),
-		Value:      &et.Order_LimitOrder{LimitOrder: payload},
-		Ty:         et.TyLimitOrderAction,
+		Value:      &et.Order_MarketOrder{MarketOrder: payload},
+		Ty:         et.TyMarketOrderAction,
 		Executed:   0,
 		AVGPrice:   0,
 		Balance:    payload.GetAmount(),
@@ -262,123 +960,84 @@ func (a *Action) matchLimitOrder(payload *et.LimitOrder, leftAccountDB, rightAcc
 		UpdateTime: a.blocktime,
 		Index:      a.GetIndex(),
 	}
+	fee := a.getFeeConfig(payload.GetLeftAsset(), payload.GetRightAsset())
 	re := &et.ReceiptExchange{
-		Order: or,
-		Index: a.GetIndex(),
+		Order:   or,
+		Index:   or.Index,
+		FeeAddr: fee.addr,
+	}
+	//超出滑点范围的价格不再撮合
+	cross := func(price int64) bool {
+		if payload.Op == et.OpBuy {
+			return price <= limitPrice
+		}
+		return price >= limitPrice
 	}
 
-	//单笔交易最多撮合100笔历史订单,最大可撮合得深度，系统得自我防护
-	//迭代已有挂单价格
-	for {
-		//当撮合深度大于最大深度时跳出
+	err := a.walkMatchOrders(payload.GetLeftAsset(), payload.GetRightAsset(), a.OpSwap(payload.Op), cross, func(matchorder *et.Order) (bool, error) {
+		//当撮合深度大于等于最大深度时跳出
 		if count >= et.MaxMatchCount {
-			break
+			return true, nil
 		}
-		//获取现有市场挂单价格信息
-		marketDepthList, err := QueryMarketDepth(a.localDB, payload.GetLeftAsset(), payload.GetRightAsset(), a.OpSwap(payload.Op), priceKey, et.Count)
-		if err == types.ErrNotFound {
-			break
+		//同地址不能交易
+		if matchorder.Addr == a.fromaddr {
+			return false, nil
 		}
-		for _, marketDepth := range marketDepthList.List {
-			if count >= et.MaxMatchCount {
-				break
-			}
-			// 卖单价大于买单价
-			if payload.Op == et.OpBuy && marketDepth.Price > payload.GetPrice() {
-				continue
-			}
-			// 买单价小于卖单价
-			if payload.Op == et.OpSell && marketDepth.Price < payload.GetPrice() {
-				continue
-			}
-			//根据价格进行迭代
-			for {
-				//当撮合深度大于等于最大深度时跳出
-				if count >= et.MaxMatchCount {
-					break
-				}
-				orderList, err := findOrderIDListByPrice(a.localDB, payload.GetLeftAsset(), payload.GetRightAsset(), marketDepth.Price, a.OpSwap(payload.Op), et.ListASC, orderKey)
-				if err == types.ErrNotFound {
-					break
-				}
-
-				for _, matchorder := range orderList.List {
-					//当撮合深度大于最大深度时跳出
-					if count >= et.MaxMatchCount {
-						break
-					}
-					//同地址不能交易
-					if matchorder.Addr == a.fromaddr {
-						continue
-					}
-					//撮合,指针传递
-					log, kv, err := a.matchModel(leftAccountDB, rightAccountDB, payload, matchorder, or, re) // payload, or redundant
-					if err != nil {
-						return nil, err
-					}
-					logs = append(logs, log...)
-					kvs = append(kvs, kv...)
-					//订单完成,直接返回，如果没有完成，则继续撮合，直到count等于
-					if or.Status == et.Completed {
-						receiptlog := &types.ReceiptLog{Ty: et.TyLimitOrderLog, Log: types.Encode(re)}
-						logs = append(logs, receiptlog)
-						receipts := &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}
-						return receipts, nil
-					}
-					//TODO 这里得逻辑是否需要调整?当匹配的单数过多，会导致receipt日志数量激增，理论上存在日志存储攻击，需要加下最大匹配深度，防止这种攻击发生
-					//撮合深度计数
-					count = count + 1
-				}
-				//查询数据不满足10条说明没有了,跳出循环
-				if orderList.PrimaryKey == "" {
-					break
-				}
-				orderKey = orderList.PrimaryKey
+		//过期的挂单不再成交,直接撤销
+		if isOrderExpired(matchorder, a.height, a.blocktime) {
+			log, kv, err := a.revokeExpiredOrder(matchorder, re, leftAccountDB, rightAccountDB)
+			if err != nil {
+				return true, err
 			}
+			logs = append(logs, log...)
+			kvs = append(kvs, kv...)
+			count = count + 1
+			return false, nil
 		}
-
-		//查询的数据如果没有primaryKey说明没有后续数据了,跳出循环
-		if marketDepthList.PrimaryKey == "" {
-			break
+		//市价单以挂单价格成交
+		price := matchorder.GetLimitOrder().GetPrice()
+		limitOrder := &et.LimitOrder{
+			LeftAsset:  payload.GetLeftAsset(),
+			RightAsset: payload.GetRightAsset(),
+			Price:      price,
+			Amount:     payload.GetAmount(),
+			Op:         payload.GetOp(),
 		}
-		priceKey = marketDepthList.PrimaryKey
-	}
-
-	//未完成的订单需要冻结剩余未成交的资金
-	if payload.Op == et.OpBuy {
-		amount := CalcActualCost(et.OpBuy, or.Balance, payload.Price)
-		receipt, err := rightAccountDB.ExecFrozen(a.fromaddr, a.execaddr, amount)
+		executed, avgPrice := or.Executed, or.AVGPrice
+		log, kv, err := a.matchModel(leftAccountDB, rightAccountDB, limitOrder, matchorder, or, re, fee)
 		if err != nil {
-			elog.Error("LimitOrder.ExecFrozen", "addr", a.fromaddr, "amount", amount, "err", err.Error())
-			return nil, err
+			return true, err
 		}
-		logs = append(logs, receipt.Logs...)
-		kvs = append(kvs, receipt.KV...)
+		logs = append(logs, log...)
+		kvs = append(kvs, kv...)
+		//市价单会跨越多个价格成交,需要按成交量加权计算平均价格
+		or.AVGPrice = calcAVGPrice(avgPrice, executed, price, or.Executed-executed)
+		count = count + 1
+		return or.Status == et.Completed, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	if payload.Op == et.OpSell {
-		amount := CalcActualCost(et.OpSell, or.Balance, payload.Price)
-		receipt, err := leftAccountDB.ExecFrozen(a.fromaddr, a.execaddr, amount)
-		if err != nil {
-			elog.Error("LimitOrder.ExecFrozen", "addr", a.fromaddr, "amount", amount, "err", err.Error())
-			return nil, err
-		}
-		logs = append(logs, receipt.Logs...)
-		kvs = append(kvs, receipt.KV...)
+
+	//有成交的订单视为完成,balance记录退回的未成交数量;完全没有成交的订单视为撤回
+	if or.Executed > 0 {
+		or.Status = et.Completed
+	} else {
+		or.Status = et.Revoked
 	}
-	//更新order状态
 	kvs = append(kvs, a.GetKVSet(or)...)
 	re.Order = or
-	receiptlog := &types.ReceiptLog{Ty: et.TyLimitOrderLog, Log: types.Encode(re)}
-	logs = append(logs, receiptlog)
+	logs = append(logs, a.receiptLog(et.TyMarketOrderLog, re))
 	receipts := &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: logs}
 	return receipts, nil
 }
 
 //交易撮合模型
-func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *et.LimitOrder, matchorder *et.Order, or *et.Order, re *et.ReceiptExchange) ([]*types.ReceiptLog, []*types.KeyValue, error) {
+func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *et.LimitOrder, matchorder *et.Order, or *et.Order, re *et.ReceiptExchange, fee *feeConfig) ([]*types.ReceiptLog, []*types.KeyValue, error) {
 	var logs []*types.ReceiptLog
 	var kvs []*types.KeyValue
 	var matched int64
+	var price int64
 
 	if matchorder.GetBalance() >= or.GetBalance() {
 		matched = or.GetBalance()
@@ -420,6 +1079,7 @@ func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *
 		logs = append(logs, receipt.Logs...)
 		kvs = append(kvs, receipt.KV...)
 
+		price = payload.Price
 		//卖单成交得平均价格始终与自身挂单价格相同
 		or.AVGPrice = payload.Price
 		//计算matchOrder平均成交价格
@@ -445,12 +1105,48 @@ func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *
 		logs = append(logs, receipt.Logs...)
 		kvs = append(kvs, receipt.KV...)
 
+		price = matchorder.GetLimitOrder().Price
 		//买单得话，价格选取卖单的价格
 		or.AVGPrice = matchorder.GetLimitOrder().Price
 		//计算matchOrder平均成交价格
 		matchorder.AVGPrice = caclAVGPrice(matchorder, matchorder.GetLimitOrder().Price, matched) //TODO
 	}
 
+	//收取手续费,吃单方和挂单方分别以各自收到的资产支付
+	detail := &et.MatchDetail{
+		MatchOrderID: matchorder.OrderID,
+		Price:        price,
+		Amount:       matched,
+	}
+	if fee.addr != "" {
+		takerAccountDB, makerAccountDB := leftAccountDB, rightAccountDB
+		takerAmount, makerAmount := matched, SafeMul(matched, price)
+		if payload.Op == et.OpSell {
+			takerAccountDB, makerAccountDB = rightAccountDB, leftAccountDB
+			takerAmount, makerAmount = makerAmount, takerAmount
+		}
+		takerFee, receipt, err := a.chargeFee(takerAccountDB, a.fromaddr, fee.addr, takerAmount, fee.takerRate)
+		if err != nil {
+			return nil, nil, err
+		}
+		if receipt != nil {
+			logs = append(logs, receipt.Logs...)
+			kvs = append(kvs, receipt.KV...)
+		}
+		makerFee, receipt, err := a.chargeFee(makerAccountDB, matchorder.Addr, fee.addr, makerAmount, fee.makerRate)
+		if err != nil {
+			return nil, nil, err
+		}
+		if receipt != nil {
+			logs = append(logs, receipt.Logs...)
+			kvs = append(kvs, receipt.KV...)
+		}
+		or.Fee += takerFee
+		matchorder.Fee += makerFee
+		detail.TakerFee = takerFee
+		detail.MakerFee = makerFee
+	}
+
 	if matched == matchorder.GetBalance() {
 		matchorder.Status = et.Completed
 	} else {
@@ -480,11 +1176,132 @@ func (a *Action) matchModel(leftAccountDB, rightAccountDB *account.DB, payload *
 		kvs = append(kvs, a.GetKVSet(matchorder)...) //matchorder complete
 	}
 
+	a.book.update(matchorder)
+	a.book.lastPrice = price
 	re.Order = or
 	re.MatchOrders = append(re.MatchOrders, matchorder)
+	re.MatchDetails = append(re.MatchDetails, detail)
 	return logs, kvs, nil
 }
 
+//手续费配置
+type feeConfig struct {
+	addr      string
+	makerRate int64
+	takerRate int64
+}
+
+//读取交易对的手续费配置,交易对单独配置的费率优先于全局费率
+func (a *Action) getFeeConfig(left, right *et.Asset) *feeConfig {
+	cfg := a.api.GetConfig()
+	fee := &feeConfig{addr: getConfString(cfg, a.statedb, ConfNameFeeAddr, "")}
+	if fee.addr == "" {
+		return fee
+	}
+	pair := calcPairName(left, right)
+	makerRate := getConfValue(cfg, a.statedb, ConfNameMakerFee, DefaultFeeRate)
+	fee.makerRate = getConfValue(cfg, a.statedb, ConfNameMakerFee+"-"+pair, makerRate)
+	takerRate := getConfValue(cfg, a.statedb, ConfNameTakerFee, DefaultFeeRate)
+	fee.takerRate = getConfValue(cfg, a.statedb, ConfNameTakerFee+"-"+pair, takerRate)
+	if !CheckFeeRate(fee.makerRate) {
+		elog.Error("getFeeConfig", "pair", pair, "invalid makerRate", fee.makerRate)
+		fee.makerRate = DefaultFeeRate
+	}
+	if !CheckFeeRate(fee.takerRate) {
+		elog.Error("getFeeConfig", "pair", pair, "invalid takerRate", fee.takerRate)
+		fee.takerRate = DefaultFeeRate
+	}
+	return fee
+}
+
+//从收款方的合约账户中扣除手续费,转入手续费地址
+func (a *Action) chargeFee(accountDB *account.DB, addr, feeAddr string, amount, rate int64) (int64, *types.Receipt, error) {
+	fee := CalcFee(amount, rate)
+	if fee == 0 || addr == feeAddr {
+		return 0, nil, nil
+	}
+	receipt, err := accountDB.ExecTransfer(addr, feeAddr, a.execaddr, fee)
+	if err != nil {
+		elog.Error("chargeFee.ExecTransfer", "from", addr, "to", feeAddr, "amount", fee, "err", err.Error())
+		return 0, nil, err
+	}
+	return fee, receipt, nil
+}
+
+//计算手续费,rate单位为万分比
+func CalcFee(amount, rate int64) int64 {
+	fee := big.NewInt(0).Mul(big.NewInt(amount), big.NewInt(rate))
+	fee = big.NewInt(0).Div(fee, big.NewInt(et.RateBase))
+	return fee.Int64()
+}
+
+//交易对名称,用于手续费等配置项的后缀,格式为 {leftExecer}.{leftSymbol}-{rightExecer}.{rightSymbol}
+func calcPairName(left, right *et.Asset) string {
+	return fmt.Sprintf("%s.%s-%s.%s", left.GetExecer(), left.GetSymbol(), right.GetExecer(), right.GetSymbol())
+}
+
+func getConfValue(cfg *types.Chain33Config, db dbm.KV, key string, defaultValue int64) int64 {
+	values := getConfValues(cfg, db, key)
+	if len(values) == 0 {
+		return defaultValue
+	}
+	//取数组最后一位，作为最新配置项的值
+	v, err := strconv.ParseInt(values[len(values)-1], 10, 64)
+	if err != nil {
+		elog.Debug("exchange getConfValue", "Type conversion error:", err.Error())
+		return defaultValue
+	}
+	return v
+}
+
+func getConfString(cfg *types.Chain33Config, db dbm.KV, key, defaultValue string) string {
+	values := getConfValues(cfg, db, key)
+	if len(values) == 0 {
+		return defaultValue
+	}
+	return values[len(values)-1]
+}
+
+func getConfValues(cfg *types.Chain33Config, db dbm.KV, key string) []string {
+	var item types.ConfigItem
+	value, err := getManageKey(cfg, key, db)
+	if err != nil {
+		return nil
+	}
+	if value != nil {
+		err = types.Decode(value, &item)
+		if err != nil {
+			elog.Debug("exchange getConfValues", "decode db key:", key, "err", err.Error())
+			return nil
+		}
+	}
+	return item.GetArr().GetValue()
+}
+
+func getManageKey(cfg *types.Chain33Config, key string, db dbm.KV) ([]byte, error) {
+	manageKey := types.ManageKey(key)
+	value, err := db.Get([]byte(manageKey))
+	if err != nil {
+		if cfg.IsPara() { //平行链只有一种存储方式
+			elog.Debug("exchange getManage", "can't get value from db,key:", key, "err", err.Error())
+			return nil, err
+		}
+		elog.Debug("exchange getManageKey", "get db key", "not found")
+		return getConfigKey(key, db)
+	}
+	return value, nil
+}
+
+func getConfigKey(key string, db dbm.KV) ([]byte, error) {
+	configKey := types.ConfigKey(key)
+	value, err := db.Get([]byte(configKey))
+	if err != nil {
+		elog.Debug("exchange getConfigKey", "can't get value from db,key:", key, "err", err.Error())
+		return nil, err
+	}
+	return value, nil
+}
+
 //根据订单号查询，分为两步，优先去localdb中查询，如没有则再去状态数据库中查询
 // 1.挂单中得订单信会根据orderID在localdb中存储
 // 2.订单撤销，或者成交后，根据orderID在localdb中存储得数据会被删除，这时只能到状态数据库中查询
@@ -529,7 +1346,7 @@ func findOrderIDListByPrice(localdb dbm.KV, left, right *et.Asset, price int64,
 	for _, row := range rows {
 		order := row.Data.(*et.Order)
 		//替换已经成交得量
-		order.Executed = order.GetLimitOrder().Amount - order.Balance
+		order.Executed = getOrderAmount(order) - order.Balance
 		orderList.List = append(orderList.List, order)
 	}
 	//设置主键索引
@@ -608,7 +1425,7 @@ HERE:
 			continue
 		}
 		//替换已经成交得量
-		order.Executed = order.GetLimitOrder().Amount - order.Balance
+		order.Executed = getOrderAmount(order) - order.Balance
 		orderList.List = append(orderList.List, order)
 		if len(orderList.List) == int(count) {
 			//设置主键索引
@@ -628,6 +1445,8 @@ func QueryOrderList(localdb dbm.KV, addr string, status, count, direction int32,
 	var table *tab.Table
 	if status == et.Completed || status == et.Revoked {
 		table = NewHistoryOrderTable(localdb)
+	} else if status == et.Inactive {
+		table = NewStopOrderTable(localdb)
 	} else {
 		table = NewMarketOrderTable(localdb)
 	}
@@ -651,7 +1470,7 @@ func QueryOrderList(localdb dbm.KV, addr string, status, count, direction int32,
 	for _, row := range rows {
 		order := row.Data.(*et.Order)
 		//替换已经成交得量
-		order.Executed = order.GetLimitOrder().Amount - order.Balance
+		order.Executed = getOrderAmount(order) - order.Balance
 		orderList.List = append(orderList.List, order)
 	}
 	//设置主键索引
@@ -671,6 +1490,144 @@ func queryMarketDepth(localdb dbm.KV, left, right *et.Asset, op int32, price int
 	return row.Data.(*et.MarketDepth), nil
 }
 
+func queryCandle(localdb dbm.KV, left, right *et.Asset, period string, start int64) (*et.Candle, error) {
+	table := NewCandleTable(localdb)
+	primaryKey := []byte(fmt.Sprintf("%s:%s:%s:%016d", left.GetSymbol(), right.GetSymbol(), period, start))
+	row, err := table.GetData(primaryKey)
+	if err != nil {
+		return nil, err
+	}
+	return row.Data.(*et.Candle), nil
+}
+
+//最新成交价取最近一根1分钟K线的收盘价
+func queryLastPrice(localdb dbm.KV, left, right *et.Asset) (int64, error) {
+	table := NewCandleTable(localdb)
+	prefix := []byte(fmt.Sprintf("%s:%s:%s:", left.GetSymbol(), right.GetSymbol(), et.Period1m))
+	rows, err := listCandles(table, prefix, nil, 1, et.ListDESC)
+	if err != nil {
+		return 0, err
+	}
+	return rows[0].Data.(*et.Candle).Close, nil
+}
+
+//QueryCandles 分页查询K线,默认展示最新的
+func QueryCandles(localdb dbm.KV, left, right *et.Asset, period string, primaryKey string, count, direction int32) (*et.CandleList, error) {
+	table := NewCandleTable(localdb)
+	prefix := []byte(fmt.Sprintf("%s:%s:%s:", left.GetSymbol(), right.GetSymbol(), period))
+	if count == 0 {
+		count = et.Count
+	}
+	rows, err := listCandles(table, prefix, []byte(primaryKey), count, direction)
+	var list et.CandleList
+	if err == types.ErrNotFound {
+		return &list, nil
+	}
+	if err != nil {
+		elog.Error("QueryCandles.", "left", left, "right", right, "period", period, "err", err.Error())
+		return nil, err
+	}
+	for _, row := range rows {
+		list.List = append(list.List, row.Data.(*et.Candle))
+	}
+	//设置主键索引
+	if len(rows) == int(count) {
+		list.PrimaryKey = string(rows[len(rows)-1].Primary)
+	}
+	return &list, nil
+}
+
+//主键索引同时指定前缀和起始主键时,底层会重复拼接前缀,所以带起始主键时不传前缀,再按前缀过滤
+func listCandles(table *tab.Table, prefix, primaryKey []byte, count, direction int32) ([]*tab.Row, error) {
+	if len(primaryKey) == 0 {
+		return table.ListIndex("candle", prefix, nil, count, direction)
+	}
+	rows, err := table.ListIndex("candle", nil, primaryKey, count, direction)
+	if err != nil {
+		return nil, err
+	}
+	var list []*tab.Row
+	for _, row := range rows {
+		if !bytes.HasPrefix(row.Primary, prefix) {
+			break
+		}
+		list = append(list, row)
+	}
+	if len(list) == 0 {
+		return nil, types.ErrNotFound
+	}
+	return list, nil
+}
+
+//QueryTicker 查询截止到指定时间的24小时行情,以5分钟K线为统计粒度,时间为0时统计到最近一笔成交
+func QueryTicker(localdb dbm.KV, left, right *et.Asset, end int64) (*et.Ticker, error) {
+	table := NewCandleTable(localdb)
+	prefix := []byte(fmt.Sprintf("%s:%s:%s:", left.GetSymbol(), right.GetSymbol(), et.Period5m))
+	//从最新的K线往前翻页,只用已存在的主键作为起始位置
+	var candles []*et.Candle
+	var last *et.Candle
+	var primaryKey []byte
+	for done := false; !done; {
+		rows, err := listCandles(table, prefix, primaryKey, et.MaxCandleCount, et.ListDESC)
+		if err == types.ErrNotFound {
+			break
+		}
+		if err != nil {
+			elog.Error("QueryTicker.", "left", left, "right", right, "err", err.Error())
+			return nil, err
+		}
+		for _, row := range rows {
+			candle := row.Data.(*et.Candle)
+			if end == 0 {
+				end = candle.Time + et.GetPeriodSeconds(et.Period5m) - 1
+			}
+			if candle.Time > end {
+				continue
+			}
+			if last == nil {
+				last = candle
+			}
+			if candle.Time <= end-et.TickerWindow {
+				done = true
+				break
+			}
+			candles = append(candles, candle)
+		}
+		if len(rows) < int(et.MaxCandleCount) {
+			break
+		}
+		primaryKey = rows[len(rows)-1].Primary
+	}
+	if last == nil {
+		return nil, types.ErrNotFound
+	}
+	ticker := &et.Ticker{
+		LeftAsset:  left,
+		RightAsset: right,
+		LastPrice:  last.Close,
+		Open:       last.Close,
+		High:       last.Close,
+		Low:        last.Close,
+		Time:       end,
+	}
+	for i, candle := range candles {
+		if i == 0 {
+			ticker.High, ticker.Low = candle.High, candle.Low
+		}
+		if candle.High > ticker.High {
+			ticker.High = candle.High
+		}
+		if candle.Low < ticker.Low {
+			ticker.Low = candle.Low
+		}
+		ticker.Open = candle.Open
+		ticker.Volume += candle.Volume
+		ticker.Turnover += candle.Turnover
+	}
+	ticker.Change = ticker.LastPrice - ticker.Open
+	return ticker, nil
+}
+
 //math库中的安全大数乘法，防溢出
 func SafeMul(x, y int64) int64 {
 	res := big.NewInt(0).Mul(big.NewInt(x), big.NewInt(y))
@@ -680,10 +1637,45 @@ func SafeMul(x, y int64) int64 {
 
 //计算平均成交价格
 func caclAVGPrice(order *et.Order, price int64, amount int64) int64 {
-	x := big.NewInt(0).Mul(big.NewInt(order.AVGPrice), big.NewInt(order.GetLimitOrder().Amount-order.GetBalance()))
+	return calcAVGPrice(order.AVGPrice, order.GetLimitOrder().Amount-order.GetBalance(), price, amount)
+}
+
+//按成交量加权计算平均成交价格
+func calcAVGPrice(avgPrice, executed, price, amount int64) int64 {
+	x := big.NewInt(0).Mul(big.NewInt(avgPrice), big.NewInt(executed))
 	y := big.NewInt(0).Mul(big.NewInt(price), big.NewInt(amount))
 	total := big.NewInt(0).Add(x, y)
-	div := big.NewInt(0).Add(big.NewInt(order.GetLimitOrder().Amount-order.GetBalance()), big.NewInt(amount))
+	div := big.NewInt(0).Add(big.NewInt(executed), big.NewInt(amount))
+	if div.Sign() == 0 {
+		return avgPrice
+	}
 	avg := big.NewInt(0).Div(total, div)
 	return avg.Int64()
 }
+
+//计算滑点范围内可以接受的最差成交价格,买单向上浮动,卖单向下浮动
+func CalcSlippagePrice(op int32, price int64, slippage int64) int64 {
+	delta := big.NewInt(0).Mul(big.NewInt(price), big.NewInt(slippage))
+	delta = big.NewInt(0).Div(delta, big.NewInt(et.RateBase))
+	if op == et.OpBuy {
+		return price + delta.Int64()
+	}
+	return price - delta.Int64()
+}
+
+//获取订单的交易对和买卖操作,兼容限价单和市价单
+func getOrderPair(order *et.Order) (left, right *et.Asset, op int32) {
+	if marketOrder := order.GetMarketOrder(); marketOrder != nil {
+		return marketOrder.GetLeftAsset(), marketOrder.GetRightAsset(), marketOrder.GetOp()
+	}
+	limitOrder := order.GetLimitOrder()
+	return limitOrder.GetLeftAsset(), limitOrder.GetRightAsset(), limitOrder.GetOp()
+}
+
+//获取订单的委托总量,兼容限价单和市价单
+func getOrderAmount(order *et.Order) int64 {
+	if marketOrder := order.GetMarketOrder(); marketOrder != nil {
+		return marketOrder.GetAmount()
+	}
+	return order.GetLimitOrder().GetAmount()
+}
@@ -1,7 +1,10 @@
 package executor
 
 import (
+	"fmt"
+
 	"github.com/33cn/chain33/common/db/table"
+	"github.com/33cn/chain33/system/dapp"
 	"github.com/33cn/chain33/types"
 	ety "github.com/33cn/plugin/plugin/dapp/exchange/types"
 )
@@ -12,73 +15,51 @@ import (
  */
 
 func (e *exchange) ExecLocal_LimitOrder(payload *ety.LimitOrder, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
-	dbSet := &types.LocalDBSet{}
-	if receiptData.Ty == types.ExecOk {
-		for _, log := range receiptData.Logs {
-			switch log.Ty {
-			case ety.TyLimitOrderLog:
-				receipt := &ety.ReceiptExchange{}
-				if err := types.Decode(log.Log, receipt); err != nil {
-					return nil, err
-				}
-				kv := e.updateIndex(receipt)
-				dbSet.KV = append(dbSet.KV, kv...)
-			}
-		}
-	}
-	return e.addAutoRollBack(tx, dbSet.KV), nil
+	return e.execLocalOrders(tx, receiptData)
 }
 
 func (e *exchange) ExecLocal_MarketOrder(payload *ety.MarketOrder, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
-	dbSet := &types.LocalDBSet{}
-	if receiptData.Ty == types.ExecOk {
-		for _, log := range receiptData.Logs {
-			switch log.Ty {
-			case ety.TyMarketOrderLog:
-				receipt := &ety.ReceiptExchange{}
-				if err := types.Decode(log.Log, receipt); err != nil {
-					return nil, err
-				}
-				kv := e.updateIndex(receipt)
-				dbSet.KV = append(dbSet.KV, kv...)
-			}
-		}
-	}
-	return e.addAutoRollBack(tx, dbSet.KV), nil
+	return e.execLocalOrders(tx, receiptData)
 }
 
 func (e *exchange) ExecLocal_RevokeOrder(payload *ety.RevokeOrder, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
-	dbSet := &types.LocalDBSet{}
+	return e.execLocalOrders(tx, receiptData)
+}
+
+func (e *exchange) ExecLocal_TriggerOrder(payload *ety.TriggerOrder, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return e.execLocalOrders(tx, receiptData)
+}
+
+//execLocalOrders 依次处理交易中的订单回执,撮合之后自动激活的止损单和撤销的过期订单都有各自的回执,
+//后面的回执依赖前面回执更新之后的本地索引,所以每处理完一个回执就写入本地数据库,并设置自动回滚
+func (e *exchange) execLocalOrders(tx *types.Transaction, receiptData *types.ReceiptData) (*types.LocalDBSet, error) {
+	kvc := dapp.NewKVCreator(e.GetLocalDB(), types.CalcLocalPrefix(tx.Execer), types.CalcRollbackKey(types.GetRealExecName(tx.Execer), tx.Hash()))
 	if receiptData.Ty == types.ExecOk {
 		for _, log := range receiptData.Logs {
 			switch log.Ty {
-			case ety.TyRevokeOrderLog:
+			case ety.TyLimitOrderLog, ety.TyMarketOrderLog, ety.TyRevokeOrderLog, ety.TyTriggerOrderLog:
 				receipt := &ety.ReceiptExchange{}
 				if err := types.Decode(log.Log, receipt); err != nil {
 					return nil, err
 				}
-				kv := e.updateIndex(receipt)
-				dbSet.KV = append(dbSet.KV, kv...)
+				kvc.AddListNoPrefix(e.updateIndex(receipt))
 			}
 		}
 	}
-	return e.addAutoRollBack(tx, dbSet.KV), nil
-}
-
-//设置自动回滚
-func (e *exchange) addAutoRollBack(tx *types.Transaction, kv []*types.KeyValue) *types.LocalDBSet {
-	dbSet := &types.LocalDBSet{}
-	dbSet.KV = e.AddRollbackKV(tx, tx.Execer, kv)
-	return dbSet
+	kvc.AddRollbackKV()
+	return &types.LocalDBSet{KV: kvc.KVList()}, nil
 }
 
 func (e *exchange) updateIndex(receipt *ety.ReceiptExchange) (kvs []*types.KeyValue) {
 	historyTable := NewHistoryOrderTable(e.GetLocalDB())
 	marketTable := NewMarketDepthTable(e.GetLocalDB())
 	orderTable := NewMarketOrderTable(e.GetLocalDB())
+	candleTable := NewCandleTable(e.GetLocalDB())
+	stopTable := NewStopOrderTable(e.GetLocalDB())
+	expireTable := NewExpireOrderTable(e.GetLocalDB())
 	switch receipt.Order.Status {
 	case ety.Ordered:
-		err := e.updateOrder(marketTable, orderTable, historyTable, receipt.GetOrder(), receipt.GetIndex())
+		err := e.updateOrder(marketTable, orderTable, historyTable, stopTable, receipt.GetOrder(), receipt.GetIndex())
 		if err != nil {
 			return nil
 		}
@@ -87,7 +68,7 @@ func (e *exchange) updateIndex(receipt *ety.ReceiptExchange) (kvs []*types.KeyVa
 			return nil
 		}
 	case ety.Completed:
-		err := e.updateOrder(marketTable, orderTable, historyTable, receipt.GetOrder(), receipt.GetIndex())
+		err := e.updateOrder(marketTable, orderTable, historyTable, stopTable, receipt.GetOrder(), receipt.GetIndex())
 		if err != nil {
 			return nil
 		}
@@ -96,10 +77,32 @@ func (e *exchange) updateIndex(receipt *ety.ReceiptExchange) (kvs []*types.KeyVa
 			return nil
 		}
 	case ety.Revoked:
-		err := e.updateOrder(marketTable, orderTable, historyTable, receipt.GetOrder(), receipt.GetIndex())
+		err := e.updateOrder(marketTable, orderTable, historyTable, stopTable, receipt.GetOrder(), receipt.GetIndex())
+		if err != nil {
+			return nil
+		}
+		//IOC订单没有成交时也可能撤销了过期的挂单
+		err = e.updateMatchOrders(marketTable, orderTable, historyTable, receipt.GetOrder(), receipt.GetMatchOrders(), receipt.GetIndex())
 		if err != nil {
 			return nil
 		}
+	case ety.Inactive:
+		err := e.updateOrder(marketTable, orderTable, historyTable, stopTable, receipt.GetOrder(), receipt.GetIndex())
+		if err != nil {
+			return nil
+		}
+	}
+
+	//更新过期表
+	err := e.updateExpireOrders(expireTable, receipt)
+	if err != nil {
+		return nil
+	}
+
+	//更新K线
+	err = e.updateCandles(candleTable, receipt)
+	if err != nil {
+		return nil
 	}
 
 	//刷新KV
@@ -121,11 +124,45 @@ func (e *exchange) updateIndex(receipt *ety.ReceiptExchange) (kvs []*types.KeyVa
 		return nil
 	}
 	kvs = append(kvs, kv...)
+	kv, err = stopTable.Save()
+	if err != nil {
+		elog.Error("updateIndex", "stopTable.Save", err.Error())
+		return nil
+	}
+	kvs = append(kvs, kv...)
+	kv, err = expireTable.Save()
+	if err != nil {
+		elog.Error("updateIndex", "expireTable.Save", err.Error())
+		return nil
+	}
+	kvs = append(kvs, kv...)
+	kv, err = candleTable.Save()
+	if err != nil {
+		elog.Error("updateIndex", "candleTable.Save", err.Error())
+		return nil
+	}
+	kvs = append(kvs, kv...)
 
 	return
 }
 
-func (e *exchange) updateOrder(marketTable, orderTable, historyTable *table.Table, order *ety.Order, index int64) error {
+func (e *exchange) updateOrder(marketTable, orderTable, historyTable, stopTable *table.Table, order *ety.Order, index int64) error {
+	//止损单在等待触发期间只记录在止损表中
+	if order.GetLimitOrder().GetTriggerPrice() > 0 {
+		done, err := e.updateStopOrder(stopTable, historyTable, order, index)
+		if err != nil || done {
+			return err
+		}
+	}
+	//市价单和IOC,FOK订单不挂单,不影响市场深度,只记录到历史订单中
+	if order.GetMarketOrder() != nil || order.GetLimitOrder().GetTimeInForce() != ety.TimeInForceGTC {
+		err := historyTable.Replace(order)
+		if err != nil {
+			elog.Error("updateIndex", "historyTable.Replace", err.Error())
+			return err
+		}
+		return nil
+	}
 	left := order.GetLimitOrder().GetLeftAsset()
 	right := order.GetLimitOrder().GetRightAsset()
 	op := order.GetLimitOrder().GetOp()
@@ -208,10 +245,46 @@ func (e *exchange) updateOrder(marketTable, orderTable, historyTable *table.Tabl
 	}
 	return nil
 }
+
+//更新止损表,返回值表示订单是否已经处理完毕
+func (e *exchange) updateStopOrder(stopTable, historyTable *table.Table, order *ety.Order, index int64) (bool, error) {
+	if order.Status == ety.Inactive {
+		err := stopTable.Replace(order)
+		if err != nil {
+			elog.Error("updateIndex", "stopTable.Replace", err.Error())
+			return true, err
+		}
+		return true, nil
+	}
+	//不在止损表中,说明下单时已经满足触发条件,按普通限价单处理
+	primaryKey := []byte(fmt.Sprintf("%022d", order.OrderID))
+	if _, err := stopTable.GetData(primaryKey); err != nil {
+		return false, nil
+	}
+	//删除原有状态orderID
+	status := order.Status
+	order.Status = ety.Inactive
+	err := stopTable.DelRow(order)
+	order.Status = status
+	if err != nil {
+		elog.Error("updateIndex", "stopTable.DelRow", err.Error())
+		return true, err
+	}
+	//没有激活就撤销的止损单不影响市场深度,只记录到历史订单中
+	if order.Status == ety.Revoked {
+		order.Index = index
+		err = historyTable.Replace(order)
+		if err != nil {
+			elog.Error("updateIndex", "historyTable.Replace", err.Error())
+			return true, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
 func (e *exchange) updateMatchOrders(marketTable, orderTable, historyTable *table.Table, order *ety.Order, matchOrders []*ety.Order, index int64) error {
-	left := order.GetLimitOrder().GetLeftAsset()
-	right := order.GetLimitOrder().GetRightAsset()
-	op := order.GetLimitOrder().GetOp()
+	left, right, op := getOrderPair(order)
 	if len(matchOrders) > 0 {
 		//撮合交易更新
 		cache := make(map[int64]int64)
@@ -233,6 +306,24 @@ func (e *exchange) updateMatchOrders(marketTable, orderTable, historyTable *tabl
 					return err
 				}
 			}
+			if matchOrder.Status == ety.Revoked {
+				//过期撤销的挂单从市场深度中扣除剩余数量
+				matchOrder.Status = ety.Ordered
+				err := orderTable.DelRow(matchOrder)
+				if err != nil {
+					elog.Error("updateIndex", "orderTable.DelRow", err.Error())
+					return err
+				}
+				matchOrder.Status = ety.Revoked
+				matchOrder.Index = index + int64(i+1)
+				err = historyTable.Replace(matchOrder)
+				if err != nil {
+					elog.Error("updateIndex", "historyTable.Replace", err.Error())
+					return err
+				}
+				cache[matchOrder.GetLimitOrder().Price] += matchOrder.Balance
+				continue
+			}
 			if matchOrder.Status == ety.Ordered {
 				//更新数据
 				err := orderTable.Replace(matchOrder)
@@ -277,6 +368,103 @@ func (e *exchange) updateMatchOrders(marketTable, orderTable, historyTable *tabl
 	}
 	return nil
 }
+
+//带有过期条件的订单在挂单或者等待触发时记录到过期表,成交或者撤销后删除
+func (e *exchange) updateExpireOrders(expireTable *table.Table, receipt *ety.ReceiptExchange) error {
+	orders := append([]*ety.Order{receipt.GetOrder()}, receipt.GetMatchOrders()...)
+	for _, order := range orders {
+		limitOrder := order.GetLimitOrder()
+		if limitOrder.GetExpireHeight() == 0 && limitOrder.GetExpireTime() == 0 {
+			continue
+		}
+		if order.Status == ety.Ordered || order.Status == ety.Inactive {
+			err := expireTable.Replace(order)
+			if err != nil {
+				elog.Error("updateIndex", "expireTable.Replace", err.Error())
+				return err
+			}
+			continue
+		}
+		err := expireTable.DelRow(order)
+		if err != nil && err != types.ErrNotFound {
+			elog.Error("updateIndex", "expireTable.DelRow", err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+//根据撮合成交明细更新各个周期的K线,同一笔交易的成交都落在相同的周期内
+func (e *exchange) updateCandles(candleTable *table.Table, receipt *ety.ReceiptExchange) error {
+	details := getMatchDetails(receipt)
+	if len(details) == 0 {
+		return nil
+	}
+	left, right, _ := getOrderPair(receipt.GetOrder())
+	blocktime := e.GetBlockTime()
+	for _, period := range ety.CandlePeriods {
+		start := blocktime - blocktime%ety.GetPeriodSeconds(period)
+		candle, err := queryCandle(e.GetLocalDB(), left, right, period, start)
+		if err == types.ErrNotFound {
+			candle = &ety.Candle{
+				LeftAsset:  left,
+				RightAsset: right,
+				Period:     period,
+				Time:       start,
+				Open:       details[0].Price,
+				High:       details[0].Price,
+				Low:        details[0].Price,
+			}
+		} else if err != nil {
+			elog.Error("updateCandles", "queryCandle", err.Error())
+			return err
+		}
+		for _, detail := range details {
+			if detail.Price > candle.High {
+				candle.High = detail.Price
+			}
+			if detail.Price < candle.Low {
+				candle.Low = detail.Price
+			}
+			candle.Close = detail.Price
+			candle.Volume += detail.Amount
+			candle.Turnover += SafeMul(detail.Amount, detail.Price)
+		}
+		err = candleTable.Replace(candle)
+		if err != nil {
+			elog.Error("updateCandles", "candleTable.Replace", err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+//获取撮合成交明细,兼容没有记录成交明细的历史回执
+func getMatchDetails(receipt *ety.ReceiptExchange) []*ety.MatchDetail {
+	if len(receipt.GetMatchDetails()) > 0 || len(receipt.GetMatchOrders()) == 0 {
+		return receipt.GetMatchDetails()
+	}
+	order := receipt.GetOrder().GetLimitOrder()
+	var details []*ety.MatchDetail
+	for _, matchOrder := range receipt.GetMatchOrders() {
+		//过期撤销的挂单没有成交
+		if matchOrder.Status == ety.Revoked {
+			continue
+		}
+		//卖单主动成交时以卖单价格成交,买单主动成交时以挂单价格成交
+		price := matchOrder.GetLimitOrder().GetPrice()
+		if order.GetOp() == ety.OpSell {
+			price = order.GetPrice()
+		}
+		details = append(details, &ety.MatchDetail{
+			MatchOrderID: matchOrder.OrderID,
+			Price:        price,
+			Amount:       matchOrder.Executed,
+		})
+	}
+	return details
+}
+
 func OpSwap(op int32) int32 {
 	if op == ety.OpBuy {
 		return ety.OpSell
@@ -61,3 +61,31 @@ func (s *exchange) Query_QueryOrderList(in *et.QueryOrderList) (types.Message, e
 	}
 	return QueryOrderList(s.GetLocalDB(), in.Address, in.Status, in.Count, in.Direction, in.PrimaryKey)
 }
+
+//分页查询交易对的K线
+func (s *exchange) Query_QueryCandles(in *et.QueryCandles) (types.Message, error) {
+	if !CheckExchangeAsset(in.LeftAsset, in.RightAsset) {
+		return nil, et.ErrAsset
+	}
+	if !CheckPeriod(in.Period) {
+		return nil, et.ErrPeriod
+	}
+	if !CheckCandleCount(in.Count) {
+		return nil, et.ErrCandleCount
+	}
+	if !CheckDirection(in.Direction) {
+		return nil, et.ErrDirection
+	}
+	return QueryCandles(s.GetLocalDB(), in.LeftAsset, in.RightAsset, in.Period, in.PrimaryKey, in.Count, in.Direction)
+}
+
+//查询交易对的24小时行情
+func (s *exchange) Query_QueryTicker(in *et.QueryTicker) (types.Message, error) {
+	if !CheckExchangeAsset(in.LeftAsset, in.RightAsset) {
+		return nil, et.ErrAsset
+	}
+	if in.Time < 0 {
+		return nil, types.ErrInvalidParam
+	}
+	return QueryTicker(s.GetLocalDB(), in.LeftAsset, in.RightAsset, in.Time)
+}
@@ -2,6 +2,7 @@ package executor
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/33cn/chain33/common/db"
 	"github.com/33cn/chain33/common/db/table"
@@ -50,6 +51,30 @@ var opt_exchange_history = &table.Option{
 	Index:   []string{"name", "addr_status"},
 }
 
+//止损表,记录等待触发的止损限价单,激活或者撤销后删除
+var opt_exchange_stop = &table.Option{
+	Prefix:  KeyPrefixLocalDB,
+	Name:    "stop",
+	Primary: "orderID",
+	Index:   []string{"addr_status", "trigger"},
+}
+
+//过期表,记录带有过期高度或者过期时间的挂单和止损单,成交或者撤销后删除
+var opt_exchange_expire = &table.Option{
+	Prefix:  KeyPrefixLocalDB,
+	Name:    "expire",
+	Primary: "orderID",
+	Index:   []string{"expire_height", "expire_time"},
+}
+
+//K线表,主键由交易对,周期和周期开始时间构成,按主键前缀即可分页查询
+var opt_exchange_candle = &table.Option{
+	Prefix:  KeyPrefixLocalDB,
+	Name:    "candle",
+	Primary: "candle",
+	Index:   nil,
+}
+
 //NewTable 新建表
 func NewMarketDepthTable(kvdb db.KV) *table.Table {
 	rowmeta := NewMarketDepthRow()
@@ -78,6 +103,33 @@ func NewHistoryOrderTable(kvdb db.KV) *table.Table {
 	return table
 }
 
+func NewStopOrderTable(kvdb db.KV) *table.Table {
+	rowmeta := NewOrderRow()
+	table, err := table.NewTable(rowmeta, kvdb, opt_exchange_stop)
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
+func NewExpireOrderTable(kvdb db.KV) *table.Table {
+	rowmeta := NewOrderRow()
+	table, err := table.NewTable(rowmeta, kvdb, opt_exchange_expire)
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
+func NewCandleTable(kvdb db.KV) *table.Table {
+	rowmeta := NewCandleRow()
+	table, err := table.NewTable(rowmeta, kvdb, opt_exchange_candle)
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
 //OrderRow table meta 结构
 type OrderRow struct {
 	*ety.Order
@@ -110,10 +162,24 @@ func (r *OrderRow) Get(key string) ([]byte, error) {
 		return []byte(fmt.Sprintf("%s:%s:%d:%016d", r.GetLimitOrder().LeftAsset.GetSymbol(), r.GetLimitOrder().RightAsset.GetSymbol(), r.GetLimitOrder().Op, r.GetLimitOrder().Price)), nil
 	} else if key == "addr_status" {
 		return []byte(fmt.Sprintf("%s:%d", r.Addr, r.Status)), nil
+	} else if key == "trigger" {
+		return []byte(fmt.Sprintf("%s:%s:%d:%016d", r.GetLimitOrder().LeftAsset.GetSymbol(), r.GetLimitOrder().RightAsset.GetSymbol(), r.GetLimitOrder().Op, r.GetLimitOrder().TriggerPrice)), nil
+	} else if key == "expire_height" {
+		return []byte(fmt.Sprintf("%019d", expireValue(r.GetLimitOrder().ExpireHeight))), nil
+	} else if key == "expire_time" {
+		return []byte(fmt.Sprintf("%019d", expireValue(r.GetLimitOrder().ExpireTime))), nil
 	}
 	return nil, types.ErrNotFound
 }
 
+//不过期的订单排在过期索引的最后
+func expireValue(expire int64) int64 {
+	if expire == 0 {
+		return math.MaxInt64
+	}
+	return expire
+}
+
 //HistoryOrderRow table meta 结构
 type HistoryOrderRow struct {
 	*ety.Order
@@ -141,7 +207,8 @@ func (m *HistoryOrderRow) Get(key string) ([]byte, error) {
 	if key == "index" {
 		return []byte(fmt.Sprintf("%022d", m.Index)), nil
 	} else if key == "name" {
-		return []byte(fmt.Sprintf("%s:%s", m.GetLimitOrder().LeftAsset.GetSymbol(), m.GetLimitOrder().RightAsset.GetSymbol())), nil
+		left, right, _ := getOrderPair(m.Order)
+		return []byte(fmt.Sprintf("%s:%s", left.GetSymbol(), right.GetSymbol())), nil
 	} else if key == "addr_status" {
 		return []byte(fmt.Sprintf("%s:%d", m.Addr, m.Status)), nil
 	}
@@ -179,3 +246,35 @@ func (m *MarketDepthRow) Get(key string) ([]byte, error) {
 	}
 	return nil, types.ErrNotFound
 }
+
+//CandleRow table meta 结构
+type CandleRow struct {
+	*ety.Candle
+}
+
+//NewCandleRow 新建一个meta 结构
+func NewCandleRow() *CandleRow {
+	return &CandleRow{Candle: &ety.Candle{}}
+}
+
+//CreateRow 新建数据行
+func (m *CandleRow) CreateRow() *table.Row {
+	return &table.Row{Data: &ety.Candle{}}
+}
+
+//SetPayload 设置数据
+func (m *CandleRow) SetPayload(data types.Message) error {
+	if txdata, ok := data.(*ety.Candle); ok {
+		m.Candle = txdata
+		return nil
+	}
+	return types.ErrTypeAsset
+}
+
+//Get 按照indexName 查询 indexValue
+func (m *CandleRow) Get(key string) ([]byte, error) {
+	if key == "candle" {
+		return []byte(fmt.Sprintf("%s:%s:%s:%016d", m.LeftAsset.GetSymbol(), m.RightAsset.GetSymbol(), m.Period, m.Time)), nil
+	}
+	return nil, types.ErrNotFound
+}
@@ -1,7 +1,56 @@
 package rpc
 
+import (
+	"encoding/hex"
+
+	"github.com/33cn/chain33/types"
+	et "github.com/33cn/plugin/plugin/dapp/exchange/types"
+)
+
 /*
  * 实现json rpc和grpc service接口
  * json rpc用Jrpc结构作为接收实例
  * grpc使用channelClient结构作为接收实例
  */
+
+// CreateRawLimitOrderTx 创建限价挂单的未签名交易
+func (c *Jrpc) CreateRawLimitOrderTx(param *et.LimitOrder, result *interface{}) error {
+	if param == nil {
+		return types.ErrInvalidParam
+	}
+	return c.createRawTx(et.NameLimitOrderAction, param, result)
+}
+
+// CreateRawMarketOrderTx 创建市价委托的未签名交易
+func (c *Jrpc) CreateRawMarketOrderTx(param *et.MarketOrder, result *interface{}) error {
+	if param == nil {
+		return types.ErrInvalidParam
+	}
+	return c.createRawTx(et.NameMarketOrderAction, param, result)
+}
+
+// CreateRawRevokeOrderTx 创建撤单的未签名交易
+func (c *Jrpc) CreateRawRevokeOrderTx(param *et.RevokeOrder, result *interface{}) error {
+	if param == nil {
+		return types.ErrInvalidParam
+	}
+	return c.createRawTx(et.NameRevokeOrderAction, param, result)
+}
+
+// CreateRawTriggerOrderTx 创建激活止损限价单的未签名交易
+func (c *Jrpc) CreateRawTriggerOrderTx(param *et.TriggerOrder, result *interface{}) error {
+	if param == nil {
+		return types.ErrInvalidParam
+	}
+	return c.createRawTx(et.NameTriggerOrderAction, param, result)
+}
+
+func (c *Jrpc) createRawTx(action string, param types.Message, result *interface{}) error {
+	cfg := c.cli.GetConfig()
+	data, err := types.CallCreateTx(cfg, cfg.ExecName(et.ExchangeX), action, param)
+	if err != nil {
+		return err
+	}
+	*result = hex.EncodeToString(data)
+	return nil
+}
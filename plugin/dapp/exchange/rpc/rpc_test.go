@@ -0,0 +1,103 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/33cn/chain33/client/mocks"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	et "github.com/33cn/plugin/plugin/dapp/exchange/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestJrpc() *Jrpc {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	api := new(mocks.QueueProtocolAPI)
+	api.On("GetConfig", mock.Anything).Return(cfg, nil)
+	cli := &channelClient{
+		ChannelClient: rpctypes.ChannelClient{
+			QueueProtocolAPI: api,
+		},
+	}
+	return &Jrpc{cli: cli}
+}
+
+func decodeTx(t *testing.T, result interface{}) *et.ExchangeAction {
+	data, err := hex.DecodeString(result.(string))
+	assert.Nil(t, err)
+	var tx types.Transaction
+	assert.Nil(t, types.Decode(data, &tx))
+	assert.Equal(t, et.ExchangeX, string(tx.Execer))
+	var action et.ExchangeAction
+	assert.Nil(t, types.Decode(tx.Payload, &action))
+	return &action
+}
+
+func TestJrpc_CreateRawLimitOrderTx(t *testing.T) {
+	client := newTestJrpc()
+	var result interface{}
+	err := client.CreateRawLimitOrderTx(nil, &result)
+	assert.Equal(t, types.ErrInvalidParam, err)
+	assert.Nil(t, result)
+
+	order := &et.LimitOrder{
+		LeftAsset:  &et.Asset{Execer: "coins", Symbol: "bty"},
+		RightAsset: &et.Asset{Execer: "token", Symbol: "CCNY"},
+		Price:      types.Coin,
+		Amount:     types.Coin,
+		Op:         et.OpBuy,
+	}
+	err = client.CreateRawLimitOrderTx(order, &result)
+	assert.Nil(t, err)
+	action := decodeTx(t, result)
+	assert.Equal(t, int32(et.TyLimitOrderAction), action.Ty)
+	assert.Equal(t, order.Price, action.GetLimitOrder().Price)
+}
+
+func TestJrpc_CreateRawMarketOrderTx(t *testing.T) {
+	client := newTestJrpc()
+	var result interface{}
+	err := client.CreateRawMarketOrderTx(nil, &result)
+	assert.Equal(t, types.ErrInvalidParam, err)
+
+	order := &et.MarketOrder{
+		LeftAsset:  &et.Asset{Execer: "coins", Symbol: "bty"},
+		RightAsset: &et.Asset{Execer: "token", Symbol: "CCNY"},
+		Amount:     types.Coin,
+		Op:         et.OpSell,
+		Slippage:   100,
+	}
+	err = client.CreateRawMarketOrderTx(order, &result)
+	assert.Nil(t, err)
+	action := decodeTx(t, result)
+	assert.Equal(t, int32(et.TyMarketOrderAction), action.Ty)
+	assert.Equal(t, order.Slippage, action.GetMarketOrder().Slippage)
+}
+
+func TestJrpc_CreateRawRevokeOrderTx(t *testing.T) {
+	client := newTestJrpc()
+	var result interface{}
+	err := client.CreateRawRevokeOrderTx(nil, &result)
+	assert.Equal(t, types.ErrInvalidParam, err)
+
+	err = client.CreateRawRevokeOrderTx(&et.RevokeOrder{OrderID: 1000}, &result)
+	assert.Nil(t, err)
+	action := decodeTx(t, result)
+	assert.Equal(t, int32(et.TyRevokeOrderAction), action.Ty)
+	assert.Equal(t, int64(1000), action.GetRevokeOrder().OrderID)
+}
+
+func TestJrpc_CreateRawTriggerOrderTx(t *testing.T) {
+	client := newTestJrpc()
+	var result interface{}
+	err := client.CreateRawTriggerOrderTx(nil, &result)
+	assert.Equal(t, types.ErrInvalidParam, err)
+
+	err = client.CreateRawTriggerOrderTx(&et.TriggerOrder{OrderID: 1000}, &result)
+	assert.Nil(t, err)
+	action := decodeTx(t, result)
+	assert.Equal(t, int32(et.TyTriggerOrderAction), action.Ty)
+	assert.Equal(t, int64(1000), action.GetTriggerOrder().OrderID)
+}
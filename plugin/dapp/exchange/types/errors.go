@@ -13,6 +13,15 @@ var (
 	ErrAsset        = fmt.Errorf("%s", "The asset's execer or symbol can't be nil,The same assets cannot be exchanged!")
 	ErrCount        = fmt.Errorf("%s", "The param count can't large  20")
 	ErrDirection    = fmt.Errorf("%s", "The direction only 0 or 1!")
-	ErrStatus       = fmt.Errorf("%s", "The status only in  0 , 1, 2!")
+	ErrStatus       = fmt.Errorf("%s", "The status only in  0 , 1, 2, 3!")
 	ErrOrderID      = fmt.Errorf("%s", "Wrong OrderID!")
+	ErrSlippage     = fmt.Errorf("%s", "The slippage only in 0 ~ 10000!")
+	ErrMarketDepth  = fmt.Errorf("%s", "There is no order on the opposite side of the market!")
+	ErrPeriod       = fmt.Errorf("%s", "The period only in 1m, 5m, 1h, 1d!")
+	ErrCandleCount  = fmt.Errorf("%s", "The param count can't large  200")
+	ErrTimeInForce  = fmt.Errorf("%s", "The timeInForce only in 0, 1, 2, and can't be used with postOnly or triggerPrice!")
+	ErrPostOnly     = fmt.Errorf("%s", "The post only order would be matched immediately!")
+	ErrFillOrKill   = fmt.Errorf("%s", "The fill or kill order can't be filled completely!")
+	ErrExpire       = fmt.Errorf("%s", "The order expire height or time is not valid!")
+	ErrTrigger      = fmt.Errorf("%s", "The last price has not crossed the trigger price!")
 )
@@ -18,15 +18,19 @@ const (
 	TyLimitOrderAction
 	TyMarketOrderAction
 	TyRevokeOrderAction
+	TyTriggerOrderAction
 
-	NameLimitOrderAction  = "LimitOrder"
-	NameMarketOrderAction = "MarketOrder"
-	NameRevokeOrderAction = "RevokeOrder"
+	NameLimitOrderAction   = "LimitOrder"
+	NameMarketOrderAction  = "MarketOrder"
+	NameRevokeOrderAction  = "RevokeOrder"
+	NameTriggerOrderAction = "TriggerOrder"
 
 	FuncNameQueryMarketDepth      = "QueryMarketDepth"
 	FuncNameQueryHistoryOrderList = "QueryHistoryOrderList"
 	FuncNameQueryOrder            = "QueryOrder"
 	FuncNameQueryOrderList        = "QueryOrderList"
+	FuncNameQueryCandles          = "QueryCandles"
+	FuncNameQueryTicker           = "QueryTicker"
 )
 
 // log类型id值
@@ -35,6 +39,7 @@ const (
 	TyLimitOrderLog
 	TyMarketOrderLog
 	TyRevokeOrderLog
+	TyTriggerOrderLog
 )
 
 // OP
@@ -48,6 +53,18 @@ const (
 	Ordered = iota
 	Completed
 	Revoked
+	//止损限价单等待触发
+	Inactive
+)
+
+//成交时效
+const (
+	//撤单前一直有效
+	TimeInForceGTC = iota
+	//立即成交,剩余部分撤销
+	TimeInForceIOC
+	//全部成交,否则交易执行失败
+	TimeInForceFOK
 )
 
 //const
@@ -57,11 +74,33 @@ const (
 	ListSeek = int32(2)
 )
 
+//K线周期
+const (
+	Period1m = "1m"
+	Period5m = "5m"
+	Period1h = "1h"
+	Period1d = "1d"
+	//24小时行情统计的时间窗口
+	TickerWindow = int64(24 * 3600)
+)
+
 const (
 	//单次list还回条数
 	Count = int32(10)
 	//系统最大撮合深度
 	MaxMatchCount = 100
+	//万分比的基数,滑点和手续费费率都以万分比计
+	RateBase = int64(10000)
+	//市价单滑点上限,万分比
+	MaxSlippage = RateBase
+	//手续费费率上限,万分比
+	MaxFeeRate = int64(1000)
+	//K线单次查询最多返回的条数
+	MaxCandleCount = int32(200)
+	//单笔交易撮合后最多自动激活的止损单数量
+	MaxTriggerCount = 10
+	//单笔交易撮合后最多撤销的过期订单数量
+	MaxExpireCount = 10
 )
 
 var (
@@ -69,19 +108,35 @@ var (
 	ExchangeX = "exchange"
 	//定义actionMap
 	actionMap = map[string]int32{
-		NameLimitOrderAction:  TyLimitOrderAction,
-		NameMarketOrderAction: TyMarketOrderAction,
-		NameRevokeOrderAction: TyRevokeOrderAction,
+		NameLimitOrderAction:   TyLimitOrderAction,
+		NameMarketOrderAction:  TyMarketOrderAction,
+		NameRevokeOrderAction:  TyRevokeOrderAction,
+		NameTriggerOrderAction: TyTriggerOrderAction,
 	}
 	//定义log的id和具体log类型及名称，填入具体自定义log类型
 	logMap = map[int64]*types.LogInfo{
-		TyLimitOrderLog:  {Ty: reflect.TypeOf(ReceiptExchange{}), Name: "TyLimitOrderLog"},
-		TyMarketOrderLog: {Ty: reflect.TypeOf(ReceiptExchange{}), Name: "TyMarketOrderLog"},
-		TyRevokeOrderLog: {Ty: reflect.TypeOf(ReceiptExchange{}), Name: "TyRevokeOrderLog"},
+		TyLimitOrderLog:   {Ty: reflect.TypeOf(ReceiptExchange{}), Name: "TyLimitOrderLog"},
+		TyMarketOrderLog:  {Ty: reflect.TypeOf(ReceiptExchange{}), Name: "TyMarketOrderLog"},
+		TyRevokeOrderLog:  {Ty: reflect.TypeOf(ReceiptExchange{}), Name: "TyRevokeOrderLog"},
+		TyTriggerOrderLog: {Ty: reflect.TypeOf(ReceiptExchange{}), Name: "TyTriggerOrderLog"},
 	}
 	//tlog = log.New("module", "exchange.types")
+	//CandlePeriods 支持的K线周期
+	CandlePeriods = []string{Period1m, Period5m, Period1h, Period1d}
+	//K线周期对应的秒数
+	periodSeconds = map[string]int64{
+		Period1m: 60,
+		Period5m: 5 * 60,
+		Period1h: 3600,
+		Period1d: 24 * 3600,
+	}
 )
 
+// GetPeriodSeconds 获取K线周期对应的秒数,不支持的周期返回0
+func GetPeriodSeconds(period string) int64 {
+	return periodSeconds[period]
+}
+
 // init defines a register function
 func init() {
 	types.AllowUserExec = append(types.AllowUserExec, []byte(ExchangeX))
@@ -59,6 +59,7 @@ type ExchangeAction struct {
 	//	*ExchangeAction_LimitOrder
 	//	*ExchangeAction_MarketOrder
 	//	*ExchangeAction_RevokeOrder
+	//	*ExchangeAction_TriggerOrder
 	Value                isExchangeAction_Value `protobuf_oneof:"value"`
 	Ty                   int32                  `protobuf:"varint,6,opt,name=ty,proto3" json:"ty,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
@@ -107,12 +108,18 @@ type ExchangeAction_RevokeOrder struct {
 	RevokeOrder *RevokeOrder `protobuf:"bytes,3,opt,name=revokeOrder,proto3,oneof"`
 }
 
+type ExchangeAction_TriggerOrder struct {
+	TriggerOrder *TriggerOrder `protobuf:"bytes,4,opt,name=triggerOrder,proto3,oneof"`
+}
+
 func (*ExchangeAction_LimitOrder) isExchangeAction_Value() {}
 
 func (*ExchangeAction_MarketOrder) isExchangeAction_Value() {}
 
 func (*ExchangeAction_RevokeOrder) isExchangeAction_Value() {}
 
+func (*ExchangeAction_TriggerOrder) isExchangeAction_Value() {}
+
 func (m *ExchangeAction) GetValue() isExchangeAction_Value {
 	if m != nil {
 		return m.Value
@@ -141,6 +148,13 @@ func (m *ExchangeAction) GetRevokeOrder() *RevokeOrder {
 	return nil
 }
 
+func (m *ExchangeAction) GetTriggerOrder() *TriggerOrder {
+	if x, ok := m.GetValue().(*ExchangeAction_TriggerOrder); ok {
+		return x.TriggerOrder
+	}
+	return nil
+}
+
 func (m *ExchangeAction) GetTy() int32 {
 	if m != nil {
 		return m.Ty
@@ -154,6 +168,7 @@ func (*ExchangeAction) XXX_OneofWrappers() []interface{} {
 		(*ExchangeAction_LimitOrder)(nil),
 		(*ExchangeAction_MarketOrder)(nil),
 		(*ExchangeAction_RevokeOrder)(nil),
+		(*ExchangeAction_TriggerOrder)(nil),
 	}
 }
 
@@ -168,7 +183,17 @@ type LimitOrder struct {
 	//总量
 	Amount int64 `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
 	//操作， 1为买，2为卖
-	Op                   int32    `protobuf:"varint,5,opt,name=op,proto3" json:"op,omitempty"`
+	Op int32 `protobuf:"varint,5,opt,name=op,proto3" json:"op,omitempty"`
+	//成交时效,0 撤单前一直有效(GTC), 1 立即成交剩余部分撤销(IOC), 2 全部成交否则失败(FOK)
+	TimeInForce int32 `protobuf:"varint,6,opt,name=timeInForce,proto3" json:"timeInForce,omitempty"`
+	//只做挂单方,会立即成交时交易执行失败
+	PostOnly bool `protobuf:"varint,7,opt,name=postOnly,proto3" json:"postOnly,omitempty"`
+	//过期高度,达到该高度后订单视为撤销,0表示不过期
+	ExpireHeight int64 `protobuf:"varint,8,opt,name=expireHeight,proto3" json:"expireHeight,omitempty"`
+	//过期时间,区块时间达到该时间后订单视为撤销,0表示不过期
+	ExpireTime int64 `protobuf:"varint,9,opt,name=expireTime,proto3" json:"expireTime,omitempty"`
+	//止损触发价格,最新成交价穿过该价格前订单不生效,0表示普通限价单
+	TriggerPrice         int64    `protobuf:"varint,10,opt,name=triggerPrice,proto3" json:"triggerPrice,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -234,6 +259,41 @@ func (m *LimitOrder) GetOp() int32 {
 	return 0
 }
 
+func (m *LimitOrder) GetTimeInForce() int32 {
+	if m != nil {
+		return m.TimeInForce
+	}
+	return 0
+}
+
+func (m *LimitOrder) GetPostOnly() bool {
+	if m != nil {
+		return m.PostOnly
+	}
+	return false
+}
+
+func (m *LimitOrder) GetExpireHeight() int64 {
+	if m != nil {
+		return m.ExpireHeight
+	}
+	return 0
+}
+
+func (m *LimitOrder) GetExpireTime() int64 {
+	if m != nil {
+		return m.ExpireTime
+	}
+	return 0
+}
+
+func (m *LimitOrder) GetTriggerPrice() int64 {
+	if m != nil {
+		return m.TriggerPrice
+	}
+	return 0
+}
+
 //市价委托
 type MarketOrder struct {
 	//资产1
@@ -243,7 +303,9 @@ type MarketOrder struct {
 	//总量
 	Amount int64 `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
 	//操作， 1为买，2为卖
-	Op                   int32    `protobuf:"varint,4,opt,name=op,proto3" json:"op,omitempty"`
+	Op int32 `protobuf:"varint,4,opt,name=op,proto3" json:"op,omitempty"`
+	//滑点上限，万分比，成交价格偏离盘口最优价格超过该比例时停止撮合
+	Slippage             int64    `protobuf:"varint,5,opt,name=slippage,proto3" json:"slippage,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -302,6 +364,13 @@ func (m *MarketOrder) GetOp() int32 {
 	return 0
 }
 
+func (m *MarketOrder) GetSlippage() int64 {
+	if m != nil {
+		return m.Slippage
+	}
+	return 0
+}
+
 //撤回订单
 type RevokeOrder struct {
 	//订单号
@@ -343,6 +412,46 @@ func (m *RevokeOrder) GetOrderID() int64 {
 	return 0
 }
 
+//激活已经满足触发条件的止损限价单
+type TriggerOrder struct {
+	OrderID              int64    `protobuf:"varint,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TriggerOrder) Reset()         { *m = TriggerOrder{} }
+func (m *TriggerOrder) String() string { return proto.CompactTextString(m) }
+func (*TriggerOrder) ProtoMessage()    {}
+func (*TriggerOrder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{5}
+}
+
+func (m *TriggerOrder) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TriggerOrder.Unmarshal(m, b)
+}
+func (m *TriggerOrder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TriggerOrder.Marshal(b, m, deterministic)
+}
+func (m *TriggerOrder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TriggerOrder.Merge(m, src)
+}
+func (m *TriggerOrder) XXX_Size() int {
+	return xxx_messageInfo_TriggerOrder.Size(m)
+}
+func (m *TriggerOrder) XXX_DiscardUnknown() {
+	xxx_messageInfo_TriggerOrder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TriggerOrder proto.InternalMessageInfo
+
+func (m *TriggerOrder) GetOrderID() int64 {
+	if m != nil {
+		return m.OrderID
+	}
+	return 0
+}
+
 //资产类型
 type Asset struct {
 	Execer               string   `protobuf:"bytes,1,opt,name=execer,proto3" json:"execer,omitempty"`
@@ -356,7 +465,7 @@ func (m *Asset) Reset()         { *m = Asset{} }
 func (m *Asset) String() string { return proto.CompactTextString(m) }
 func (*Asset) ProtoMessage()    {}
 func (*Asset) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{5}
+	return fileDescriptor_e0328a4f16f87ea1, []int{6}
 }
 
 func (m *Asset) XXX_Unmarshal(b []byte) error {
@@ -406,14 +515,16 @@ type Order struct {
 	AVGPrice int64 `protobuf:"varint,6,opt,name=AVG_price,json=AVGPrice,proto3" json:"AVG_price,omitempty"`
 	//余额
 	Balance int64 `protobuf:"varint,7,opt,name=balance,proto3" json:"balance,omitempty"`
-	//状态,0 挂单中ordered， 1 完成completed， 2撤回 revoked
+	//状态,0 挂单中ordered， 1 完成completed， 2撤回 revoked， 3 等待触发inactive
 	Status int32 `protobuf:"varint,8,opt,name=status,proto3" json:"status,omitempty"`
 	//用户地址
 	Addr string `protobuf:"bytes,9,opt,name=addr,proto3" json:"addr,omitempty"`
 	//更新时间
 	UpdateTime int64 `protobuf:"varint,10,opt,name=updateTime,proto3" json:"updateTime,omitempty"`
 	//索引
-	Index                int64    `protobuf:"varint,11,opt,name=index,proto3" json:"index,omitempty"`
+	Index int64 `protobuf:"varint,11,opt,name=index,proto3" json:"index,omitempty"`
+	//累计支付的手续费,以收到的资产计价
+	Fee                  int64    `protobuf:"varint,12,opt,name=fee,proto3" json:"fee,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -423,7 +534,7 @@ func (m *Order) Reset()         { *m = Order{} }
 func (m *Order) String() string { return proto.CompactTextString(m) }
 func (*Order) ProtoMessage()    {}
 func (*Order) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{6}
+	return fileDescriptor_e0328a4f16f87ea1, []int{7}
 }
 
 func (m *Order) XXX_Unmarshal(b []byte) error {
@@ -544,6 +655,13 @@ func (m *Order) GetIndex() int64 {
 	return 0
 }
 
+func (m *Order) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Order) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -573,7 +691,7 @@ func (m *QueryMarketDepth) Reset()         { *m = QueryMarketDepth{} }
 func (m *QueryMarketDepth) String() string { return proto.CompactTextString(m) }
 func (*QueryMarketDepth) ProtoMessage()    {}
 func (*QueryMarketDepth) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{7}
+	return fileDescriptor_e0328a4f16f87ea1, []int{8}
 }
 
 func (m *QueryMarketDepth) XXX_Unmarshal(b []byte) error {
@@ -650,7 +768,7 @@ func (m *MarketDepth) Reset()         { *m = MarketDepth{} }
 func (m *MarketDepth) String() string { return proto.CompactTextString(m) }
 func (*MarketDepth) ProtoMessage()    {}
 func (*MarketDepth) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{8}
+	return fileDescriptor_e0328a4f16f87ea1, []int{9}
 }
 
 func (m *MarketDepth) XXX_Unmarshal(b []byte) error {
@@ -719,7 +837,7 @@ func (m *MarketDepthList) Reset()         { *m = MarketDepthList{} }
 func (m *MarketDepthList) String() string { return proto.CompactTextString(m) }
 func (*MarketDepthList) ProtoMessage()    {}
 func (*MarketDepthList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{9}
+	return fileDescriptor_e0328a4f16f87ea1, []int{10}
 }
 
 func (m *MarketDepthList) XXX_Unmarshal(b []byte) error {
@@ -775,7 +893,7 @@ func (m *QueryHistoryOrderList) Reset()         { *m = QueryHistoryOrderList{} }
 func (m *QueryHistoryOrderList) String() string { return proto.CompactTextString(m) }
 func (*QueryHistoryOrderList) ProtoMessage()    {}
 func (*QueryHistoryOrderList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{10}
+	return fileDescriptor_e0328a4f16f87ea1, []int{11}
 }
 
 func (m *QueryHistoryOrderList) XXX_Unmarshal(b []byte) error {
@@ -843,7 +961,7 @@ func (m *QueryOrder) Reset()         { *m = QueryOrder{} }
 func (m *QueryOrder) String() string { return proto.CompactTextString(m) }
 func (*QueryOrder) ProtoMessage()    {}
 func (*QueryOrder) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{11}
+	return fileDescriptor_e0328a4f16f87ea1, []int{12}
 }
 
 func (m *QueryOrder) XXX_Unmarshal(b []byte) error {
@@ -892,7 +1010,7 @@ func (m *QueryOrderList) Reset()         { *m = QueryOrderList{} }
 func (m *QueryOrderList) String() string { return proto.CompactTextString(m) }
 func (*QueryOrderList) ProtoMessage()    {}
 func (*QueryOrderList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{12}
+	return fileDescriptor_e0328a4f16f87ea1, []int{13}
 }
 
 func (m *QueryOrderList) XXX_Unmarshal(b []byte) error {
@@ -961,7 +1079,7 @@ func (m *OrderList) Reset()         { *m = OrderList{} }
 func (m *OrderList) String() string { return proto.CompactTextString(m) }
 func (*OrderList) ProtoMessage()    {}
 func (*OrderList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{13}
+	return fileDescriptor_e0328a4f16f87ea1, []int{14}
 }
 
 func (m *OrderList) XXX_Unmarshal(b []byte) error {
@@ -996,11 +1114,528 @@ func (m *OrderList) GetPrimaryKey() string {
 	return ""
 }
 
+//K线,记录交易对在一个周期内的成交情况
+type Candle struct {
+	//资产1
+	LeftAsset *Asset `protobuf:"bytes,1,opt,name=leftAsset,proto3" json:"leftAsset,omitempty"`
+	//资产2
+	RightAsset *Asset `protobuf:"bytes,2,opt,name=rightAsset,proto3" json:"rightAsset,omitempty"`
+	//周期,取值为 1m,5m,1h,1d
+	Period string `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	//周期开始时间
+	Time int64 `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	//开盘价
+	Open int64 `protobuf:"varint,5,opt,name=open,proto3" json:"open,omitempty"`
+	//最高价
+	High int64 `protobuf:"varint,6,opt,name=high,proto3" json:"high,omitempty"`
+	//最低价
+	Low int64 `protobuf:"varint,7,opt,name=low,proto3" json:"low,omitempty"`
+	//收盘价
+	Close int64 `protobuf:"varint,8,opt,name=close,proto3" json:"close,omitempty"`
+	//成交量,以leftAsset计
+	Volume int64 `protobuf:"varint,9,opt,name=volume,proto3" json:"volume,omitempty"`
+	//成交额,以rightAsset计
+	Turnover             int64    `protobuf:"varint,10,opt,name=turnover,proto3" json:"turnover,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Candle) Reset()         { *m = Candle{} }
+func (m *Candle) String() string { return proto.CompactTextString(m) }
+func (*Candle) ProtoMessage()    {}
+func (*Candle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{15}
+}
+
+func (m *Candle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Candle.Unmarshal(m, b)
+}
+func (m *Candle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Candle.Marshal(b, m, deterministic)
+}
+func (m *Candle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Candle.Merge(m, src)
+}
+func (m *Candle) XXX_Size() int {
+	return xxx_messageInfo_Candle.Size(m)
+}
+func (m *Candle) XXX_DiscardUnknown() {
+	xxx_messageInfo_Candle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Candle proto.InternalMessageInfo
+
+func (m *Candle) GetLeftAsset() *Asset {
+	if m != nil {
+		return m.LeftAsset
+	}
+	return nil
+}
+
+func (m *Candle) GetRightAsset() *Asset {
+	if m != nil {
+		return m.RightAsset
+	}
+	return nil
+}
+
+func (m *Candle) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+func (m *Candle) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Candle) GetOpen() int64 {
+	if m != nil {
+		return m.Open
+	}
+	return 0
+}
+
+func (m *Candle) GetHigh() int64 {
+	if m != nil {
+		return m.High
+	}
+	return 0
+}
+
+func (m *Candle) GetLow() int64 {
+	if m != nil {
+		return m.Low
+	}
+	return 0
+}
+
+func (m *Candle) GetClose() int64 {
+	if m != nil {
+		return m.Close
+	}
+	return 0
+}
+
+func (m *Candle) GetVolume() int64 {
+	if m != nil {
+		return m.Volume
+	}
+	return 0
+}
+
+func (m *Candle) GetTurnover() int64 {
+	if m != nil {
+		return m.Turnover
+	}
+	return 0
+}
+
+//查询K线
+type QueryCandles struct {
+	//资产1
+	LeftAsset *Asset `protobuf:"bytes,1,opt,name=leftAsset,proto3" json:"leftAsset,omitempty"`
+	//资产2
+	RightAsset *Asset `protobuf:"bytes,2,opt,name=rightAsset,proto3" json:"rightAsset,omitempty"`
+	//周期,取值为 1m,5m,1h,1d
+	Period string `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	// 主键索引
+	PrimaryKey string `protobuf:"bytes,4,opt,name=primaryKey,proto3" json:"primaryKey,omitempty"`
+	//单页返回多少条记录，默认返回10条,最多单次只能返回200条
+	Count int32 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	// 0降序，1升序，默认降序
+	Direction            int32    `protobuf:"varint,6,opt,name=direction,proto3" json:"direction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryCandles) Reset()         { *m = QueryCandles{} }
+func (m *QueryCandles) String() string { return proto.CompactTextString(m) }
+func (*QueryCandles) ProtoMessage()    {}
+func (*QueryCandles) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{16}
+}
+
+func (m *QueryCandles) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryCandles.Unmarshal(m, b)
+}
+func (m *QueryCandles) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryCandles.Marshal(b, m, deterministic)
+}
+func (m *QueryCandles) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryCandles.Merge(m, src)
+}
+func (m *QueryCandles) XXX_Size() int {
+	return xxx_messageInfo_QueryCandles.Size(m)
+}
+func (m *QueryCandles) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryCandles.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryCandles proto.InternalMessageInfo
+
+func (m *QueryCandles) GetLeftAsset() *Asset {
+	if m != nil {
+		return m.LeftAsset
+	}
+	return nil
+}
+
+func (m *QueryCandles) GetRightAsset() *Asset {
+	if m != nil {
+		return m.RightAsset
+	}
+	return nil
+}
+
+func (m *QueryCandles) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+func (m *QueryCandles) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
+func (m *QueryCandles) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *QueryCandles) GetDirection() int32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
+}
+
+//K线列表
+type CandleList struct {
+	List                 []*Candle `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	PrimaryKey           string    `protobuf:"bytes,2,opt,name=primaryKey,proto3" json:"primaryKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CandleList) Reset()         { *m = CandleList{} }
+func (m *CandleList) String() string { return proto.CompactTextString(m) }
+func (*CandleList) ProtoMessage()    {}
+func (*CandleList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{17}
+}
+
+func (m *CandleList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CandleList.Unmarshal(m, b)
+}
+func (m *CandleList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CandleList.Marshal(b, m, deterministic)
+}
+func (m *CandleList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CandleList.Merge(m, src)
+}
+func (m *CandleList) XXX_Size() int {
+	return xxx_messageInfo_CandleList.Size(m)
+}
+func (m *CandleList) XXX_DiscardUnknown() {
+	xxx_messageInfo_CandleList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CandleList proto.InternalMessageInfo
+
+func (m *CandleList) GetList() []*Candle {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+func (m *CandleList) GetPrimaryKey() string {
+	if m != nil {
+		return m.PrimaryKey
+	}
+	return ""
+}
+
+//查询24小时行情
+type QueryTicker struct {
+	//资产1
+	LeftAsset *Asset `protobuf:"bytes,1,opt,name=leftAsset,proto3" json:"leftAsset,omitempty"`
+	//资产2
+	RightAsset *Asset `protobuf:"bytes,2,opt,name=rightAsset,proto3" json:"rightAsset,omitempty"`
+	//统计截止时间,默认为最近一笔成交的时间
+	Time                 int64    `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryTicker) Reset()         { *m = QueryTicker{} }
+func (m *QueryTicker) String() string { return proto.CompactTextString(m) }
+func (*QueryTicker) ProtoMessage()    {}
+func (*QueryTicker) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{18}
+}
+
+func (m *QueryTicker) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryTicker.Unmarshal(m, b)
+}
+func (m *QueryTicker) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryTicker.Marshal(b, m, deterministic)
+}
+func (m *QueryTicker) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryTicker.Merge(m, src)
+}
+func (m *QueryTicker) XXX_Size() int {
+	return xxx_messageInfo_QueryTicker.Size(m)
+}
+func (m *QueryTicker) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryTicker.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryTicker proto.InternalMessageInfo
+
+func (m *QueryTicker) GetLeftAsset() *Asset {
+	if m != nil {
+		return m.LeftAsset
+	}
+	return nil
+}
+
+func (m *QueryTicker) GetRightAsset() *Asset {
+	if m != nil {
+		return m.RightAsset
+	}
+	return nil
+}
+
+func (m *QueryTicker) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+//24小时行情
+type Ticker struct {
+	//资产1
+	LeftAsset *Asset `protobuf:"bytes,1,opt,name=leftAsset,proto3" json:"leftAsset,omitempty"`
+	//资产2
+	RightAsset *Asset `protobuf:"bytes,2,opt,name=rightAsset,proto3" json:"rightAsset,omitempty"`
+	//最新成交价
+	LastPrice int64 `protobuf:"varint,3,opt,name=lastPrice,proto3" json:"lastPrice,omitempty"`
+	//24小时前的开盘价
+	Open int64 `protobuf:"varint,4,opt,name=open,proto3" json:"open,omitempty"`
+	//24小时涨跌额
+	Change int64 `protobuf:"varint,5,opt,name=change,proto3" json:"change,omitempty"`
+	//24小时最高价
+	High int64 `protobuf:"varint,6,opt,name=high,proto3" json:"high,omitempty"`
+	//24小时最低价
+	Low int64 `protobuf:"varint,7,opt,name=low,proto3" json:"low,omitempty"`
+	//24小时成交量,以leftAsset计
+	Volume int64 `protobuf:"varint,8,opt,name=volume,proto3" json:"volume,omitempty"`
+	//24小时成交额,以rightAsset计
+	Turnover int64 `protobuf:"varint,9,opt,name=turnover,proto3" json:"turnover,omitempty"`
+	//统计截止时间
+	Time                 int64    `protobuf:"varint,10,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Ticker) Reset()         { *m = Ticker{} }
+func (m *Ticker) String() string { return proto.CompactTextString(m) }
+func (*Ticker) ProtoMessage()    {}
+func (*Ticker) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{19}
+}
+
+func (m *Ticker) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ticker.Unmarshal(m, b)
+}
+func (m *Ticker) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ticker.Marshal(b, m, deterministic)
+}
+func (m *Ticker) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ticker.Merge(m, src)
+}
+func (m *Ticker) XXX_Size() int {
+	return xxx_messageInfo_Ticker.Size(m)
+}
+func (m *Ticker) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ticker.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ticker proto.InternalMessageInfo
+
+func (m *Ticker) GetLeftAsset() *Asset {
+	if m != nil {
+		return m.LeftAsset
+	}
+	return nil
+}
+
+func (m *Ticker) GetRightAsset() *Asset {
+	if m != nil {
+		return m.RightAsset
+	}
+	return nil
+}
+
+func (m *Ticker) GetLastPrice() int64 {
+	if m != nil {
+		return m.LastPrice
+	}
+	return 0
+}
+
+func (m *Ticker) GetOpen() int64 {
+	if m != nil {
+		return m.Open
+	}
+	return 0
+}
+
+func (m *Ticker) GetChange() int64 {
+	if m != nil {
+		return m.Change
+	}
+	return 0
+}
+
+func (m *Ticker) GetHigh() int64 {
+	if m != nil {
+		return m.High
+	}
+	return 0
+}
+
+func (m *Ticker) GetLow() int64 {
+	if m != nil {
+		return m.Low
+	}
+	return 0
+}
+
+func (m *Ticker) GetVolume() int64 {
+	if m != nil {
+		return m.Volume
+	}
+	return 0
+}
+
+func (m *Ticker) GetTurnover() int64 {
+	if m != nil {
+		return m.Turnover
+	}
+	return 0
+}
+
+func (m *Ticker) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+//单笔撮合成交明细
+type MatchDetail struct {
+	//挂单方订单号
+	MatchOrderID int64 `protobuf:"varint,1,opt,name=matchOrderID,proto3" json:"matchOrderID,omitempty"`
+	//成交价格
+	Price int64 `protobuf:"varint,2,opt,name=price,proto3" json:"price,omitempty"`
+	//成交数量
+	Amount int64 `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	//挂单方手续费,以挂单方收到的资产计价
+	MakerFee int64 `protobuf:"varint,4,opt,name=makerFee,proto3" json:"makerFee,omitempty"`
+	//吃单方手续费,以吃单方收到的资产计价
+	TakerFee             int64    `protobuf:"varint,5,opt,name=takerFee,proto3" json:"takerFee,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MatchDetail) Reset()         { *m = MatchDetail{} }
+func (m *MatchDetail) String() string { return proto.CompactTextString(m) }
+func (*MatchDetail) ProtoMessage()    {}
+func (*MatchDetail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0328a4f16f87ea1, []int{20}
+}
+
+func (m *MatchDetail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatchDetail.Unmarshal(m, b)
+}
+func (m *MatchDetail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatchDetail.Marshal(b, m, deterministic)
+}
+func (m *MatchDetail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatchDetail.Merge(m, src)
+}
+func (m *MatchDetail) XXX_Size() int {
+	return xxx_messageInfo_MatchDetail.Size(m)
+}
+func (m *MatchDetail) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatchDetail.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatchDetail proto.InternalMessageInfo
+
+func (m *MatchDetail) GetMatchOrderID() int64 {
+	if m != nil {
+		return m.MatchOrderID
+	}
+	return 0
+}
+
+func (m *MatchDetail) GetPrice() int64 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *MatchDetail) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *MatchDetail) GetMakerFee() int64 {
+	if m != nil {
+		return m.MakerFee
+	}
+	return 0
+}
+
+func (m *MatchDetail) GetTakerFee() int64 {
+	if m != nil {
+		return m.TakerFee
+	}
+	return 0
+}
+
 // exchange执行票据日志
 type ReceiptExchange struct {
-	Order                *Order   `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
-	MatchOrders          []*Order `protobuf:"bytes,2,rep,name=matchOrders,proto3" json:"matchOrders,omitempty"`
-	Index                int64    `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	Order        *Order         `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	MatchOrders  []*Order       `protobuf:"bytes,2,rep,name=matchOrders,proto3" json:"matchOrders,omitempty"`
+	Index        int64          `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	MatchDetails []*MatchDetail `protobuf:"bytes,4,rep,name=matchDetails,proto3" json:"matchDetails,omitempty"`
+	//手续费收款地址
+	FeeAddr              string   `protobuf:"bytes,5,opt,name=feeAddr,proto3" json:"feeAddr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1010,7 +1645,7 @@ func (m *ReceiptExchange) Reset()         { *m = ReceiptExchange{} }
 func (m *ReceiptExchange) String() string { return proto.CompactTextString(m) }
 func (*ReceiptExchange) ProtoMessage()    {}
 func (*ReceiptExchange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e0328a4f16f87ea1, []int{14}
+	return fileDescriptor_e0328a4f16f87ea1, []int{21}
 }
 
 func (m *ReceiptExchange) XXX_Unmarshal(b []byte) error {
@@ -1052,12 +1687,27 @@ func (m *ReceiptExchange) GetIndex() int64 {
 	return 0
 }
 
+func (m *ReceiptExchange) GetMatchDetails() []*MatchDetail {
+	if m != nil {
+		return m.MatchDetails
+	}
+	return nil
+}
+
+func (m *ReceiptExchange) GetFeeAddr() string {
+	if m != nil {
+		return m.FeeAddr
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Exchange)(nil), "types.Exchange")
 	proto.RegisterType((*ExchangeAction)(nil), "types.ExchangeAction")
 	proto.RegisterType((*LimitOrder)(nil), "types.LimitOrder")
 	proto.RegisterType((*MarketOrder)(nil), "types.MarketOrder")
 	proto.RegisterType((*RevokeOrder)(nil), "types.RevokeOrder")
+	proto.RegisterType((*TriggerOrder)(nil), "types.TriggerOrder")
 	proto.RegisterType((*Asset)(nil), "types.asset")
 	proto.RegisterType((*Order)(nil), "types.Order")
 	proto.RegisterType((*QueryMarketDepth)(nil), "types.QueryMarketDepth")
@@ -1067,6 +1717,12 @@ func init() {
 	proto.RegisterType((*QueryOrder)(nil), "types.QueryOrder")
 	proto.RegisterType((*QueryOrderList)(nil), "types.QueryOrderList")
 	proto.RegisterType((*OrderList)(nil), "types.OrderList")
+	proto.RegisterType((*Candle)(nil), "types.Candle")
+	proto.RegisterType((*QueryCandles)(nil), "types.QueryCandles")
+	proto.RegisterType((*CandleList)(nil), "types.CandleList")
+	proto.RegisterType((*QueryTicker)(nil), "types.QueryTicker")
+	proto.RegisterType((*Ticker)(nil), "types.Ticker")
+	proto.RegisterType((*MatchDetail)(nil), "types.MatchDetail")
 	proto.RegisterType((*ReceiptExchange)(nil), "types.ReceiptExchange")
 }
 
@@ -1075,49 +1731,73 @@ func init() {
 }
 
 var fileDescriptor_e0328a4f16f87ea1 = []byte{
-	// 667 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xdd, 0x6e, 0xd3, 0x4a,
-	0x10, 0xae, 0xed, 0x38, 0xa9, 0x27, 0x47, 0xe9, 0x39, 0xab, 0x73, 0x8e, 0x2c, 0x40, 0xa8, 0xf2,
-	0x45, 0xa9, 0x10, 0xca, 0x45, 0x2b, 0xc1, 0x75, 0x50, 0x51, 0x8b, 0x68, 0x05, 0xac, 0x50, 0x25,
-	0xae, 0x90, 0x6b, 0x0f, 0xcd, 0xaa, 0x49, 0x6c, 0xad, 0x37, 0x55, 0x2d, 0x1e, 0x82, 0x3b, 0x9e,
-	0x00, 0x5e, 0x80, 0x77, 0xe0, 0x96, 0x27, 0xe0, 0x61, 0xd0, 0xce, 0xae, 0xe3, 0x4d, 0x5b, 0xd4,
-	0x0a, 0x94, 0x3b, 0x7f, 0xf3, 0xb3, 0xfe, 0x66, 0xe6, 0x9b, 0x5d, 0x18, 0xe0, 0x45, 0x36, 0x4e,
-	0x67, 0xa7, 0x38, 0x2c, 0x65, 0xa1, 0x0a, 0x16, 0xaa, 0xba, 0xc4, 0x2a, 0x01, 0x58, 0x7f, 0x66,
-	0x1d, 0xc9, 0x77, 0x0f, 0x06, 0x0d, 0x18, 0x65, 0x4a, 0x14, 0x33, 0xb6, 0x0b, 0x30, 0x11, 0x53,
-	0xa1, 0x5e, 0xca, 0x1c, 0x65, 0xec, 0x6d, 0x7a, 0xdb, 0xfd, 0x9d, 0x7f, 0x86, 0x94, 0x3a, 0x3c,
-	0x5c, 0x38, 0x0e, 0xd6, 0xb8, 0x13, 0xc6, 0x1e, 0x43, 0x7f, 0x9a, 0xca, 0x33, 0xb4, 0x59, 0x3e,
-	0x65, 0x31, 0x9b, 0x75, 0xd4, 0x7a, 0x0e, 0xd6, 0xb8, 0x1b, 0xa8, 0xf3, 0x24, 0x9e, 0x17, 0x67,
-	0x68, 0xf2, 0x82, 0xa5, 0x3c, 0xde, 0x7a, 0x74, 0x9e, 0x13, 0xc8, 0x06, 0xe0, 0xab, 0x3a, 0xee,
-	0x6e, 0x7a, 0xdb, 0x21, 0xf7, 0x55, 0xfd, 0xb4, 0x07, 0xe1, 0x79, 0x3a, 0x99, 0x63, 0xf2, 0xd9,
-	0x03, 0x68, 0x59, 0xb2, 0x87, 0x10, 0x4d, 0xf0, 0xbd, 0x1a, 0x55, 0x15, 0x2a, 0x5b, 0xcb, 0x5f,
-	0xf6, 0xf4, 0x54, 0xdb, 0x78, 0xeb, 0x66, 0x8f, 0x00, 0xa4, 0x38, 0x1d, 0xdb, 0x60, 0xff, 0x9a,
-	0x60, 0xc7, 0xcf, 0xfe, 0x85, 0xb0, 0x94, 0x22, 0x43, 0xe2, 0x1c, 0x70, 0x03, 0xd8, 0xff, 0xd0,
-	0x4d, 0xa7, 0xc5, 0x7c, 0xa6, 0xe2, 0x0e, 0x99, 0x2d, 0xd2, 0x7c, 0x8b, 0x32, 0x0e, 0x0d, 0xdf,
-	0xa2, 0x4c, 0x3e, 0x7a, 0xd0, 0x77, 0xda, 0xb2, 0x42, 0x9e, 0x2d, 0xa3, 0xe0, 0x1a, 0x46, 0x9d,
-	0x05, 0xa3, 0x07, 0xd0, 0x77, 0xfa, 0xcd, 0x62, 0xe8, 0x15, 0xfa, 0xe3, 0xf9, 0x1e, 0xd1, 0x09,
-	0x78, 0x03, 0x93, 0x27, 0x10, 0xa6, 0xcd, 0xc9, 0x78, 0x81, 0x99, 0x15, 0x49, 0xc4, 0x2d, 0xd2,
-	0xf6, 0xaa, 0x9e, 0x9e, 0x14, 0x13, 0xe2, 0x16, 0x71, 0x8b, 0x92, 0x1f, 0x3e, 0x84, 0x37, 0x1c,
-	0x7e, 0x49, 0x7c, 0xfe, 0x6f, 0x89, 0x2f, 0xb8, 0xad, 0xf8, 0x8c, 0x88, 0x3a, 0x8d, 0x88, 0xd8,
-	0x1d, 0x58, 0xd7, 0x25, 0xcc, 0x15, 0xe6, 0x34, 0xaa, 0x80, 0x2f, 0x30, 0xbb, 0x0b, 0xd1, 0xe8,
-	0x78, 0xff, 0x9d, 0x19, 0x79, 0xd7, 0x38, 0x47, 0xc7, 0xfb, 0xaf, 0x68, 0xea, 0x31, 0xf4, 0x4e,
-	0xd2, 0x49, 0x3a, 0xcb, 0x30, 0xee, 0x99, 0x7a, 0x2c, 0xa4, 0x5e, 0xa8, 0x54, 0xcd, 0xab, 0x78,
-	0x9d, 0x7e, 0x63, 0x11, 0x63, 0xd0, 0x49, 0xf3, 0x5c, 0xc6, 0x11, 0x75, 0x88, 0xbe, 0xd9, 0x7d,
-	0x80, 0x79, 0x99, 0xa7, 0x0a, 0xdf, 0x88, 0x29, 0xc6, 0x40, 0x07, 0x39, 0x16, 0xad, 0x38, 0x31,
-	0xcb, 0xf1, 0x22, 0xee, 0x1b, 0xc5, 0x11, 0x68, 0x95, 0xff, 0xd5, 0x83, 0xbf, 0x5f, 0xcf, 0x51,
-	0xd6, 0xa6, 0xe2, 0x3d, 0x2c, 0xd5, 0x78, 0x85, 0xba, 0x32, 0xfa, 0x09, 0x1a, 0xfd, 0x68, 0xf6,
-	0xa5, 0x14, 0xd3, 0x54, 0xd6, 0x2f, 0xd0, 0x34, 0x35, 0xe2, 0x8e, 0x45, 0xb3, 0xcf, 0x48, 0x86,
-	0x66, 0x09, 0x0c, 0x48, 0xbe, 0x2c, 0xf6, 0x60, 0xd5, 0x7c, 0xff, 0x6c, 0x5f, 0xdf, 0xc2, 0x86,
-	0x43, 0xf3, 0x50, 0x54, 0x8a, 0x6d, 0x41, 0x67, 0x22, 0x2a, 0xcd, 0x32, 0xb8, 0x22, 0x37, 0x8a,
-	0xe2, 0xe4, 0xbf, 0xd4, 0x18, 0xff, 0x72, 0x63, 0x92, 0x6f, 0x1e, 0xfc, 0x47, 0x73, 0x3b, 0x10,
-	0x95, 0x2a, 0x64, 0x4d, 0xda, 0xa4, 0x3f, 0xac, 0xae, 0x19, 0xcb, 0x9c, 0x82, 0x5f, 0x0f, 0xab,
-	0xe3, 0x0c, 0x8b, 0xdd, 0x83, 0x28, 0x17, 0x12, 0xe9, 0x99, 0xb0, 0xbd, 0x69, 0x0d, 0xc9, 0x16,
-	0x00, 0x95, 0x71, 0xd3, 0xfd, 0xf1, 0xc9, 0x83, 0x41, 0x1b, 0x48, 0x85, 0xb6, 0x5b, 0xe2, 0x2d,
-	0x6d, 0x49, 0x0c, 0x3d, 0xbd, 0x19, 0x58, 0x55, 0xb6, 0x6f, 0x0d, 0x5c, 0x49, 0x01, 0x47, 0x10,
-	0xb5, 0x94, 0x36, 0x97, 0xa6, 0xdb, 0x74, 0x92, 0xfc, 0xb7, 0x9c, 0xeb, 0x07, 0xd8, 0xe0, 0x98,
-	0xa1, 0x28, 0x55, 0xf3, 0xc0, 0xb2, 0x04, 0xc2, 0xc2, 0x79, 0x55, 0x97, 0x4f, 0x35, 0x2e, 0x36,
-	0xd4, 0x97, 0x99, 0xca, 0xc6, 0x64, 0xd4, 0x75, 0x5f, 0xfd, 0xbf, 0x1b, 0xd0, 0xde, 0x0a, 0x81,
-	0x73, 0x2b, 0xec, 0x80, 0xbe, 0xca, 0xcc, 0x5f, 0x4f, 0xba, 0xf4, 0xfa, 0xef, 0xfe, 0x0c, 0x00,
-	0x00, 0xff, 0xff, 0xf2, 0x3c, 0xac, 0xed, 0x0f, 0x08, 0x00, 0x00,
+	// 1052 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0x41, 0x6f, 0xdc, 0x44,
+	0x14, 0xae, 0xed, 0xf5, 0x66, 0xfd, 0x76, 0x49, 0xcb, 0x00, 0x95, 0x15, 0xaa, 0x2a, 0xf8, 0x50,
+	0x22, 0x84, 0x72, 0x68, 0xa5, 0x22, 0x8e, 0x81, 0xd0, 0xa6, 0xa2, 0x55, 0xca, 0x28, 0xaa, 0xc4,
+	0x09, 0x39, 0xde, 0x97, 0xdd, 0x51, 0xbc, 0xb6, 0x35, 0x9e, 0x0d, 0x59, 0xf1, 0x0b, 0xb8, 0x72,
+	0x00, 0x89, 0x3b, 0x27, 0x6e, 0xfc, 0x07, 0x2e, 0x48, 0xdc, 0xf8, 0x1d, 0xfc, 0x06, 0x34, 0x6f,
+	0xc6, 0xf6, 0x78, 0x9b, 0xd2, 0xa8, 0x68, 0xc5, 0x6d, 0xbe, 0xf7, 0xde, 0xac, 0xdf, 0xfb, 0xe6,
+	0x7b, 0x6f, 0x66, 0x61, 0x1b, 0x2f, 0xb3, 0x79, 0x5a, 0xcc, 0x70, 0xbf, 0x92, 0xa5, 0x2a, 0x59,
+	0xa8, 0x56, 0x15, 0xd6, 0x09, 0xc0, 0xe8, 0x0b, 0xeb, 0x48, 0xbe, 0xf7, 0x61, 0xbb, 0x01, 0x07,
+	0x99, 0x12, 0x65, 0xc1, 0x1e, 0x00, 0xe4, 0x62, 0x21, 0xd4, 0xb1, 0x9c, 0xa2, 0x8c, 0xbd, 0x5d,
+	0x6f, 0x6f, 0x7c, 0xff, 0xed, 0x7d, 0xda, 0xba, 0xff, 0xb4, 0x75, 0x1c, 0xdd, 0xe0, 0x4e, 0x18,
+	0x7b, 0x08, 0xe3, 0x45, 0x2a, 0xcf, 0xd1, 0xee, 0xf2, 0x69, 0x17, 0xb3, 0xbb, 0x9e, 0x75, 0x9e,
+	0xa3, 0x1b, 0xdc, 0x0d, 0xd4, 0xfb, 0x24, 0x5e, 0x94, 0xe7, 0x68, 0xf6, 0x05, 0xbd, 0x7d, 0xbc,
+	0xf3, 0xe8, 0x7d, 0x4e, 0x20, 0xfb, 0x14, 0x26, 0x4a, 0x8a, 0xd9, 0x0c, 0xa5, 0xd9, 0x38, 0xa0,
+	0x8d, 0xef, 0xd8, 0x8d, 0x27, 0x8e, 0xeb, 0xe8, 0x06, 0xef, 0x85, 0xb2, 0x6d, 0xf0, 0xd5, 0x2a,
+	0x1e, 0xee, 0x7a, 0x7b, 0x21, 0xf7, 0xd5, 0xea, 0xb3, 0x2d, 0x08, 0x2f, 0xd2, 0x7c, 0x89, 0xc9,
+	0x1f, 0x3e, 0x40, 0x57, 0x20, 0xfb, 0x08, 0xa2, 0x1c, 0xcf, 0xd4, 0x41, 0x5d, 0xa3, 0xb2, 0x34,
+	0x4c, 0xec, 0xef, 0xa7, 0xda, 0xc6, 0x3b, 0x37, 0xfb, 0x18, 0x40, 0x8a, 0xd9, 0xdc, 0x06, 0xfb,
+	0x57, 0x04, 0x3b, 0x7e, 0xf6, 0x2e, 0x84, 0x95, 0x14, 0x19, 0x52, 0xb9, 0x01, 0x37, 0x80, 0xdd,
+	0x86, 0x61, 0xba, 0x28, 0x97, 0x85, 0xa2, 0x62, 0x02, 0x6e, 0x91, 0xce, 0xb7, 0xac, 0xe2, 0xd0,
+	0xe4, 0x5b, 0x56, 0x6c, 0x17, 0xc6, 0x4a, 0x2c, 0xf0, 0x49, 0xf1, 0xa8, 0x94, 0x19, 0xda, 0x42,
+	0x5c, 0x13, 0xdb, 0x81, 0x51, 0x55, 0xd6, 0xea, 0xb8, 0xc8, 0x57, 0xf1, 0xd6, 0xae, 0xb7, 0x37,
+	0xe2, 0x2d, 0x66, 0x09, 0x4c, 0xf0, 0xb2, 0x12, 0x12, 0x8f, 0x50, 0x27, 0x14, 0x8f, 0xe8, 0x5b,
+	0x3d, 0x1b, 0xbb, 0x0b, 0x60, 0xf0, 0x89, 0x58, 0x60, 0x1c, 0x51, 0x84, 0x63, 0xd1, 0xbf, 0x61,
+	0x19, 0x7d, 0x4e, 0x65, 0x80, 0xf9, 0x0d, 0xd7, 0x96, 0xfc, 0xea, 0xc1, 0xd8, 0x39, 0xf7, 0x0d,
+	0xb2, 0xd9, 0xf1, 0x16, 0x5c, 0xc1, 0xdb, 0xa0, 0xe5, 0x6d, 0x07, 0x46, 0x75, 0x2e, 0xaa, 0x2a,
+	0x9d, 0x21, 0xb1, 0x19, 0xf0, 0x16, 0x27, 0x1f, 0xc2, 0xd8, 0x11, 0x1b, 0x8b, 0x61, 0xab, 0xd4,
+	0x8b, 0x27, 0x87, 0x94, 0x6a, 0xc0, 0x1b, 0x98, 0xec, 0xc1, 0xc4, 0x15, 0xd7, 0xbf, 0x44, 0x7e,
+	0x02, 0x61, 0xda, 0xe4, 0x87, 0x97, 0x98, 0xd9, 0x5e, 0x8a, 0xb8, 0x45, 0xda, 0x5e, 0xaf, 0x16,
+	0xa7, 0x65, 0x4e, 0x15, 0x46, 0xdc, 0xa2, 0xe4, 0x6f, 0x1f, 0xc2, 0xd7, 0xfc, 0xf8, 0x5a, 0x8f,
+	0xfa, 0x6f, 0xd4, 0xa3, 0xc1, 0x75, 0x7b, 0xd4, 0x34, 0xcc, 0xa0, 0x69, 0x18, 0x4d, 0xa4, 0x2e,
+	0x61, 0xa9, 0x70, 0xda, 0x10, 0xd9, 0x60, 0xf6, 0x3e, 0x44, 0x07, 0x2f, 0x1e, 0x7f, 0x63, 0xe4,
+	0x3d, 0x34, 0xce, 0x83, 0x17, 0x8f, 0x49, 0x13, 0xba, 0x9e, 0xd3, 0x34, 0x4f, 0x8b, 0x0c, 0x49,
+	0x96, 0x01, 0x6f, 0x20, 0x71, 0xa1, 0x52, 0xb5, 0xac, 0x49, 0x8f, 0x21, 0xb7, 0x88, 0x31, 0x18,
+	0xa4, 0xd3, 0xa9, 0x24, 0x0d, 0x46, 0x9c, 0xd6, 0x5a, 0x9d, 0xcb, 0x6a, 0x9a, 0x2a, 0xa3, 0x4e,
+	0xa3, 0x3d, 0xc7, 0xa2, 0xbb, 0x4b, 0x14, 0x53, 0xbc, 0x8c, 0xc7, 0xa6, 0xbb, 0x08, 0xb0, 0x5b,
+	0x10, 0x9c, 0x21, 0xc6, 0x13, 0xb2, 0xe9, 0x65, 0xd7, 0xf7, 0xbf, 0x79, 0x70, 0xeb, 0xab, 0x25,
+	0xca, 0x95, 0xe1, 0xe0, 0x10, 0x2b, 0x35, 0xdf, 0xa0, 0x5e, 0x8d, 0x2e, 0x83, 0x56, 0x97, 0x77,
+	0x01, 0x2a, 0x29, 0x16, 0xa9, 0x5c, 0x7d, 0x89, 0x86, 0xe6, 0x88, 0x3b, 0x16, 0x5d, 0x4f, 0x46,
+	0xf2, 0x36, 0x23, 0xc0, 0x80, 0xe4, 0x97, 0xb6, 0xbf, 0x36, 0x9d, 0xef, 0x7f, 0x9a, 0x56, 0xc9,
+	0xd7, 0x70, 0xd3, 0x49, 0xf3, 0xa9, 0xa8, 0x15, 0xbb, 0x07, 0x83, 0x5c, 0xd4, 0x3a, 0xcb, 0xe0,
+	0x25, 0x01, 0x52, 0x14, 0x27, 0xff, 0x1a, 0x31, 0xfe, 0x3a, 0x31, 0xc9, 0xef, 0x1e, 0xbc, 0x47,
+	0xe7, 0x76, 0x24, 0x6a, 0x55, 0xca, 0x15, 0xa9, 0x95, 0xbe, 0xb0, 0x39, 0x32, 0xfa, 0x39, 0x05,
+	0xaf, 0x3e, 0xac, 0x81, 0x73, 0x58, 0xec, 0x0e, 0x44, 0x53, 0x21, 0x91, 0xee, 0x57, 0xcb, 0x4d,
+	0x67, 0x48, 0xee, 0x01, 0x50, 0x19, 0xaf, 0x9b, 0x28, 0x3f, 0x7a, 0xb0, 0xdd, 0x05, 0x52, 0xa1,
+	0x5d, 0xdf, 0x78, 0xbd, 0xbe, 0x89, 0x61, 0x4b, 0xf7, 0x0a, 0xd6, 0xb5, 0xe5, 0xad, 0x81, 0x1b,
+	0x29, 0xe0, 0x19, 0x44, 0x5d, 0x4a, 0xbb, 0xbd, 0xd3, 0x6d, 0x98, 0x24, 0xff, 0x35, 0xcf, 0xf5,
+	0x07, 0x1f, 0x86, 0x9f, 0xa7, 0xc5, 0x34, 0xc7, 0xcd, 0xde, 0x1a, 0x15, 0x4a, 0x51, 0x4e, 0x2d,
+	0x07, 0x16, 0xe9, 0x89, 0xa3, 0xaf, 0x52, 0xab, 0x6a, 0x5a, 0x6b, 0x5b, 0x59, 0x61, 0x61, 0x87,
+	0x1d, 0xad, 0xb5, 0x6d, 0x2e, 0x66, 0x73, 0x3b, 0xe3, 0x68, 0xad, 0x67, 0x4c, 0x5e, 0x7e, 0x6b,
+	0x67, 0x9b, 0x5e, 0x12, 0x9b, 0x79, 0x59, 0xa3, 0xbd, 0x66, 0x0d, 0xd0, 0xdf, 0xbe, 0x28, 0xf3,
+	0x65, 0x7b, 0xb7, 0x5a, 0xa4, 0x07, 0xab, 0x5a, 0xca, 0xa2, 0xbc, 0x40, 0x69, 0xe7, 0x5a, 0x8b,
+	0x93, 0xbf, 0x3c, 0x98, 0xd0, 0xe1, 0x1b, 0x66, 0xea, 0xff, 0x81, 0x9a, 0x37, 0x1a, 0x54, 0x7d,
+	0xe9, 0x0c, 0xd7, 0xa5, 0x73, 0x0c, 0x60, 0x0a, 0x22, 0xed, 0x7c, 0xd0, 0xd3, 0xce, 0x5b, 0x36,
+	0x43, 0x13, 0x70, 0x4d, 0xf1, 0x7c, 0x07, 0x63, 0xa2, 0xe9, 0x44, 0x64, 0xe7, 0x1b, 0x7d, 0x76,
+	0x34, 0x42, 0x09, 0x3a, 0xa1, 0x24, 0x3f, 0xfb, 0x30, 0xdc, 0xf8, 0x87, 0xef, 0x40, 0x94, 0xa7,
+	0xb5, 0x7a, 0xee, 0xcc, 0xe4, 0xce, 0xd0, 0x6a, 0x75, 0xe0, 0x68, 0xf5, 0x36, 0x0c, 0xcd, 0x0b,
+	0xdf, 0x2a, 0xd8, 0xa2, 0x6b, 0x6a, 0xb8, 0x53, 0xeb, 0xe8, 0x95, 0x6a, 0x8d, 0xfa, 0x6a, 0x6d,
+	0xc9, 0x01, 0x87, 0x9c, 0x9f, 0xe8, 0xc6, 0x52, 0xd9, 0xfc, 0x10, 0x55, 0x2a, 0x72, 0xfd, 0x8a,
+	0x5c, 0x68, 0x78, 0xdc, 0x9b, 0x76, 0x3d, 0x5b, 0x77, 0xf7, 0xf8, 0x57, 0xdf, 0x3d, 0xfd, 0x17,
+	0xdf, 0x0e, 0x8c, 0x16, 0xe9, 0x39, 0xca, 0x47, 0xd8, 0xf4, 0x6f, 0x8b, 0x29, 0xdb, 0xc6, 0x67,
+	0x1f, 0x2d, 0x0d, 0x4e, 0xfe, 0xf4, 0xe0, 0x26, 0xc7, 0x0c, 0x45, 0xa5, 0x9a, 0xff, 0x42, 0x2c,
+	0x81, 0xb0, 0x74, 0xfe, 0x00, 0xf5, 0xe7, 0x98, 0x71, 0xb1, 0x7d, 0x18, 0x77, 0xd9, 0xea, 0x49,
+	0xfb, 0xf2, 0xc4, 0x73, 0x03, 0xba, 0x97, 0x49, 0xe0, 0xbe, 0x4c, 0x1e, 0x5a, 0x1e, 0x0c, 0x2d,
+	0x75, 0x3c, 0x58, 0xbb, 0x16, 0x5b, 0x17, 0xef, 0xc5, 0xe9, 0x19, 0x7f, 0x86, 0x78, 0xa0, 0x9f,
+	0x47, 0xa1, 0x99, 0xf1, 0x16, 0xde, 0x07, 0xfd, 0x40, 0x33, 0x75, 0x9c, 0x0e, 0xe9, 0xaf, 0xdf,
+	0x83, 0x7f, 0x06, 0x00, 0xea, 0xdb, 0xfe, 0x88, 0x0c, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
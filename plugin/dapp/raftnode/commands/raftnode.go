@@ -0,0 +1,102 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commands
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/33cn/chain33/rpc/jsonclient"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	rt "github.com/33cn/plugin/plugin/dapp/raftnode/types"
+	"github.com/spf13/cobra"
+)
+
+// RaftCmd raftnode cmd register
+func RaftCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "raftnode",
+		Short: "Construct raft membership transactions",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		confChangeCmd(rt.RaftConfChangeAddNode, "Add a raft voting node"),
+		confChangeCmd(rt.RaftConfChangeAddLearnerNode, "Add a raft learner (read only) node"),
+		confChangeCmd(rt.RaftConfChangePromoteLearner, "Promote a raft learner to a voting node"),
+		confChangeCmd(rt.RaftConfChangeRemoveNode, "Remove a raft node"),
+		GetHistoryCmd(),
+	)
+	return cmd
+}
+
+func confChangeCmd(ty int32, short string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   rt.ConfChangeNames[ty],
+		Short: short,
+		Run: func(cmd *cobra.Command, args []string) {
+			confChange(cmd, ty)
+		},
+	}
+	cmd.Flags().Uint64P("id", "i", 0, "raft node id")
+	cmd.MarkFlagRequired("id")
+	if ty == rt.RaftConfChangeAddNode || ty == rt.RaftConfChangeAddLearnerNode {
+		cmd.Flags().StringP("url", "u", "", "raft peer url, e.g. http://127.0.0.1:9021")
+		cmd.MarkFlagRequired("url")
+	}
+	return cmd
+}
+
+func confChange(cmd *cobra.Command, ty int32) {
+	title, _ := cmd.Flags().GetString("title")
+	cfg := types.GetCliSysParam(title)
+	nodeID, _ := cmd.Flags().GetUint64("id")
+	url, _ := cmd.Flags().GetString("url")
+
+	tx, err := rt.CreateConfChangeTx(cfg, &rt.ConfChangeTx{Type: rt.ConfChangeNames[ty], NodeID: nodeID, URL: url})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(hex.EncodeToString(types.Encode(tx)))
+}
+
+// GetHistoryCmd get raft membership history
+func GetHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Get raft membership change history",
+		Run:   getHistory,
+	}
+	cmd.Flags().Int64P("height", "t", 0, "start block height, 0 for the first (or last) record")
+	cmd.Flags().Int32P("index", "x", 0, "start tx index in the block")
+	cmd.Flags().Int32P("count", "c", 10, "record count")
+	cmd.Flags().Int32P("direction", "d", 0, "query direction, 0: desc, 1: asc")
+	return cmd
+}
+
+func getHistory(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	height, _ := cmd.Flags().GetInt64("height")
+	index, _ := cmd.Flags().GetInt32("index")
+	count, _ := cmd.Flags().GetInt32("count")
+	direction, _ := cmd.Flags().GetInt32("direction")
+	req := &rt.ReqRaftConfChangeHistory{
+		Height:    height,
+		Index:     index,
+		Count:     count,
+		Direction: direction,
+	}
+	params := rpctypes.Query4Jrpc{
+		Execer:   rt.RaftNodeX,
+		FuncName: "GetConfChangeHistory",
+		Payload:  types.MustPBToJSON(req),
+	}
+
+	var res rt.RaftConfChangeRecords
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &res)
+	ctx.Run()
+}
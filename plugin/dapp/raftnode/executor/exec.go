@@ -0,0 +1,73 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"errors"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	pty "github.com/33cn/plugin/plugin/dapp/raftnode/types"
+)
+
+const managerKey = "raft-manager"
+
+// Exec_ConfChange 检查管理员权限并记录成员变更, 区块提交后由raft leader执行
+func (r *RaftNode) Exec_ConfChange(cc *pty.RaftConfChange, tx *types.Transaction, index int) (*types.Receipt, error) {
+	if !isValidManager(tx.From(), r.GetStateDB()) {
+		return nil, errors.New("not valid manager")
+	}
+	if err := pty.CheckConfChange(cc); err != nil {
+		return nil, err
+	}
+	record := &pty.RaftConfChangeRecord{
+		ConfChange: cc,
+		Operator:   tx.From(),
+		TxHash:     common.ToHex(tx.Hash()),
+		Height:     r.GetHeight(),
+		Index:      int32(index),
+		BlockTime:  r.GetBlockTime(),
+	}
+	clog.Info("raft conf change", "type", pty.ConfChangeNames[cc.Type], "nodeID", cc.NodeID, "url", cc.Url, "operator", record.Operator)
+	log := &types.ReceiptLog{Ty: pty.TyLogRaftConfChange, Log: types.Encode(record)}
+	receipt := &types.Receipt{Ty: types.ExecOk, KV: nil, Logs: []*types.ReceiptLog{log}}
+	return receipt, nil
+}
+
+func getManageKey(key string, db dbm.KV) ([]byte, error) {
+	manageKey := types.ManageKey(key)
+	value, err := db.Get([]byte(manageKey))
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func isValidManager(addr string, db dbm.KV) bool {
+	value, err := getManageKey(managerKey, db)
+	if err != nil {
+		clog.Error("isValidManager nil key", "managerKey", managerKey)
+		return false
+	}
+	if value == nil {
+		clog.Error("isValidManager nil value")
+		return false
+	}
+
+	var item types.ConfigItem
+	err = types.Decode(value, &item)
+	if err != nil {
+		clog.Error("isValidManager decode fail", "err", err)
+		return false
+	}
+
+	for _, op := range item.GetArr().Value {
+		if op == addr {
+			return true
+		}
+	}
+	return false
+}
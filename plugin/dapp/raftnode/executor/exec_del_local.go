@@ -0,0 +1,22 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"github.com/33cn/chain33/types"
+	pty "github.com/33cn/plugin/plugin/dapp/raftnode/types"
+)
+
+// ExecDelLocal_ConfChange method
+func (r *RaftNode) ExecDelLocal_ConfChange(cc *pty.RaftConfChange, tx *types.Transaction, receipt *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	set := &types.LocalDBSet{}
+	for _, log := range receipt.GetLogs() {
+		if log.Ty == pty.TyLogRaftConfChange {
+			key := CalcConfChangeHeightIndexKey(r.GetHeight(), index)
+			set.KV = append(set.KV, &types.KeyValue{Key: key, Value: nil})
+		}
+	}
+	return set, nil
+}
@@ -0,0 +1,60 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"github.com/33cn/chain33/types"
+	pty "github.com/33cn/plugin/plugin/dapp/raftnode/types"
+)
+
+const maxQueryCount = 100
+
+// Query_GetConfChangeByHeight 查询某个高度的成员变更
+func (r *RaftNode) Query_GetConfChangeByHeight(in *pty.ReqRaftConfChangeByHeight) (types.Message, error) {
+	if in.GetHeight() <= 0 {
+		return nil, types.ErrInvalidParam
+	}
+	values, err := r.GetLocalDB().List(CalcConfChangeHeightKey(in.GetHeight()), nil, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	return decodeConfChangeRecords(values)
+}
+
+// Query_GetConfChangeHistory 分页查询成员变更历史
+func (r *RaftNode) Query_GetConfChangeHistory(in *pty.ReqRaftConfChangeHistory) (types.Message, error) {
+	if in.GetCount() > maxQueryCount || in.GetHeight() < 0 {
+		return nil, types.ErrInvalidParam
+	}
+	count := in.GetCount()
+	if count <= 0 {
+		count = maxQueryCount
+	}
+	var key []byte
+	if in.GetHeight() > 0 {
+		key = CalcConfChangeHeightIndexKey(in.GetHeight(), int(in.GetIndex()))
+	}
+	values, err := r.GetLocalDB().List(CalcConfChangePrefix(), key, count, in.GetDirection())
+	if err != nil {
+		return nil, err
+	}
+	return decodeConfChangeRecords(values)
+}
+
+func decodeConfChangeRecords(values [][]byte) (*pty.RaftConfChangeRecords, error) {
+	if len(values) == 0 {
+		return nil, types.ErrNotFound
+	}
+	reply := &pty.RaftConfChangeRecords{}
+	for _, value := range values {
+		var record pty.RaftConfChangeRecord
+		err := types.Decode(value, &record)
+		if err != nil {
+			return nil, err
+		}
+		reply.Records = append(reply.Records, &record)
+	}
+	return reply, nil
+}
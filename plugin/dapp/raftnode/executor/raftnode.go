@@ -0,0 +1,77 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"fmt"
+
+	log "github.com/33cn/chain33/common/log/log15"
+	drivers "github.com/33cn/chain33/system/dapp"
+	"github.com/33cn/chain33/types"
+)
+
+var clog = log.New("module", "execs.raftnode")
+var driverName = "raftnode"
+
+// Init method
+func Init(name string, cfg *types.Chain33Config, sub []byte) {
+	clog.Debug("register raftnode execer")
+	drivers.Register(cfg, GetName(), newRaftNode, 0)
+	InitExecType()
+}
+
+// InitExecType method
+func InitExecType() {
+	ety := types.LoadExecutorType(driverName)
+	ety.InitFuncList(types.ListMethod(&RaftNode{}))
+}
+
+// GetName method
+func GetName() string {
+	return newRaftNode().GetName()
+}
+
+// RaftNode struct
+type RaftNode struct {
+	drivers.DriverBase
+}
+
+func newRaftNode() drivers.Driver {
+	n := &RaftNode{}
+	n.SetChild(n)
+	n.SetIsFree(true)
+	n.SetExecutorType(types.LoadExecutorType(driverName))
+	return n
+}
+
+// GetDriverName method
+func (r *RaftNode) GetDriverName() string {
+	return driverName
+}
+
+// CheckTx method
+func (r *RaftNode) CheckTx(tx *types.Transaction, index int) error {
+	return nil
+}
+
+// CalcConfChangeHeightIndexKey 成员变更记录的key, 按照区块高度和交易序号排序
+func CalcConfChangeHeightIndexKey(height int64, index int) []byte {
+	return []byte(fmt.Sprintf("LODB-raftnode-ConfChange:%018d:%018d", height, int64(index)))
+}
+
+// CalcConfChangeHeightKey method
+func CalcConfChangeHeightKey(height int64) []byte {
+	return []byte(fmt.Sprintf("LODB-raftnode-ConfChange:%018d:", height))
+}
+
+// CalcConfChangePrefix method
+func CalcConfChangePrefix() []byte {
+	return []byte("LODB-raftnode-ConfChange:")
+}
+
+// CheckReceiptExecOk return true to check if receipt ty is ok
+func (r *RaftNode) CheckReceiptExecOk() bool {
+	return true
+}
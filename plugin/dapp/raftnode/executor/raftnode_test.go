@@ -0,0 +1,102 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"strings"
+	"testing"
+
+	apimock "github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/common/crypto"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	pty "github.com/33cn/plugin/plugin/dapp/raftnode/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	chain33TestCfg = types.NewChain33Config(strings.Replace(types.GetDefaultCfgstring(), "Title=\"local\"", "Title=\"chain33\"", 1))
+	managerPriv    = util.HexToPrivkey("4257D8692EF7FE13C68B65D6A52F03933DB2FA5CE8FAF210B5B8B80C721CED01")
+	otherPriv      = util.HexToPrivkey("CC38546E9E659D15E6B4893F0AB32A06D103931A8230B0BDE71459D2B27D6944")
+)
+
+func init() {
+	Init(pty.RaftNodeX, chain33TestCfg, nil)
+}
+
+func signTestConfChange(t *testing.T, param *pty.ConfChangeTx, priv crypto.PrivKey) (*types.Transaction, *pty.RaftConfChange) {
+	tx, err := pty.CreateConfChangeTx(chain33TestCfg, param)
+	require.Nil(t, err)
+	tx.Sign(types.SECP256K1, priv)
+	var action pty.RaftNodeAction
+	require.Nil(t, types.Decode(tx.Payload, &action))
+	return tx, action.GetConfChange()
+}
+
+func TestConfChange(t *testing.T) {
+	stateDB, _ := dbm.NewGoMemDB("state", "", 100)
+	dir, ldb, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+	api := new(apimock.QueueProtocolAPI)
+	api.On("GetConfig", mock.Anything).Return(chain33TestCfg, nil)
+	r := newRaftNode().(*RaftNode)
+	r.SetAPI(api)
+	r.SetStateDB(stateDB)
+	r.SetLocalDB(kvdb)
+
+	manager := address.PubKeyToAddress(managerPriv.PubKey().Bytes()).String()
+	item := &types.ConfigItem{
+		Key:   managerKey,
+		Value: &types.ConfigItem_Arr{Arr: &types.ArrayConfig{Value: []string{manager}}},
+	}
+	require.Nil(t, stateDB.Set([]byte(types.ManageKey(managerKey)), types.Encode(item)))
+
+	//非管理员不能变更成员
+	tx, cc := signTestConfChange(t, &pty.ConfChangeTx{Type: "add", NodeID: 4, URL: "http://127.0.0.1:9024"}, otherPriv)
+	r.SetEnv(10, 100, 0)
+	_, err := r.Exec_ConfChange(cc, tx, 0)
+	assert.NotNil(t, err)
+
+	tx, cc = signTestConfChange(t, &pty.ConfChangeTx{Type: "add", NodeID: 4, URL: "http://127.0.0.1:9024"}, managerPriv)
+	_, err = r.Exec_ConfChange(&pty.RaftConfChange{Type: pty.RaftConfChangeAddNode, NodeID: 4}, tx, 0)
+	assert.Equal(t, pty.ErrNodeURL, err)
+
+	for i, param := range []*pty.ConfChangeTx{
+		{Type: "add", NodeID: 4, URL: "http://127.0.0.1:9024"},
+		{Type: "remove", NodeID: 2},
+	} {
+		tx, cc = signTestConfChange(t, param, managerPriv)
+		r.SetEnv(int64(10+i), 100, 0)
+		receipt, err := r.Exec_ConfChange(cc, tx, 1)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(receipt.Logs))
+		set, err := r.ExecLocal_ConfChange(cc, tx, &types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs}, 1)
+		require.Nil(t, err)
+		for _, kv := range set.KV {
+			require.Nil(t, kvdb.Set(kv.Key, kv.Value))
+		}
+	}
+
+	reply, err := r.Query_GetConfChangeByHeight(&pty.ReqRaftConfChangeByHeight{Height: 10})
+	require.Nil(t, err)
+	records := reply.(*pty.RaftConfChangeRecords).GetRecords()
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, manager, records[0].Operator)
+	assert.Equal(t, uint64(4), records[0].ConfChange.NodeID)
+	assert.Equal(t, "http://127.0.0.1:9024", records[0].ConfChange.Url)
+	_, err = r.Query_GetConfChangeByHeight(&pty.ReqRaftConfChangeByHeight{Height: 12})
+	assert.Equal(t, types.ErrNotFound, err)
+
+	reply, err = r.Query_GetConfChangeHistory(&pty.ReqRaftConfChangeHistory{Direction: 0})
+	require.Nil(t, err)
+	records = reply.(*pty.RaftConfChangeRecords).GetRecords()
+	require.Equal(t, 2, len(records))
+	assert.Equal(t, int32(pty.RaftConfChangeRemoveNode), records[0].ConfChange.Type)
+	assert.Equal(t, int64(11), records[0].Height)
+}
@@ -0,0 +1,39 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+// RaftNodeX define
+const RaftNodeX = "raftnode"
+
+// raftnode action
+const (
+	RaftNodeActionConfChange = 1
+)
+
+// conf change type
+const (
+	RaftConfChangeAddNode        = 1
+	RaftConfChangeRemoveNode     = 2
+	RaftConfChangeAddLearnerNode = 3
+	RaftConfChangePromoteLearner = 4
+)
+
+// log ty
+const (
+	TyLogRaftConfChange = 1311
+)
+
+// action name
+const (
+	ActionConfChange = "ConfChange"
+)
+
+// ConfChangeNames 成员变更类型的名称
+var ConfChangeNames = map[int32]string{
+	RaftConfChangeAddNode:        "add",
+	RaftConfChangeRemoveNode:     "remove",
+	RaftConfChangeAddLearnerNode: "add_learner",
+	RaftConfChangePromoteLearner: "promote",
+}
@@ -0,0 +1,142 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"reflect"
+
+	"github.com/33cn/chain33/common/address"
+	log "github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/types"
+)
+
+var tlog = log.New("module", "exectype."+RaftNodeX)
+
+// error defines
+var (
+	ErrConfChangeType = errors.New("ErrConfChangeType")
+	ErrNodeID         = errors.New("ErrNodeID")
+	ErrNodeURL        = errors.New("ErrNodeURL")
+)
+
+func init() {
+	types.AllowUserExec = append(types.AllowUserExec, []byte(RaftNodeX))
+	types.RegFork(RaftNodeX, InitFork)
+	types.RegExec(RaftNodeX, InitExecutor)
+}
+
+// InitFork method
+func InitFork(cfg *types.Chain33Config) {
+	cfg.RegisterDappFork(RaftNodeX, "Enable", 0)
+}
+
+// InitExecutor method
+func InitExecutor(cfg *types.Chain33Config) {
+	types.RegistorExecutor(RaftNodeX, NewType(cfg))
+}
+
+// RaftNodeType stuct
+type RaftNodeType struct {
+	types.ExecTypeBase
+}
+
+// NewType method
+func NewType(cfg *types.Chain33Config) *RaftNodeType {
+	c := &RaftNodeType{}
+	c.SetChild(c)
+	c.SetConfig(cfg)
+	return c
+}
+
+// GetName 获取执行器名称
+func (t *RaftNodeType) GetName() string {
+	return RaftNodeX
+}
+
+// GetPayload method
+func (t *RaftNodeType) GetPayload() types.Message {
+	return &RaftNodeAction{}
+}
+
+// GetTypeMap method
+func (t *RaftNodeType) GetTypeMap() map[string]int32 {
+	return map[string]int32{
+		"ConfChange": RaftNodeActionConfChange,
+	}
+}
+
+// GetLogMap method
+func (t *RaftNodeType) GetLogMap() map[int64]*types.LogInfo {
+	return map[int64]*types.LogInfo{
+		TyLogRaftConfChange: {Ty: reflect.TypeOf(RaftConfChangeRecord{}), Name: "LogRaftConfChange"},
+	}
+}
+
+// CreateTx method
+func (t *RaftNodeType) CreateTx(action string, message json.RawMessage) (*types.Transaction, error) {
+	tlog.Debug("raftnode.CreateTx", "action", action)
+	if action == ActionConfChange {
+		var param ConfChangeTx
+		err := json.Unmarshal(message, &param)
+		if err != nil {
+			tlog.Error("raftnode.CreateTx", "err", err)
+			return nil, types.ErrInvalidParam
+		}
+		return CreateConfChangeTx(t.GetConfig(), &param)
+	}
+	return nil, types.ErrNotSupport
+}
+
+// CreateConfChangeTx 构造成员变更交易, 交易需要由raft-manager中配置的管理员签名
+func CreateConfChangeTx(cfg *types.Chain33Config, parm *ConfChangeTx) (*types.Transaction, error) {
+	if parm == nil {
+		tlog.Error("CreateConfChangeTx", "parm", parm)
+		return nil, types.ErrInvalidParam
+	}
+	cc := &RaftConfChange{
+		NodeID: parm.NodeID,
+		Url:    parm.URL,
+	}
+	for ty, name := range ConfChangeNames {
+		if name == parm.Type {
+			cc.Type = ty
+		}
+	}
+	if err := CheckConfChange(cc); err != nil {
+		return nil, err
+	}
+	action := &RaftNodeAction{
+		Ty:    RaftNodeActionConfChange,
+		Value: &RaftNodeAction_ConfChange{ConfChange: cc},
+	}
+
+	execName := cfg.ExecName(RaftNodeX)
+	tx := &types.Transaction{
+		Execer:  []byte(execName),
+		Payload: types.Encode(action),
+		To:      address.ExecAddress(execName),
+	}
+	return types.FormatTx(cfg, execName, tx)
+}
+
+// CheckConfChange 检查成员变更的参数, 新增节点必须提供raft通信地址
+func CheckConfChange(cc *RaftConfChange) error {
+	if _, ok := ConfChangeNames[cc.GetType()]; !ok {
+		return ErrConfChangeType
+	}
+	if cc.GetNodeID() == 0 {
+		return ErrNodeID
+	}
+	if cc.GetType() == RaftConfChangeAddNode || cc.GetType() == RaftConfChangeAddLearnerNode {
+		u, err := url.Parse(cc.GetUrl())
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return ErrNodeURL
+		}
+	}
+	return nil
+}
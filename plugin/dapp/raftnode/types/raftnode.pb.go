@@ -0,0 +1,412 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: raftnode.proto
+
+package types
+
+import (
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/golang/protobuf/proto"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// raft集群成员变更, type为RaftConfChangeAddNode等
+type RaftConfChange struct {
+	Type                 int32    `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	NodeID               uint64   `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	Url                  string   `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RaftConfChange) Reset()         { *m = RaftConfChange{} }
+func (m *RaftConfChange) String() string { return proto.CompactTextString(m) }
+func (*RaftConfChange) ProtoMessage()    {}
+func (*RaftConfChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fa53cf2bc1f8ce39, []int{0}
+}
+
+func (m *RaftConfChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftConfChange.Unmarshal(m, b)
+}
+func (m *RaftConfChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftConfChange.Marshal(b, m, deterministic)
+}
+func (m *RaftConfChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftConfChange.Merge(m, src)
+}
+func (m *RaftConfChange) XXX_Size() int {
+	return xxx_messageInfo_RaftConfChange.Size(m)
+}
+func (m *RaftConfChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftConfChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftConfChange proto.InternalMessageInfo
+
+func (m *RaftConfChange) GetType() int32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *RaftConfChange) GetNodeID() uint64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *RaftConfChange) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+type RaftNodeAction struct {
+	// Types that are valid to be assigned to Value:
+	//	*RaftNodeAction_ConfChange
+	Value                isRaftNodeAction_Value `protobuf_oneof:"value"`
+	Ty                   int32                  `protobuf:"varint,2,opt,name=Ty,proto3" json:"Ty,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *RaftNodeAction) Reset()         { *m = RaftNodeAction{} }
+func (m *RaftNodeAction) String() string { return proto.CompactTextString(m) }
+func (*RaftNodeAction) ProtoMessage()    {}
+func (*RaftNodeAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fa53cf2bc1f8ce39, []int{1}
+}
+
+func (m *RaftNodeAction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftNodeAction.Unmarshal(m, b)
+}
+func (m *RaftNodeAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftNodeAction.Marshal(b, m, deterministic)
+}
+func (m *RaftNodeAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftNodeAction.Merge(m, src)
+}
+func (m *RaftNodeAction) XXX_Size() int {
+	return xxx_messageInfo_RaftNodeAction.Size(m)
+}
+func (m *RaftNodeAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftNodeAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftNodeAction proto.InternalMessageInfo
+
+type isRaftNodeAction_Value interface {
+	isRaftNodeAction_Value()
+}
+
+type RaftNodeAction_ConfChange struct {
+	ConfChange *RaftConfChange `protobuf:"bytes,1,opt,name=confChange,proto3,oneof"`
+}
+
+func (*RaftNodeAction_ConfChange) isRaftNodeAction_Value() {}
+
+func (m *RaftNodeAction) GetValue() isRaftNodeAction_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *RaftNodeAction) GetConfChange() *RaftConfChange {
+	if x, ok := m.GetValue().(*RaftNodeAction_ConfChange); ok {
+		return x.ConfChange
+	}
+	return nil
+}
+
+func (m *RaftNodeAction) GetTy() int32 {
+	if m != nil {
+		return m.Ty
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*RaftNodeAction) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*RaftNodeAction_ConfChange)(nil),
+	}
+}
+
+//成员变更记录, 由执行交易的区块生成, 用于审计
+type RaftConfChangeRecord struct {
+	ConfChange           *RaftConfChange `protobuf:"bytes,1,opt,name=confChange,proto3" json:"confChange,omitempty"`
+	Operator             string          `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	TxHash               string          `protobuf:"bytes,3,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	Height               int64           `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Index                int32           `protobuf:"varint,5,opt,name=index,proto3" json:"index,omitempty"`
+	BlockTime            int64           `protobuf:"varint,6,opt,name=blockTime,proto3" json:"blockTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RaftConfChangeRecord) Reset()         { *m = RaftConfChangeRecord{} }
+func (m *RaftConfChangeRecord) String() string { return proto.CompactTextString(m) }
+func (*RaftConfChangeRecord) ProtoMessage()    {}
+func (*RaftConfChangeRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fa53cf2bc1f8ce39, []int{2}
+}
+
+func (m *RaftConfChangeRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftConfChangeRecord.Unmarshal(m, b)
+}
+func (m *RaftConfChangeRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftConfChangeRecord.Marshal(b, m, deterministic)
+}
+func (m *RaftConfChangeRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftConfChangeRecord.Merge(m, src)
+}
+func (m *RaftConfChangeRecord) XXX_Size() int {
+	return xxx_messageInfo_RaftConfChangeRecord.Size(m)
+}
+func (m *RaftConfChangeRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftConfChangeRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftConfChangeRecord proto.InternalMessageInfo
+
+func (m *RaftConfChangeRecord) GetConfChange() *RaftConfChange {
+	if m != nil {
+		return m.ConfChange
+	}
+	return nil
+}
+
+func (m *RaftConfChangeRecord) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *RaftConfChangeRecord) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *RaftConfChangeRecord) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RaftConfChangeRecord) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *RaftConfChangeRecord) GetBlockTime() int64 {
+	if m != nil {
+		return m.BlockTime
+	}
+	return 0
+}
+
+type RaftConfChangeRecords struct {
+	Records              []*RaftConfChangeRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *RaftConfChangeRecords) Reset()         { *m = RaftConfChangeRecords{} }
+func (m *RaftConfChangeRecords) String() string { return proto.CompactTextString(m) }
+func (*RaftConfChangeRecords) ProtoMessage()    {}
+func (*RaftConfChangeRecords) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fa53cf2bc1f8ce39, []int{3}
+}
+
+func (m *RaftConfChangeRecords) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftConfChangeRecords.Unmarshal(m, b)
+}
+func (m *RaftConfChangeRecords) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftConfChangeRecords.Marshal(b, m, deterministic)
+}
+func (m *RaftConfChangeRecords) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftConfChangeRecords.Merge(m, src)
+}
+func (m *RaftConfChangeRecords) XXX_Size() int {
+	return xxx_messageInfo_RaftConfChangeRecords.Size(m)
+}
+func (m *RaftConfChangeRecords) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftConfChangeRecords.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftConfChangeRecords proto.InternalMessageInfo
+
+func (m *RaftConfChangeRecords) GetRecords() []*RaftConfChangeRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+type ReqRaftConfChangeByHeight struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqRaftConfChangeByHeight) Reset()         { *m = ReqRaftConfChangeByHeight{} }
+func (m *ReqRaftConfChangeByHeight) String() string { return proto.CompactTextString(m) }
+func (*ReqRaftConfChangeByHeight) ProtoMessage()    {}
+func (*ReqRaftConfChangeByHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fa53cf2bc1f8ce39, []int{4}
+}
+
+func (m *ReqRaftConfChangeByHeight) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqRaftConfChangeByHeight.Unmarshal(m, b)
+}
+func (m *ReqRaftConfChangeByHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqRaftConfChangeByHeight.Marshal(b, m, deterministic)
+}
+func (m *ReqRaftConfChangeByHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqRaftConfChangeByHeight.Merge(m, src)
+}
+func (m *ReqRaftConfChangeByHeight) XXX_Size() int {
+	return xxx_messageInfo_ReqRaftConfChangeByHeight.Size(m)
+}
+func (m *ReqRaftConfChangeByHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqRaftConfChangeByHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqRaftConfChangeByHeight proto.InternalMessageInfo
+
+func (m *ReqRaftConfChangeByHeight) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// height和index为0时从头(或尾)开始查询
+type ReqRaftConfChangeHistory struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Index                int32    `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Count                int32    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Direction            int32    `protobuf:"varint,4,opt,name=direction,proto3" json:"direction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqRaftConfChangeHistory) Reset()         { *m = ReqRaftConfChangeHistory{} }
+func (m *ReqRaftConfChangeHistory) String() string { return proto.CompactTextString(m) }
+func (*ReqRaftConfChangeHistory) ProtoMessage()    {}
+func (*ReqRaftConfChangeHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fa53cf2bc1f8ce39, []int{5}
+}
+
+func (m *ReqRaftConfChangeHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqRaftConfChangeHistory.Unmarshal(m, b)
+}
+func (m *ReqRaftConfChangeHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqRaftConfChangeHistory.Marshal(b, m, deterministic)
+}
+func (m *ReqRaftConfChangeHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqRaftConfChangeHistory.Merge(m, src)
+}
+func (m *ReqRaftConfChangeHistory) XXX_Size() int {
+	return xxx_messageInfo_ReqRaftConfChangeHistory.Size(m)
+}
+func (m *ReqRaftConfChangeHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqRaftConfChangeHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqRaftConfChangeHistory proto.InternalMessageInfo
+
+func (m *ReqRaftConfChangeHistory) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ReqRaftConfChangeHistory) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ReqRaftConfChangeHistory) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ReqRaftConfChangeHistory) GetDirection() int32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*RaftConfChange)(nil), "types.RaftConfChange")
+	proto.RegisterType((*RaftNodeAction)(nil), "types.RaftNodeAction")
+	proto.RegisterType((*RaftConfChangeRecord)(nil), "types.RaftConfChangeRecord")
+	proto.RegisterType((*RaftConfChangeRecords)(nil), "types.RaftConfChangeRecords")
+	proto.RegisterType((*ReqRaftConfChangeByHeight)(nil), "types.ReqRaftConfChangeByHeight")
+	proto.RegisterType((*ReqRaftConfChangeHistory)(nil), "types.ReqRaftConfChangeHistory")
+}
+
+func init() {
+	proto.RegisterFile("raftnode.proto", fileDescriptor_fa53cf2bc1f8ce39)
+}
+
+var fileDescriptor_fa53cf2bc1f8ce39 = []byte{
+	// 350 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0xcd, 0x6e, 0xf2, 0x30,
+	0x10, 0xfc, 0x9c, 0x60, 0xf8, 0x58, 0x24, 0x54, 0x59, 0x50, 0xb9, 0x3f, 0x87, 0x28, 0xa7, 0x9c,
+	0x38, 0x80, 0x50, 0xcf, 0x85, 0x1e, 0xd2, 0x0b, 0x87, 0x15, 0x2f, 0x10, 0x12, 0x43, 0xa2, 0xd2,
+	0x98, 0x3a, 0xa6, 0x22, 0x52, 0x5f, 0xb1, 0xef, 0x54, 0xc5, 0x49, 0x49, 0xa2, 0x52, 0xa9, 0xb7,
+	0x9d, 0xf5, 0xce, 0xce, 0xcc, 0xca, 0x30, 0x54, 0xc1, 0x56, 0xa7, 0x32, 0x12, 0x93, 0x83, 0x92,
+	0x5a, 0x32, 0xaa, 0xf3, 0x83, 0xc8, 0xdc, 0x15, 0x0c, 0x31, 0xd8, 0xea, 0xa5, 0x4c, 0xb7, 0xcb,
+	0x38, 0x48, 0x77, 0x82, 0x31, 0xe8, 0x14, 0x4f, 0x9c, 0x38, 0xc4, 0xa3, 0x68, 0x6a, 0x76, 0x0d,
+	0xdd, 0x82, 0xfa, 0xfc, 0xc4, 0x2d, 0x87, 0x78, 0x1d, 0xac, 0x10, 0xbb, 0x02, 0xfb, 0xa8, 0xf6,
+	0xdc, 0x76, 0x88, 0xd7, 0xc7, 0xa2, 0x74, 0x37, 0xe5, 0xbe, 0x95, 0x8c, 0xc4, 0x63, 0xa8, 0x13,
+	0x99, 0xb2, 0x07, 0x80, 0xf0, 0xbc, 0xdd, 0x6c, 0x1d, 0x4c, 0xc7, 0x13, 0xa3, 0x3e, 0x69, 0x4b,
+	0xfb, 0xff, 0xb0, 0x31, 0xca, 0x86, 0x60, 0xad, 0x73, 0x23, 0x48, 0xd1, 0x5a, 0xe7, 0x8b, 0x1e,
+	0xd0, 0xf7, 0x60, 0x7f, 0x14, 0xee, 0x27, 0x81, 0x51, 0x9b, 0x89, 0x22, 0x94, 0x2a, 0x62, 0xf3,
+	0x3f, 0x4b, 0xb5, 0x84, 0x6e, 0xe1, 0xbf, 0x3c, 0x08, 0x15, 0x68, 0xa9, 0x8c, 0x5c, 0x1f, 0xcf,
+	0xb8, 0x48, 0xae, 0x4f, 0x7e, 0x90, 0xc5, 0x55, 0xc8, 0x0a, 0x15, 0xfd, 0x58, 0x24, 0xbb, 0x58,
+	0xf3, 0x8e, 0x43, 0x3c, 0x1b, 0x2b, 0xc4, 0x46, 0x40, 0x93, 0x34, 0x12, 0x27, 0x4e, 0x8d, 0xef,
+	0x12, 0xb0, 0x7b, 0xe8, 0x6f, 0xf6, 0x32, 0x7c, 0x59, 0x27, 0xaf, 0x82, 0x77, 0x0d, 0xa1, 0x6e,
+	0xb8, 0x2b, 0x18, 0x5f, 0x8a, 0x93, 0xb1, 0x39, 0xf4, 0x54, 0x59, 0x72, 0xe2, 0xd8, 0xde, 0x60,
+	0x7a, 0x77, 0x39, 0x8c, 0x99, 0xc1, 0xef, 0x59, 0x77, 0x06, 0x37, 0x28, 0xde, 0xda, 0x33, 0x8b,
+	0xdc, 0x2f, 0x0d, 0xd6, 0xc6, 0x49, 0xd3, 0xb8, 0xfb, 0x01, 0xfc, 0x07, 0xc9, 0x4f, 0x32, 0x2d,
+	0x55, 0xfe, 0x1b, 0xa7, 0x0e, 0x6b, 0x35, 0xc3, 0x8e, 0x80, 0x86, 0xf2, 0x98, 0x6a, 0x73, 0x31,
+	0x8a, 0x25, 0x28, 0x4e, 0x10, 0x25, 0x4a, 0x98, 0x3f, 0x61, 0x6e, 0x46, 0xb1, 0x6e, 0x6c, 0xba,
+	0xe6, 0x53, 0xce, 0xbe, 0x06, 0x00, 0x9b, 0x29, 0xef, 0xcf, 0xa6, 0x02, 0x00, 0x00,
+}
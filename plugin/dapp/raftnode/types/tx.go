@@ -0,0 +1,12 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+// ConfChangeTx for construction
+type ConfChangeTx struct {
+	Type   string `json:"type"`
+	NodeID uint64 `json:"nodeID"`
+	URL    string `json:"url"`
+}
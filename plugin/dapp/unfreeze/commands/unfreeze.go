@@ -30,6 +30,8 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(createCmd())
 	cmd.AddCommand(withdrawCmd())
 	cmd.AddCommand(terminateCmd())
+	cmd.AddCommand(transferCmd())
+	cmd.AddCommand(reduceCmd())
 	cmd.AddCommand(showCmd())
 	cmd.AddCommand(queryWithdrawCmd())
 	cmd.AddCommand(listUnfreezeCmd())
@@ -44,6 +46,8 @@ func createCmd() *cobra.Command {
 
 	cmd.AddCommand(fixAmountCmd())
 	cmd.AddCommand(leftCmd())
+	cmd.AddCommand(cliffLinearCmd())
+	cmd.AddCommand(milestonesCmd())
 	return cmd
 }
 
@@ -205,6 +209,122 @@ func left(cmd *cobra.Command, args []string) {
 	ctx.RunWithoutMarshal()
 }
 
+func cliffLinearCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cliff_linear",
+		Short: "create cliff linear means unfreeze construct",
+		Run:   cliffLinear,
+	}
+	cmd = createFlag(cmd)
+	cmd.Flags().Int64P("cliff_ts", "c", 0, "nothing unfreeze before cliff, UTC timestamp")
+	cmd.MarkFlagRequired("cliff_ts")
+
+	cmd.Flags().Int64P("end_ts", "", 0, "all unfreeze at end, UTC timestamp")
+	cmd.MarkFlagRequired("end_ts")
+
+	cmd.Flags().Int64P("period", "p", 0, "period in second")
+	cmd.MarkFlagRequired("period")
+	return cmd
+}
+
+func cliffLinear(cmd *cobra.Command, args []string) {
+	title, _ := cmd.Flags().GetString("title")
+	cfg := types.GetCliSysParam(title)
+
+	create, err := getCreateFlags(cmd)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+
+	cliffTs, _ := cmd.Flags().GetInt64("cliff_ts")
+	endTs, _ := cmd.Flags().GetInt64("end_ts")
+	period, _ := cmd.Flags().GetInt64("period")
+	if period <= 0 {
+		fmt.Fprintf(os.Stderr, "period must be positive integer")
+		return
+	}
+	if cliffTs < create.StartTime || endTs < cliffTs {
+		fmt.Fprintf(os.Stderr, "must be start_ts <= cliff_ts <= end_ts")
+		return
+	}
+
+	create.Means = pty.CliffLinearX
+	create.MeansOpt = &pty.UnfreezeCreate_CliffLinear{
+		CliffLinear: &pty.CliffLinear{CliffTime: cliffTs, EndTime: endTs, Period: period}}
+
+	params := &rpctypes.CreateTxIn{
+		Execer:     cfg.ExecName(pty.UnfreezeX),
+		ActionName: pty.Action_CreateUnfreeze,
+		Payload:    types.MustPBToJSON(create),
+	}
+
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.CreateTransaction", params, nil)
+	ctx.RunWithoutMarshal()
+}
+
+func milestonesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "milestones",
+		Short: "create custom milestones means unfreeze construct",
+		Run:   milestones,
+	}
+	cmd = createFlag(cmd)
+	cmd.Flags().StringP("milestones", "m", "", "UTC timestamp and accumulative unfreeze amount, like 1600000000:10,1700000000:100")
+	cmd.MarkFlagRequired("milestones")
+	return cmd
+}
+
+func milestones(cmd *cobra.Command, args []string) {
+	title, _ := cmd.Flags().GetString("title")
+	cfg := types.GetCliSysParam(title)
+
+	create, err := getCreateFlags(cmd)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+
+	str, _ := cmd.Flags().GetString("milestones")
+	opt := &pty.Milestones{}
+	for _, item := range strings.Split(str, ",") {
+		var ts int64
+		var amount float64
+		if _, err := fmt.Sscanf(strings.TrimSpace(item), "%d:%f", &ts, &amount); err != nil {
+			fmt.Fprintln(os.Stderr, "bad milestone", item, err)
+			return
+		}
+		if err = checkAmount(amount); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		amountInt64 := int64(math.Trunc((amount+0.0000001)*1e4)) * 1e4
+		opt.Milestones = append(opt.Milestones, &pty.Milestone{Time: ts, Amount: amountInt64})
+	}
+	if len(opt.Milestones) > pty.MaxMilestones {
+		fmt.Fprintf(os.Stderr, "milestones must less than %d", pty.MaxMilestones)
+		return
+	}
+	if opt.Milestones[len(opt.Milestones)-1].Amount != create.TotalCount {
+		fmt.Fprintf(os.Stderr, "last milestone amount must equal to total")
+		return
+	}
+
+	create.Means = pty.MilestonesX
+	create.MeansOpt = &pty.UnfreezeCreate_Milestones{Milestones: opt}
+
+	params := &rpctypes.CreateTxIn{
+		Execer:     cfg.ExecName(pty.UnfreezeX),
+		ActionName: pty.Action_CreateUnfreeze,
+		Payload:    types.MustPBToJSON(create),
+	}
+
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.CreateTransaction", params, nil)
+	ctx.RunWithoutMarshal()
+}
+
 func withdrawCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "withdraw",
@@ -229,6 +349,75 @@ func terminateCmd() *cobra.Command {
 	return cmd
 }
 
+func transferCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer",
+		Short: "transfer construct to new beneficiary",
+		Run:   transfer,
+	}
+	cmd.Flags().StringP("id", "", "", "unfreeze construct id")
+	cmd.MarkFlagRequired("id")
+
+	cmd.Flags().StringP("beneficiary", "b", "", "address of new beneficiary")
+	cmd.MarkFlagRequired("beneficiary")
+	return cmd
+}
+
+func transfer(cmd *cobra.Command, args []string) {
+	title, _ := cmd.Flags().GetString("title")
+	cfg := types.GetCliSysParam(title)
+
+	id, _ := cmd.Flags().GetString("id")
+	beneficiary, _ := cmd.Flags().GetString("beneficiary")
+
+	params := &rpctypes.CreateTxIn{
+		Execer:     cfg.ExecName(pty.UnfreezeX),
+		ActionName: pty.Action_TransferUnfreeze,
+		Payload:    types.MustPBToJSON(&pty.UnfreezeTransfer{UnfreezeID: id, Beneficiary: beneficiary}),
+	}
+
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.CreateTransaction", params, nil)
+	ctx.RunWithoutMarshal()
+}
+
+func reduceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reduce",
+		Short: "reduce frozen amount of construct",
+		Run:   reduce,
+	}
+	cmd.Flags().StringP("id", "", "", "unfreeze construct id")
+	cmd.MarkFlagRequired("id")
+
+	cmd.Flags().Float64P("amount", "a", 0, "amount to reduce")
+	cmd.MarkFlagRequired("amount")
+	return cmd
+}
+
+func reduce(cmd *cobra.Command, args []string) {
+	title, _ := cmd.Flags().GetString("title")
+	cfg := types.GetCliSysParam(title)
+
+	id, _ := cmd.Flags().GetString("id")
+	amount, _ := cmd.Flags().GetFloat64("amount")
+	if amount <= 0 || checkAmount(amount) != nil {
+		fmt.Fprintln(os.Stderr, types.ErrAmount)
+		return
+	}
+	amountInt64 := int64(math.Trunc((amount+0.0000001)*1e4)) * 1e4
+
+	params := &rpctypes.CreateTxIn{
+		Execer:     cfg.ExecName(pty.UnfreezeX),
+		ActionName: pty.Action_ReduceUnfreeze,
+		Payload:    types.MustPBToJSON(&pty.UnfreezeReduce{UnfreezeID: id, Amount: amountInt64}),
+	}
+
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.CreateTransaction", params, nil)
+	ctx.RunWithoutMarshal()
+}
+
 func showCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "show",
@@ -6,14 +6,20 @@
 // 功能描述：定期解冻合约帮助用户锁定一定量的币， 按在指定的规制解冻给受益人，
 // 适用于分期付款， 分期支付形式的员工激励等情景。
 //
-// 合约提供了3类操作
+// 合约提供了5类操作
 //  1. 创建定期解冻合约：创建时需要指定支付的资产和总量，以及定期解冻的形式。
 //  1. 受益人提币：受益人提走解冻了的资产。
 //  1. 发起人终止合约： 发起人可以终止合约的履行。
+//  1. 受益人转移合约：已经解冻的资产提给原受益人，剩余未解冻的资产改由新的受益人接收。
+//  1. 发起人减少冻结数量：发起人取回一部分还没有解冻的资产，减少的数量从解冻计划的末尾扣除，已经解冻的部分不受影响。
+// 后两类操作在ForkUnfreezeTransfer之后才能使用。
 //
-// 解冻的形式目前支持两种
+// 解冻的形式目前支持四种
 //  1. 固定数额解冻：指定时间间隔，解冻固定的资产。
 //  1. 按剩余量的固定比例解冻：指定时间间隔，按剩余量的固定比例解冻。 这种方式，越到后面解冻的越少。
+//  1. 悬崖期加线性解冻：悬崖期之前不解冻，之后按从开始时间经过的周期数线性解冻，到结束时间全部解冻。
+//  1. 自定义时间点解冻：指定一组时间点和到该时间点累计解冻的数量，最后一个时间点全部解冻。
+// 后两种形式在ForkUnfreezeVesting之后才能创建。
 // 说明：在合约创建时， 就可以解冻一次。
 // 举例1， 一个固定数额解冻和合约， 总量为100, 一个月解冻10. 创建时可以由受益人提走10, 第一个月后又可以提走10.
 //       在受益人没有及时提币的情况下， 受益人在一段时间之后可以一次性提走本该解冻的所有的币。 即解冻的币是按指定
@@ -21,5 +27,7 @@
 // 举例2， 一个按剩余量的固定比例解冻的合约， 总量为100, 一个月解冻剩余的10%. 创建时可以由受益人提走10 （100× 10%）, 第一个月后又可以提走9 （90 × 10%）.
 //       在受益人没有及时提币的情况下， 受益人在一段时间之后可以一次性提走本该解冻的所有的币。 即解冻的币是按指定
 //       形式解冻的，和受益人的提币时间和次数等都不会影响解冻的进程。
+// 举例3， 一个悬崖期加线性解冻的合约， 总量为120, 按月解冻, 一年后结束, 悬崖期为3个月. 前3个月不能提币,
+//       第3个月后可以一次提走30, 之后每个月可以提走10.
 
 package unfreeze
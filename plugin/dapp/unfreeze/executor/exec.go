@@ -6,6 +6,7 @@ package executor
 
 import (
 	"github.com/33cn/chain33/account"
+	"github.com/33cn/chain33/common/address"
 	dbm "github.com/33cn/chain33/common/db"
 	"github.com/33cn/chain33/system/dapp"
 	"github.com/33cn/chain33/types"
@@ -122,6 +123,95 @@ func (u *Unfreeze) Exec_Terminate(payload *pty.UnfreezeTerminate, tx *types.Tran
 	return mergeReceipt(receipt, receipt1)
 }
 
+// Exec_Transfer 执行受益人转移冻结合约
+func (u *Unfreeze) Exec_Transfer(payload *pty.UnfreezeTransfer, tx *types.Transaction, index int) (*types.Receipt, error) {
+	cfg := u.GetAPI().GetConfig()
+	if !cfg.IsDappFork(u.GetHeight(), pty.UnfreezeX, pty.ForkUnfreezeTransferX) {
+		return nil, types.ErrNotSupport
+	}
+	payload.UnfreezeID = unfreezeIDFromHex(payload.UnfreezeID)
+	unfreeze, err := loadUnfreeze(payload.UnfreezeID, u.GetStateDB())
+	if err != nil {
+		return nil, err
+	}
+	if unfreeze.Beneficiary != tx.From() {
+		uflog.Error("unfreeze transfer no privilege", "beneficiary", unfreeze.Beneficiary, "txFrom", tx.From())
+		return nil, pty.ErrNoPrivilege
+	}
+	if payload.Beneficiary == unfreeze.Beneficiary || address.CheckAddress(payload.Beneficiary) != nil {
+		uflog.Error("unfreeze transfer beneficiary", "beneficiary", payload.Beneficiary)
+		return nil, pty.ErrBeneficiary
+	}
+	if unfreeze.Remaining <= 0 {
+		uflog.Error("unfreeze transfer no asset")
+		return nil, pty.ErrUnfreezeEmptied
+	}
+
+	amount, receipt1, err := u.transfer(unfreeze, payload.Beneficiary)
+	if err != nil {
+		uflog.Error("unfreeze transfer", "err", err, "unfreeze", unfreeze)
+		return nil, err
+	}
+	if amount == 0 {
+		return receipt1, nil
+	}
+
+	//已经解冻的部分提给原来的受益人
+	acc, err := account.NewAccountDB(cfg, unfreeze.AssetExec, unfreeze.AssetSymbol, u.GetStateDB())
+	if err != nil {
+		return nil, err
+	}
+	execAddr := dapp.ExecAddress(string(tx.Execer))
+	receipt, err := acc.ExecTransferFrozen(unfreeze.Initiator, tx.From(), execAddr, amount)
+	if err != nil {
+		uflog.Error("unfreeze transfer withdraw", "execaddr", execAddr, "err", err, "from", unfreeze.Initiator,
+			"withdraw", amount)
+		return nil, err
+	}
+	return mergeReceipt(receipt, receipt1)
+}
+
+// Exec_Reduce 执行创建者减少未解冻的数量
+func (u *Unfreeze) Exec_Reduce(payload *pty.UnfreezeReduce, tx *types.Transaction, index int) (*types.Receipt, error) {
+	cfg := u.GetAPI().GetConfig()
+	if !cfg.IsDappFork(u.GetHeight(), pty.UnfreezeX, pty.ForkUnfreezeTransferX) {
+		return nil, types.ErrNotSupport
+	}
+	if payload.Amount <= 0 {
+		return nil, types.ErrInvalidParam
+	}
+	payload.UnfreezeID = unfreezeIDFromHex(payload.UnfreezeID)
+	unfreeze, err := loadUnfreeze(payload.UnfreezeID, u.GetStateDB())
+	if err != nil {
+		return nil, err
+	}
+	if tx.From() != unfreeze.Initiator {
+		uflog.Error("unfreeze reduce no privilege", "initiator", unfreeze.Initiator, "from", tx.From())
+		return nil, pty.ErrNoPrivilege
+	}
+	if unfreeze.Terminated {
+		return nil, pty.ErrTerminated
+	}
+
+	receipt1, err := u.reduce(unfreeze, payload.Amount)
+	if err != nil {
+		uflog.Error("unfreeze reduce", "err", err, "unfreeze", unfreeze, "amount", payload.Amount)
+		return nil, err
+	}
+
+	acc, err := account.NewAccountDB(cfg, unfreeze.AssetExec, unfreeze.AssetSymbol, u.GetStateDB())
+	if err != nil {
+		return nil, err
+	}
+	execAddr := dapp.ExecAddress(string(tx.Execer))
+	receipt, err := acc.ExecActive(unfreeze.Initiator, execAddr, payload.Amount)
+	if err != nil {
+		uflog.Error("unfreeze reduce ", "addr", unfreeze.Initiator, "execaddr", execAddr, "err", err)
+		return nil, err
+	}
+	return mergeReceipt(receipt, receipt1)
+}
+
 func (u *Unfreeze) newEntity(payload *pty.UnfreezeCreate, tx *types.Transaction) (*pty.Unfreeze, error) {
 	id := unfreezeID(tx.Hash())
 	unfreeze := &pty.Unfreeze{
@@ -139,6 +229,10 @@ func (u *Unfreeze) newEntity(payload *pty.UnfreezeCreate, tx *types.Transaction)
 		unfreeze.StartTime = u.GetBlockTime()
 	}
 	cfg := u.GetAPI().GetConfig()
+	if (payload.Means == pty.CliffLinearX || payload.Means == pty.MilestonesX) &&
+		!cfg.IsDappFork(u.GetHeight(), pty.UnfreezeX, pty.ForkUnfreezeVestingX) {
+		return nil, types.ErrNotSupport
+	}
 	means, err := newMeans(cfg, payload.Means, u.GetHeight())
 	if err != nil {
 		return nil, err
@@ -188,7 +282,7 @@ func (u *Unfreeze) withdraw(unfreeze *pty.Unfreeze) (int64, *types.Receipt, erro
 		return 0, nil, err
 
 	}
-	frozen, err := means.calcFrozen(unfreeze, u.GetBlockTime())
+	frozen, err := frozenAmount(means, unfreeze, u.GetBlockTime())
 	if err != nil {
 		return 0, nil, err
 	}
@@ -207,6 +301,63 @@ func (u *Unfreeze) withdraw(unfreeze *pty.Unfreeze) (int64, *types.Receipt, erro
 		Logs: []*types.ReceiptLog{receiptLog}}, nil
 }
 
+// 转移受益人, 已经解冻的部分提给原来的受益人
+func (u *Unfreeze) transfer(unfreeze *pty.Unfreeze, beneficiary string) (int64, *types.Receipt, error) {
+	cfg := u.GetAPI().GetConfig()
+	means, err := newMeans(cfg, unfreeze.Means, u.GetHeight())
+	if err != nil {
+		return 0, nil, err
+	}
+	frozen, err := frozenAmount(means, unfreeze, u.GetBlockTime())
+	if err != nil {
+		return 0, nil, err
+	}
+	unfreezeOld := *unfreeze
+	unfreeze, amount := withdraw(unfreeze, frozen)
+	unfreeze.Beneficiary = beneficiary
+	receiptLog := getUnfreezeLog(&unfreezeOld, unfreeze, pty.TyLogTransferUnfreeze)
+
+	k := []byte(unfreeze.UnfreezeID)
+	v := types.Encode(unfreeze)
+	err = u.GetStateDB().Set(k, v)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return amount, &types.Receipt{Ty: types.ExecOk, KV: []*types.KeyValue{{Key: k, Value: v}},
+		Logs: []*types.ReceiptLog{receiptLog}}, nil
+}
+
+// 减少未解冻的数量, 只能减少还没有解冻的部分
+func (u *Unfreeze) reduce(unfreeze *pty.Unfreeze, amount int64) (*types.Receipt, error) {
+	cfg := u.GetAPI().GetConfig()
+	means, err := newMeans(cfg, unfreeze.Means, u.GetHeight())
+	if err != nil {
+		return nil, err
+	}
+	frozen, err := frozenAmount(means, unfreeze, u.GetBlockTime())
+	if err != nil {
+		return nil, err
+	}
+	if amount > frozen {
+		return nil, pty.ErrReduceAmount
+	}
+	unfreezeOld := *unfreeze
+	unfreeze.Reduced += amount
+	unfreeze.Remaining -= amount
+	receiptLog := getUnfreezeLog(&unfreezeOld, unfreeze, pty.TyLogReduceUnfreeze)
+
+	k := []byte(unfreeze.UnfreezeID)
+	v := types.Encode(unfreeze)
+	err = u.GetStateDB().Set(k, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Receipt{Ty: types.ExecOk, KV: []*types.KeyValue{{Key: k, Value: v}},
+		Logs: []*types.ReceiptLog{receiptLog}}, nil
+}
+
 // 中止定期解冻
 func (u *Unfreeze) terminator(unfreeze *pty.Unfreeze) (int64, *types.Receipt, error) {
 	if unfreeze.Remaining <= 0 {
@@ -224,7 +375,7 @@ func (u *Unfreeze) terminator(unfreeze *pty.Unfreeze) (int64, *types.Receipt, er
 		if err != nil {
 			return 0, nil, err
 		}
-		frozen, err := m.calcFrozen(unfreeze, u.GetBlockTime())
+		frozen, err := frozenAmount(m, unfreeze, u.GetBlockTime())
 		if err != nil {
 			return 0, nil, err
 		}
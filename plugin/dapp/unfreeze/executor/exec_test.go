@@ -292,3 +292,190 @@ func signTx(tx *types.Transaction, hexPrivKey string) (*types.Transaction, error
 	tx.Sign(int32(signType), privKey)
 	return tx, nil
 }
+
+func TestUnfreezeVesting(t *testing.T) {
+	total := int64(100000)
+	execAddr := address.ExecAddress(pty.UnfreezeX)
+	stateDB, _ := dbm.NewGoMemDB("1", "2", 100)
+	_, ldb, kvdb := util.CreateTestDB()
+	defer ldb.Close()
+
+	accA, _ := account.NewAccountDB(chain33TestCfg, AssetExecPara, Symbol, stateDB)
+	accA.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: string(Nodes[0])})
+	accB, _ := account.NewAccountDB(chain33TestCfg, AssetExecPara, Symbol, stateDB)
+
+	ty := pty.UnfreezeType{}
+	ty.SetConfig(chain33TestCfg)
+	api := new(apimock.QueueProtocolAPI)
+	api.On("GetConfig", mock.Anything).Return(chain33TestCfg, nil)
+	exec := newUnfreeze()
+	exec.SetAPI(api)
+	exec.SetStateDB(stateDB)
+	exec.SetLocalDB(kvdb)
+
+	p1 := &pty.UnfreezeCreate{
+		StartTime:   1000,
+		AssetExec:   AssetExecPara,
+		AssetSymbol: Symbol,
+		TotalCount:  10000,
+		Beneficiary: string(Nodes[1]),
+		Means:       pty.MilestonesX,
+		MeansOpt: &pty.UnfreezeCreate_Milestones{Milestones: &pty.Milestones{Milestones: []*pty.Milestone{
+			{Time: 1100, Amount: 3000},
+			{Time: 1200, Amount: 10000},
+		}}},
+	}
+	createTx, err := ty.RPC_UnfreezeCreateTx(p1)
+	assert.Nil(t, err)
+	createTx, err = signTx(createTx, PrivKeyA)
+	assert.Nil(t, err)
+
+	// 分叉之前不支持
+	height := int64(1500000)
+	exec.SetEnv(height, 1000, 0)
+	_, err = exec.Exec(createTx, 1)
+	assert.Equal(t, types.ErrNotSupport, err)
+
+	chain33TestCfg.SetDappFork(pty.UnfreezeX, pty.ForkUnfreezeVestingX, height)
+	_, err = exec.Exec(createTx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, p1.TotalCount, accA.LoadExecAccount(string(Nodes[0]), execAddr).Frozen)
+
+	unfreezeID := hex.EncodeToString(createTx.Hash())
+	withdrawTx, err := ty.RPC_UnfreezeWithdrawTx(&pty.UnfreezeWithdraw{UnfreezeID: unfreezeID})
+	assert.Nil(t, err)
+	withdrawTx, err = signTx(withdrawTx, PrivKeyB)
+	assert.Nil(t, err)
+
+	// 第一个时间点之前没有可提币量
+	exec.SetEnv(height+1, 1099, 0)
+	_, err = exec.Exec(withdrawTx, 1)
+	assert.Equal(t, types.ErrAmount, err)
+
+	exec.SetEnv(height+1, 1150, 0)
+	_, err = exec.Exec(withdrawTx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3000), accB.LoadExecAccount(string(Nodes[1]), execAddr).Balance)
+
+	// 终止后剩余冻结的部分退回给创建者
+	terminateTx, err := ty.RPC_UnfreezeTerminateTx(&pty.UnfreezeTerminate{UnfreezeID: unfreezeID})
+	assert.Nil(t, err)
+	terminateTx, err = signTx(terminateTx, PrivKeyA)
+	assert.Nil(t, err)
+	exec.SetEnv(height+2, 1160, 0)
+	_, err = exec.Exec(terminateTx, 1)
+	assert.Nil(t, err)
+	accATmp := accA.LoadExecAccount(string(Nodes[0]), execAddr)
+	assert.Equal(t, total-3000, accATmp.Balance)
+	assert.Equal(t, int64(0), accATmp.Frozen)
+}
+
+func TestUnfreezeTransferReduce(t *testing.T) {
+	total := int64(100000)
+	execAddr := address.ExecAddress(pty.UnfreezeX)
+	stateDB, _ := dbm.NewGoMemDB("1", "2", 100)
+	_, ldb, kvdb := util.CreateTestDB()
+	defer ldb.Close()
+
+	accA, _ := account.NewAccountDB(chain33TestCfg, AssetExecPara, Symbol, stateDB)
+	accA.SaveExecAccount(execAddr, &types.Account{Balance: total, Addr: string(Nodes[0])})
+
+	ty := pty.UnfreezeType{}
+	ty.SetConfig(chain33TestCfg)
+	api := new(apimock.QueueProtocolAPI)
+	api.On("GetConfig", mock.Anything).Return(chain33TestCfg, nil)
+	exec := newUnfreeze()
+	exec.SetAPI(api)
+	exec.SetStateDB(stateDB)
+	exec.SetLocalDB(kvdb)
+
+	height := int64(1600000)
+	execTx := func(tx *types.Transaction, key string, blockTime int64) (*types.ReceiptData, error) {
+		tx, err := signTx(tx, key)
+		assert.Nil(t, err)
+		exec.SetEnv(height, blockTime, 0)
+		receipt, err := exec.Exec(tx, 1)
+		if err != nil {
+			return nil, err
+		}
+		receiptData := &types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs}
+		_, err = exec.ExecLocal(tx, receiptData, 1)
+		assert.Nil(t, err)
+		return receiptData, nil
+	}
+	listByBeneficiary := func(addr string) int {
+		reply, err := exec.Query("ListUnfreezeByBeneficiary", types.Encode(&pty.ReqUnfreezes{Beneficiary: addr}))
+		if err == types.ErrNotFound {
+			return 0
+		}
+		assert.Nil(t, err)
+		return len(reply.(*pty.ReplyUnfreezes).Unfreeze)
+	}
+
+	// 每10秒解冻1000
+	createTx, err := ty.RPC_UnfreezeCreateTx(&pty.UnfreezeCreate{
+		StartTime:   1000,
+		AssetExec:   AssetExecPara,
+		AssetSymbol: Symbol,
+		TotalCount:  10000,
+		Beneficiary: string(Nodes[1]),
+		Means:       pty.FixAmountX,
+		MeansOpt:    &pty.UnfreezeCreate_FixAmount{FixAmount: &pty.FixAmount{Period: 10, Amount: 1000}},
+	})
+	assert.Nil(t, err)
+	_, err = execTx(createTx, PrivKeyA, 1000)
+	assert.Nil(t, err)
+	unfreezeID := hex.EncodeToString(createTx.Hash())
+
+	transferTx, err := ty.RPC_UnfreezeTransferTx(&pty.UnfreezeTransfer{UnfreezeID: unfreezeID, Beneficiary: string(Nodes[2])})
+	assert.Nil(t, err)
+	reduceTx, err := ty.RPC_UnfreezeReduceTx(&pty.UnfreezeReduce{UnfreezeID: unfreezeID, Amount: 3000})
+	assert.Nil(t, err)
+
+	// 分叉之前不支持
+	_, err = execTx(transferTx, PrivKeyB, 1010)
+	assert.Equal(t, types.ErrNotSupport, err)
+	chain33TestCfg.SetDappFork(pty.UnfreezeX, pty.ForkUnfreezeTransferX, height)
+
+	// 只有受益人可以转移, 只有创建者可以减少
+	_, err = execTx(transferTx, PrivKeyA, 1010)
+	assert.Equal(t, pty.ErrNoPrivilege, err)
+	_, err = execTx(reduceTx, PrivKeyB, 1010)
+	assert.Equal(t, pty.ErrNoPrivilege, err)
+
+	// 转移时已经解冻的2000提给原来的受益人
+	transferData, err := execTx(transferTx, PrivKeyB, 1010)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2000), accA.LoadExecAccount(string(Nodes[1]), execAddr).Balance)
+	assert.Equal(t, 0, listByBeneficiary(string(Nodes[1])))
+	assert.Equal(t, 1, listByBeneficiary(string(Nodes[2])))
+
+	_, err = execTx(transferTx, PrivKeyC, 1010)
+	assert.Equal(t, pty.ErrBeneficiary, err)
+
+	// 减少的部分从末尾扣除, 退回给创建者
+	_, err = execTx(reduceTx, PrivKeyA, 1020)
+	assert.Nil(t, err)
+	accATmp := accA.LoadExecAccount(string(Nodes[0]), execAddr)
+	assert.Equal(t, total-7000, accATmp.Balance)
+	assert.Equal(t, int64(5000), accATmp.Frozen)
+
+	reduceTx, err = ty.RPC_UnfreezeReduceTx(&pty.UnfreezeReduce{UnfreezeID: unfreezeID, Amount: 5000})
+	assert.Nil(t, err)
+	_, err = execTx(reduceTx, PrivKeyA, 1020)
+	assert.Equal(t, pty.ErrReduceAmount, err)
+
+	withdrawTx, err := ty.RPC_UnfreezeWithdrawTx(&pty.UnfreezeWithdraw{UnfreezeID: unfreezeID})
+	assert.Nil(t, err)
+	_, err = execTx(withdrawTx, PrivKeyC, 1100)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5000), accA.LoadExecAccount(string(Nodes[2]), execAddr).Balance)
+	assert.Equal(t, int64(0), accA.LoadExecAccount(string(Nodes[0]), execAddr).Frozen)
+
+	// 回滚转移后索引恢复
+	exec.SetEnv(height, 1010, 0)
+	_, err = exec.ExecDelLocal(transferTx, transferData, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, listByBeneficiary(string(Nodes[1])))
+	assert.Equal(t, 0, listByBeneficiary(string(Nodes[2])))
+}
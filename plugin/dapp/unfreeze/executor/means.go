@@ -5,6 +5,8 @@
 package executor
 
 import (
+	"math/big"
+
 	"github.com/33cn/chain33/types"
 	pty "github.com/33cn/plugin/plugin/dapp/unfreeze/types"
 )
@@ -21,6 +23,10 @@ func newMeans(cfg *types.Chain33Config, means string, height int64) (Means, erro
 			return &fixAmountV2{}, nil
 		} else if means == "LeftProportion" {
 			return &leftProportionV2{}, nil
+		} else if means == pty.CliffLinearX {
+			return &cliffLinear{}, nil
+		} else if means == pty.MilestonesX {
+			return &milestones{}, nil
 		}
 		return nil, types.ErrNotSupport
 	}
@@ -88,6 +94,18 @@ func (opt *leftProportion) calcFrozen(unfreeze *pty.Unfreeze, now int64) (int64,
 	return int64(frozen), nil
 }
 
+//创建者减少的数量从解冻计划的末尾扣除
+func frozenAmount(means Means, unfreeze *pty.Unfreeze, now int64) (int64, error) {
+	frozen, err := means.calcFrozen(unfreeze, now)
+	if err != nil {
+		return 0, err
+	}
+	if frozen <= unfreeze.Reduced {
+		return 0, nil
+	}
+	return frozen - unfreeze.Reduced, nil
+}
+
 func withdraw(unfreeze *pty.Unfreeze, frozen int64) (*pty.Unfreeze, int64) {
 	if unfreeze.Remaining == 0 {
 		return unfreeze, 0
@@ -158,3 +176,84 @@ func (opt *leftProportionV2) calcFrozen(unfreeze *pty.Unfreeze, now int64) (int6
 	}
 	return int64(frozen), nil
 }
+
+type cliffLinear struct {
+}
+
+func (opt *cliffLinear) setOpt(unfreeze *pty.Unfreeze, from *pty.UnfreezeCreate) (*pty.Unfreeze, error) {
+	o := from.GetCliffLinear()
+	if o == nil {
+		return nil, types.ErrInvalidParam
+	}
+	if o.Period <= 0 || o.CliffTime < unfreeze.StartTime || o.EndTime < o.CliffTime ||
+		o.EndTime-unfreeze.StartTime < o.Period {
+		return nil, types.ErrInvalidParam
+	}
+	unfreeze.MeansOpt = &pty.Unfreeze_CliffLinear{CliffLinear: o}
+	return unfreeze, nil
+}
+
+//悬崖期之前全部冻结, 悬崖期之后按从startTime开始经过的周期数线性解冻
+func (opt *cliffLinear) calcFrozen(unfreeze *pty.Unfreeze, now int64) (int64, error) {
+	means := unfreeze.GetCliffLinear()
+	if means == nil {
+		return 0, types.ErrInvalidParam
+	}
+	if unfreeze.Terminated || now >= means.EndTime {
+		return 0, nil
+	}
+	if now < means.CliffTime {
+		return unfreeze.TotalCount, nil
+	}
+	totalTimes := (means.EndTime - unfreeze.StartTime + means.Period - 1) / means.Period
+	unfreezeTimes := (now - unfreeze.StartTime) / means.Period
+	//用big.Int计算避免TotalCount*unfreezeTimes溢出
+	amount := big.NewInt(unfreeze.TotalCount)
+	amount.Mul(amount, big.NewInt(unfreezeTimes))
+	amount.Quo(amount, big.NewInt(totalTimes))
+	return unfreeze.TotalCount - amount.Int64(), nil
+}
+
+type milestones struct {
+}
+
+func (opt *milestones) setOpt(unfreeze *pty.Unfreeze, from *pty.UnfreezeCreate) (*pty.Unfreeze, error) {
+	o := from.GetMilestones()
+	if o == nil || len(o.Milestones) == 0 || len(o.Milestones) > pty.MaxMilestones {
+		return nil, types.ErrInvalidParam
+	}
+	lastTime, lastAmount := unfreeze.StartTime-1, int64(0)
+	for _, m := range o.Milestones {
+		if m == nil || m.Time <= lastTime || m.Amount <= lastAmount {
+			return nil, types.ErrInvalidParam
+		}
+		lastTime, lastAmount = m.Time, m.Amount
+	}
+	//最后一个时间点必须全部解冻
+	if lastAmount != unfreeze.TotalCount {
+		return nil, types.ErrInvalidParam
+	}
+	unfreeze.MeansOpt = &pty.Unfreeze_Milestones{Milestones: o}
+	return unfreeze, nil
+}
+
+func (opt *milestones) calcFrozen(unfreeze *pty.Unfreeze, now int64) (int64, error) {
+	means := unfreeze.GetMilestones()
+	if means == nil {
+		return 0, types.ErrInvalidParam
+	}
+	if unfreeze.Terminated {
+		return 0, nil
+	}
+	var unfreezeAmount int64
+	for _, m := range means.Milestones {
+		if now < m.Time {
+			break
+		}
+		unfreezeAmount = m.Amount
+	}
+	if unfreeze.TotalCount <= unfreezeAmount {
+		return 0, nil
+	}
+	return unfreeze.TotalCount - unfreezeAmount, nil
+}
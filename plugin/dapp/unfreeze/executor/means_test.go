@@ -3,6 +3,7 @@ package executor
 import (
 	"testing"
 
+	"github.com/33cn/chain33/types"
 	"github.com/stretchr/testify/assert"
 
 	pty "github.com/33cn/plugin/plugin/dapp/unfreeze/types"
@@ -233,3 +234,106 @@ func TestLeftV2(t *testing.T) {
 		})
 	}
 }
+
+func TestCliffLinear(t *testing.T) {
+	m, err := newMeans(chain33TestCfg, pty.CliffLinearX, 15000000)
+	assert.Nil(t, err)
+
+	opt := &pty.CliffLinear{CliffTime: 10300, EndTime: 11000, Period: 100}
+	create := &pty.UnfreezeCreate{
+		TotalCount: 1000,
+		Means:      pty.CliffLinearX,
+		MeansOpt:   &pty.UnfreezeCreate_CliffLinear{CliffLinear: opt},
+	}
+	u, err := m.setOpt(&pty.Unfreeze{StartTime: 10000, TotalCount: 1000}, create)
+	assert.Nil(t, err)
+
+	cases := []struct {
+		now    int64
+		expect int64
+	}{
+		{9000, 1000},
+		{10299, 1000},
+		{10300, 700},
+		{10399, 700},
+		{10400, 600},
+		{10999, 100},
+		{11000, 0},
+		{12000, 0},
+	}
+	for _, c := range cases {
+		f, err := m.calcFrozen(u, c.now)
+		assert.Nil(t, err)
+		assert.Equal(t, c.expect, f, "now %d", c.now)
+	}
+	u.Terminated = true
+	f, err := m.calcFrozen(u, 10300)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), f)
+
+	//总量很大时不能溢出
+	u = &pty.Unfreeze{StartTime: 0, TotalCount: 9e18, MeansOpt: &pty.Unfreeze_CliffLinear{
+		CliffLinear: &pty.CliffLinear{CliffTime: 0, EndTime: 1e9, Period: 1}}}
+	f, err = m.calcFrozen(u, 5e8)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(45e17), f)
+
+	bad := []*pty.CliffLinear{
+		{CliffTime: 10300, EndTime: 11000, Period: 0},
+		{CliffTime: 9999, EndTime: 11000, Period: 100},
+		{CliffTime: 10300, EndTime: 10200, Period: 100},
+		{CliffTime: 10000, EndTime: 10050, Period: 100},
+	}
+	for _, o := range bad {
+		create.MeansOpt = &pty.UnfreezeCreate_CliffLinear{CliffLinear: o}
+		_, err = m.setOpt(&pty.Unfreeze{StartTime: 10000, TotalCount: 1000}, create)
+		assert.Equal(t, types.ErrInvalidParam, err)
+	}
+}
+
+func TestMilestones(t *testing.T) {
+	m, err := newMeans(chain33TestCfg, pty.MilestonesX, 15000000)
+	assert.Nil(t, err)
+
+	create := &pty.UnfreezeCreate{
+		TotalCount: 1000,
+		Means:      pty.MilestonesX,
+		MeansOpt: &pty.UnfreezeCreate_Milestones{Milestones: &pty.Milestones{Milestones: []*pty.Milestone{
+			{Time: 10000, Amount: 100},
+			{Time: 10500, Amount: 600},
+			{Time: 12000, Amount: 1000},
+		}}},
+	}
+	u, err := m.setOpt(&pty.Unfreeze{StartTime: 10000, TotalCount: 1000}, create)
+	assert.Nil(t, err)
+
+	cases := []struct {
+		now    int64
+		expect int64
+	}{
+		{9999, 1000},
+		{10000, 900},
+		{10499, 900},
+		{10500, 400},
+		{12000, 0},
+	}
+	for _, c := range cases {
+		f, err := m.calcFrozen(u, c.now)
+		assert.Nil(t, err)
+		assert.Equal(t, c.expect, f, "now %d", c.now)
+	}
+
+	bad := [][]*pty.Milestone{
+		nil,
+		{{Time: 9999, Amount: 1000}},
+		{{Time: 10000, Amount: 100}, {Time: 10000, Amount: 1000}},
+		{{Time: 10000, Amount: 100}, {Time: 10100, Amount: 100}, {Time: 10200, Amount: 1000}},
+		{{Time: 10000, Amount: 100}, {Time: 10100, Amount: 900}},
+		{{Time: 10000, Amount: 100}, nil},
+	}
+	for _, ms := range bad {
+		create.MeansOpt = &pty.UnfreezeCreate_Milestones{Milestones: &pty.Milestones{Milestones: ms}}
+		_, err = m.setOpt(&pty.Unfreeze{StartTime: 10000, TotalCount: 1000}, create)
+		assert.Equal(t, types.ErrInvalidParam, err)
+	}
+}
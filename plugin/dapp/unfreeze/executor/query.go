@@ -58,7 +58,7 @@ func getWithdrawAvailable(cfg *types.Chain33Config, unfreeze *pty.Unfreeze, calc
 	if err != nil {
 		return 0, err
 	}
-	frozen, err := means.calcFrozen(unfreeze, calcTime)
+	frozen, err := frozenAmount(means, unfreeze, calcTime)
 	if err != nil {
 		return 0, err
 	}
@@ -141,11 +141,16 @@ func fmtLocalUnfreeze(rows []*table.Row) (*pty.ReplyUnfreezes, error) {
 			Means:       r.Unfreeze.Means,
 			Terminated:  r.Unfreeze.Terminated,
 			Key:         r.TxIndex,
+			Reduced:     r.Unfreeze.Reduced,
 		}
 		if v.Means == pty.FixAmountX {
 			v.MeansOpt = &pty.ReplyUnfreeze_FixAmount{FixAmount: r.Unfreeze.GetFixAmount()}
 		} else if v.Means == pty.LeftProportionX {
 			v.MeansOpt = &pty.ReplyUnfreeze_LeftProportion{LeftProportion: r.Unfreeze.GetLeftProportion()}
+		} else if v.Means == pty.CliffLinearX {
+			v.MeansOpt = &pty.ReplyUnfreeze_CliffLinear{CliffLinear: r.Unfreeze.GetCliffLinear()}
+		} else if v.Means == pty.MilestonesX {
+			v.MeansOpt = &pty.ReplyUnfreeze_Milestones{Milestones: r.Unfreeze.GetMilestones()}
 		}
 		results.Unfreeze = append(results.Unfreeze, v)
 	}
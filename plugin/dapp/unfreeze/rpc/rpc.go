@@ -99,3 +99,31 @@ func (c *Jrpc) CreateRawUnfreezeTerminate(param *pty.UnfreezeTerminate, result *
 	*result = hex.EncodeToString(data)
 	return nil
 }
+
+// CreateRawUnfreezeTransfer 转移冻结合约的受益人
+func (c *Jrpc) CreateRawUnfreezeTransfer(param *pty.UnfreezeTransfer, result *interface{}) error {
+	if param == nil {
+		return types.ErrInvalidParam
+	}
+	cfg := c.cli.GetConfig()
+	data, err := types.CallCreateTx(cfg, cfg.ExecName(pty.UnfreezeX), "Transfer", param)
+	if err != nil {
+		return err
+	}
+	*result = hex.EncodeToString(data)
+	return nil
+}
+
+// CreateRawUnfreezeReduce 减少冻结合约未解冻的数量
+func (c *Jrpc) CreateRawUnfreezeReduce(param *pty.UnfreezeReduce, result *interface{}) error {
+	if param == nil {
+		return types.ErrInvalidParam
+	}
+	cfg := c.cli.GetConfig()
+	data, err := types.CallCreateTx(cfg, cfg.ExecName(pty.UnfreezeX), "Reduce", param)
+	if err != nil {
+		return err
+	}
+	*result = hex.EncodeToString(data)
+	return nil
+}
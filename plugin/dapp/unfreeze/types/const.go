@@ -9,11 +9,15 @@ const (
 	UnfreezeActionCreate = iota + 1
 	UnfreezeActionWithdraw
 	UnfreezeActionTerminate
+	UnfreezeActionTransfer
+	UnfreezeActionReduce
 
 	//log for unfreeze
 	TyLogCreateUnfreeze    = 2001 // TODO 修改具体编号
 	TyLogWithdrawUnfreeze  = 2002
 	TyLogTerminateUnfreeze = 2003
+	TyLogTransferUnfreeze  = 2004
+	TyLogReduceUnfreeze    = 2005
 )
 
 const (
@@ -23,6 +27,15 @@ const (
 	Action_WithdrawUnfreeze = "withdrawUnfreeze"
 	// Action_TerminateUnfreeze Action 名字
 	Action_TerminateUnfreeze = "terminateUnfreeze"
+	// Action_TransferUnfreeze Action 名字
+	Action_TransferUnfreeze = "transferUnfreeze"
+	// Action_ReduceUnfreeze Action 名字
+	Action_ReduceUnfreeze = "reduceUnfreeze"
+)
+
+const (
+	// MaxMilestones 自定义解冻时间点的最大数量
+	MaxMilestones = 100
 )
 
 const (
@@ -41,8 +54,12 @@ var (
 
 	FixAmountX      = "FixAmount"
 	LeftProportionX = "LeftProportion"
-	SupportMeans    = []string{"FixAmount", "LeftProportion"}
+	CliffLinearX    = "CliffLinear"
+	MilestonesX     = "Milestones"
+	SupportMeans    = []string{"FixAmount", "LeftProportion", "CliffLinear", "Milestones"}
 
-	ForkTerminatePartX = "ForkTerminatePart"
-	ForkUnfreezeIDX    = "ForkUnfreezeIDX"
+	ForkTerminatePartX    = "ForkTerminatePart"
+	ForkUnfreezeIDX       = "ForkUnfreezeIDX"
+	ForkUnfreezeVestingX  = "ForkUnfreezeVesting"
+	ForkUnfreezeTransferX = "ForkUnfreezeTransfer"
 )
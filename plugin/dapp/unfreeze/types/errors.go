@@ -17,4 +17,8 @@ var (
 	ErrNoPrivilege = errors.New("ErrNoPrivilege")
 	// ErrTerminated 已经被取消过了
 	ErrTerminated = errors.New("ErrTerminated")
+	// ErrBeneficiary 新的受益人地址错误
+	ErrBeneficiary = errors.New("ErrBeneficiary")
+	// ErrReduceAmount 减少的数量超过了未解冻的数量
+	ErrReduceAmount = errors.New("ErrReduceAmount")
 )
@@ -31,6 +31,8 @@ func InitFork(cfg *types.Chain33Config) {
 	cfg.RegisterDappFork(name, "Enable", 0)
 	cfg.RegisterDappFork(name, ForkTerminatePartX, 1298600)
 	cfg.RegisterDappFork(name, ForkUnfreezeIDX, 1450000)
+	cfg.RegisterDappFork(name, ForkUnfreezeVestingX, types.MaxHeight)
+	cfg.RegisterDappFork(name, ForkUnfreezeTransferX, types.MaxHeight)
 }
 
 func InitExecutor(cfg *types.Chain33Config) {
@@ -66,6 +68,8 @@ func (u *UnfreezeType) GetLogMap() map[int64]*types.LogInfo {
 		TyLogCreateUnfreeze:    {Ty: reflect.TypeOf(ReceiptUnfreeze{}), Name: "LogCreateUnfreeze"},
 		TyLogWithdrawUnfreeze:  {Ty: reflect.TypeOf(ReceiptUnfreeze{}), Name: "LogWithdrawUnfreeze"},
 		TyLogTerminateUnfreeze: {Ty: reflect.TypeOf(ReceiptUnfreeze{}), Name: "LogTerminateUnfreeze"},
+		TyLogTransferUnfreeze:  {Ty: reflect.TypeOf(ReceiptUnfreeze{}), Name: "LogTransferUnfreeze"},
+		TyLogReduceUnfreeze:    {Ty: reflect.TypeOf(ReceiptUnfreeze{}), Name: "LogReduceUnfreeze"},
 	}
 }
 
@@ -80,6 +84,8 @@ func (u *UnfreezeType) GetTypeMap() map[string]int32 {
 		"Create":    UnfreezeActionCreate,
 		"Withdraw":  UnfreezeActionWithdraw,
 		"Terminate": UnfreezeActionTerminate,
+		"Transfer":  UnfreezeActionTransfer,
+		"Reduce":    UnfreezeActionReduce,
 	}
 }
 
@@ -110,6 +116,22 @@ func (u *UnfreezeType) CreateTx(action string, message json.RawMessage) (*types.
 			return nil, types.ErrInvalidParam
 		}
 		return u.RPC_UnfreezeTerminateTx(&param)
+	} else if action == Action_TransferUnfreeze {
+		var param UnfreezeTransfer
+		err := types.JSONToPB(message, &param)
+		if err != nil {
+			tlog.Error("CreateTx", "Error", err)
+			return nil, types.ErrInvalidParam
+		}
+		return u.RPC_UnfreezeTransferTx(&param)
+	} else if action == Action_ReduceUnfreeze {
+		var param UnfreezeReduce
+		err := types.JSONToPB(message, &param)
+		if err != nil {
+			tlog.Error("CreateTx", "Error", err)
+			return nil, types.ErrInvalidParam
+		}
+		return u.RPC_UnfreezeReduceTx(&param)
 	}
 
 	return nil, types.ErrNotSupport
@@ -208,6 +230,66 @@ func CreateUnfreezeTerminateTx(cfg *types.Chain33Config, title string, parm *Unf
 	return tx, nil
 }
 
+// RPC_UnfreezeTransferTx 创建转移受益人交易入口
+func (u *UnfreezeType) RPC_UnfreezeTransferTx(parm *UnfreezeTransfer) (*types.Transaction, error) {
+	cfg := u.GetConfig()
+	return CreateUnfreezeTransferTx(cfg, cfg.GetParaName(), parm)
+}
+
+// CreateUnfreezeTransferTx 创建转移受益人交易
+func CreateUnfreezeTransferTx(cfg *types.Chain33Config, title string, parm *UnfreezeTransfer) (*types.Transaction, error) {
+	if parm == nil || parm.Beneficiary == "" {
+		tlog.Error("RPC_UnfreezeTransferTx", "parm", parm)
+		return nil, types.ErrInvalidParam
+	}
+	v := &UnfreezeTransfer{
+		UnfreezeID:  parm.UnfreezeID,
+		Beneficiary: parm.Beneficiary,
+	}
+	transfer := &UnfreezeAction{
+		Ty:    UnfreezeActionTransfer,
+		Value: &UnfreezeAction_Transfer{v},
+	}
+	tx := &types.Transaction{
+		Execer:  []byte(getRealExecName(cfg, title)),
+		Payload: types.Encode(transfer),
+		Nonce:   rand.New(rand.NewSource(time.Now().UnixNano())).Int63(),
+		To:      address.ExecAddress(getRealExecName(cfg, cfg.GetParaName())),
+	}
+	tx.SetRealFee(cfg.GetMinTxFeeRate())
+	return tx, nil
+}
+
+// RPC_UnfreezeReduceTx 创建减少冻结数量交易入口
+func (u *UnfreezeType) RPC_UnfreezeReduceTx(parm *UnfreezeReduce) (*types.Transaction, error) {
+	cfg := u.GetConfig()
+	return CreateUnfreezeReduceTx(cfg, cfg.GetParaName(), parm)
+}
+
+// CreateUnfreezeReduceTx 创建减少冻结数量交易
+func CreateUnfreezeReduceTx(cfg *types.Chain33Config, title string, parm *UnfreezeReduce) (*types.Transaction, error) {
+	if parm == nil || parm.Amount <= 0 {
+		tlog.Error("RPC_UnfreezeReduceTx", "parm", parm)
+		return nil, types.ErrInvalidParam
+	}
+	v := &UnfreezeReduce{
+		UnfreezeID: parm.UnfreezeID,
+		Amount:     parm.Amount,
+	}
+	reduce := &UnfreezeAction{
+		Ty:    UnfreezeActionReduce,
+		Value: &UnfreezeAction_Reduce{v},
+	}
+	tx := &types.Transaction{
+		Execer:  []byte(getRealExecName(cfg, title)),
+		Payload: types.Encode(reduce),
+		Nonce:   rand.New(rand.NewSource(time.Now().UnixNano())).Int63(),
+		To:      address.ExecAddress(getRealExecName(cfg, cfg.GetParaName())),
+	}
+	tx.SetRealFee(cfg.GetMinTxFeeRate())
+	return tx, nil
+}
+
 func supportMeans(means string) bool {
 	for _, m := range SupportMeans {
 		if m == means {
@@ -42,16 +42,20 @@ type Unfreeze struct {
 	Beneficiary string `protobuf:"bytes,7,opt,name=beneficiary,proto3" json:"beneficiary,omitempty"`
 	//解冻剩余币数
 	Remaining int64 `protobuf:"varint,8,opt,name=remaining,proto3" json:"remaining,omitempty"`
-	//解冻方式（百分比；固额）
+	//解冻方式（百分比；固额；悬崖期线性；自定义时间点）
 	Means string `protobuf:"bytes,9,opt,name=means,proto3" json:"means,omitempty"`
 	// Types that are valid to be assigned to MeansOpt:
 	//	*Unfreeze_FixAmount
 	//	*Unfreeze_LeftProportion
-	MeansOpt             isUnfreeze_MeansOpt `protobuf_oneof:"meansOpt"`
-	Terminated           bool                `protobuf:"varint,12,opt,name=terminated,proto3" json:"terminated,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	//	*Unfreeze_CliffLinear
+	//	*Unfreeze_Milestones
+	MeansOpt   isUnfreeze_MeansOpt `protobuf_oneof:"meansOpt"`
+	Terminated bool                `protobuf:"varint,12,opt,name=terminated,proto3" json:"terminated,omitempty"`
+	//创建者减少的未解冻数量, 从解冻计划的末尾扣除
+	Reduced              int64    `protobuf:"varint,15,opt,name=reduced,proto3" json:"reduced,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Unfreeze) Reset()         { *m = Unfreeze{} }
@@ -154,10 +158,22 @@ type Unfreeze_LeftProportion struct {
 	LeftProportion *LeftProportion `protobuf:"bytes,11,opt,name=leftProportion,proto3,oneof"`
 }
 
+type Unfreeze_CliffLinear struct {
+	CliffLinear *CliffLinear `protobuf:"bytes,13,opt,name=cliffLinear,proto3,oneof"`
+}
+
+type Unfreeze_Milestones struct {
+	Milestones *Milestones `protobuf:"bytes,14,opt,name=milestones,proto3,oneof"`
+}
+
 func (*Unfreeze_FixAmount) isUnfreeze_MeansOpt() {}
 
 func (*Unfreeze_LeftProportion) isUnfreeze_MeansOpt() {}
 
+func (*Unfreeze_CliffLinear) isUnfreeze_MeansOpt() {}
+
+func (*Unfreeze_Milestones) isUnfreeze_MeansOpt() {}
+
 func (m *Unfreeze) GetMeansOpt() isUnfreeze_MeansOpt {
 	if m != nil {
 		return m.MeansOpt
@@ -179,6 +195,20 @@ func (m *Unfreeze) GetLeftProportion() *LeftProportion {
 	return nil
 }
 
+func (m *Unfreeze) GetCliffLinear() *CliffLinear {
+	if x, ok := m.GetMeansOpt().(*Unfreeze_CliffLinear); ok {
+		return x.CliffLinear
+	}
+	return nil
+}
+
+func (m *Unfreeze) GetMilestones() *Milestones {
+	if x, ok := m.GetMeansOpt().(*Unfreeze_Milestones); ok {
+		return x.Milestones
+	}
+	return nil
+}
+
 func (m *Unfreeze) GetTerminated() bool {
 	if m != nil {
 		return m.Terminated
@@ -186,11 +216,20 @@ func (m *Unfreeze) GetTerminated() bool {
 	return false
 }
 
+func (m *Unfreeze) GetReduced() int64 {
+	if m != nil {
+		return m.Reduced
+	}
+	return 0
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Unfreeze) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*Unfreeze_FixAmount)(nil),
 		(*Unfreeze_LeftProportion)(nil),
+		(*Unfreeze_CliffLinear)(nil),
+		(*Unfreeze_Milestones)(nil),
 	}
 }
 
@@ -290,12 +329,157 @@ func (m *LeftProportion) GetTenThousandth() int64 {
 	return 0
 }
 
+// 悬崖期之前不解冻, 之后按周期线性解冻, endTime时全部解冻
+type CliffLinear struct {
+	CliffTime            int64    `protobuf:"varint,1,opt,name=cliffTime,proto3" json:"cliffTime,omitempty"`
+	EndTime              int64    `protobuf:"varint,2,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	Period               int64    `protobuf:"varint,3,opt,name=period,proto3" json:"period,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CliffLinear) Reset()         { *m = CliffLinear{} }
+func (m *CliffLinear) String() string { return proto.CompactTextString(m) }
+func (*CliffLinear) ProtoMessage()    {}
+func (*CliffLinear) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6caa0554cb0b9167, []int{3}
+}
+
+func (m *CliffLinear) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CliffLinear.Unmarshal(m, b)
+}
+func (m *CliffLinear) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CliffLinear.Marshal(b, m, deterministic)
+}
+func (m *CliffLinear) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CliffLinear.Merge(m, src)
+}
+func (m *CliffLinear) XXX_Size() int {
+	return xxx_messageInfo_CliffLinear.Size(m)
+}
+func (m *CliffLinear) XXX_DiscardUnknown() {
+	xxx_messageInfo_CliffLinear.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CliffLinear proto.InternalMessageInfo
+
+func (m *CliffLinear) GetCliffTime() int64 {
+	if m != nil {
+		return m.CliffTime
+	}
+	return 0
+}
+
+func (m *CliffLinear) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *CliffLinear) GetPeriod() int64 {
+	if m != nil {
+		return m.Period
+	}
+	return 0
+}
+
+// 按自定义的时间点解冻, amount为到该时间点累计解冻的数量
+type Milestone struct {
+	Time                 int64    `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Amount               int64    `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Milestone) Reset()         { *m = Milestone{} }
+func (m *Milestone) String() string { return proto.CompactTextString(m) }
+func (*Milestone) ProtoMessage()    {}
+func (*Milestone) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6caa0554cb0b9167, []int{4}
+}
+
+func (m *Milestone) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Milestone.Unmarshal(m, b)
+}
+func (m *Milestone) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Milestone.Marshal(b, m, deterministic)
+}
+func (m *Milestone) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Milestone.Merge(m, src)
+}
+func (m *Milestone) XXX_Size() int {
+	return xxx_messageInfo_Milestone.Size(m)
+}
+func (m *Milestone) XXX_DiscardUnknown() {
+	xxx_messageInfo_Milestone.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Milestone proto.InternalMessageInfo
+
+func (m *Milestone) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Milestone) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+type Milestones struct {
+	Milestones           []*Milestone `protobuf:"bytes,1,rep,name=milestones,proto3" json:"milestones,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Milestones) Reset()         { *m = Milestones{} }
+func (m *Milestones) String() string { return proto.CompactTextString(m) }
+func (*Milestones) ProtoMessage()    {}
+func (*Milestones) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6caa0554cb0b9167, []int{5}
+}
+
+func (m *Milestones) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Milestones.Unmarshal(m, b)
+}
+func (m *Milestones) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Milestones.Marshal(b, m, deterministic)
+}
+func (m *Milestones) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Milestones.Merge(m, src)
+}
+func (m *Milestones) XXX_Size() int {
+	return xxx_messageInfo_Milestones.Size(m)
+}
+func (m *Milestones) XXX_DiscardUnknown() {
+	xxx_messageInfo_Milestones.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Milestones proto.InternalMessageInfo
+
+func (m *Milestones) GetMilestones() []*Milestone {
+	if m != nil {
+		return m.Milestones
+	}
+	return nil
+}
+
 // message for execs.unfreeze
 type UnfreezeAction struct {
 	// Types that are valid to be assigned to Value:
 	//	*UnfreezeAction_Create
 	//	*UnfreezeAction_Withdraw
 	//	*UnfreezeAction_Terminate
+	//	*UnfreezeAction_Transfer
+	//	*UnfreezeAction_Reduce
 	Value                isUnfreezeAction_Value `protobuf_oneof:"value"`
 	Ty                   int32                  `protobuf:"varint,4,opt,name=ty,proto3" json:"ty,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
@@ -307,7 +491,7 @@ func (m *UnfreezeAction) Reset()         { *m = UnfreezeAction{} }
 func (m *UnfreezeAction) String() string { return proto.CompactTextString(m) }
 func (*UnfreezeAction) ProtoMessage()    {}
 func (*UnfreezeAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6caa0554cb0b9167, []int{3}
+	return fileDescriptor_6caa0554cb0b9167, []int{6}
 }
 
 func (m *UnfreezeAction) XXX_Unmarshal(b []byte) error {
@@ -344,12 +528,24 @@ type UnfreezeAction_Terminate struct {
 	Terminate *UnfreezeTerminate `protobuf:"bytes,3,opt,name=terminate,proto3,oneof"`
 }
 
+type UnfreezeAction_Transfer struct {
+	Transfer *UnfreezeTransfer `protobuf:"bytes,5,opt,name=transfer,proto3,oneof"`
+}
+
+type UnfreezeAction_Reduce struct {
+	Reduce *UnfreezeReduce `protobuf:"bytes,6,opt,name=reduce,proto3,oneof"`
+}
+
 func (*UnfreezeAction_Create) isUnfreezeAction_Value() {}
 
 func (*UnfreezeAction_Withdraw) isUnfreezeAction_Value() {}
 
 func (*UnfreezeAction_Terminate) isUnfreezeAction_Value() {}
 
+func (*UnfreezeAction_Transfer) isUnfreezeAction_Value() {}
+
+func (*UnfreezeAction_Reduce) isUnfreezeAction_Value() {}
+
 func (m *UnfreezeAction) GetValue() isUnfreezeAction_Value {
 	if m != nil {
 		return m.Value
@@ -378,6 +574,20 @@ func (m *UnfreezeAction) GetTerminate() *UnfreezeTerminate {
 	return nil
 }
 
+func (m *UnfreezeAction) GetTransfer() *UnfreezeTransfer {
+	if x, ok := m.GetValue().(*UnfreezeAction_Transfer); ok {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (m *UnfreezeAction) GetReduce() *UnfreezeReduce {
+	if x, ok := m.GetValue().(*UnfreezeAction_Reduce); ok {
+		return x.Reduce
+	}
+	return nil
+}
+
 func (m *UnfreezeAction) GetTy() int32 {
 	if m != nil {
 		return m.Ty
@@ -391,6 +601,8 @@ func (*UnfreezeAction) XXX_OneofWrappers() []interface{} {
 		(*UnfreezeAction_Create)(nil),
 		(*UnfreezeAction_Withdraw)(nil),
 		(*UnfreezeAction_Terminate)(nil),
+		(*UnfreezeAction_Transfer)(nil),
+		(*UnfreezeAction_Reduce)(nil),
 	}
 }
 
@@ -405,6 +617,8 @@ type UnfreezeCreate struct {
 	// Types that are valid to be assigned to MeansOpt:
 	//	*UnfreezeCreate_FixAmount
 	//	*UnfreezeCreate_LeftProportion
+	//	*UnfreezeCreate_CliffLinear
+	//	*UnfreezeCreate_Milestones
 	MeansOpt             isUnfreezeCreate_MeansOpt `protobuf_oneof:"meansOpt"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
@@ -415,7 +629,7 @@ func (m *UnfreezeCreate) Reset()         { *m = UnfreezeCreate{} }
 func (m *UnfreezeCreate) String() string { return proto.CompactTextString(m) }
 func (*UnfreezeCreate) ProtoMessage()    {}
 func (*UnfreezeCreate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6caa0554cb0b9167, []int{4}
+	return fileDescriptor_6caa0554cb0b9167, []int{7}
 }
 
 func (m *UnfreezeCreate) XXX_Unmarshal(b []byte) error {
@@ -490,10 +704,22 @@ type UnfreezeCreate_LeftProportion struct {
 	LeftProportion *LeftProportion `protobuf:"bytes,8,opt,name=leftProportion,proto3,oneof"`
 }
 
+type UnfreezeCreate_CliffLinear struct {
+	CliffLinear *CliffLinear `protobuf:"bytes,9,opt,name=cliffLinear,proto3,oneof"`
+}
+
+type UnfreezeCreate_Milestones struct {
+	Milestones *Milestones `protobuf:"bytes,10,opt,name=milestones,proto3,oneof"`
+}
+
 func (*UnfreezeCreate_FixAmount) isUnfreezeCreate_MeansOpt() {}
 
 func (*UnfreezeCreate_LeftProportion) isUnfreezeCreate_MeansOpt() {}
 
+func (*UnfreezeCreate_CliffLinear) isUnfreezeCreate_MeansOpt() {}
+
+func (*UnfreezeCreate_Milestones) isUnfreezeCreate_MeansOpt() {}
+
 func (m *UnfreezeCreate) GetMeansOpt() isUnfreezeCreate_MeansOpt {
 	if m != nil {
 		return m.MeansOpt
@@ -515,11 +741,27 @@ func (m *UnfreezeCreate) GetLeftProportion() *LeftProportion {
 	return nil
 }
 
+func (m *UnfreezeCreate) GetCliffLinear() *CliffLinear {
+	if x, ok := m.GetMeansOpt().(*UnfreezeCreate_CliffLinear); ok {
+		return x.CliffLinear
+	}
+	return nil
+}
+
+func (m *UnfreezeCreate) GetMilestones() *Milestones {
+	if x, ok := m.GetMeansOpt().(*UnfreezeCreate_Milestones); ok {
+		return x.Milestones
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*UnfreezeCreate) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*UnfreezeCreate_FixAmount)(nil),
 		(*UnfreezeCreate_LeftProportion)(nil),
+		(*UnfreezeCreate_CliffLinear)(nil),
+		(*UnfreezeCreate_Milestones)(nil),
 	}
 }
 
@@ -534,7 +776,7 @@ func (m *UnfreezeWithdraw) Reset()         { *m = UnfreezeWithdraw{} }
 func (m *UnfreezeWithdraw) String() string { return proto.CompactTextString(m) }
 func (*UnfreezeWithdraw) ProtoMessage()    {}
 func (*UnfreezeWithdraw) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6caa0554cb0b9167, []int{5}
+	return fileDescriptor_6caa0554cb0b9167, []int{8}
 }
 
 func (m *UnfreezeWithdraw) XXX_Unmarshal(b []byte) error {
@@ -573,7 +815,7 @@ func (m *UnfreezeTerminate) Reset()         { *m = UnfreezeTerminate{} }
 func (m *UnfreezeTerminate) String() string { return proto.CompactTextString(m) }
 func (*UnfreezeTerminate) ProtoMessage()    {}
 func (*UnfreezeTerminate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6caa0554cb0b9167, []int{6}
+	return fileDescriptor_6caa0554cb0b9167, []int{9}
 }
 
 func (m *UnfreezeTerminate) XXX_Unmarshal(b []byte) error {
@@ -601,6 +843,102 @@ func (m *UnfreezeTerminate) GetUnfreezeID() string {
 	return ""
 }
 
+// 受益人把剩余的资产转给新的受益人
+type UnfreezeTransfer struct {
+	UnfreezeID           string   `protobuf:"bytes,1,opt,name=unfreezeID,proto3" json:"unfreezeID,omitempty"`
+	Beneficiary          string   `protobuf:"bytes,2,opt,name=beneficiary,proto3" json:"beneficiary,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnfreezeTransfer) Reset()         { *m = UnfreezeTransfer{} }
+func (m *UnfreezeTransfer) String() string { return proto.CompactTextString(m) }
+func (*UnfreezeTransfer) ProtoMessage()    {}
+func (*UnfreezeTransfer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6caa0554cb0b9167, []int{10}
+}
+
+func (m *UnfreezeTransfer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnfreezeTransfer.Unmarshal(m, b)
+}
+func (m *UnfreezeTransfer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnfreezeTransfer.Marshal(b, m, deterministic)
+}
+func (m *UnfreezeTransfer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnfreezeTransfer.Merge(m, src)
+}
+func (m *UnfreezeTransfer) XXX_Size() int {
+	return xxx_messageInfo_UnfreezeTransfer.Size(m)
+}
+func (m *UnfreezeTransfer) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnfreezeTransfer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnfreezeTransfer proto.InternalMessageInfo
+
+func (m *UnfreezeTransfer) GetUnfreezeID() string {
+	if m != nil {
+		return m.UnfreezeID
+	}
+	return ""
+}
+
+func (m *UnfreezeTransfer) GetBeneficiary() string {
+	if m != nil {
+		return m.Beneficiary
+	}
+	return ""
+}
+
+// 创建者减少未解冻的数量
+type UnfreezeReduce struct {
+	UnfreezeID           string   `protobuf:"bytes,1,opt,name=unfreezeID,proto3" json:"unfreezeID,omitempty"`
+	Amount               int64    `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnfreezeReduce) Reset()         { *m = UnfreezeReduce{} }
+func (m *UnfreezeReduce) String() string { return proto.CompactTextString(m) }
+func (*UnfreezeReduce) ProtoMessage()    {}
+func (*UnfreezeReduce) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6caa0554cb0b9167, []int{11}
+}
+
+func (m *UnfreezeReduce) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnfreezeReduce.Unmarshal(m, b)
+}
+func (m *UnfreezeReduce) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnfreezeReduce.Marshal(b, m, deterministic)
+}
+func (m *UnfreezeReduce) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnfreezeReduce.Merge(m, src)
+}
+func (m *UnfreezeReduce) XXX_Size() int {
+	return xxx_messageInfo_UnfreezeReduce.Size(m)
+}
+func (m *UnfreezeReduce) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnfreezeReduce.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnfreezeReduce proto.InternalMessageInfo
+
+func (m *UnfreezeReduce) GetUnfreezeID() string {
+	if m != nil {
+		return m.UnfreezeID
+	}
+	return ""
+}
+
+func (m *UnfreezeReduce) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
 // receipt
 type ReceiptUnfreeze struct {
 	Prev                 *Unfreeze `protobuf:"bytes,1,opt,name=prev,proto3" json:"prev,omitempty"`
@@ -614,7 +952,7 @@ func (m *ReceiptUnfreeze) Reset()         { *m = ReceiptUnfreeze{} }
 func (m *ReceiptUnfreeze) String() string { return proto.CompactTextString(m) }
 func (*ReceiptUnfreeze) ProtoMessage()    {}
 func (*ReceiptUnfreeze) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6caa0554cb0b9167, []int{7}
+	return fileDescriptor_6caa0554cb0b9167, []int{12}
 }
 
 func (m *ReceiptUnfreeze) XXX_Unmarshal(b []byte) error {
@@ -661,7 +999,7 @@ func (m *LocalUnfreeze) Reset()         { *m = LocalUnfreeze{} }
 func (m *LocalUnfreeze) String() string { return proto.CompactTextString(m) }
 func (*LocalUnfreeze) ProtoMessage()    {}
 func (*LocalUnfreeze) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6caa0554cb0b9167, []int{8}
+	return fileDescriptor_6caa0554cb0b9167, []int{13}
 }
 
 func (m *LocalUnfreeze) XXX_Unmarshal(b []byte) error {
@@ -709,7 +1047,7 @@ func (m *ReplyQueryUnfreezeWithdraw) Reset()         { *m = ReplyQueryUnfreezeWi
 func (m *ReplyQueryUnfreezeWithdraw) String() string { return proto.CompactTextString(m) }
 func (*ReplyQueryUnfreezeWithdraw) ProtoMessage()    {}
 func (*ReplyQueryUnfreezeWithdraw) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6caa0554cb0b9167, []int{9}
+	return fileDescriptor_6caa0554cb0b9167, []int{14}
 }
 
 func (m *ReplyQueryUnfreezeWithdraw) XXX_Unmarshal(b []byte) error {
@@ -759,7 +1097,7 @@ func (m *ReqUnfreezes) Reset()         { *m = ReqUnfreezes{} }
 func (m *ReqUnfreezes) String() string { return proto.CompactTextString(m) }
 func (*ReqUnfreezes) ProtoMessage()    {}
 func (*ReqUnfreezes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6caa0554cb0b9167, []int{10}
+	return fileDescriptor_6caa0554cb0b9167, []int{15}
 }
 
 func (m *ReqUnfreezes) XXX_Unmarshal(b []byte) error {
@@ -831,14 +1169,17 @@ type ReplyUnfreeze struct {
 	Beneficiary string `protobuf:"bytes,7,opt,name=beneficiary,proto3" json:"beneficiary,omitempty"`
 	//解冻剩余币数
 	Remaining int64 `protobuf:"varint,8,opt,name=remaining,proto3" json:"remaining,omitempty"`
-	//解冻方式（百分比；固额）
+	//解冻方式（百分比；固额；悬崖期线性；自定义时间点）
 	Means string `protobuf:"bytes,9,opt,name=means,proto3" json:"means,omitempty"`
 	// Types that are valid to be assigned to MeansOpt:
 	//	*ReplyUnfreeze_FixAmount
 	//	*ReplyUnfreeze_LeftProportion
+	//	*ReplyUnfreeze_CliffLinear
+	//	*ReplyUnfreeze_Milestones
 	MeansOpt             isReplyUnfreeze_MeansOpt `protobuf_oneof:"meansOpt"`
 	Terminated           bool                     `protobuf:"varint,12,opt,name=terminated,proto3" json:"terminated,omitempty"`
 	Key                  string                   `protobuf:"bytes,13,opt,name=key,proto3" json:"key,omitempty"`
+	Reduced              int64                    `protobuf:"varint,16,opt,name=reduced,proto3" json:"reduced,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
 	XXX_sizecache        int32                    `json:"-"`
@@ -848,7 +1189,7 @@ func (m *ReplyUnfreeze) Reset()         { *m = ReplyUnfreeze{} }
 func (m *ReplyUnfreeze) String() string { return proto.CompactTextString(m) }
 func (*ReplyUnfreeze) ProtoMessage()    {}
 func (*ReplyUnfreeze) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6caa0554cb0b9167, []int{11}
+	return fileDescriptor_6caa0554cb0b9167, []int{16}
 }
 
 func (m *ReplyUnfreeze) XXX_Unmarshal(b []byte) error {
@@ -944,10 +1285,22 @@ type ReplyUnfreeze_LeftProportion struct {
 	LeftProportion *LeftProportion `protobuf:"bytes,11,opt,name=leftProportion,proto3,oneof"`
 }
 
+type ReplyUnfreeze_CliffLinear struct {
+	CliffLinear *CliffLinear `protobuf:"bytes,14,opt,name=cliffLinear,proto3,oneof"`
+}
+
+type ReplyUnfreeze_Milestones struct {
+	Milestones *Milestones `protobuf:"bytes,15,opt,name=milestones,proto3,oneof"`
+}
+
 func (*ReplyUnfreeze_FixAmount) isReplyUnfreeze_MeansOpt() {}
 
 func (*ReplyUnfreeze_LeftProportion) isReplyUnfreeze_MeansOpt() {}
 
+func (*ReplyUnfreeze_CliffLinear) isReplyUnfreeze_MeansOpt() {}
+
+func (*ReplyUnfreeze_Milestones) isReplyUnfreeze_MeansOpt() {}
+
 func (m *ReplyUnfreeze) GetMeansOpt() isReplyUnfreeze_MeansOpt {
 	if m != nil {
 		return m.MeansOpt
@@ -969,6 +1322,20 @@ func (m *ReplyUnfreeze) GetLeftProportion() *LeftProportion {
 	return nil
 }
 
+func (m *ReplyUnfreeze) GetCliffLinear() *CliffLinear {
+	if x, ok := m.GetMeansOpt().(*ReplyUnfreeze_CliffLinear); ok {
+		return x.CliffLinear
+	}
+	return nil
+}
+
+func (m *ReplyUnfreeze) GetMilestones() *Milestones {
+	if x, ok := m.GetMeansOpt().(*ReplyUnfreeze_Milestones); ok {
+		return x.Milestones
+	}
+	return nil
+}
+
 func (m *ReplyUnfreeze) GetTerminated() bool {
 	if m != nil {
 		return m.Terminated
@@ -983,11 +1350,20 @@ func (m *ReplyUnfreeze) GetKey() string {
 	return ""
 }
 
+func (m *ReplyUnfreeze) GetReduced() int64 {
+	if m != nil {
+		return m.Reduced
+	}
+	return 0
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*ReplyUnfreeze) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*ReplyUnfreeze_FixAmount)(nil),
 		(*ReplyUnfreeze_LeftProportion)(nil),
+		(*ReplyUnfreeze_CliffLinear)(nil),
+		(*ReplyUnfreeze_Milestones)(nil),
 	}
 }
 
@@ -1002,7 +1378,7 @@ func (m *ReplyUnfreezes) Reset()         { *m = ReplyUnfreezes{} }
 func (m *ReplyUnfreezes) String() string { return proto.CompactTextString(m) }
 func (*ReplyUnfreezes) ProtoMessage()    {}
 func (*ReplyUnfreezes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6caa0554cb0b9167, []int{12}
+	return fileDescriptor_6caa0554cb0b9167, []int{17}
 }
 
 func (m *ReplyUnfreezes) XXX_Unmarshal(b []byte) error {
@@ -1034,10 +1410,15 @@ func init() {
 	proto.RegisterType((*Unfreeze)(nil), "types.Unfreeze")
 	proto.RegisterType((*FixAmount)(nil), "types.FixAmount")
 	proto.RegisterType((*LeftProportion)(nil), "types.LeftProportion")
+	proto.RegisterType((*CliffLinear)(nil), "types.CliffLinear")
+	proto.RegisterType((*Milestone)(nil), "types.Milestone")
+	proto.RegisterType((*Milestones)(nil), "types.Milestones")
 	proto.RegisterType((*UnfreezeAction)(nil), "types.UnfreezeAction")
 	proto.RegisterType((*UnfreezeCreate)(nil), "types.UnfreezeCreate")
 	proto.RegisterType((*UnfreezeWithdraw)(nil), "types.UnfreezeWithdraw")
 	proto.RegisterType((*UnfreezeTerminate)(nil), "types.UnfreezeTerminate")
+	proto.RegisterType((*UnfreezeTransfer)(nil), "types.UnfreezeTransfer")
+	proto.RegisterType((*UnfreezeReduce)(nil), "types.UnfreezeReduce")
 	proto.RegisterType((*ReceiptUnfreeze)(nil), "types.ReceiptUnfreeze")
 	proto.RegisterType((*LocalUnfreeze)(nil), "types.LocalUnfreeze")
 	proto.RegisterType((*ReplyQueryUnfreezeWithdraw)(nil), "types.ReplyQueryUnfreezeWithdraw")
@@ -1051,55 +1432,67 @@ func init() {
 }
 
 var fileDescriptor_6caa0554cb0b9167 = []byte{
-	// 755 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x56, 0xcb, 0x6e, 0x13, 0x4b,
-	0x10, 0xf5, 0x78, 0x3c, 0x7e, 0x94, 0x63, 0xc7, 0xb7, 0x6f, 0xee, 0x65, 0x14, 0x21, 0x64, 0x06,
-	0x16, 0x46, 0x48, 0x01, 0x39, 0x20, 0x21, 0xb1, 0x40, 0x49, 0x78, 0x38, 0x22, 0xe2, 0x31, 0x09,
-	0xb0, 0x6e, 0x8f, 0xcb, 0x49, 0x8b, 0x79, 0xa5, 0xdd, 0x4e, 0x3c, 0x7c, 0x04, 0x5f, 0xc0, 0x9f,
-	0xb0, 0x66, 0xc5, 0x8e, 0x2f, 0x42, 0xd3, 0xf3, 0x1e, 0x27, 0x58, 0x61, 0x87, 0xc4, 0xce, 0x75,
-	0xaa, 0xea, 0x74, 0x75, 0xd5, 0xa9, 0x1e, 0x43, 0x77, 0xee, 0x4e, 0x39, 0xe2, 0x27, 0xdc, 0xf2,
-	0xb9, 0x27, 0x3c, 0xa2, 0x89, 0xc0, 0xc7, 0xd9, 0xe6, 0x9a, 0xe5, 0x39, 0x8e, 0xe7, 0x46, 0xa0,
-	0xf1, 0x55, 0x85, 0xe6, 0xbb, 0x38, 0x8e, 0xdc, 0x00, 0x48, 0x72, 0xf6, 0x9f, 0xea, 0x4a, 0x5f,
-	0x19, 0xb4, 0xcc, 0x1c, 0x42, 0xae, 0x43, 0x6b, 0x26, 0x28, 0x17, 0x47, 0xcc, 0x41, 0xbd, 0xda,
-	0x57, 0x06, 0xaa, 0x99, 0x01, 0xa1, 0x97, 0xce, 0x66, 0x28, 0x9e, 0x2d, 0xd0, 0xd2, 0x55, 0x99,
-	0x9c, 0x01, 0xa4, 0x0f, 0x6d, 0x69, 0x1c, 0x06, 0xce, 0xd8, 0xb3, 0xf5, 0x9a, 0xf4, 0xe7, 0xa1,
-	0xf0, 0x74, 0xe1, 0x09, 0x6a, 0xef, 0x79, 0x73, 0x57, 0xe8, 0x9a, 0xa4, 0xcf, 0x21, 0x21, 0x3f,
-	0x73, 0x99, 0x60, 0x54, 0x78, 0x5c, 0xaf, 0x47, 0xfc, 0x29, 0x10, 0xf2, 0x8f, 0xd1, 0xc5, 0x29,
-	0xb3, 0x18, 0xe5, 0x81, 0xde, 0x88, 0xf8, 0x73, 0x50, 0x98, 0xcf, 0xd1, 0xa1, 0xcc, 0x65, 0xee,
-	0xb1, 0xde, 0x8c, 0xaa, 0x4f, 0x01, 0xb2, 0x01, 0x9a, 0x83, 0xd4, 0x9d, 0xe9, 0x2d, 0x99, 0x19,
-	0x19, 0xe4, 0x3e, 0xb4, 0xa6, 0x6c, 0xb1, 0xe3, 0xc8, 0x92, 0xa0, 0xaf, 0x0c, 0xda, 0xc3, 0xde,
-	0x96, 0xec, 0xe3, 0xd6, 0xf3, 0x04, 0x1f, 0x55, 0xcc, 0x2c, 0x88, 0x3c, 0x81, 0xae, 0x8d, 0x53,
-	0xf1, 0x86, 0x7b, 0xbe, 0xc7, 0x05, 0xf3, 0x5c, 0xbd, 0x2d, 0xd3, 0xfe, 0x8b, 0xd3, 0x0e, 0x0a,
-	0xce, 0x51, 0xc5, 0x2c, 0x85, 0xcb, 0x36, 0x20, 0x77, 0x98, 0x4b, 0x05, 0x4e, 0xf4, 0xb5, 0xbe,
-	0x32, 0x68, 0x9a, 0x39, 0x64, 0x17, 0xa0, 0x29, 0x6b, 0x7b, 0xed, 0x0b, 0xe3, 0x31, 0xb4, 0xd2,
-	0x32, 0xc8, 0xff, 0x50, 0xf7, 0x91, 0x33, 0x6f, 0x22, 0x27, 0xa7, 0x9a, 0xb1, 0x15, 0xe2, 0x34,
-	0xba, 0x40, 0x34, 0xb2, 0xd8, 0x32, 0x5e, 0x41, 0xb7, 0x58, 0xcc, 0xa5, 0x0c, 0xb7, 0xa1, 0x23,
-	0xd0, 0x3d, 0x3a, 0xf1, 0xe6, 0x33, 0xea, 0x4e, 0xc4, 0x49, 0x4c, 0x54, 0x04, 0x8d, 0xef, 0x0a,
-	0x74, 0x13, 0x29, 0xed, 0x58, 0x92, 0xf0, 0x1e, 0xd4, 0x2d, 0x8e, 0x54, 0xa0, 0x24, 0xcc, 0x9a,
-	0x90, 0x84, 0xed, 0x49, 0xe7, 0xa8, 0x62, 0xc6, 0x61, 0xe4, 0x21, 0x34, 0xcf, 0x99, 0x38, 0x99,
-	0x70, 0x7a, 0x2e, 0x0f, 0x69, 0x0f, 0xaf, 0x95, 0x52, 0x3e, 0xc4, 0xee, 0x51, 0xc5, 0x4c, 0x43,
-	0xc9, 0x23, 0x68, 0xa5, 0x1d, 0x92, 0xd2, 0x6b, 0x0f, 0xf5, 0x52, 0xde, 0x51, 0xe2, 0x0f, 0xc7,
-	0x95, 0x06, 0x93, 0x2e, 0x54, 0x45, 0x20, 0xd5, 0xa8, 0x99, 0x55, 0x11, 0xec, 0x36, 0x40, 0x3b,
-	0xa3, 0xf6, 0x1c, 0x8d, 0x6f, 0xd5, 0xec, 0x36, 0x51, 0x99, 0x45, 0xf9, 0x2b, 0xbf, 0x94, 0x7f,
-	0x75, 0x85, 0xfc, 0xd5, 0x55, 0xf2, 0xaf, 0x2d, 0xc9, 0xbf, 0x24, 0x70, 0x6d, 0x59, 0xe0, 0xa9,
-	0x84, 0xeb, 0x97, 0x4a, 0xb8, 0xf1, 0x7b, 0x12, 0x6e, 0x5e, 0x49, 0xc2, 0x05, 0x89, 0x0e, 0xa1,
-	0x57, 0x1e, 0xdd, 0xaa, 0x77, 0xc6, 0xd8, 0x86, 0x7f, 0x96, 0xc6, 0xb6, 0x32, 0x89, 0xc2, 0xba,
-	0x89, 0x16, 0x32, 0x5f, 0xa4, 0xef, 0xd9, 0x2d, 0xa8, 0xf9, 0x1c, 0xcf, 0x62, 0xf1, 0xad, 0x97,
-	0x14, 0x61, 0x4a, 0x27, 0xb9, 0x03, 0x0d, 0x6b, 0xce, 0x39, 0xc6, 0xfb, 0x71, 0x41, 0x5c, 0xe2,
-	0x37, 0xde, 0x43, 0xe7, 0xc0, 0xb3, 0xa8, 0x9d, 0x1e, 0x70, 0x17, 0x9a, 0x49, 0x05, 0x97, 0x1d,
-	0x92, 0x06, 0x10, 0x1d, 0x1a, 0x62, 0xb1, 0xef, 0x4e, 0x70, 0x11, 0xcb, 0x23, 0x31, 0x8d, 0x29,
-	0x6c, 0x9a, 0xe8, 0xdb, 0xc1, 0xdb, 0x39, 0xf2, 0xe0, 0xaa, 0xdd, 0x22, 0x03, 0x58, 0xa7, 0x67,
-	0x94, 0xd9, 0x74, 0x6c, 0xe3, 0x4e, 0x7e, 0xd1, 0xcb, 0xb0, 0xf1, 0x45, 0x81, 0x35, 0x13, 0x4f,
-	0x93, 0x13, 0x66, 0xa1, 0x66, 0x27, 0x8c, 0xa3, 0x5c, 0x56, 0xc9, 0xac, 0x99, 0x19, 0x10, 0xea,
-	0xc9, 0x4a, 0xe9, 0x34, 0x33, 0x32, 0xc2, 0x6b, 0x4c, 0xb9, 0xe7, 0xbc, 0xc4, 0x20, 0x56, 0x71,
-	0x62, 0x16, 0x1f, 0xe8, 0xda, 0x8a, 0x07, 0x7a, 0x59, 0xbf, 0xc6, 0x0f, 0x15, 0x3a, 0xb2, 0x0f,
-	0x7f, 0x3f, 0x48, 0x7f, 0xd0, 0x07, 0x89, 0xf4, 0x40, 0xfd, 0x88, 0x81, 0xde, 0x91, 0x65, 0x86,
-	0x3f, 0x0b, 0xfb, 0xbf, 0x0b, 0xdd, 0xc2, 0x4c, 0xc3, 0x2b, 0xe4, 0x97, 0x46, 0x1d, 0xb4, 0x87,
-	0x1b, 0x71, 0x29, 0x85, 0xc0, 0x6c, 0x73, 0x86, 0x9f, 0x95, 0x2c, 0x85, 0x1c, 0xc0, 0xbf, 0x2f,
-	0x50, 0x2c, 0x6d, 0x49, 0x2f, 0xe5, 0x38, 0x3d, 0x14, 0x9c, 0xb9, 0xc7, 0x9b, 0x37, 0xf3, 0xac,
-	0x17, 0xae, 0x96, 0x51, 0x21, 0x0f, 0xa0, 0x53, 0x70, 0x5d, 0xc0, 0x53, 0x5e, 0x69, 0xa3, 0x32,
-	0xae, 0xcb, 0x3f, 0x4f, 0xdb, 0x3f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xd2, 0x37, 0x34, 0x48, 0x63,
-	0x09, 0x00, 0x00,
+	// 952 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x57, 0xd9, 0x6e, 0x23, 0x45,
+	0x14, 0xf5, 0xbe, 0x5c, 0xc7, 0x6d, 0x4f, 0x31, 0x40, 0x2b, 0x42, 0xc8, 0x34, 0x3c, 0x18, 0x21,
+	0x85, 0x91, 0xc3, 0x26, 0x21, 0x81, 0x92, 0xb0, 0x78, 0x44, 0xd8, 0x6a, 0x0c, 0x3c, 0xf1, 0x50,
+	0x69, 0x5f, 0x4f, 0x4a, 0x74, 0x57, 0x7b, 0xaa, 0xcb, 0x49, 0x9a, 0x8f, 0xe0, 0x0b, 0xf8, 0x0a,
+	0xf8, 0x03, 0xfe, 0x82, 0xbf, 0x41, 0x55, 0xbd, 0xb7, 0x6d, 0x0c, 0x81, 0x07, 0x1e, 0x78, 0xf3,
+	0x5d, 0xeb, 0xd6, 0xa9, 0x7b, 0x4f, 0x5f, 0x83, 0xb5, 0x11, 0x2b, 0x89, 0xf8, 0x23, 0x9e, 0xac,
+	0x65, 0xa0, 0x02, 0xd2, 0x56, 0xd1, 0x1a, 0xc3, 0xe3, 0x23, 0x37, 0xf0, 0xfd, 0x40, 0xc4, 0x4a,
+	0xe7, 0xb7, 0x16, 0xf4, 0xbe, 0x49, 0xfc, 0xc8, 0xcb, 0x00, 0x69, 0xcc, 0xe3, 0x8f, 0xec, 0xfa,
+	0xa4, 0x3e, 0xed, 0xd3, 0x82, 0x86, 0xbc, 0x04, 0xfd, 0x50, 0x31, 0xa9, 0x16, 0xdc, 0x47, 0xbb,
+	0x31, 0xa9, 0x4f, 0x9b, 0x34, 0x57, 0x68, 0x2b, 0x0b, 0x43, 0x54, 0x1f, 0xdf, 0xa1, 0x6b, 0x37,
+	0x4d, 0x70, 0xae, 0x20, 0x13, 0x18, 0x18, 0xe1, 0x49, 0xe4, 0x5f, 0x05, 0x9e, 0xdd, 0x32, 0xf6,
+	0xa2, 0x4a, 0x9f, 0xae, 0x02, 0xc5, 0xbc, 0x8b, 0x60, 0x23, 0x94, 0xdd, 0x36, 0xe9, 0x0b, 0x1a,
+	0x9d, 0x9f, 0x0b, 0xae, 0x38, 0x53, 0x81, 0xb4, 0x3b, 0x71, 0xfe, 0x4c, 0xa1, 0xf3, 0x5f, 0xa1,
+	0xc0, 0x15, 0x77, 0x39, 0x93, 0x91, 0xdd, 0x8d, 0xf3, 0x17, 0x54, 0x3a, 0x5e, 0xa2, 0xcf, 0xb8,
+	0xe0, 0xe2, 0xa9, 0xdd, 0x8b, 0xab, 0xcf, 0x14, 0xe4, 0x21, 0xb4, 0x7d, 0x64, 0x22, 0xb4, 0xfb,
+	0x26, 0x32, 0x16, 0xc8, 0x23, 0xe8, 0xaf, 0xf8, 0xdd, 0x99, 0x6f, 0x4a, 0x82, 0x49, 0x7d, 0x3a,
+	0x98, 0x8d, 0x4f, 0x0c, 0x8e, 0x27, 0x9f, 0xa4, 0xfa, 0x79, 0x8d, 0xe6, 0x4e, 0xe4, 0x43, 0xb0,
+	0x3c, 0x5c, 0xa9, 0xaf, 0x64, 0xb0, 0x0e, 0xa4, 0xe2, 0x81, 0xb0, 0x07, 0x26, 0xec, 0xf9, 0x24,
+	0xec, 0xb2, 0x64, 0x9c, 0xd7, 0x68, 0xc5, 0x9d, 0xbc, 0x03, 0x03, 0xd7, 0xe3, 0xab, 0xd5, 0x25,
+	0x17, 0xc8, 0xa4, 0x3d, 0x34, 0xd1, 0x24, 0x89, 0xbe, 0xc8, 0x2d, 0xf3, 0x1a, 0x2d, 0x3a, 0x92,
+	0x53, 0x00, 0x9f, 0x7b, 0x18, 0xaa, 0x40, 0x60, 0x68, 0x5b, 0x26, 0xec, 0x41, 0x12, 0xf6, 0x79,
+	0x66, 0x98, 0xd7, 0x68, 0xc1, 0xcd, 0x60, 0x8e, 0xd2, 0xe7, 0x82, 0x29, 0x5c, 0xda, 0x47, 0x93,
+	0xfa, 0xb4, 0x47, 0x0b, 0x1a, 0x62, 0x43, 0x57, 0xe2, 0x72, 0xe3, 0xe2, 0xd2, 0x1e, 0x19, 0xc4,
+	0x52, 0xf1, 0x1c, 0xa0, 0x67, 0x20, 0xfa, 0x72, 0xad, 0x9c, 0xf7, 0xa1, 0x9f, 0xa1, 0x41, 0x5e,
+	0x80, 0xce, 0x1a, 0x25, 0x0f, 0x96, 0xa6, 0x81, 0x9a, 0x34, 0x91, 0xb4, 0x9e, 0xc5, 0x38, 0xc6,
+	0x9d, 0x93, 0x48, 0xce, 0x17, 0x60, 0x95, 0x31, 0xd9, 0x9b, 0xe1, 0x35, 0x18, 0x2a, 0x14, 0x8b,
+	0xeb, 0x60, 0x13, 0x32, 0xb1, 0x54, 0xd7, 0x49, 0xa2, 0xb2, 0xd2, 0xf9, 0x1e, 0x06, 0x05, 0x94,
+	0xf4, 0xab, 0x1b, 0x94, 0x4c, 0xcf, 0xc6, 0xf9, 0x72, 0x85, 0xbe, 0x1f, 0x8a, 0x65, 0xa1, 0x9f,
+	0x53, 0xb1, 0x50, 0x44, 0xb3, 0x58, 0x84, 0xf3, 0x2e, 0xf4, 0x33, 0x34, 0x09, 0x81, 0x96, 0xca,
+	0xf3, 0xb6, 0x54, 0x12, 0xb8, 0xf3, 0x9e, 0x1f, 0x00, 0xe4, 0xcf, 0x40, 0x1e, 0x95, 0x5e, 0xab,
+	0x3e, 0x69, 0x16, 0x3a, 0x2b, 0x73, 0x2b, 0x3e, 0x95, 0xf3, 0x6b, 0x03, 0xac, 0x74, 0x52, 0xcf,
+	0x5c, 0x03, 0xd4, 0x9b, 0xd0, 0x71, 0x25, 0x32, 0x15, 0x17, 0x90, 0xf7, 0x58, 0xea, 0x76, 0x61,
+	0x8c, 0xf3, 0x1a, 0x4d, 0xdc, 0xc8, 0xdb, 0xd0, 0xbb, 0xe5, 0xea, 0x7a, 0x29, 0xd9, 0xad, 0xa9,
+	0x6e, 0x30, 0x7b, 0xb1, 0x12, 0xf2, 0x5d, 0x62, 0x9e, 0xd7, 0x68, 0xe6, 0x4a, 0xde, 0x83, 0x7e,
+	0xd6, 0x13, 0x06, 0x8e, 0xc1, 0xcc, 0xae, 0xc4, 0x2d, 0x52, 0xbb, 0x9e, 0x86, 0xcc, 0x59, 0x1f,
+	0xa8, 0x24, 0x13, 0xe1, 0x0a, 0xa5, 0xdd, 0xde, 0x79, 0xe0, 0x22, 0x31, 0xeb, 0x03, 0x53, 0x57,
+	0x7d, 0xb1, 0xb8, 0xcf, 0xec, 0xce, 0xce, 0x8b, 0x51, 0x63, 0xd4, 0x17, 0x8b, 0xdd, 0x88, 0x05,
+	0x0d, 0x15, 0x19, 0x52, 0x69, 0xd3, 0x86, 0x8a, 0xce, 0xbb, 0xd0, 0xbe, 0x61, 0xde, 0x06, 0x9d,
+	0x5f, 0x9a, 0x60, 0x95, 0xe1, 0x28, 0xb3, 0x58, 0xfd, 0x4f, 0x59, 0xac, 0x71, 0x80, 0xc5, 0x9a,
+	0x87, 0x58, 0xac, 0xb5, 0xc5, 0x62, 0x15, 0x9e, 0x6a, 0x6f, 0xf3, 0x54, 0xc6, 0x44, 0x9d, 0xbd,
+	0x4c, 0xd4, 0xbd, 0x1f, 0x13, 0xf5, 0xfe, 0x11, 0x13, 0xf5, 0xef, 0xc7, 0x44, 0xf0, 0x97, 0x98,
+	0xa8, 0xc4, 0x27, 0x33, 0x18, 0x57, 0xfb, 0xf1, 0xd0, 0xb7, 0xc9, 0x39, 0x85, 0x07, 0x5b, 0xbd,
+	0x78, 0x30, 0x68, 0x01, 0xe3, 0x6a, 0x1f, 0x1e, 0x8a, 0xa9, 0x3e, 0x60, 0x63, 0xeb, 0x01, 0x9d,
+	0x39, 0x58, 0xe5, 0x46, 0x3d, 0x98, 0x73, 0x1f, 0x67, 0x30, 0x18, 0x51, 0x74, 0x91, 0xaf, 0x55,
+	0xf6, 0x8d, 0x7e, 0x15, 0x5a, 0x6b, 0x89, 0x37, 0xc9, 0xc4, 0x8f, 0xaa, 0x83, 0x61, 0x8c, 0xe4,
+	0x75, 0xe8, 0xba, 0x1b, 0x29, 0x31, 0x49, 0xb8, 0xc3, 0x2f, 0xb5, 0x3b, 0xdf, 0xc2, 0xf0, 0x32,
+	0x70, 0x99, 0x97, 0x1d, 0xf0, 0x06, 0xf4, 0xd2, 0xca, 0xf6, 0x1d, 0x92, 0x39, 0x68, 0xfe, 0x54,
+	0x77, 0x8f, 0xc5, 0x12, 0xef, 0x12, 0x20, 0x52, 0xd1, 0x59, 0xc1, 0x31, 0xc5, 0xb5, 0x17, 0x7d,
+	0xbd, 0x41, 0x19, 0xfd, 0xdd, 0xd7, 0x24, 0x53, 0x18, 0xb1, 0x1b, 0xc6, 0x3d, 0x76, 0xe5, 0xe1,
+	0x59, 0x11, 0x99, 0xaa, 0xda, 0xf9, 0xb9, 0x0e, 0x47, 0x14, 0x9f, 0xa5, 0x27, 0x84, 0x7a, 0x80,
+	0x97, 0x5c, 0xa2, 0x61, 0x48, 0x93, 0xb9, 0x4d, 0x73, 0x85, 0x1e, 0x2e, 0x37, 0x4b, 0xd7, 0xa6,
+	0xb1, 0xa0, 0xaf, 0xb1, 0x92, 0x81, 0xff, 0x19, 0x46, 0xc9, 0x48, 0xa7, 0x62, 0x79, 0xe9, 0x68,
+	0x1d, 0x58, 0x3a, 0xb6, 0x87, 0xd9, 0xf9, 0xbd, 0x05, 0x43, 0x83, 0xc3, 0xff, 0x4b, 0xd6, 0x7f,
+	0x78, 0xc9, 0xb2, 0xee, 0x47, 0x6d, 0xa3, 0x7f, 0x67, 0xc9, 0x1a, 0x43, 0xf3, 0x07, 0x8c, 0xcc,
+	0xa6, 0xd7, 0xa7, 0xfa, 0x67, 0x71, 0xed, 0x1a, 0xef, 0x5f, 0xbb, 0xce, 0xc1, 0x2a, 0xb5, 0x96,
+	0x46, 0xb2, 0x38, 0xbb, 0x7a, 0xa7, 0x78, 0x98, 0x14, 0x57, 0x72, 0xcc, 0x07, 0x78, 0xf6, 0x53,
+	0x3d, 0x0f, 0x21, 0x97, 0xf0, 0xdc, 0xa7, 0xa8, 0xb6, 0x86, 0x75, 0x9c, 0xe5, 0x78, 0xf6, 0x44,
+	0x49, 0x2e, 0x9e, 0x1e, 0xbf, 0x52, 0xcc, 0xba, 0x73, 0xc2, 0x9d, 0x1a, 0x79, 0x0b, 0x86, 0x25,
+	0xd3, 0x8e, 0x3c, 0x55, 0x66, 0x71, 0x6a, 0x57, 0x1d, 0xf3, 0xbf, 0xe4, 0xf4, 0x8f, 0x01, 0x00,
+	0xd3, 0x03, 0x2c, 0x23, 0xbe, 0x0c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -38,6 +38,7 @@ func ValCmd() *cobra.Command {
 		IsSyncCmd(),
 		GetBlockInfoCmd(),
 		GetNodeInfoCmd(),
+		GetJailCmd(),
 		AddNodeCmd(),
 		CreateCmd(),
 	)
@@ -111,6 +112,44 @@ func getBlockInfo(cmd *cobra.Command, args []string) {
 	ctx.Run()
 }
 
+// GetJailCmd get validator jail record
+func GetJailCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jail",
+		Short: "Get the punishment of a double signing validator",
+		Run:   getJail,
+	}
+	addGetJailFlags(cmd)
+	return cmd
+}
+
+func addGetJailFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("pubkey", "p", "", "public key of the validator")
+	cmd.MarkFlagRequired("pubkey")
+}
+
+func getJail(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	pubkey, _ := cmd.Flags().GetString("pubkey")
+	pubkeyBytes, err := hex.DecodeString(pubkey)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	req := &vt.ReqValNodeJail{
+		PubKey: pubkeyBytes,
+	}
+	params := rpctypes.Query4Jrpc{
+		Execer:   vt.ValNodeX,
+		FuncName: "GetValNodeJail",
+		Payload:  types.MustPBToJSON(req),
+	}
+
+	var res vt.ValNodeJail
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &res)
+	ctx.Run()
+}
+
 // AddNodeCmd add validator node
 func AddNodeCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -31,7 +31,15 @@ func (val *ValNode) Exec_Node(node *pty.ValNode, tx *types.Transaction, index in
 
 // Exec_BlockInfo method
 func (val *ValNode) Exec_BlockInfo(blockInfo *pty.TendermintBlockInfo, tx *types.Transaction, index int) (*types.Receipt, error) {
-	receipt := &types.Receipt{Ty: types.ExecOk, KV: nil, Logs: nil}
+	logs, err := val.release()
+	if err != nil {
+		return nil, err
+	}
+	kvs, punishLogs, err := val.punish(blockInfo)
+	if err != nil {
+		return nil, err
+	}
+	receipt := &types.Receipt{Ty: types.ExecOk, KV: kvs, Logs: append(logs, punishLogs...)}
 	return receipt, nil
 }
 
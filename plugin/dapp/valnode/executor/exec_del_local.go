@@ -22,5 +22,11 @@ func (val *ValNode) ExecDelLocal_BlockInfo(blockInfo *pty.TendermintBlockInfo, t
 	set := &types.LocalDBSet{}
 	key := CalcValNodeBlockInfoHeightKey(val.GetHeight())
 	set.KV = append(set.KV, &types.KeyValue{Key: key, Value: nil})
+	for i, log := range receipt.GetLogs() {
+		if log.Ty == pty.TyLogValNodeJail || log.Ty == pty.TyLogValNodeRelease {
+			key := CalcValNodeUpdateHeightIndexLogKey(val.GetHeight(), index, i)
+			set.KV = append(set.KV, &types.KeyValue{Key: key, Value: nil})
+		}
+	}
 	return set, nil
 }
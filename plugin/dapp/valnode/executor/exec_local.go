@@ -25,5 +25,25 @@ func (val *ValNode) ExecLocal_BlockInfo(blockInfo *pty.TendermintBlockInfo, tx *
 	set := &types.LocalDBSet{}
 	key := CalcValNodeBlockInfoHeightKey(val.GetHeight())
 	set.KV = append(set.KV, &types.KeyValue{Key: key, Value: types.Encode(blockInfo)})
+	//惩罚和恢复的验证者在本高度更新投票权
+	for i, log := range receipt.GetLogs() {
+		var jail pty.ValNodeJail
+		switch log.Ty {
+		case pty.TyLogValNodeJail, pty.TyLogValNodeRelease:
+			err := types.Decode(log.Log, &jail)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			continue
+		}
+		node := &pty.ValNode{PubKey: jail.PubKey}
+		if log.Ty == pty.TyLogValNodeRelease {
+			node.Power = jail.Power
+		}
+		clog.Info("update validator", "pubkey", hex.EncodeToString(node.GetPubKey()), "power", node.GetPower())
+		key := CalcValNodeUpdateHeightIndexLogKey(val.GetHeight(), index, i)
+		set.KV = append(set.KV, &types.KeyValue{Key: key, Value: types.Encode(node)})
+	}
 	return set, nil
 }
@@ -0,0 +1,190 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/33cn/chain33/common/crypto"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	pty "github.com/33cn/plugin/plugin/dapp/valnode/types"
+)
+
+// jailBlocksKey 作恶验证者被禁止的区块数, 影响共识, 所有节点必须一致,
+// 由管理员通过manage合约配置, 取最后一次配置的值, 没有配置时永久移除
+const jailBlocksKey = "valnode-jailBlocks"
+
+func calcValNodeJailKey(pubKey []byte) []byte {
+	return []byte("mavl-valnode-jail-" + hex.EncodeToString(pubKey))
+}
+
+func calcValNodeEvidenceKey(hash []byte) []byte {
+	return []byte("mavl-valnode-evidence-" + hex.EncodeToString(hash))
+}
+
+func calcValNodeReleaseKey(height int64) []byte {
+	return []byte(fmt.Sprintf("mavl-valnode-release-%018d", height))
+}
+
+func getValNodeJail(db dbm.KV, pubKey []byte) (*pty.ValNodeJail, error) {
+	value, err := db.Get(calcValNodeJailKey(pubKey))
+	if err != nil {
+		return nil, err
+	}
+	jail := &pty.ValNodeJail{}
+	err = types.Decode(value, jail)
+	if err != nil {
+		return nil, err
+	}
+	return jail, nil
+}
+
+// verifyEvidence 验证证据的签名, 证据不能晚于当前区块, 也不能超过共识参数中的最大有效期
+func verifyEvidence(ev *ttypes.DuplicateVoteEvidence, state *pty.State, height int64) error {
+	if ev.GetVoteA() == nil || ev.Height() > height {
+		return ttypes.ErrEvidenceInvalid
+	}
+	maxAge := state.GetConsensusParams().GetEvidenceParams().GetMaxAge()
+	if maxAge > 0 && ev.Height() < height-maxAge {
+		return ttypes.ErrEvidenceTooOld
+	}
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	if err != nil {
+		return err
+	}
+	return ev.Verify(state.GetChainID(), cr)
+}
+
+// getJailBlocks 从manage合约的配置中读取禁止的区块数, 没有配置或者配置错误时返回0
+func getJailBlocks(db dbm.KV) int64 {
+	value, err := db.Get([]byte(types.ManageKey(jailBlocksKey)))
+	if err != nil {
+		return 0
+	}
+	var item types.ConfigItem
+	err = types.Decode(value, &item)
+	if err != nil {
+		clog.Error("getJailBlocks decode fail", "err", err)
+		return 0
+	}
+	values := item.GetArr().GetValue()
+	if len(values) == 0 {
+		return 0
+	}
+	blocks, err := strconv.ParseInt(values[len(values)-1], 10, 64)
+	if err != nil || blocks < 0 {
+		clog.Error("getJailBlocks invalid value", "value", values[len(values)-1], "err", err)
+		return 0
+	}
+	return blocks
+}
+
+// punish 惩罚区块中证据对应的验证者, 投票权设为0, 配置了禁止的区块数时到期后恢复原来的投票权
+func (val *ValNode) punish(blockInfo *pty.TendermintBlockInfo) ([]*types.KeyValue, []*types.ReceiptLog, error) {
+	var kvs []*types.KeyValue
+	var logs []*types.ReceiptLog
+	height := val.GetHeight()
+	jailBlocks := getJailBlocks(val.GetStateDB())
+	state := blockInfo.GetState()
+	var releases *pty.ValNodeJails
+	punished := make(map[string]bool)
+	for _, item := range blockInfo.GetBlock().GetEvidence() {
+		ev := &ttypes.DuplicateVoteEvidence{DuplicateVoteEvidence: item}
+		if err := verifyEvidence(ev, state, height); err != nil {
+			clog.Error("punish verify evidence fail", "height", height, "err", err)
+			return nil, nil, err
+		}
+		hash := ev.Hash()
+		if _, err := val.GetStateDB().Get(calcValNodeEvidenceKey(hash)); err == nil {
+			clog.Info("punish evidence already committed", "hash", hex.EncodeToString(hash))
+			continue
+		}
+		var offender *pty.Validator
+		for _, v := range state.GetValidators().GetValidators() {
+			if bytes.Equal(v.GetPubKey(), ev.PubKey) {
+				offender = v
+			}
+		}
+		//已经不是验证者的不再惩罚
+		if offender == nil || offender.GetVotingPower() == 0 {
+			clog.Info("punish validator not found", "pubkey", hex.EncodeToString(ev.PubKey))
+			continue
+		}
+		//同一个验证者的多个证据只惩罚一次
+		if punished[string(ev.PubKey)] {
+			continue
+		}
+		punished[string(ev.PubKey)] = true
+		jail := &pty.ValNodeJail{
+			PubKey:       ev.PubKey,
+			Power:        offender.GetVotingPower(),
+			Height:       height,
+			EvidenceHash: hash,
+		}
+		if jailBlocks > 0 {
+			jail.ReleaseHeight = height + jailBlocks
+			if releases == nil {
+				var err error
+				releases, err = val.getReleases(jail.ReleaseHeight)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			releases.Jails = append(releases.Jails, jail)
+		}
+		clog.Info("punish validator", "pubkey", hex.EncodeToString(ev.PubKey), "power", jail.Power, "releaseHeight", jail.ReleaseHeight)
+		value := types.Encode(jail)
+		kvs = append(kvs, &types.KeyValue{Key: calcValNodeJailKey(ev.PubKey), Value: value})
+		kvs = append(kvs, &types.KeyValue{Key: calcValNodeEvidenceKey(hash), Value: value})
+		logs = append(logs, &types.ReceiptLog{Ty: pty.TyLogValNodeJail, Log: value})
+	}
+	if releases != nil {
+		kvs = append(kvs, &types.KeyValue{Key: calcValNodeReleaseKey(height + jailBlocks), Value: types.Encode(releases)})
+	}
+	return kvs, logs, nil
+}
+
+func (val *ValNode) getReleases(height int64) (*pty.ValNodeJails, error) {
+	releases := &pty.ValNodeJails{}
+	value, err := val.GetStateDB().Get(calcValNodeReleaseKey(height))
+	if err == types.ErrNotFound {
+		return releases, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = types.Decode(value, releases)
+	if err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+// release 恢复到期的验证者的投票权, 期间再次被惩罚的不恢复
+func (val *ValNode) release() ([]*types.ReceiptLog, error) {
+	var logs []*types.ReceiptLog
+	height := val.GetHeight()
+	releases, err := val.getReleases(height)
+	if err != nil {
+		return nil, err
+	}
+	for _, jail := range releases.GetJails() {
+		current, err := getValNodeJail(val.GetStateDB(), jail.PubKey)
+		if err != nil {
+			return nil, err
+		}
+		if current.ReleaseHeight != height {
+			continue
+		}
+		clog.Info("release validator", "pubkey", hex.EncodeToString(jail.PubKey), "power", jail.Power)
+		logs = append(logs, &types.ReceiptLog{Ty: pty.TyLogValNodeRelease, Log: types.Encode(jail)})
+	}
+	return logs, nil
+}
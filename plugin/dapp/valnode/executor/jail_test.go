@@ -0,0 +1,125 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"strings"
+	"testing"
+
+	apimock "github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/common/crypto"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	pty "github.com/33cn/plugin/plugin/dapp/valnode/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var chain33TestCfg = types.NewChain33Config(strings.Replace(types.GetDefaultCfgstring(), "Title=\"local\"", "Title=\"chain33\"", 1))
+
+func init() {
+	Init(pty.ValNodeX, chain33TestCfg, nil)
+}
+
+func makeTestEvidence(t *testing.T, pv *ttypes.PrivValidatorImp, height int64) *pty.DuplicateVoteEvidence {
+	var votes []*ttypes.Vote
+	for _, hash := range []string{"hashA", "hashB"} {
+		vote := &ttypes.Vote{Vote: &pty.Vote{
+			ValidatorAddress: pv.GetAddress(),
+			Height:           height,
+			Type:             uint32(ttypes.VoteTypePrecommit),
+			BlockID:          &pty.BlockID{Hash: []byte(hash)},
+		}}
+		require.Nil(t, pv.SignVote("test", vote))
+		votes = append(votes, vote)
+	}
+	return ttypes.NewDuplicateVoteEvidence(pv.GetPubKey().Bytes(), votes[0], votes[1]).DuplicateVoteEvidence
+}
+
+func execTestBlockInfo(t *testing.T, val *ValNode, kvdb dbm.KVDB, height int64, blockInfo *pty.TendermintBlockInfo) *types.Receipt {
+	val.SetEnv(height, 0, 0)
+	receipt, err := val.Exec_BlockInfo(blockInfo, &types.Transaction{}, 0)
+	require.Nil(t, err)
+	for _, kv := range receipt.KV {
+		require.Nil(t, val.GetStateDB().Set(kv.Key, kv.Value))
+	}
+	set, err := val.ExecLocal_BlockInfo(blockInfo, &types.Transaction{}, &types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs}, 0)
+	require.Nil(t, err)
+	for _, kv := range set.KV {
+		require.Nil(t, kvdb.Set(kv.Key, kv.Value))
+	}
+	return receipt
+}
+
+func TestPunishAndRelease(t *testing.T) {
+	cr, err := crypto.New(types.GetSignName("", types.ED25519))
+	require.Nil(t, err)
+	ttypes.ConsensusCrypto = cr
+	pv := ttypes.GenPrivValidatorImp("")
+	pubKey := pv.GetPubKey().Bytes()
+
+	stateDB, _ := dbm.NewGoMemDB("state", "", 100)
+	dir, ldb, kvdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, ldb)
+	api := new(apimock.QueueProtocolAPI)
+	api.On("GetConfig", mock.Anything).Return(chain33TestCfg, nil)
+	val := newValNode().(*ValNode)
+	val.SetAPI(api)
+	val.SetStateDB(stateDB)
+	val.SetLocalDB(kvdb)
+	assert.Equal(t, int64(0), getJailBlocks(stateDB))
+	item := &types.ConfigItem{
+		Key:   jailBlocksKey,
+		Value: &types.ConfigItem_Arr{Arr: &types.ArrayConfig{Value: []string{"100", "5"}}},
+	}
+	require.Nil(t, stateDB.Set([]byte(types.ManageKey(jailBlocksKey)), types.Encode(item)))
+	assert.Equal(t, int64(5), getJailBlocks(stateDB))
+
+	ev := makeTestEvidence(t, pv, 9)
+	state := &pty.State{
+		ChainID:         "test",
+		Validators:      &pty.ValidatorSet{Validators: []*pty.Validator{{Address: pv.GetAddress(), PubKey: pubKey, VotingPower: 10}}},
+		ConsensusParams: &pty.ConsensusParams{EvidenceParams: &pty.EvidenceParams{MaxAge: 100}},
+	}
+	blockInfo := &pty.TendermintBlockInfo{State: state, Block: &pty.TendermintBlock{Evidence: []*pty.DuplicateVoteEvidence{ev, ev}}}
+	receipt := execTestBlockInfo(t, val, kvdb, 10, blockInfo)
+	require.Equal(t, 1, len(receipt.Logs))
+	assert.Equal(t, int32(pty.TyLogValNodeJail), receipt.Logs[0].Ty)
+
+	jail, err := getValNodeJail(stateDB, pubKey)
+	require.Nil(t, err)
+	assert.Equal(t, int64(10), jail.Power)
+	assert.Equal(t, int64(15), jail.ReleaseHeight)
+	reply, err := val.Query_GetValNodeByHeight(&pty.ReqNodeInfo{Height: 10})
+	require.Nil(t, err)
+	nodes := reply.(*pty.ValNodes).Nodes
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, int64(0), nodes[0].Power)
+
+	//已经处理过的证据不再惩罚
+	receipt = execTestBlockInfo(t, val, kvdb, 11, blockInfo)
+	assert.Equal(t, 0, len(receipt.Logs))
+
+	//到期后恢复投票权
+	blockInfo.Block.Evidence = nil
+	receipt = execTestBlockInfo(t, val, kvdb, 15, blockInfo)
+	require.Equal(t, 1, len(receipt.Logs))
+	assert.Equal(t, int32(pty.TyLogValNodeRelease), receipt.Logs[0].Ty)
+	reply, err = val.Query_GetValNodeByHeight(&pty.ReqNodeInfo{Height: 15})
+	require.Nil(t, err)
+	nodes = reply.(*pty.ValNodes).Nodes
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, int64(10), nodes[0].Power)
+
+	//签名错误的证据使区块执行失败
+	ev.VoteB.Signature = ev.VoteA.Signature
+	blockInfo.Block.Evidence = []*pty.DuplicateVoteEvidence{ev}
+	val.SetEnv(16, 0, 0)
+	_, err = val.Exec_BlockInfo(blockInfo, &types.Transaction{}, 0)
+	assert.NotNil(t, err)
+}
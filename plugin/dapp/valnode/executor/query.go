@@ -60,3 +60,11 @@ func (val *ValNode) Query_GetBlockInfoByHeight(in *pty.ReqBlockInfo) (types.Mess
 	}
 	return reply, nil
 }
+
+// Query_GetValNodeJail 查询验证者的惩罚记录
+func (val *ValNode) Query_GetValNodeJail(in *pty.ReqValNodeJail) (types.Message, error) {
+	if len(in.GetPubKey()) == 0 {
+		return nil, types.ErrInvalidParam
+	}
+	return getValNodeJail(val.GetStateDB(), in.GetPubKey())
+}
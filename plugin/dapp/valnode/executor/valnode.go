@@ -60,6 +60,11 @@ func CalcValNodeUpdateHeightIndexKey(height int64, index int) []byte {
 	return []byte(fmt.Sprintf("LODB-valnode-Update:%18d:%18d", height, int64(index)))
 }
 
+// CalcValNodeUpdateHeightIndexLogKey 区块信息交易中惩罚和恢复验证者的更新记录
+func CalcValNodeUpdateHeightIndexLogKey(height int64, index int, logIndex int) []byte {
+	return []byte(fmt.Sprintf("LODB-valnode-Update:%18d:%18d:%d", height, int64(index), logIndex))
+}
+
 // CalcValNodeUpdateHeightKey method
 func CalcValNodeUpdateHeightKey(height int64) []byte {
 	return []byte(fmt.Sprintf("LODB-valnode-Update:%18d:", height))
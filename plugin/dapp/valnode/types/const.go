@@ -13,6 +13,12 @@ const (
 	ValNodeActionBlockInfo = 2
 )
 
+// log ty
+const (
+	TyLogValNodeJail    = 1301
+	TyLogValNodeRelease = 1302
+)
+
 // action name
 const (
 	ActionNodeUpdate = "NodeUpdate"
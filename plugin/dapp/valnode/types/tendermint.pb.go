@@ -203,6 +203,62 @@ func (m *Vote) GetSignature() []byte {
 	return nil
 }
 
+//验证者在同一高度, 轮次对不同区块的两个投票
+type DuplicateVoteEvidence struct {
+	PubKey               []byte   `protobuf:"bytes,1,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	VoteA                *Vote    `protobuf:"bytes,2,opt,name=voteA,proto3" json:"voteA,omitempty"`
+	VoteB                *Vote    `protobuf:"bytes,3,opt,name=voteB,proto3" json:"voteB,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DuplicateVoteEvidence) Reset()         { *m = DuplicateVoteEvidence{} }
+func (m *DuplicateVoteEvidence) String() string { return proto.CompactTextString(m) }
+func (*DuplicateVoteEvidence) ProtoMessage()    {}
+func (*DuplicateVoteEvidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04f926c8da23c367, []int{3}
+}
+
+func (m *DuplicateVoteEvidence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DuplicateVoteEvidence.Unmarshal(m, b)
+}
+func (m *DuplicateVoteEvidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DuplicateVoteEvidence.Marshal(b, m, deterministic)
+}
+func (m *DuplicateVoteEvidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DuplicateVoteEvidence.Merge(m, src)
+}
+func (m *DuplicateVoteEvidence) XXX_Size() int {
+	return xxx_messageInfo_DuplicateVoteEvidence.Size(m)
+}
+func (m *DuplicateVoteEvidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_DuplicateVoteEvidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DuplicateVoteEvidence proto.InternalMessageInfo
+
+func (m *DuplicateVoteEvidence) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *DuplicateVoteEvidence) GetVoteA() *Vote {
+	if m != nil {
+		return m.VoteA
+	}
+	return nil
+}
+
+func (m *DuplicateVoteEvidence) GetVoteB() *Vote {
+	if m != nil {
+		return m.VoteB
+	}
+	return nil
+}
+
 type TendermintCommit struct {
 	BlockID              *BlockID `protobuf:"bytes,1,opt,name=BlockID,proto3" json:"BlockID,omitempty"`
 	Precommits           []*Vote  `protobuf:"bytes,2,rep,name=Precommits,proto3" json:"Precommits,omitempty"`
@@ -215,7 +271,7 @@ func (m *TendermintCommit) Reset()         { *m = TendermintCommit{} }
 func (m *TendermintCommit) String() string { return proto.CompactTextString(m) }
 func (*TendermintCommit) ProtoMessage()    {}
 func (*TendermintCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{3}
+	return fileDescriptor_04f926c8da23c367, []int{4}
 }
 
 func (m *TendermintCommit) XXX_Unmarshal(b []byte) error {
@@ -263,7 +319,7 @@ func (m *TendermintBlockInfo) Reset()         { *m = TendermintBlockInfo{} }
 func (m *TendermintBlockInfo) String() string { return proto.CompactTextString(m) }
 func (*TendermintBlockInfo) ProtoMessage()    {}
 func (*TendermintBlockInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{4}
+	return fileDescriptor_04f926c8da23c367, []int{5}
 }
 
 func (m *TendermintBlockInfo) XXX_Unmarshal(b []byte) error {
@@ -318,7 +374,7 @@ func (m *BlockSize) Reset()         { *m = BlockSize{} }
 func (m *BlockSize) String() string { return proto.CompactTextString(m) }
 func (*BlockSize) ProtoMessage()    {}
 func (*BlockSize) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{5}
+	return fileDescriptor_04f926c8da23c367, []int{6}
 }
 
 func (m *BlockSize) XXX_Unmarshal(b []byte) error {
@@ -372,7 +428,7 @@ func (m *TxSize) Reset()         { *m = TxSize{} }
 func (m *TxSize) String() string { return proto.CompactTextString(m) }
 func (*TxSize) ProtoMessage()    {}
 func (*TxSize) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{6}
+	return fileDescriptor_04f926c8da23c367, []int{7}
 }
 
 func (m *TxSize) XXX_Unmarshal(b []byte) error {
@@ -418,7 +474,7 @@ func (m *BlockGossip) Reset()         { *m = BlockGossip{} }
 func (m *BlockGossip) String() string { return proto.CompactTextString(m) }
 func (*BlockGossip) ProtoMessage()    {}
 func (*BlockGossip) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{7}
+	return fileDescriptor_04f926c8da23c367, []int{8}
 }
 
 func (m *BlockGossip) XXX_Unmarshal(b []byte) error {
@@ -457,7 +513,7 @@ func (m *EvidenceParams) Reset()         { *m = EvidenceParams{} }
 func (m *EvidenceParams) String() string { return proto.CompactTextString(m) }
 func (*EvidenceParams) ProtoMessage()    {}
 func (*EvidenceParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{8}
+	return fileDescriptor_04f926c8da23c367, []int{9}
 }
 
 func (m *EvidenceParams) XXX_Unmarshal(b []byte) error {
@@ -499,7 +555,7 @@ func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
 func (m *ConsensusParams) String() string { return proto.CompactTextString(m) }
 func (*ConsensusParams) ProtoMessage()    {}
 func (*ConsensusParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{9}
+	return fileDescriptor_04f926c8da23c367, []int{10}
 }
 
 func (m *ConsensusParams) XXX_Unmarshal(b []byte) error {
@@ -562,7 +618,7 @@ func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{10}
+	return fileDescriptor_04f926c8da23c367, []int{11}
 }
 
 func (m *Validator) XXX_Unmarshal(b []byte) error {
@@ -623,7 +679,7 @@ func (m *ValidatorSet) Reset()         { *m = ValidatorSet{} }
 func (m *ValidatorSet) String() string { return proto.CompactTextString(m) }
 func (*ValidatorSet) ProtoMessage()    {}
 func (*ValidatorSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{11}
+	return fileDescriptor_04f926c8da23c367, []int{12}
 }
 
 func (m *ValidatorSet) XXX_Unmarshal(b []byte) error {
@@ -680,7 +736,7 @@ func (m *State) Reset()         { *m = State{} }
 func (m *State) String() string { return proto.CompactTextString(m) }
 func (*State) ProtoMessage()    {}
 func (*State) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{12}
+	return fileDescriptor_04f926c8da23c367, []int{13}
 }
 
 func (m *State) XXX_Unmarshal(b []byte) error {
@@ -799,6 +855,7 @@ type TendermintBlockHeader struct {
 	AppHash              []byte   `protobuf:"bytes,11,opt,name=appHash,proto3" json:"appHash,omitempty"`
 	LastResultsHash      []byte   `protobuf:"bytes,12,opt,name=lastResultsHash,proto3" json:"lastResultsHash,omitempty"`
 	ProposerAddr         []byte   `protobuf:"bytes,13,opt,name=proposerAddr,proto3" json:"proposerAddr,omitempty"`
+	EvidenceHash         []byte   `protobuf:"bytes,14,opt,name=evidenceHash,proto3" json:"evidenceHash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -808,7 +865,7 @@ func (m *TendermintBlockHeader) Reset()         { *m = TendermintBlockHeader{} }
 func (m *TendermintBlockHeader) String() string { return proto.CompactTextString(m) }
 func (*TendermintBlockHeader) ProtoMessage()    {}
 func (*TendermintBlockHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{13}
+	return fileDescriptor_04f926c8da23c367, []int{14}
 }
 
 func (m *TendermintBlockHeader) XXX_Unmarshal(b []byte) error {
@@ -920,20 +977,28 @@ func (m *TendermintBlockHeader) GetProposerAddr() []byte {
 	return nil
 }
 
+func (m *TendermintBlockHeader) GetEvidenceHash() []byte {
+	if m != nil {
+		return m.EvidenceHash
+	}
+	return nil
+}
+
 type TendermintBlock struct {
-	Header               *TendermintBlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Data                 *types.Block           `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	LastCommit           *TendermintCommit      `protobuf:"bytes,4,opt,name=lastCommit,proto3" json:"lastCommit,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Header               *TendermintBlockHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Data                 *types.Block             `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	LastCommit           *TendermintCommit        `protobuf:"bytes,4,opt,name=lastCommit,proto3" json:"lastCommit,omitempty"`
+	Evidence             []*DuplicateVoteEvidence `protobuf:"bytes,5,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
 func (m *TendermintBlock) Reset()         { *m = TendermintBlock{} }
 func (m *TendermintBlock) String() string { return proto.CompactTextString(m) }
 func (*TendermintBlock) ProtoMessage()    {}
 func (*TendermintBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{14}
+	return fileDescriptor_04f926c8da23c367, []int{15}
 }
 
 func (m *TendermintBlock) XXX_Unmarshal(b []byte) error {
@@ -975,6 +1040,13 @@ func (m *TendermintBlock) GetLastCommit() *TendermintCommit {
 	return nil
 }
 
+func (m *TendermintBlock) GetEvidence() []*DuplicateVoteEvidence {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
 type Proposal struct {
 	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 	Round                int32    `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
@@ -992,7 +1064,7 @@ func (m *Proposal) Reset()         { *m = Proposal{} }
 func (m *Proposal) String() string { return proto.CompactTextString(m) }
 func (*Proposal) ProtoMessage()    {}
 func (*Proposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{15}
+	return fileDescriptor_04f926c8da23c367, []int{16}
 }
 
 func (m *Proposal) XXX_Unmarshal(b []byte) error {
@@ -1077,7 +1149,7 @@ func (m *NewRoundStepMsg) Reset()         { *m = NewRoundStepMsg{} }
 func (m *NewRoundStepMsg) String() string { return proto.CompactTextString(m) }
 func (*NewRoundStepMsg) ProtoMessage()    {}
 func (*NewRoundStepMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{16}
+	return fileDescriptor_04f926c8da23c367, []int{17}
 }
 
 func (m *NewRoundStepMsg) XXX_Unmarshal(b []byte) error {
@@ -1147,7 +1219,7 @@ func (m *ValidBlockMsg) Reset()         { *m = ValidBlockMsg{} }
 func (m *ValidBlockMsg) String() string { return proto.CompactTextString(m) }
 func (*ValidBlockMsg) ProtoMessage()    {}
 func (*ValidBlockMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{17}
+	return fileDescriptor_04f926c8da23c367, []int{18}
 }
 
 func (m *ValidBlockMsg) XXX_Unmarshal(b []byte) error {
@@ -1209,7 +1281,7 @@ func (m *ProposalPOLMsg) Reset()         { *m = ProposalPOLMsg{} }
 func (m *ProposalPOLMsg) String() string { return proto.CompactTextString(m) }
 func (*ProposalPOLMsg) ProtoMessage()    {}
 func (*ProposalPOLMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{18}
+	return fileDescriptor_04f926c8da23c367, []int{19}
 }
 
 func (m *ProposalPOLMsg) XXX_Unmarshal(b []byte) error {
@@ -1265,7 +1337,7 @@ func (m *HasVoteMsg) Reset()         { *m = HasVoteMsg{} }
 func (m *HasVoteMsg) String() string { return proto.CompactTextString(m) }
 func (*HasVoteMsg) ProtoMessage()    {}
 func (*HasVoteMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{19}
+	return fileDescriptor_04f926c8da23c367, []int{20}
 }
 
 func (m *HasVoteMsg) XXX_Unmarshal(b []byte) error {
@@ -1328,7 +1400,7 @@ func (m *VoteSetMaj23Msg) Reset()         { *m = VoteSetMaj23Msg{} }
 func (m *VoteSetMaj23Msg) String() string { return proto.CompactTextString(m) }
 func (*VoteSetMaj23Msg) ProtoMessage()    {}
 func (*VoteSetMaj23Msg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{20}
+	return fileDescriptor_04f926c8da23c367, []int{21}
 }
 
 func (m *VoteSetMaj23Msg) XXX_Unmarshal(b []byte) error {
@@ -1392,7 +1464,7 @@ func (m *VoteSetBitsMsg) Reset()         { *m = VoteSetBitsMsg{} }
 func (m *VoteSetBitsMsg) String() string { return proto.CompactTextString(m) }
 func (*VoteSetBitsMsg) ProtoMessage()    {}
 func (*VoteSetBitsMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{21}
+	return fileDescriptor_04f926c8da23c367, []int{22}
 }
 
 func (m *VoteSetBitsMsg) XXX_Unmarshal(b []byte) error {
@@ -1464,7 +1536,7 @@ func (m *Heartbeat) Reset()         { *m = Heartbeat{} }
 func (m *Heartbeat) String() string { return proto.CompactTextString(m) }
 func (*Heartbeat) ProtoMessage()    {}
 func (*Heartbeat) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{22}
+	return fileDescriptor_04f926c8da23c367, []int{23}
 }
 
 func (m *Heartbeat) XXX_Unmarshal(b []byte) error {
@@ -1527,6 +1599,78 @@ func (m *Heartbeat) GetSignature() []byte {
 	return nil
 }
 
+//验证者用自己的共识私钥签名的共识监听地址, 节点间互相转发, 用于自动连接当前的验证者
+type ValidatorAddress struct {
+	PubKey               []byte   `protobuf:"bytes,1,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	NodeID               string   `protobuf:"bytes,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	Address              string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Timestamp            int64    `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Signature            []byte   `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidatorAddress) Reset()         { *m = ValidatorAddress{} }
+func (m *ValidatorAddress) String() string { return proto.CompactTextString(m) }
+func (*ValidatorAddress) ProtoMessage()    {}
+func (*ValidatorAddress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04f926c8da23c367, []int{24}
+}
+
+func (m *ValidatorAddress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidatorAddress.Unmarshal(m, b)
+}
+func (m *ValidatorAddress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidatorAddress.Marshal(b, m, deterministic)
+}
+func (m *ValidatorAddress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorAddress.Merge(m, src)
+}
+func (m *ValidatorAddress) XXX_Size() int {
+	return xxx_messageInfo_ValidatorAddress.Size(m)
+}
+func (m *ValidatorAddress) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorAddress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorAddress proto.InternalMessageInfo
+
+func (m *ValidatorAddress) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *ValidatorAddress) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *ValidatorAddress) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ValidatorAddress) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ValidatorAddress) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type IsHealthy struct {
 	IsHealthy            bool     `protobuf:"varint,1,opt,name=isHealthy,proto3" json:"isHealthy,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1538,7 +1682,7 @@ func (m *IsHealthy) Reset()         { *m = IsHealthy{} }
 func (m *IsHealthy) String() string { return proto.CompactTextString(m) }
 func (*IsHealthy) ProtoMessage()    {}
 func (*IsHealthy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04f926c8da23c367, []int{23}
+	return fileDescriptor_04f926c8da23c367, []int{25}
 }
 
 func (m *IsHealthy) XXX_Unmarshal(b []byte) error {
@@ -1570,6 +1714,7 @@ func init() {
 	proto.RegisterType((*BlockID)(nil), "types.BlockID")
 	proto.RegisterType((*TendermintBitArray)(nil), "types.TendermintBitArray")
 	proto.RegisterType((*Vote)(nil), "types.Vote")
+	proto.RegisterType((*DuplicateVoteEvidence)(nil), "types.DuplicateVoteEvidence")
 	proto.RegisterType((*TendermintCommit)(nil), "types.TendermintCommit")
 	proto.RegisterType((*TendermintBlockInfo)(nil), "types.TendermintBlockInfo")
 	proto.RegisterType((*BlockSize)(nil), "types.BlockSize")
@@ -1590,6 +1735,7 @@ func init() {
 	proto.RegisterType((*VoteSetMaj23Msg)(nil), "types.VoteSetMaj23Msg")
 	proto.RegisterType((*VoteSetBitsMsg)(nil), "types.VoteSetBitsMsg")
 	proto.RegisterType((*Heartbeat)(nil), "types.Heartbeat")
+	proto.RegisterType((*ValidatorAddress)(nil), "types.ValidatorAddress")
 	proto.RegisterType((*IsHealthy)(nil), "types.IsHealthy")
 }
 
@@ -1598,89 +1744,95 @@ func init() {
 }
 
 var fileDescriptor_04f926c8da23c367 = []byte{
-	// 1334 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xdf, 0x6e, 0x1b, 0xc5,
-	0x17, 0xd6, 0xc6, 0x7f, 0x12, 0x1f, 0xe7, 0x9f, 0xa6, 0xbf, 0xf6, 0x67, 0x4a, 0x90, 0xac, 0x11,
-	0x20, 0xd3, 0x56, 0xa1, 0x4a, 0x2b, 0x71, 0x51, 0x8a, 0x9a, 0xa4, 0x55, 0x13, 0x48, 0xa8, 0x35,
-	0xb6, 0xca, 0xf5, 0xc4, 0x1e, 0xec, 0x05, 0x7b, 0xd7, 0xec, 0x8c, 0x9d, 0x18, 0x89, 0x1b, 0xde,
-	0x00, 0x89, 0x27, 0xe0, 0x9e, 0x2b, 0x2e, 0x78, 0x04, 0x9e, 0x80, 0x4b, 0x78, 0x16, 0x74, 0xce,
-	0xcc, 0xae, 0x67, 0xd7, 0x6e, 0x4a, 0x11, 0xe2, 0x6e, 0xcf, 0x37, 0xdf, 0xcc, 0xd9, 0x39, 0xe7,
-	0x3b, 0x67, 0x66, 0x60, 0xd7, 0xa8, 0xa8, 0xaf, 0x92, 0x71, 0x18, 0x99, 0xfd, 0x49, 0x12, 0x9b,
-	0x98, 0x55, 0xcc, 0x7c, 0xa2, 0xf4, 0xed, 0xdd, 0x8b, 0x51, 0xdc, 0xfb, 0xba, 0x37, 0x94, 0x61,
-	0x64, 0x07, 0xf8, 0x3b, 0xb0, 0x7e, 0x84, 0xd8, 0xe9, 0x53, 0xc6, 0xa0, 0x7c, 0x22, 0xf5, 0xb0,
-	0x11, 0x34, 0x83, 0xd6, 0xa6, 0xa0, 0x6f, 0xfe, 0x09, 0xb0, 0x6e, 0xb6, 0xd6, 0x51, 0x68, 0x0e,
-	0x93, 0x44, 0xce, 0x91, 0x79, 0x14, 0x1a, 0x4d, 0xcc, 0x8a, 0xa0, 0x6f, 0xf6, 0x3f, 0xa8, 0x3c,
-	0x1b, 0xa9, 0xb1, 0x6e, 0xac, 0x35, 0x4b, 0xad, 0xb2, 0xb0, 0x06, 0xff, 0x7e, 0x0d, 0xca, 0x2f,
-	0x63, 0xa3, 0xd8, 0x1d, 0xd8, 0x7d, 0x29, 0x47, 0x61, 0x5f, 0x9a, 0x38, 0x39, 0xec, 0xf7, 0x13,
-	0xa5, 0xb5, 0x73, 0xb4, 0x84, 0xb3, 0xf7, 0x61, 0x3b, 0xc3, 0x4e, 0xa3, 0xbe, 0xba, 0x6a, 0xac,
-	0x91, 0xa3, 0x02, 0xca, 0x6e, 0x41, 0xf5, 0x44, 0x85, 0x83, 0xa1, 0x69, 0x94, 0x9a, 0x41, 0xab,
-	0x24, 0x9c, 0x85, 0xbf, 0x22, 0xe2, 0x69, 0xd4, 0x6f, 0x94, 0x69, 0x9a, 0x35, 0xd8, 0x1e, 0xd4,
-	0xba, 0xe1, 0x58, 0x69, 0x23, 0xc7, 0x93, 0x46, 0x85, 0x26, 0x2c, 0x00, 0xdc, 0x52, 0x77, 0x3e,
-	0x51, 0x8d, 0x6a, 0x33, 0x68, 0x6d, 0x09, 0xfa, 0x66, 0xad, 0x2c, 0x36, 0x8d, 0xf5, 0x66, 0xd0,
-	0xaa, 0x1f, 0x6c, 0xef, 0x53, 0x18, 0xf7, 0x1d, 0x2a, 0xb2, 0xd0, 0xed, 0x41, 0xad, 0x13, 0x0e,
-	0x22, 0x69, 0xa6, 0x89, 0x6a, 0x6c, 0xd0, 0xb6, 0x16, 0x00, 0x0f, 0x61, 0x77, 0x11, 0xc4, 0xe3,
-	0x78, 0x3c, 0x0e, 0x8d, 0xbf, 0x76, 0x70, 0xfd, 0xda, 0x77, 0x01, 0xda, 0x89, 0xea, 0xd1, 0x34,
-	0x1b, 0xdd, 0xfa, 0x41, 0xdd, 0x91, 0x31, 0xb4, 0xc2, 0x1b, 0xe6, 0x3f, 0x06, 0x70, 0xc3, 0x4b,
-	0x18, 0x2d, 0x11, 0x7d, 0x19, 0x33, 0x0e, 0x95, 0x8e, 0x91, 0x46, 0x51, 0x24, 0xeb, 0x07, 0x9b,
-	0x6e, 0x3e, 0x61, 0xc2, 0x0e, 0xb1, 0xbb, 0xb0, 0xd1, 0x4e, 0xe2, 0x49, 0xac, 0xe5, 0x88, 0x02,
-	0x5a, 0x3f, 0xd8, 0x71, 0xb4, 0x14, 0x16, 0x19, 0x81, 0xdd, 0x83, 0x0a, 0x69, 0x89, 0x62, 0x5c,
-	0x3f, 0xb8, 0xe5, 0x98, 0x05, 0xdf, 0xc2, 0x92, 0xf8, 0x17, 0x50, 0x23, 0xbb, 0x13, 0x7e, 0xab,
-	0xd8, 0x6d, 0xd8, 0x38, 0x97, 0x57, 0x47, 0x73, 0xa3, 0x52, 0x05, 0x65, 0x36, 0xa6, 0xf4, 0x5c,
-	0x5e, 0x75, 0xaf, 0xb4, 0x4b, 0xb9, 0xb3, 0x1c, 0xfe, 0x5c, 0xea, 0x34, 0xd5, 0xd6, 0xe2, 0x1f,
-	0x43, 0xb5, 0x7b, 0xf5, 0x37, 0x57, 0xc5, 0xd9, 0x6b, 0xb9, 0xd9, 0x8f, 0xa1, 0x4e, 0xbf, 0xf5,
-	0x3c, 0xd6, 0x3a, 0x9c, 0xb0, 0x7d, 0x60, 0x64, 0xb6, 0x65, 0x62, 0x70, 0x4d, 0x7f, 0xb1, 0x15,
-	0x23, 0xbc, 0x05, 0xdb, 0xcf, 0x66, 0x61, 0x5f, 0x45, 0x3d, 0xd5, 0x96, 0x89, 0x1c, 0xa7, 0x8e,
-	0x0e, 0x07, 0x8a, 0x66, 0x59, 0x47, 0x87, 0x03, 0xc5, 0xff, 0x08, 0x60, 0xe7, 0x38, 0x8e, 0xb4,
-	0x8a, 0xf4, 0x54, 0x3b, 0xee, 0xbe, 0x17, 0x13, 0xa7, 0x81, 0x5d, 0x5f, 0x03, 0x88, 0x0b, 0x2f,
-	0x6c, 0xef, 0xa5, 0x5b, 0x75, 0x39, 0xdc, 0x4a, 0x43, 0x4e, 0xa0, 0x48, 0xe3, 0xf0, 0x30, 0xb7,
-	0x27, 0x97, 0x48, 0xe6, 0x2f, 0x6c, 0x47, 0x44, 0x6e, 0xeb, 0x8f, 0x8b, 0x5b, 0x71, 0x79, 0xbd,
-	0xe9, 0x26, 0xe6, 0x07, 0x45, 0x81, 0xcc, 0xa7, 0x50, 0xcb, 0x6a, 0x93, 0x35, 0x60, 0x3d, 0x5f,
-	0xe1, 0xa9, 0x89, 0xe1, 0x69, 0x4f, 0x2f, 0x3e, 0x53, 0x73, 0xda, 0xc2, 0xa6, 0x70, 0x16, 0x6b,
-	0x42, 0xfd, 0x65, 0x6c, 0xc2, 0x68, 0xd0, 0x8e, 0x2f, 0x55, 0xe2, 0x52, 0xec, 0x43, 0x58, 0xd2,
-	0x87, 0xbd, 0xde, 0x74, 0x4c, 0xbf, 0x55, 0x12, 0xd6, 0xe0, 0x11, 0x6c, 0x66, 0x6e, 0x3b, 0xca,
-	0xb0, 0xfb, 0x00, 0x99, 0x8d, 0xce, 0x4b, 0x5e, 0x4c, 0xb3, 0x01, 0xe1, 0x71, 0xd8, 0xbd, 0x54,
-	0xf3, 0x2a, 0x71, 0x61, 0x5d, 0xe6, 0x67, 0x0c, 0xfe, 0x7b, 0xd9, 0x95, 0x11, 0xee, 0xf1, 0x18,
-	0xbb, 0xa8, 0x2b, 0xdf, 0x9a, 0x48, 0x4d, 0xd6, 0x82, 0x9d, 0x33, 0xa9, 0xad, 0xfc, 0x5d, 0x77,
-	0xb2, 0xa2, 0x2b, 0xc2, 0xd8, 0x12, 0x33, 0xa8, 0x1b, 0x1b, 0x39, 0xea, 0x5e, 0xb9, 0xad, 0x2f,
-	0xe1, 0xec, 0x3e, 0xd4, 0x33, 0xec, 0xf4, 0xa9, 0x4b, 0x4e, 0xb1, 0x65, 0xf8, 0x14, 0xf6, 0x2e,
-	0x6c, 0x2d, 0x56, 0x09, 0xc7, 0xca, 0xb5, 0xbc, 0x3c, 0xc8, 0x1e, 0xe4, 0x22, 0x56, 0xa5, 0x65,
-	0x6f, 0x14, 0x23, 0xd0, 0x51, 0x26, 0x17, 0xb4, 0x47, 0xb0, 0x8d, 0xab, 0x78, 0x13, 0xd7, 0x5f,
-	0x3d, 0xb1, 0x40, 0x65, 0x4f, 0xe0, 0x6d, 0x44, 0x6c, 0x0c, 0x16, 0xf8, 0xf1, 0x50, 0x46, 0x03,
-	0xd5, 0xa7, 0xe6, 0x59, 0x12, 0xd7, 0x51, 0xd8, 0x93, 0xa5, 0x5a, 0x6a, 0xd4, 0x72, 0x4d, 0xa8,
-	0x30, 0x2a, 0x96, 0x4a, 0xef, 0x53, 0x68, 0x2e, 0x1c, 0x14, 0x06, 0xd3, 0x1f, 0x01, 0xfa, 0x91,
-	0xd7, 0xf2, 0xd2, 0x7c, 0x0b, 0xa5, 0xa7, 0x23, 0xa3, 0xe9, 0x00, 0xad, 0x93, 0xb8, 0x8b, 0x30,
-	0xd5, 0xc5, 0x64, 0x42, 0x8c, 0x4d, 0x57, 0x17, 0xd6, 0xe4, 0xbf, 0x96, 0xe0, 0x66, 0xa1, 0x73,
-	0x9e, 0x28, 0xd9, 0x57, 0x54, 0x4b, 0xbd, 0xbc, 0xce, 0x9c, 0x89, 0xb5, 0x34, 0xf4, 0xe5, 0xe5,
-	0x2c, 0xac, 0x94, 0x84, 0x0e, 0x3f, 0x2b, 0x25, 0x6b, 0xe0, 0xf1, 0x66, 0x50, 0x04, 0xb6, 0x7c,
-	0xe8, 0x1b, 0x57, 0x88, 0xa6, 0x63, 0xec, 0xb5, 0x56, 0x1a, 0xce, 0x42, 0xad, 0x8d, 0x3c, 0xad,
-	0x55, 0x57, 0x6b, 0xcd, 0xa3, 0x60, 0xef, 0x35, 0x56, 0xa8, 0x56, 0x0a, 0x25, 0x91, 0xd9, 0x78,
-	0x98, 0x23, 0xd5, 0x1e, 0x7b, 0xb4, 0x79, 0x7b, 0x3e, 0x16, 0x50, 0xe4, 0xcd, 0xb2, 0x54, 0x13,
-	0xaf, 0x66, 0x79, 0x79, 0x14, 0x75, 0xdd, 0x4b, 0x33, 0x41, 0x34, 0x20, 0x5a, 0x1e, 0xc4, 0xb8,
-	0x49, 0x17, 0x6b, 0x9b, 0x8d, 0xd4, 0xc4, 0x7c, 0x8d, 0x0a, 0xf9, 0xb2, 0xd9, 0x28, 0xc2, 0x8c,
-	0xc3, 0xe6, 0xc4, 0x55, 0x3e, 0x36, 0xb0, 0xc6, 0x16, 0xd1, 0x72, 0x18, 0xff, 0x29, 0x80, 0x9d,
-	0x42, 0xe6, 0xd8, 0x43, 0xcc, 0x0c, 0x66, 0xcf, 0x75, 0xf5, 0xbd, 0xd5, 0x67, 0xa3, 0xcd, 0xb0,
-	0x70, 0x5c, 0xd6, 0x84, 0x72, 0x5f, 0x1a, 0x59, 0x38, 0xa0, 0xed, 0x29, 0x4a, 0x23, 0xec, 0x23,
-	0x80, 0x45, 0xcc, 0x5c, 0x0b, 0xf8, 0xff, 0xd2, 0xda, 0x76, 0x58, 0x78, 0x54, 0xfe, 0x67, 0xb0,
-	0x38, 0xd9, 0x3d, 0xdd, 0x04, 0xab, 0x75, 0x63, 0x0f, 0x5e, 0xa7, 0x9b, 0x3d, 0xa8, 0x99, 0xec,
-	0xd2, 0x64, 0x15, 0xb5, 0x00, 0x30, 0xef, 0xed, 0x17, 0x67, 0xfe, 0x5d, 0x2b, 0xb3, 0xd9, 0x3e,
-	0x40, 0xfb, 0xc5, 0x59, 0x2a, 0xa2, 0xca, 0x4a, 0x11, 0x79, 0x0c, 0xf4, 0xa4, 0xb3, 0x2b, 0x54,
-	0xd5, 0x5e, 0xa1, 0x32, 0x00, 0x47, 0xe9, 0x26, 0x31, 0xc4, 0x7c, 0xad, 0xdb, 0xd1, 0x0c, 0xe0,
-	0xbf, 0x04, 0xb0, 0xf3, 0xb9, 0xba, 0x24, 0xc7, 0x1d, 0xa3, 0x26, 0xe7, 0x7a, 0xf0, 0x86, 0xfb,
-	0x64, 0x50, 0xd6, 0x46, 0xd9, 0x2d, 0x56, 0x04, 0x7d, 0xb3, 0x87, 0x70, 0x53, 0xab, 0x5e, 0x1c,
-	0xf5, 0x75, 0x27, 0x8c, 0x7a, 0xaa, 0x63, 0x64, 0x62, 0xba, 0x69, 0x11, 0x55, 0xc4, 0xea, 0xc1,
-	0x54, 0x5f, 0x2e, 0x0d, 0xe4, 0xa9, 0x42, 0xfc, 0x22, 0xcc, 0x2f, 0x61, 0x8b, 0x9a, 0x1b, 0x45,
-	0xe0, 0xcd, 0x7f, 0x39, 0x17, 0x92, 0x52, 0x21, 0x24, 0x98, 0x9a, 0x50, 0x7b, 0x52, 0xd9, 0x10,
-	0x99, 0xcd, 0x7f, 0x08, 0x60, 0x3b, 0xd5, 0x43, 0xfb, 0xc5, 0xd9, 0x75, 0xae, 0xef, 0xc0, 0xee,
-	0x64, 0xc1, 0x14, 0xde, 0x5f, 0x2c, 0xe1, 0xec, 0x11, 0xd4, 0x3d, 0xcc, 0xdd, 0x3c, 0xde, 0x5a,
-	0x16, 0xbf, 0x7b, 0x45, 0x08, 0x9f, 0xcd, 0xfb, 0x00, 0x27, 0x52, 0xe3, 0x7d, 0xf6, 0x1f, 0x25,
-	0x0f, 0x9d, 0xa4, 0xc9, 0xc3, 0x6f, 0x64, 0x86, 0xf4, 0x74, 0x70, 0x6f, 0x00, 0x32, 0xf8, 0x77,
-	0xb0, 0x83, 0x2e, 0x3a, 0xca, 0x9c, 0xcb, 0xaf, 0x0e, 0x1e, 0xfc, 0x3b, 0xae, 0x5a, 0xb0, 0x7e,
-	0x71, 0xed, 0xb9, 0x9c, 0x0e, 0xf3, 0x9f, 0x03, 0xd8, 0x76, 0xfe, 0xf1, 0xcd, 0xf4, 0x1f, 0xbb,
-	0x67, 0x1f, 0x42, 0x65, 0x16, 0xe3, 0x95, 0xb6, 0xf2, 0xba, 0xd4, 0x58, 0x1e, 0xff, 0x2d, 0x80,
-	0xda, 0x89, 0x92, 0x89, 0xb9, 0x50, 0x92, 0xb4, 0x30, 0x7b, 0xc5, 0x13, 0x6e, 0xb6, 0xe2, 0x09,
-	0x37, 0x5b, 0xf9, 0x84, 0x9b, 0x2d, 0x3d, 0xe1, 0x86, 0xb9, 0x27, 0x5c, 0x71, 0xfb, 0x65, 0x7f,
-	0xfb, 0xb7, 0x61, 0x43, 0xab, 0x6f, 0xa6, 0x78, 0xf1, 0x74, 0x45, 0x95, 0xd9, 0xd7, 0xf7, 0x0f,
-	0xfe, 0x01, 0xd4, 0x4e, 0xf5, 0x89, 0x92, 0x23, 0x33, 0x9c, 0x23, 0x35, 0x4c, 0x0d, 0xda, 0xc1,
-	0x86, 0x58, 0x00, 0x17, 0x55, 0x7a, 0x18, 0x3f, 0xf8, 0x2b, 0x00, 0x00, 0xff, 0xff, 0xbc, 0x0d,
-	0x4d, 0x91, 0x45, 0x0f, 0x00, 0x00,
+	// 1437 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xdf, 0x6e, 0x1b, 0x45,
+	0x17, 0xd7, 0xc6, 0x5e, 0xc7, 0x3e, 0x4e, 0x9c, 0x68, 0xfa, 0xa5, 0xdf, 0x7e, 0xfd, 0x8a, 0x64,
+	0x46, 0x80, 0x4c, 0x5b, 0x85, 0x2a, 0xad, 0x04, 0x52, 0x29, 0x6a, 0xfe, 0x54, 0x4d, 0x20, 0xa1,
+	0xd6, 0xd8, 0x2a, 0xd7, 0x13, 0x7b, 0xb0, 0x17, 0xec, 0xdd, 0x65, 0x67, 0xec, 0x26, 0x48, 0xdc,
+	0xf0, 0x06, 0x48, 0x5c, 0xf0, 0x12, 0x5c, 0xf1, 0x10, 0x3c, 0x01, 0x57, 0xfc, 0x79, 0x16, 0x34,
+	0x67, 0x66, 0xd7, 0xb3, 0x6b, 0x27, 0xa5, 0x08, 0x71, 0xe7, 0xf3, 0x9b, 0xdf, 0xce, 0x99, 0x73,
+	0xce, 0x6f, 0xce, 0xcc, 0x18, 0xb6, 0x95, 0x88, 0x86, 0x22, 0x9d, 0x86, 0x91, 0xda, 0x4d, 0xd2,
+	0x58, 0xc5, 0xc4, 0x57, 0x97, 0x89, 0x90, 0xb7, 0xb6, 0xcf, 0x27, 0xf1, 0xe0, 0xcb, 0xc1, 0x98,
+	0x87, 0x91, 0x19, 0xa0, 0x6f, 0xc0, 0xfa, 0x81, 0xc6, 0x4e, 0x8e, 0x08, 0x81, 0xea, 0x31, 0x97,
+	0xe3, 0xc0, 0x6b, 0x7b, 0x9d, 0x0d, 0x86, 0xbf, 0xe9, 0x47, 0x40, 0xfa, 0xf9, 0x5c, 0x07, 0xa1,
+	0xda, 0x4f, 0x53, 0x7e, 0xa9, 0x99, 0x07, 0xa1, 0x92, 0xc8, 0xf4, 0x19, 0xfe, 0x26, 0xff, 0x01,
+	0xff, 0xe9, 0x44, 0x4c, 0x65, 0xb0, 0xd6, 0xae, 0x74, 0xaa, 0xcc, 0x18, 0xf4, 0xdb, 0x35, 0xa8,
+	0xbe, 0x88, 0x95, 0x20, 0x77, 0x60, 0xfb, 0x05, 0x9f, 0x84, 0x43, 0xae, 0xe2, 0x74, 0x7f, 0x38,
+	0x4c, 0x85, 0x94, 0xd6, 0xd1, 0x12, 0x4e, 0xde, 0x81, 0x56, 0x8e, 0x9d, 0x44, 0x43, 0x71, 0x11,
+	0xac, 0xa1, 0xa3, 0x12, 0x4a, 0x6e, 0x42, 0xed, 0x58, 0x84, 0xa3, 0xb1, 0x0a, 0x2a, 0x6d, 0xaf,
+	0x53, 0x61, 0xd6, 0xd2, 0x4b, 0x61, 0xf1, 0x2c, 0x1a, 0x06, 0x55, 0xfc, 0xcc, 0x18, 0xe4, 0x36,
+	0x34, 0xfa, 0xe1, 0x54, 0x48, 0xc5, 0xa7, 0x49, 0xe0, 0xe3, 0x07, 0x0b, 0x40, 0x87, 0xd4, 0xbf,
+	0x4c, 0x44, 0x50, 0x6b, 0x7b, 0x9d, 0x4d, 0x86, 0xbf, 0x49, 0x27, 0xcf, 0x4d, 0xb0, 0xde, 0xf6,
+	0x3a, 0xcd, 0xbd, 0xd6, 0x2e, 0xa6, 0x71, 0xd7, 0xa2, 0x2c, 0x4f, 0xdd, 0x6d, 0x68, 0xf4, 0xc2,
+	0x51, 0xc4, 0xd5, 0x2c, 0x15, 0x41, 0x1d, 0xc3, 0x5a, 0x00, 0x74, 0x06, 0x3b, 0x47, 0xb3, 0x64,
+	0x12, 0x0e, 0xb8, 0x12, 0x3a, 0x19, 0x4f, 0xe7, 0xe1, 0x50, 0x44, 0x03, 0xa1, 0x03, 0x48, 0x66,
+	0xe7, 0x9f, 0x88, 0x4b, 0x9b, 0x0a, 0x6b, 0x91, 0x37, 0xc1, 0x9f, 0xc7, 0x4a, 0xec, 0x63, 0xdc,
+	0xcd, 0xbd, 0xa6, 0x75, 0xab, 0xbf, 0x65, 0x66, 0x24, 0xa3, 0x1c, 0x04, 0x95, 0x2b, 0x28, 0x07,
+	0x34, 0x84, 0xed, 0x45, 0xed, 0x0e, 0xe3, 0xe9, 0x34, 0x54, 0x6e, 0x48, 0xde, 0xf5, 0x21, 0xdd,
+	0x05, 0xe8, 0xa6, 0x62, 0x80, 0x9f, 0x99, 0xa2, 0x96, 0xbc, 0x38, 0xc3, 0xf4, 0x7b, 0x0f, 0x6e,
+	0x38, 0x3a, 0xc1, 0x29, 0xa2, 0xcf, 0x63, 0x42, 0xc1, 0xef, 0x29, 0xae, 0x84, 0x0d, 0x64, 0xc3,
+	0x7e, 0x8f, 0x18, 0x33, 0x43, 0xe4, 0x2e, 0xd4, 0xbb, 0x69, 0x9c, 0xc4, 0x92, 0x4f, 0x6c, 0x30,
+	0x5b, 0x96, 0x96, 0xc1, 0x2c, 0x27, 0x90, 0x7b, 0xe0, 0xa3, 0x84, 0xb1, 0xb4, 0xcd, 0xbd, 0x9b,
+	0x96, 0x59, 0xf2, 0xcd, 0x0c, 0x89, 0x7e, 0x06, 0x0d, 0xb4, 0x7b, 0xe1, 0xd7, 0x82, 0xdc, 0x82,
+	0xfa, 0x19, 0xbf, 0x38, 0xb8, 0x54, 0x22, 0x13, 0x6e, 0x6e, 0xeb, 0x42, 0x9c, 0xf1, 0x8b, 0xfe,
+	0x85, 0xb4, 0x4a, 0xb3, 0x96, 0xc5, 0x9f, 0x71, 0x99, 0x29, 0xcc, 0x58, 0xf4, 0x43, 0xa8, 0xf5,
+	0x2f, 0xfe, 0xe2, 0xac, 0xcf, 0xb8, 0x99, 0x75, 0xf1, 0xf5, 0x63, 0x68, 0xe2, 0xb2, 0x9e, 0xc5,
+	0x52, 0x86, 0x09, 0xd9, 0x05, 0x82, 0x66, 0x97, 0xa7, 0x4a, 0xcf, 0xe9, 0x4e, 0xb6, 0x62, 0x84,
+	0x76, 0xa0, 0x95, 0x29, 0xa8, 0xcb, 0x53, 0x3e, 0xcd, 0x1c, 0xed, 0x8f, 0x44, 0xe0, 0xe5, 0x8e,
+	0xf6, 0x47, 0x82, 0xfe, 0xee, 0xc1, 0xd6, 0x61, 0x1c, 0x49, 0x11, 0xc9, 0x99, 0xb4, 0xdc, 0x5d,
+	0x27, 0x27, 0x56, 0x03, 0xdb, 0xae, 0x06, 0x34, 0xce, 0x9c, 0xb4, 0xbd, 0x9d, 0x85, 0x6a, 0x6b,
+	0xb8, 0x99, 0xa5, 0x1c, 0x41, 0x96, 0xe5, 0xe1, 0x61, 0x21, 0x26, 0x5b, 0x48, 0xe2, 0x4e, 0x6c,
+	0x46, 0x58, 0x21, 0xf4, 0xc7, 0xe5, 0x50, 0x6c, 0x5d, 0x77, 0xec, 0x87, 0xc5, 0x41, 0x56, 0x22,
+	0xd3, 0x19, 0x34, 0xf2, 0x96, 0x40, 0x02, 0x58, 0x2f, 0x36, 0x96, 0xcc, 0xd4, 0xe9, 0xe9, 0x9a,
+	0x6d, 0xb6, 0x66, 0xb6, 0x99, 0xb1, 0x48, 0x1b, 0x9a, 0x2f, 0x62, 0x15, 0x46, 0xa3, 0x6e, 0xfc,
+	0x52, 0xa4, 0xb6, 0xc4, 0x2e, 0xa4, 0x3b, 0xc9, 0xfe, 0x60, 0x30, 0x9b, 0xe2, 0xb2, 0x2a, 0xcc,
+	0x18, 0x34, 0x82, 0x8d, 0xdc, 0x6d, 0x4f, 0x28, 0x72, 0x1f, 0x20, 0xb7, 0xb5, 0xf3, 0x8a, 0x93,
+	0xd3, 0x7c, 0x80, 0x39, 0x1c, 0x72, 0x2f, 0xd3, 0xbc, 0x48, 0x6d, 0x5a, 0x97, 0xf9, 0x39, 0x83,
+	0xfe, 0x52, 0xb5, 0xdb, 0x48, 0xc7, 0x78, 0xa8, 0x9b, 0xb7, 0xdd, 0xbe, 0x0d, 0x96, 0x99, 0xa4,
+	0x03, 0x5b, 0xa7, 0x5c, 0x1a, 0xf9, 0xdb, 0xa6, 0x68, 0x44, 0x57, 0x86, 0x75, 0x27, 0xce, 0xa1,
+	0x7e, 0xac, 0xf8, 0xa4, 0x7f, 0x61, 0x43, 0x5f, 0xc2, 0xc9, 0x7d, 0x68, 0xe6, 0xd8, 0xc9, 0x51,
+	0x50, 0x5d, 0xd9, 0x32, 0x5c, 0x0a, 0x79, 0x0b, 0x36, 0x17, 0xb3, 0x84, 0x53, 0x61, 0x3b, 0x6d,
+	0x11, 0x24, 0x0f, 0x0a, 0x19, 0xab, 0xe1, 0xb4, 0x37, 0xca, 0x19, 0xe8, 0x09, 0x55, 0x48, 0xda,
+	0x23, 0x68, 0xe9, 0x59, 0x9c, 0x0f, 0xd7, 0xaf, 0xfe, 0xb0, 0x44, 0x25, 0x4f, 0xe0, 0xff, 0x1a,
+	0x31, 0x39, 0x58, 0xe0, 0x87, 0x63, 0x1e, 0x8d, 0xc4, 0x10, 0x7b, 0x76, 0x85, 0x5d, 0x47, 0x21,
+	0x4f, 0x96, 0xf6, 0x52, 0xd0, 0x28, 0x34, 0xa1, 0xd2, 0x28, 0x5b, 0xda, 0x7a, 0x1f, 0x43, 0x7b,
+	0xe1, 0xa0, 0x34, 0x98, 0x2d, 0x04, 0x70, 0x21, 0xaf, 0xe4, 0x65, 0xf5, 0x66, 0x42, 0xce, 0x26,
+	0x4a, 0xe2, 0xb9, 0xdd, 0x44, 0x71, 0x97, 0x61, 0xdc, 0x17, 0x49, 0x82, 0x8c, 0x0d, 0xbb, 0x2f,
+	0x8c, 0x49, 0x7f, 0xad, 0xc0, 0x4e, 0xa9, 0x73, 0x1e, 0x0b, 0x3e, 0x14, 0xb8, 0x97, 0x06, 0x45,
+	0x9d, 0x59, 0x53, 0xef, 0xa5, 0xb1, 0x2b, 0x2f, 0x6b, 0xe9, 0x9d, 0x92, 0xe2, 0x99, 0x6b, 0xa4,
+	0x64, 0x0c, 0x7d, 0xaa, 0x2a, 0x2d, 0x02, 0xb3, 0x7d, 0xf0, 0xb7, 0x9e, 0x21, 0x9a, 0x4d, 0x75,
+	0xaf, 0x35, 0xd2, 0xb0, 0x96, 0xd6, 0xda, 0xc4, 0xd1, 0x5a, 0x6d, 0xb5, 0xd6, 0x1c, 0x8a, 0xee,
+	0xbd, 0xca, 0x08, 0xd5, 0x48, 0xa1, 0xc2, 0x72, 0x5b, 0xdf, 0x21, 0x34, 0xd5, 0x1c, 0x7b, 0x18,
+	0xbc, 0x39, 0x96, 0x4b, 0xa8, 0xe6, 0xcd, 0xf3, 0x52, 0x23, 0xaf, 0x61, 0x78, 0x45, 0x54, 0xeb,
+	0x7a, 0x90, 0x55, 0x02, 0x69, 0x80, 0xb4, 0x22, 0xa8, 0xf3, 0xc6, 0x93, 0xc4, 0xa9, 0x46, 0x66,
+	0xea, 0x7a, 0x4d, 0x4a, 0xf5, 0x32, 0xd5, 0x28, 0xc3, 0x84, 0xc2, 0x46, 0x62, 0x77, 0xbe, 0x6e,
+	0x60, 0xc1, 0x26, 0xd2, 0x0a, 0x98, 0xe6, 0x08, 0xdb, 0x0a, 0x71, 0xaa, 0x96, 0xe1, 0xb8, 0x18,
+	0xfd, 0xcd, 0x83, 0xad, 0x52, 0x75, 0xc9, 0x43, 0x5d, 0x3d, 0x5d, 0x61, 0xdb, 0xf9, 0x6f, 0xaf,
+	0x3e, 0x3f, 0x8d, 0x0a, 0x98, 0xe5, 0x92, 0x36, 0x54, 0x87, 0x5c, 0xf1, 0xd2, 0x21, 0x8e, 0x4c,
+	0x86, 0x23, 0xe4, 0x7d, 0x80, 0x45, 0x5e, 0x6d, 0x9b, 0xf8, 0xef, 0xd2, 0xdc, 0x66, 0x98, 0x39,
+	0x54, 0xf2, 0x01, 0xd4, 0xb3, 0x45, 0x07, 0x7e, 0xbb, 0xe2, 0x2c, 0x69, 0xe5, 0x8d, 0x89, 0xe5,
+	0x6c, 0xfa, 0x87, 0xb7, 0xb8, 0x37, 0x38, 0xaa, 0xf4, 0x56, 0xab, 0xd2, 0x1c, 0xeb, 0xc6, 0xd0,
+	0xb7, 0x35, 0x95, 0xdf, 0x04, 0x8d, 0x5e, 0x17, 0x80, 0x56, 0x55, 0xf7, 0xf9, 0xa9, 0x7b, 0x81,
+	0xcc, 0x6d, 0xb2, 0x0b, 0xd0, 0x7d, 0x7e, 0x9a, 0x49, 0xd4, 0x5f, 0x29, 0x51, 0x87, 0xa1, 0x3d,
+	0xc9, 0xfc, 0x5e, 0x58, 0x33, 0xf7, 0xc2, 0x1c, 0xd0, 0xa3, 0x78, 0x4f, 0x19, 0xeb, 0x12, 0xae,
+	0x9b, 0xd1, 0x1c, 0xa0, 0x3f, 0x79, 0xb0, 0xf5, 0xa9, 0x78, 0x89, 0x8e, 0x7b, 0x4a, 0x24, 0x67,
+	0x72, 0xf4, 0x9a, 0x71, 0x12, 0xa8, 0x4a, 0x25, 0x4c, 0x88, 0x3e, 0xc3, 0xdf, 0xe4, 0x21, 0xec,
+	0x48, 0x31, 0x88, 0xa3, 0xa1, 0xec, 0x85, 0xd1, 0x40, 0xf4, 0x14, 0x4f, 0x55, 0x3f, 0xdb, 0xa2,
+	0x3e, 0x5b, 0x3d, 0x98, 0xa9, 0xd7, 0x16, 0x10, 0x3d, 0xf9, 0xc8, 0x2f, 0xc3, 0xf4, 0x25, 0x6c,
+	0x62, 0xeb, 0xc4, 0x0c, 0xbc, 0xfe, 0x92, 0x0b, 0x29, 0xa9, 0x94, 0x52, 0xa2, 0x4b, 0x13, 0x4a,
+	0x47, 0x64, 0x75, 0x96, 0xdb, 0xf4, 0x3b, 0x0f, 0x5a, 0x99, 0x1e, 0xba, 0xcf, 0x4f, 0xaf, 0x73,
+	0x7d, 0x07, 0xb6, 0x93, 0x05, 0x93, 0x39, 0xab, 0x58, 0xc2, 0xc9, 0x23, 0x68, 0x3a, 0x98, 0xbd,
+	0xd7, 0xfc, 0x6f, 0x79, 0xdb, 0xd8, 0xa7, 0x11, 0x73, 0xd9, 0x74, 0x08, 0x70, 0xcc, 0xa5, 0x16,
+	0xf0, 0xdf, 0x2a, 0x9e, 0x76, 0x92, 0x15, 0x4f, 0xff, 0xd6, 0xcc, 0x10, 0xdf, 0x43, 0xf6, 0x61,
+	0x83, 0x06, 0xfd, 0x06, 0xb6, 0xb4, 0x8b, 0x9e, 0x50, 0x67, 0xfc, 0x8b, 0xbd, 0x07, 0xff, 0x8c,
+	0xab, 0x0e, 0xac, 0x9f, 0x5f, 0x7b, 0xea, 0x67, 0xc3, 0xf4, 0x47, 0x0f, 0x5a, 0xd6, 0xbf, 0x7e,
+	0x08, 0xfe, 0xcb, 0xee, 0xc9, 0x7b, 0xe6, 0x21, 0x24, 0x03, 0xff, 0x55, 0xa5, 0x31, 0x3c, 0xfa,
+	0xb3, 0x07, 0x8d, 0x63, 0xc1, 0x53, 0x75, 0x2e, 0x38, 0x6a, 0x61, 0x7e, 0xc5, 0xbb, 0x74, 0xbe,
+	0xe2, 0x5d, 0x3a, 0x5f, 0xf9, 0x2e, 0x9d, 0x2f, 0xbd, 0x4b, 0xc7, 0x85, 0x77, 0x69, 0x39, 0xfc,
+	0xaa, 0x1b, 0xfe, 0x2d, 0xa8, 0x4b, 0xf1, 0xd5, 0xcc, 0xb6, 0x40, 0xec, 0x37, 0x99, 0x7d, 0x7d,
+	0xff, 0xa0, 0x3f, 0x78, 0xcb, 0x8f, 0xea, 0x2b, 0xdf, 0x94, 0xfa, 0xd8, 0x8d, 0x87, 0xe2, 0xe4,
+	0x08, 0x17, 0xdd, 0x60, 0xd6, 0xc2, 0x23, 0xcb, 0xc6, 0x5d, 0x31, 0x47, 0xbd, 0x35, 0x8b, 0x6d,
+	0xb2, 0x5a, 0x6e, 0x93, 0x85, 0xa5, 0xf9, 0xe5, 0xa5, 0xbd, 0x0b, 0x8d, 0x13, 0x79, 0x2c, 0xf8,
+	0x44, 0x8d, 0x2f, 0x35, 0x35, 0xcc, 0x0c, 0x5c, 0x55, 0x9d, 0x2d, 0x80, 0xf3, 0x1a, 0xfe, 0x11,
+	0xf1, 0xe0, 0xcf, 0x01, 0x00, 0xf6, 0x57, 0x53, 0xf3, 0xb5, 0x10, 0x00, 0x00,
 }
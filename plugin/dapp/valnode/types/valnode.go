@@ -7,6 +7,7 @@ package types
 import (
 	"encoding/hex"
 	"encoding/json"
+	"reflect"
 
 	"github.com/33cn/chain33/common/address"
 
@@ -63,7 +64,10 @@ func (t *ValNodeType) GetTypeMap() map[string]int32 {
 
 // GetLogMap method
 func (t *ValNodeType) GetLogMap() map[int64]*types.LogInfo {
-	return map[int64]*types.LogInfo{}
+	return map[int64]*types.LogInfo{
+		TyLogValNodeJail:    {Ty: reflect.TypeOf(ValNodeJail{}), Name: "LogValNodeJail"},
+		TyLogValNodeRelease: {Ty: reflect.TypeOf(ValNodeJail{}), Name: "LogValNodeRelease"},
+	}
 }
 
 // CreateTx
@@ -200,6 +200,117 @@ func (*ValNodeAction) XXX_OneofWrappers() []interface{} {
 	}
 }
 
+//作恶验证者的惩罚记录, releaseHeight为0表示永久移除
+type ValNodeJail struct {
+	PubKey               []byte   `protobuf:"bytes,1,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	Power                int64    `protobuf:"varint,2,opt,name=power,proto3" json:"power,omitempty"`
+	Height               int64    `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	ReleaseHeight        int64    `protobuf:"varint,4,opt,name=releaseHeight,proto3" json:"releaseHeight,omitempty"`
+	EvidenceHash         []byte   `protobuf:"bytes,5,opt,name=evidenceHash,proto3" json:"evidenceHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValNodeJail) Reset()         { *m = ValNodeJail{} }
+func (m *ValNodeJail) String() string { return proto.CompactTextString(m) }
+func (*ValNodeJail) ProtoMessage()    {}
+func (*ValNodeJail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38e9a3523ca7e0ea, []int{3}
+}
+
+func (m *ValNodeJail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValNodeJail.Unmarshal(m, b)
+}
+func (m *ValNodeJail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValNodeJail.Marshal(b, m, deterministic)
+}
+func (m *ValNodeJail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValNodeJail.Merge(m, src)
+}
+func (m *ValNodeJail) XXX_Size() int {
+	return xxx_messageInfo_ValNodeJail.Size(m)
+}
+func (m *ValNodeJail) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValNodeJail.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValNodeJail proto.InternalMessageInfo
+
+func (m *ValNodeJail) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *ValNodeJail) GetPower() int64 {
+	if m != nil {
+		return m.Power
+	}
+	return 0
+}
+
+func (m *ValNodeJail) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ValNodeJail) GetReleaseHeight() int64 {
+	if m != nil {
+		return m.ReleaseHeight
+	}
+	return 0
+}
+
+func (m *ValNodeJail) GetEvidenceHash() []byte {
+	if m != nil {
+		return m.EvidenceHash
+	}
+	return nil
+}
+
+type ValNodeJails struct {
+	Jails                []*ValNodeJail `protobuf:"bytes,1,rep,name=jails,proto3" json:"jails,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ValNodeJails) Reset()         { *m = ValNodeJails{} }
+func (m *ValNodeJails) String() string { return proto.CompactTextString(m) }
+func (*ValNodeJails) ProtoMessage()    {}
+func (*ValNodeJails) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38e9a3523ca7e0ea, []int{4}
+}
+
+func (m *ValNodeJails) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValNodeJails.Unmarshal(m, b)
+}
+func (m *ValNodeJails) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValNodeJails.Marshal(b, m, deterministic)
+}
+func (m *ValNodeJails) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValNodeJails.Merge(m, src)
+}
+func (m *ValNodeJails) XXX_Size() int {
+	return xxx_messageInfo_ValNodeJails.Size(m)
+}
+func (m *ValNodeJails) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValNodeJails.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValNodeJails proto.InternalMessageInfo
+
+func (m *ValNodeJails) GetJails() []*ValNodeJail {
+	if m != nil {
+		return m.Jails
+	}
+	return nil
+}
+
 type ReqNodeInfo struct {
 	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -211,7 +322,7 @@ func (m *ReqNodeInfo) Reset()         { *m = ReqNodeInfo{} }
 func (m *ReqNodeInfo) String() string { return proto.CompactTextString(m) }
 func (*ReqNodeInfo) ProtoMessage()    {}
 func (*ReqNodeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_38e9a3523ca7e0ea, []int{3}
+	return fileDescriptor_38e9a3523ca7e0ea, []int{5}
 }
 
 func (m *ReqNodeInfo) XXX_Unmarshal(b []byte) error {
@@ -250,7 +361,7 @@ func (m *ReqBlockInfo) Reset()         { *m = ReqBlockInfo{} }
 func (m *ReqBlockInfo) String() string { return proto.CompactTextString(m) }
 func (*ReqBlockInfo) ProtoMessage()    {}
 func (*ReqBlockInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_38e9a3523ca7e0ea, []int{4}
+	return fileDescriptor_38e9a3523ca7e0ea, []int{6}
 }
 
 func (m *ReqBlockInfo) XXX_Unmarshal(b []byte) error {
@@ -278,12 +389,54 @@ func (m *ReqBlockInfo) GetHeight() int64 {
 	return 0
 }
 
+type ReqValNodeJail struct {
+	PubKey               []byte   `protobuf:"bytes,1,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqValNodeJail) Reset()         { *m = ReqValNodeJail{} }
+func (m *ReqValNodeJail) String() string { return proto.CompactTextString(m) }
+func (*ReqValNodeJail) ProtoMessage()    {}
+func (*ReqValNodeJail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38e9a3523ca7e0ea, []int{7}
+}
+
+func (m *ReqValNodeJail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqValNodeJail.Unmarshal(m, b)
+}
+func (m *ReqValNodeJail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqValNodeJail.Marshal(b, m, deterministic)
+}
+func (m *ReqValNodeJail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqValNodeJail.Merge(m, src)
+}
+func (m *ReqValNodeJail) XXX_Size() int {
+	return xxx_messageInfo_ReqValNodeJail.Size(m)
+}
+func (m *ReqValNodeJail) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqValNodeJail.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqValNodeJail proto.InternalMessageInfo
+
+func (m *ReqValNodeJail) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ValNode)(nil), "types.ValNode")
 	proto.RegisterType((*ValNodes)(nil), "types.ValNodes")
 	proto.RegisterType((*ValNodeAction)(nil), "types.ValNodeAction")
+	proto.RegisterType((*ValNodeJail)(nil), "types.ValNodeJail")
+	proto.RegisterType((*ValNodeJails)(nil), "types.ValNodeJails")
 	proto.RegisterType((*ReqNodeInfo)(nil), "types.ReqNodeInfo")
 	proto.RegisterType((*ReqBlockInfo)(nil), "types.ReqBlockInfo")
+	proto.RegisterType((*ReqValNodeJail)(nil), "types.ReqValNodeJail")
 }
 
 func init() {
@@ -291,28 +444,33 @@ func init() {
 }
 
 var fileDescriptor_38e9a3523ca7e0ea = []byte{
-	// 322 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x51, 0xcd, 0x6a, 0xf2, 0x40,
-	0x14, 0x4d, 0xcc, 0x97, 0xf8, 0xf5, 0x46, 0x45, 0xa6, 0x45, 0x42, 0x56, 0x61, 0xb0, 0x25, 0x50,
-	0x90, 0xa2, 0x8b, 0x42, 0x77, 0x75, 0x53, 0xa5, 0xd0, 0xc5, 0x28, 0xdd, 0xc7, 0xe4, 0xb6, 0x06,
-	0xe3, 0x4c, 0x4c, 0x46, 0x4b, 0x5e, 0xa1, 0x4f, 0x5d, 0x32, 0x99, 0x46, 0x68, 0xe9, 0xf2, 0xfc,
-	0xe5, 0x9c, 0xdc, 0x81, 0xfe, 0x29, 0xca, 0xb8, 0x48, 0x70, 0x92, 0x17, 0x42, 0x0a, 0x62, 0xcb,
-	0x2a, 0xc7, 0xd2, 0xef, 0xc5, 0x62, 0xbf, 0x17, 0xbc, 0x21, 0xfd, 0xa1, 0x44, 0x9e, 0x60, 0xb1,
-	0x4f, 0xb9, 0x6c, 0x18, 0x7a, 0x0f, 0xdd, 0xd7, 0x28, 0x7b, 0x11, 0x09, 0x92, 0x11, 0x38, 0xf9,
-	0x71, 0xf3, 0x8c, 0x95, 0x67, 0x06, 0x66, 0xd8, 0x63, 0x1a, 0x91, 0x2b, 0xb0, 0x73, 0xf1, 0x81,
-	0x85, 0xd7, 0x09, 0xcc, 0xd0, 0x62, 0x0d, 0xa0, 0x77, 0xf0, 0x5f, 0x07, 0x4b, 0x32, 0x06, 0xbb,
-	0x6e, 0x2e, 0x3d, 0x33, 0xb0, 0x42, 0x77, 0x3a, 0x98, 0xa8, 0xee, 0x89, 0xd6, 0x59, 0x23, 0xd2,
-	0x4f, 0x13, 0xfa, 0x9a, 0x7a, 0x8c, 0x65, 0x2a, 0x38, 0x19, 0xc3, 0xbf, 0x5a, 0x52, 0x7d, 0xbf,
-	0x62, 0x0b, 0x83, 0x29, 0x95, 0x3c, 0xc0, 0xc5, 0x26, 0x13, 0xf1, 0x6e, 0xc9, 0xdf, 0x84, 0xda,
-	0xe0, 0x4e, 0x7d, 0x6d, 0x5d, 0xb7, 0xbf, 0x33, 0xff, 0x76, 0x2c, 0x0c, 0x76, 0xb6, 0x93, 0x01,
-	0x74, 0xd6, 0x95, 0x67, 0x05, 0x66, 0x68, 0xb3, 0xce, 0xba, 0x9a, 0x77, 0xc1, 0x3e, 0x45, 0xd9,
-	0x11, 0xe9, 0x35, 0xb8, 0x0c, 0x0f, 0x75, 0x8f, 0xf2, 0x8d, 0xc0, 0xd9, 0x62, 0xfa, 0xbe, 0x95,
-	0x6a, 0x8b, 0xc5, 0x34, 0xa2, 0x37, 0xd0, 0x63, 0x78, 0x68, 0x3f, 0xfe, 0x97, 0x6f, 0xba, 0x83,
-	0xae, 0x3e, 0x3f, 0xb9, 0x05, 0x67, 0x59, 0xae, 0x2a, 0x1e, 0x93, 0xbe, 0x5e, 0x59, 0x17, 0xa5,
-	0x99, 0x3f, 0xd4, 0x70, 0x59, 0x2e, 0x30, 0xca, 0xe4, 0xb6, 0xa2, 0x06, 0x99, 0x81, 0xfb, 0x84,
-	0xb2, 0x9d, 0xf1, 0x23, 0x71, 0x79, 0xbe, 0x48, 0x9a, 0x44, 0x52, 0x14, 0x2b, 0x94, 0xd4, 0xd8,
-	0x38, 0xea, 0xe9, 0x66, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xce, 0xc8, 0x21, 0x55, 0xf2, 0x01,
-	0x00, 0x00,
+	// 401 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x52, 0xc1, 0x8e, 0xd3, 0x30,
+	0x14, 0xac, 0xdb, 0x4d, 0x0b, 0x2f, 0x69, 0xb5, 0x32, 0x08, 0x45, 0x3d, 0x45, 0x56, 0x41, 0x91,
+	0x90, 0x2a, 0xd4, 0x3d, 0x80, 0xb8, 0xb1, 0x17, 0x52, 0x90, 0x38, 0x78, 0x2b, 0xee, 0x6e, 0xf2,
+	0x20, 0x61, 0x5d, 0x3b, 0x8d, 0xbd, 0x45, 0xf9, 0x05, 0xfe, 0x81, 0x7f, 0x45, 0x71, 0x4c, 0xbb,
+	0x05, 0x21, 0xb1, 0xb7, 0xbc, 0x99, 0x79, 0x99, 0x79, 0x23, 0xc3, 0xf4, 0x20, 0xa4, 0xd2, 0x05,
+	0x2e, 0xeb, 0x46, 0x5b, 0x4d, 0x03, 0xdb, 0xd6, 0x68, 0xe6, 0x51, 0xae, 0x77, 0x3b, 0xad, 0x7a,
+	0x70, 0x7e, 0x69, 0x51, 0x15, 0xd8, 0xec, 0x2a, 0x65, 0x7b, 0x84, 0xbd, 0x86, 0xc9, 0x67, 0x21,
+	0x3f, 0xe9, 0x02, 0xe9, 0x33, 0x18, 0xd7, 0x77, 0xdb, 0x8f, 0xd8, 0xc6, 0x24, 0x21, 0x69, 0xc4,
+	0xfd, 0x44, 0x9f, 0x42, 0x50, 0xeb, 0xef, 0xd8, 0xc4, 0xc3, 0x84, 0xa4, 0x23, 0xde, 0x0f, 0xec,
+	0x15, 0x3c, 0xf2, 0x8b, 0x86, 0x2e, 0x20, 0xe8, 0x9c, 0x4d, 0x4c, 0x92, 0x51, 0x1a, 0xae, 0x66,
+	0x4b, 0xe7, 0xbd, 0xf4, 0x3c, 0xef, 0x49, 0xf6, 0x83, 0xc0, 0xd4, 0x43, 0xef, 0x72, 0x5b, 0x69,
+	0x45, 0x17, 0x70, 0xd1, 0x51, 0xce, 0xef, 0xaf, 0xb5, 0x6c, 0xc0, 0x1d, 0x4b, 0xdf, 0xc2, 0xe3,
+	0xad, 0xd4, 0xf9, 0xed, 0x5a, 0x7d, 0xd1, 0x2e, 0x43, 0xb8, 0x9a, 0x7b, 0xe9, 0xe6, 0x78, 0xce,
+	0xf5, 0x6f, 0x45, 0x36, 0xe0, 0x27, 0x39, 0x9d, 0xc1, 0x70, 0xd3, 0xc6, 0xa3, 0x84, 0xa4, 0x01,
+	0x1f, 0x6e, 0xda, 0xeb, 0x09, 0x04, 0x07, 0x21, 0xef, 0x90, 0xfd, 0x24, 0x10, 0x7a, 0xa3, 0x0f,
+	0xa2, 0x92, 0x0f, 0x3b, 0xbe, 0x53, 0x97, 0x58, 0x7d, 0x2d, 0xad, 0xfb, 0xf5, 0x88, 0xfb, 0x89,
+	0x2e, 0x60, 0xda, 0xa0, 0x44, 0x61, 0x30, 0xeb, 0xe9, 0x0b, 0x47, 0x9f, 0x83, 0x94, 0x41, 0x84,
+	0x87, 0xaa, 0x40, 0x95, 0x63, 0x26, 0x4c, 0x19, 0x07, 0xce, 0xf1, 0x0c, 0x63, 0x6f, 0x20, 0xba,
+	0x17, 0xcf, 0xd0, 0x14, 0x82, 0x6f, 0xdd, 0x87, 0xaf, 0x98, 0x9e, 0x77, 0xd5, 0x69, 0x78, 0x2f,
+	0x60, 0xcf, 0x21, 0xe4, 0xb8, 0xef, 0x50, 0xd7, 0xc0, 0x29, 0x2a, 0xb9, 0x1f, 0x95, 0xbd, 0x80,
+	0x88, 0xe3, 0xfe, 0x58, 0xdb, 0x3f, 0x75, 0x29, 0xcc, 0x38, 0xee, 0xff, 0xa3, 0xaa, 0xd5, 0x2d,
+	0x4c, 0xfc, 0x13, 0xa4, 0x2f, 0x61, 0xbc, 0x36, 0x37, 0xad, 0xca, 0xe9, 0xd4, 0x07, 0xed, 0x22,
+	0x55, 0x72, 0x7e, 0xe9, 0xc7, 0xb5, 0xc9, 0x50, 0x48, 0x5b, 0xb6, 0x6c, 0x40, 0xaf, 0x20, 0x7c,
+	0x8f, 0xf6, 0x18, 0xf8, 0x8f, 0x8d, 0x27, 0xa7, 0x4b, 0xab, 0x42, 0x58, 0xdd, 0xdc, 0xa0, 0x65,
+	0x83, 0xed, 0xd8, 0x3d, 0xdf, 0xab, 0x5f, 0x03, 0x00, 0x56, 0x2d, 0xa3, 0x6c, 0xf6, 0x02, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
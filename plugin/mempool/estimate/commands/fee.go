@@ -0,0 +1,68 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commands
+
+import (
+	"github.com/33cn/chain33/rpc/jsonclient"
+	"github.com/33cn/chain33/types"
+	ety "github.com/33cn/plugin/plugin/mempool/estimate/types"
+	"github.com/spf13/cobra"
+)
+
+// EstimateCmd estimate cmd register
+func EstimateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "estimate",
+		Short: "Estimate fee rate of price or score mempool",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		FeeCmd(),
+	)
+	return cmd
+}
+
+// FeeCmd get fee rate estimate of mempool
+func FeeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fee",
+		Short: "Get fee rate per kb to be packed within 10(slow), 3(normal) or 1(fast) blocks",
+		Run:   feeEstimate,
+	}
+	cmd.Flags().StringP("level", "l", "normal", "slow, normal or fast, all for fee rate distribution of queued txs")
+	return cmd
+}
+
+// feeRate 指定速度的手续费率
+type feeRate struct {
+	Height  int64  `json:"height"`
+	Level   string `json:"level"`
+	FeeRate int64  `json:"feeRate"`
+}
+
+func feeEstimate(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	level, _ := cmd.Flags().GetString("level")
+	var res ety.ReplyFeeEstimate
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, ety.EstimateX+".GetFeeEstimate", nil, &res)
+	ctx.SetResultCb(func(ret interface{}) (interface{}, error) {
+		reply := ret.(*ety.ReplyFeeEstimate)
+		rate := &feeRate{Height: reply.Height, Level: level}
+		switch level {
+		case "slow":
+			rate.FeeRate = reply.Slow
+		case "normal":
+			rate.FeeRate = reply.Normal
+		case "fast":
+			rate.FeeRate = reply.Fast
+		case "all":
+			return reply, nil
+		default:
+			return nil, types.ErrInvalidParam
+		}
+		return rate, nil
+	})
+	ctx.Run()
+}
@@ -0,0 +1,199 @@
+// Package estimate 根据排队交易的费率分布和最近区块的打包情况估计手续费
+//
+// 排队交易按费率分桶, 从高到低累计交易数, 超过目标区块数的容量时需要的费率为排队部分的估计;
+// 最近的满区块中打包的最低费率按分位数取值为历史部分的估计, 两者取大.
+// 排队中大量低费率的交易不会拉低估计, 也不会因为它们的存在而高估.
+package estimate
+
+import (
+	"sort"
+	"sync"
+
+	drivers "github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+	ety "github.com/33cn/plugin/plugin/mempool/estimate/types"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// 记录最近的区块数
+	historySize = 100
+	// 交易数达到区块容量的90%认为区块已满, 打包的最低费率是有效的竞争价格
+	fullPercent = 90
+)
+
+// 目标区块数和历史部分使用的分位数, 目标越近分位越高
+var targets = []struct {
+	blocks     int64
+	percentile int
+}{
+	{1, 90},
+	{3, 60},
+	{10, 30},
+}
+
+type blockFee struct {
+	height int64
+	//区块已满时为打包的最低费率, 否则为MinTxFeeRate
+	feeRate int64
+}
+
+// Estimator 手续费估计, 和排队策略的GetProperFee一样在mempool的锁之外遍历排队队列
+type Estimator struct {
+	mu     sync.Mutex
+	queue  drivers.QueueCache
+	unit   int64
+	maxFee int64
+	//最新区块的容量
+	capacity int64
+	history  []*blockFee
+}
+
+// NewEstimator 创建手续费估计, 费率按照MinTxFeeRate对齐, qcache为排队策略本身的队列
+func NewEstimator(cfg *types.Mempool, qcache drivers.QueueCache) *Estimator {
+	unit := cfg.MinTxFeeRate
+	if unit <= 0 {
+		unit = 1
+	}
+	return &Estimator{
+		queue:  qcache,
+		unit:   unit,
+		maxFee: cfg.MaxTxFeeRate,
+	}
+}
+
+// AddBlock 记录区块中打包的最低费率, capacity为区块最多打包的交易数
+func (est *Estimator) AddBlock(height int64, txs []*types.Transaction, capacity int64) {
+	var minRate int64
+	for _, tx := range txs {
+		rate := FeeRate(tx)
+		//挖矿交易和交易组中除第一笔以外的交易不计算
+		if rate < est.unit {
+			continue
+		}
+		if minRate == 0 || rate < minRate {
+			minRate = rate
+		}
+	}
+	if int64(len(txs))*100 < capacity*fullPercent || minRate == 0 {
+		minRate = est.unit
+	}
+	est.mu.Lock()
+	defer est.mu.Unlock()
+	//分叉或者重复的区块, 删除同一高度之后的记录
+	for len(est.history) > 0 && est.history[len(est.history)-1].height >= height {
+		est.history = est.history[:len(est.history)-1]
+	}
+	est.history = append(est.history, &blockFee{height: height, feeRate: minRate})
+	est.capacity = capacity
+	if len(est.history) > historySize {
+		est.history = est.history[len(est.history)-historySize:]
+	}
+}
+
+// DelBlock 回滚区块时删除对应的记录
+func (est *Estimator) DelBlock(height int64) {
+	est.mu.Lock()
+	defer est.mu.Unlock()
+	n := len(est.history)
+	if n > 0 && est.history[n-1].height == height {
+		est.history = est.history[:n-1]
+	}
+}
+
+// Estimate 估计在1, 3, 10个区块内打包需要的费率, capacity为区块最多打包的交易数
+func (est *Estimator) Estimate(capacity int64) *ety.ReplyFeeEstimate {
+	buckets := est.buckets()
+	est.mu.Lock()
+	defer est.mu.Unlock()
+	rates := make([]int64, len(est.history))
+	reply := &ety.ReplyFeeEstimate{Buckets: buckets}
+	for i, block := range est.history {
+		rates[i] = block.feeRate
+	}
+	if len(est.history) > 0 {
+		reply.Height = est.history[len(est.history)-1].height
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i] < rates[j] })
+	fees := make([]int64, len(targets))
+	for i, target := range targets {
+		fee := est.queueFee(buckets, target.blocks*capacity)
+		if len(rates) > 0 {
+			if rate := rates[(len(rates)-1)*target.percentile/100]; rate > fee {
+				fee = rate
+			}
+		}
+		fees[i] = est.align(fee)
+	}
+	reply.Fast, reply.Normal, reply.Slow = fees[0], fees[1], fees[2]
+	return reply
+}
+
+// ProperFee 3个区块内打包需要的费率, 还没有记录区块时返回0, 由排队策略按原来的方式计算
+func (est *Estimator) ProperFee() int64 {
+	est.mu.Lock()
+	capacity := est.capacity
+	est.mu.Unlock()
+	if capacity <= 0 {
+		return 0
+	}
+	return est.Estimate(capacity).Normal
+}
+
+// queueFee 排在前面的交易超过count时, 需要高于这些交易所在桶的费率
+func (est *Estimator) queueFee(buckets []*ety.FeeBucket, count int64) int64 {
+	var sum int64
+	for _, bucket := range buckets {
+		sum += bucket.Count
+		if sum >= count {
+			return bucket.FeeRate + est.unit
+		}
+	}
+	return est.unit
+}
+
+func (est *Estimator) align(fee int64) int64 {
+	if fee%est.unit > 0 {
+		fee = (fee/est.unit + 1) * est.unit
+	}
+	if est.maxFee > 0 && fee > est.maxFee {
+		fee = est.maxFee
+	}
+	return fee
+}
+
+func (est *Estimator) buckets() []*ety.FeeBucket {
+	index := make(map[int64]*ety.FeeBucket)
+	est.queue.Walk(0, func(item *drivers.Item) bool {
+		rate := FeeRate(item.Value) / est.unit * est.unit
+		bucket, ok := index[rate]
+		if !ok {
+			bucket = &ety.FeeBucket{FeeRate: rate}
+			index[rate] = bucket
+		}
+		bucket.Count++
+		bucket.Bytes += int64(proto.Size(item.Value))
+		return true
+	})
+	buckets := make([]*ety.FeeBucket, 0, len(index))
+	for _, bucket := range index {
+		buckets = append(buckets, bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].FeeRate > buckets[j].FeeRate })
+	return buckets
+}
+
+// FeeRate 交易的费率, 单个交易根据txsize/1000 + 1计算单元数, 交易组按照每笔交易计算
+func FeeRate(tx *types.Transaction) int64 {
+	unitFeeNum := proto.Size(tx)/1000 + 1
+	if count := tx.GetGroupCount(); count > 0 {
+		unitFeeNum = int(count)
+		txs, err := tx.GetTxGroup()
+		if err == nil {
+			for _, tx := range txs.GetTxs() {
+				unitFeeNum += proto.Size(tx) / 1000
+			}
+		}
+	}
+	return tx.Fee / int64(unitFeeNum)
+}
@@ -0,0 +1,116 @@
+package estimate
+
+import (
+	"testing"
+
+	drivers "github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+	ety "github.com/33cn/plugin/plugin/mempool/estimate/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const unit = 100000
+
+var cfg = &types.Mempool{MinTxFeeRate: unit, MaxTxFeeRate: 100 * unit}
+
+func newTx(fee, nonce int64) *types.Transaction {
+	return &types.Transaction{Execer: []byte("coins"), Fee: fee, Nonce: nonce}
+}
+
+func newEstimator(txs ...*types.Transaction) *Estimator {
+	qcache := drivers.NewSimpleQueue(drivers.SubConfig{PoolCacheSize: 1000})
+	for _, tx := range txs {
+		qcache.Push(&drivers.Item{Value: tx, Priority: tx.Fee})
+	}
+	return NewEstimator(cfg, qcache)
+}
+
+func TestEstimateEmpty(t *testing.T) {
+	est := newEstimator()
+	reply := est.Estimate(10)
+	assert.Equal(t, int64(unit), reply.Fast)
+	assert.Equal(t, int64(unit), reply.Normal)
+	assert.Equal(t, int64(unit), reply.Slow)
+	assert.Equal(t, 0, len(reply.Buckets))
+}
+
+func TestEstimateQueue(t *testing.T) {
+	var txs []*types.Transaction
+	var nonce int64
+	add := func(fee int64, n int) {
+		for i := 0; i < n; i++ {
+			nonce++
+			txs = append(txs, newTx(fee, nonce))
+		}
+	}
+	add(5*unit+1, 3)
+	add(3*unit, 3)
+	//大量低费率的交易只影响较慢的估计
+	add(unit, 20)
+	est := newEstimator(txs...)
+	reply := est.Estimate(2)
+	assert.Equal(t, int64(6*unit), reply.Fast)
+	assert.Equal(t, int64(4*unit), reply.Normal)
+	assert.Equal(t, int64(2*unit), reply.Slow)
+	assert.Equal(t, []*ety.FeeBucket{
+		{FeeRate: 5 * unit, Count: 3, Bytes: 3 * int64(types.Size(txs[0]))},
+		{FeeRate: 3 * unit, Count: 3, Bytes: 3 * int64(types.Size(txs[3]))},
+		{FeeRate: unit, Count: 20, Bytes: 20 * int64(types.Size(txs[6]))},
+	}, reply.Buckets)
+
+	reply = est.Estimate(100)
+	assert.Equal(t, int64(unit), reply.Fast)
+	assert.Equal(t, int64(unit), reply.Slow)
+}
+
+func TestEstimateHistory(t *testing.T) {
+	est := newEstimator()
+	for i := int64(1); i <= 10; i++ {
+		//挖矿交易不计算费率
+		est.AddBlock(i, []*types.Transaction{newTx(0, 0), newTx(i*unit, 1), newTx(20*unit, 2)}, 3)
+	}
+	reply := est.Estimate(3)
+	assert.Equal(t, int64(10), reply.Height)
+	assert.Equal(t, int64(9*unit), reply.Fast)
+	assert.Equal(t, int64(6*unit), reply.Normal)
+	assert.Equal(t, int64(3*unit), reply.Slow)
+
+	//区块没有满时按最低费率
+	for i := int64(11); i <= 20; i++ {
+		est.AddBlock(i, []*types.Transaction{newTx(50*unit, 1)}, 3)
+	}
+	reply = est.Estimate(3)
+	assert.Equal(t, int64(20), reply.Height)
+	assert.Equal(t, int64(8*unit), reply.Fast)
+	assert.Equal(t, int64(2*unit), reply.Normal)
+	assert.Equal(t, int64(unit), reply.Slow)
+
+	//回滚和重新添加同一高度
+	est.DelBlock(19)
+	assert.Equal(t, int64(20), est.Estimate(3).Height)
+	est.DelBlock(20)
+	assert.Equal(t, int64(19), est.Estimate(3).Height)
+	est.AddBlock(15, []*types.Transaction{newTx(unit, 1)}, 3)
+	assert.Equal(t, int64(15), est.Estimate(3).Height)
+	assert.Equal(t, 15, len(est.history))
+
+	for i := int64(16); i < 16+historySize; i++ {
+		est.AddBlock(i, nil, 3)
+	}
+	assert.Equal(t, historySize, len(est.history))
+	assert.Equal(t, int64(unit), est.Estimate(3).Fast)
+}
+
+func TestEstimateMaxFee(t *testing.T) {
+	est := newEstimator(newTx(500*unit, 1), newTx(500*unit, 2))
+	reply := est.Estimate(1)
+	assert.Equal(t, cfg.MaxTxFeeRate, reply.Fast)
+	assert.Equal(t, int64(unit), reply.Normal)
+}
+
+func TestFeeRate(t *testing.T) {
+	tx := newTx(unit, 1)
+	assert.Equal(t, int64(unit), FeeRate(tx))
+	tx.Payload = make([]byte, 1500)
+	assert.Equal(t, int64(unit/2), FeeRate(tx))
+}
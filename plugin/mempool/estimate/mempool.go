@@ -0,0 +1,60 @@
+package estimate
+
+import (
+	"github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/queue"
+	drivers "github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+	ety "github.com/33cn/plugin/plugin/mempool/estimate/types"
+)
+
+var elog = log15.New("module", "mempool.estimate")
+
+// Mempool 支持手续费估计的mempool
+type Mempool struct {
+	*drivers.Mempool
+	est  *Estimator
+	recv chan *queue.Message
+}
+
+// New 包装mempool, 记录区块的打包情况并处理手续费估计的查询, est同时用于排队策略的GetProperFee
+func New(mem *drivers.Mempool, est *Estimator) *Mempool {
+	return &Mempool{Mempool: mem, est: est}
+}
+
+// SetQueueClient 手续费估计的消息在这里处理, 其余消息转交给mempool
+func (mem *Mempool) SetQueueClient(client queue.Client) {
+	mem.recv = make(chan *queue.Message)
+	mem.Mempool.SetQueueClient(&proxyClient{Client: client, recv: mem.recv})
+	go mem.eventProcess(client)
+}
+
+func (mem *Mempool) eventProcess(client queue.Client) {
+	defer close(mem.recv)
+	for msg := range client.Recv() {
+		switch msg.Ty {
+		case ety.EventGetFeeEstimate:
+			capacity := client.GetConfig().GetP(mem.Height() + 1).MaxTxNumber
+			msg.Reply(client.NewMessage("", ety.EventReplyFeeEstimate, mem.est.Estimate(capacity)))
+			continue
+		case types.EventAddBlock:
+			block := msg.GetData().(*types.BlockDetail).Block
+			capacity := client.GetConfig().GetP(block.Height).MaxTxNumber
+			mem.est.AddBlock(block.Height, block.Txs, capacity)
+		case types.EventDelBlock:
+			mem.est.DelBlock(msg.GetData().(*types.BlockDetail).Block.Height)
+		}
+		mem.recv <- msg
+	}
+	elog.Info("fee estimate event process quit")
+}
+
+// proxyClient mempool从这里接收转交的消息
+type proxyClient struct {
+	queue.Client
+	recv chan *queue.Message
+}
+
+func (client *proxyClient) Recv() chan *queue.Message {
+	return client.recv
+}
@@ -0,0 +1,24 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estimate
+
+import (
+	"github.com/33cn/chain33/pluginmgr"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/mempool/estimate/commands"
+	"github.com/33cn/plugin/plugin/mempool/estimate/rpc"
+	ety "github.com/33cn/plugin/plugin/mempool/estimate/types"
+)
+
+func init() {
+	pluginmgr.Register(&pluginmgr.PluginBase{
+		Name:     ety.EstimateX,
+		ExecName: ety.EstimateX,
+		//mempool没有执行器, 只注册获取手续费估计的rpc和命令行
+		Exec: func(name string, cfg *types.Chain33Config, sub []byte) {},
+		Cmd:  commands.EstimateCmd,
+		RPC:  rpc.Init,
+	})
+}
@@ -0,0 +1,40 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/33cn/chain33/types"
+	ety "github.com/33cn/plugin/plugin/mempool/estimate/types"
+)
+
+// GetFeeEstimate mempool的手续费估计, mempool需要使用price或者score排队策略
+func (c *channelClient) GetFeeEstimate(ctx context.Context, req *types.ReqNil) (*ety.ReplyFeeEstimate, error) {
+	msg := c.client.NewMessage("mempool", ety.EventGetFeeEstimate, req)
+	err := c.client.Send(msg, true)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Wait(msg)
+	if err != nil {
+		return nil, err
+	}
+	reply, ok := resp.GetData().(*ety.ReplyFeeEstimate)
+	if !ok {
+		return nil, types.ErrTypeAsset
+	}
+	return reply, nil
+}
+
+// GetFeeEstimate 在1, 3, 10个区块内打包需要的手续费率
+func (c *Jrpc) GetFeeEstimate(req *types.ReqNil, result *interface{}) error {
+	data, err := c.cli.GetFeeEstimate(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	*result = data
+	return nil
+}
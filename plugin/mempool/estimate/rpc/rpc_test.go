@@ -0,0 +1,44 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	ety "github.com/33cn/plugin/plugin/mempool/estimate/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFeeEstimate(t *testing.T) {
+	q := queue.New("channel")
+	defer q.Close()
+	estimate := &ety.ReplyFeeEstimate{Height: 10, Fast: 300000, Normal: 200000, Slow: 100000,
+		Buckets: []*ety.FeeBucket{{FeeRate: 200000, Count: 3, Bytes: 600}}}
+	//模拟mempool模块, 其他排队策略不支持手续费估计
+	go func() {
+		cli := q.Client()
+		cli.Sub("mempool")
+		for msg := range cli.Recv() {
+			if msg.Ty == ety.EventGetFeeEstimate {
+				msg.Reply(cli.NewMessage("", ety.EventReplyFeeEstimate, estimate))
+				continue
+			}
+			msg.ReplyErr("mempool", types.ErrActionNotSupport)
+		}
+	}()
+	jrpc := &Jrpc{cli: &channelClient{client: q.Client()}}
+
+	reply, err := jrpc.cli.GetFeeEstimate(context.Background(), &types.ReqNil{})
+	require.Nil(t, err)
+	assert.Equal(t, estimate, reply)
+	var result interface{}
+	err = jrpc.GetFeeEstimate(&types.ReqNil{}, &result)
+	require.Nil(t, err)
+	assert.Equal(t, estimate, result)
+}
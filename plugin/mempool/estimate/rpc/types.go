@@ -0,0 +1,36 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/rpc/types"
+	ety "github.com/33cn/plugin/plugin/mempool/estimate/types"
+)
+
+// Jrpc estimate jrpc interface
+type Jrpc struct {
+	cli *channelClient
+}
+
+// Grpc estimate Grpc interface
+type Grpc struct {
+	*channelClient
+}
+
+type channelClient struct {
+	types.ChannelClient
+	//发送chain33接口没有包括的消息
+	client queue.Client
+}
+
+// Init estimate rpc register
+func Init(name string, s types.RPCServer) {
+	cli := &channelClient{client: s.GetQueueClient()}
+	grpc := &Grpc{channelClient: cli}
+	cli.Init(name, s, &Jrpc{cli: cli}, grpc)
+
+	ety.RegisterEstimateServer(s.GRPC(), grpc)
+}
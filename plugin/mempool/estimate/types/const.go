@@ -0,0 +1,16 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+// EstimateX 手续费估计的插件名, 只注册rpc和命令行
+const EstimateX = "estimate"
+
+// mempool把chain33没有定义的消息交给排队策略包装的模块, 下面的消息只由price和score排队策略处理
+const (
+	// EventGetFeeEstimate 获取mempool的手续费估计, 回复为ReplyFeeEstimate
+	EventGetFeeEstimate = 2021 + iota
+	// EventReplyFeeEstimate 手续费估计的回复
+	EventReplyFeeEstimate
+)
@@ -0,0 +1,267 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: estimate.proto
+
+package types
+
+import (
+	context "context"
+	fmt "fmt"
+	math "math"
+
+	types "github.com/33cn/chain33/types"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// FeeBucket 排队交易中同一费率的交易数和字节数
+type FeeBucket struct {
+	FeeRate              int64    `protobuf:"varint,1,opt,name=feeRate,proto3" json:"feeRate,omitempty"`
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Bytes                int64    `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FeeBucket) Reset()         { *m = FeeBucket{} }
+func (m *FeeBucket) String() string { return proto.CompactTextString(m) }
+func (*FeeBucket) ProtoMessage()    {}
+func (*FeeBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dc6b0a4941f870ba, []int{0}
+}
+
+func (m *FeeBucket) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FeeBucket.Unmarshal(m, b)
+}
+func (m *FeeBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FeeBucket.Marshal(b, m, deterministic)
+}
+func (m *FeeBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeeBucket.Merge(m, src)
+}
+func (m *FeeBucket) XXX_Size() int {
+	return xxx_messageInfo_FeeBucket.Size(m)
+}
+func (m *FeeBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeeBucket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeeBucket proto.InternalMessageInfo
+
+func (m *FeeBucket) GetFeeRate() int64 {
+	if m != nil {
+		return m.FeeRate
+	}
+	return 0
+}
+
+func (m *FeeBucket) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *FeeBucket) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+type ReplyFeeEstimate struct {
+	//估计时最新的区块高度
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	//1个区块内打包需要的费率
+	Fast int64 `protobuf:"varint,2,opt,name=fast,proto3" json:"fast,omitempty"`
+	//3个区块内打包需要的费率
+	Normal int64 `protobuf:"varint,3,opt,name=normal,proto3" json:"normal,omitempty"`
+	//10个区块内打包需要的费率
+	Slow int64 `protobuf:"varint,4,opt,name=slow,proto3" json:"slow,omitempty"`
+	//排队交易的费率分布, 费率从高到低
+	Buckets              []*FeeBucket `protobuf:"bytes,5,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ReplyFeeEstimate) Reset()         { *m = ReplyFeeEstimate{} }
+func (m *ReplyFeeEstimate) String() string { return proto.CompactTextString(m) }
+func (*ReplyFeeEstimate) ProtoMessage()    {}
+func (*ReplyFeeEstimate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dc6b0a4941f870ba, []int{1}
+}
+
+func (m *ReplyFeeEstimate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplyFeeEstimate.Unmarshal(m, b)
+}
+func (m *ReplyFeeEstimate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplyFeeEstimate.Marshal(b, m, deterministic)
+}
+func (m *ReplyFeeEstimate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplyFeeEstimate.Merge(m, src)
+}
+func (m *ReplyFeeEstimate) XXX_Size() int {
+	return xxx_messageInfo_ReplyFeeEstimate.Size(m)
+}
+func (m *ReplyFeeEstimate) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplyFeeEstimate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplyFeeEstimate proto.InternalMessageInfo
+
+func (m *ReplyFeeEstimate) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ReplyFeeEstimate) GetFast() int64 {
+	if m != nil {
+		return m.Fast
+	}
+	return 0
+}
+
+func (m *ReplyFeeEstimate) GetNormal() int64 {
+	if m != nil {
+		return m.Normal
+	}
+	return 0
+}
+
+func (m *ReplyFeeEstimate) GetSlow() int64 {
+	if m != nil {
+		return m.Slow
+	}
+	return 0
+}
+
+func (m *ReplyFeeEstimate) GetBuckets() []*FeeBucket {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*FeeBucket)(nil), "types.FeeBucket")
+	proto.RegisterType((*ReplyFeeEstimate)(nil), "types.ReplyFeeEstimate")
+}
+
+func init() {
+	proto.RegisterFile("estimate.proto", fileDescriptor_dc6b0a4941f870ba)
+}
+
+var fileDescriptor_dc6b0a4941f870ba = []byte{
+	// 236 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0x41, 0x4f, 0x83, 0x40,
+	0x14, 0x84, 0x45, 0x4a, 0xab, 0x4f, 0x6d, 0x9a, 0x17, 0xa3, 0x9b, 0x9e, 0x1a, 0x4e, 0x8d, 0x07,
+	0x0e, 0xf5, 0xe6, 0xd1, 0x44, 0xbc, 0x99, 0xb8, 0xff, 0x00, 0xc8, 0xd4, 0x12, 0x81, 0xc5, 0xee,
+	0x6b, 0x0c, 0x7f, 0xc4, 0xdf, 0x6b, 0x58, 0x16, 0xa2, 0xb7, 0xfd, 0x26, 0xb3, 0x93, 0x99, 0x47,
+	0x4b, 0x58, 0x29, 0xeb, 0x4c, 0x90, 0xb4, 0x47, 0x23, 0x86, 0x23, 0xe9, 0x5a, 0xd8, 0xf5, 0x75,
+	0x61, 0xea, 0xda, 0x34, 0x83, 0x18, 0xbf, 0xd3, 0x65, 0x0a, 0x3c, 0x9f, 0x8a, 0x4f, 0x08, 0x2b,
+	0x5a, 0xec, 0x01, 0x9d, 0x09, 0x54, 0xb0, 0x09, 0xb6, 0xa1, 0x1e, 0x91, 0x6f, 0x29, 0x2a, 0xcc,
+	0xa9, 0x11, 0x75, 0xee, 0xf4, 0x01, 0x7a, 0x35, 0xef, 0x04, 0x56, 0x85, 0x83, 0xea, 0x20, 0xfe,
+	0x09, 0x68, 0xa5, 0xd1, 0x56, 0x5d, 0x0a, 0xbc, 0xf8, 0x0a, 0x7c, 0x47, 0xf3, 0x03, 0xca, 0x8f,
+	0x83, 0xf8, 0x64, 0x4f, 0xcc, 0x34, 0xdb, 0x67, 0x76, 0xcc, 0x75, 0xef, 0xde, 0xdb, 0x98, 0x63,
+	0x9d, 0x55, 0x3e, 0xd7, 0x53, 0xef, 0xb5, 0x95, 0xf9, 0x56, 0xb3, 0xc1, 0xdb, 0xbf, 0xf9, 0x81,
+	0x16, 0xb9, 0x2b, 0x6f, 0x55, 0xb4, 0x09, 0xb7, 0x57, 0xbb, 0x55, 0xe2, 0x66, 0x26, 0xd3, 0x2a,
+	0x3d, 0x1a, 0x76, 0x29, 0x5d, 0x8c, 0x27, 0xe1, 0x27, 0x5a, 0xbe, 0x42, 0xfe, 0x36, 0xbc, 0xf1,
+	0x1f, 0x35, 0xbe, 0xde, 0xca, 0x6a, 0x7d, 0x3f, 0xe1, 0xff, 0x25, 0xf1, 0x59, 0x3e, 0x77, 0xa7,
+	0x7b, 0xfc, 0x1d, 0x00, 0xe6, 0x63, 0x00, 0xee, 0x61, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// EstimateClient is the client API for Estimate service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type EstimateClient interface {
+	GetFeeEstimate(ctx context.Context, in *types.ReqNil, opts ...grpc.CallOption) (*ReplyFeeEstimate, error)
+}
+
+type estimateClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEstimateClient(cc grpc.ClientConnInterface) EstimateClient {
+	return &estimateClient{cc}
+}
+
+func (c *estimateClient) GetFeeEstimate(ctx context.Context, in *types.ReqNil, opts ...grpc.CallOption) (*ReplyFeeEstimate, error) {
+	out := new(ReplyFeeEstimate)
+	err := c.cc.Invoke(ctx, "/types.estimate/GetFeeEstimate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EstimateServer is the server API for Estimate service.
+type EstimateServer interface {
+	GetFeeEstimate(context.Context, *types.ReqNil) (*ReplyFeeEstimate, error)
+}
+
+// UnimplementedEstimateServer can be embedded to have forward compatible implementations.
+type UnimplementedEstimateServer struct {
+}
+
+func (*UnimplementedEstimateServer) GetFeeEstimate(ctx context.Context, req *types.ReqNil) (*ReplyFeeEstimate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeeEstimate not implemented")
+}
+
+func RegisterEstimateServer(s *grpc.Server, srv EstimateServer) {
+	s.RegisterService(&_Estimate_serviceDesc, srv)
+}
+
+func _Estimate_GetFeeEstimate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.ReqNil)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EstimateServer).GetFeeEstimate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.estimate/GetFeeEstimate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EstimateServer).GetFeeEstimate(ctx, req.(*types.ReqNil))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Estimate_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.estimate",
+	HandlerType: (*EstimateServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetFeeEstimate",
+			Handler:    _Estimate_GetFeeEstimate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "estimate.proto",
+}
@@ -0,0 +1,359 @@
+package fair
+
+import (
+	"container/heap"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// ErrReplaceUnderpriced 替换同一账户的同一笔交易时手续费提高的比例不够
+var ErrReplaceUnderpriced = errors.New("ErrReplaceUnderpriced")
+
+// Queue 按账户公平排队的队列
+//
+// 价格(每千字节的手续费)高者优先, 同一价格的交易在不同账户之间轮流打包, 同一账户内按时间先后,
+// 每个账户最多占用 maxTxPerAccount 个位置. 同一账户除手续费以外内容相同的交易(发送者提高手续费后重新签名)
+// 只有在手续费提高 priceBumpPercent 以上时才会替换原来的交易.
+type Queue struct {
+	subConfig subConfig
+	txs       map[string]*entry
+	accounts  map[string]*account
+	//被替换或者被挤出的交易, 等待mempool清理账户索引等信息
+	dropped map[string]*mempool.Item
+	//等待清理的交易hash, mempool在加锁之外取走, 单独加锁
+	mu      sync.Mutex
+	pending []string
+	bytes   int64
+	seq     int64
+	//Walk 的顺序, 队列变化时重新计算
+	order []*entry
+	dirty bool
+}
+
+type entry struct {
+	*mempool.Item
+	hash  string
+	from  string
+	key   string
+	price int64
+	size  int64
+	seq   int64
+	//在账户堆中的位置
+	index int
+}
+
+type account struct {
+	//替换key对应的交易
+	txs map[string]*entry
+	//价格最低的交易在堆顶, 同价格时最后进入的在堆顶
+	worst entryHeap
+	first int64
+}
+
+// entryHeap 账户内的交易按挤出的先后排列
+type entryHeap []*entry
+
+func (h entryHeap) Len() int { return len(h) }
+
+func (h entryHeap) Less(i, j int) bool {
+	if h[i].price != h[j].price {
+		return h[i].price < h[j].price
+	}
+	return h[i].seq > h[j].seq
+}
+
+func (h entryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *entryHeap) Push(x interface{}) {
+	e := x.(*entry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *entryHeap) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return e
+}
+
+// NewQueue 创建队列
+func NewQueue(subcfg subConfig) *Queue {
+	return &Queue{
+		subConfig: subcfg,
+		txs:       make(map[string]*entry),
+		accounts:  make(map[string]*account),
+		dropped:   make(map[string]*mempool.Item),
+	}
+}
+
+// TakeDropped 取走被替换或者被挤出队列的交易, mempool 需要通过 Remove 清理这些交易
+func (cache *Queue) TakeDropped() []string {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	hashes := cache.pending
+	cache.pending = nil
+	return hashes
+}
+
+func newEntry(item *mempool.Item) *entry {
+	size := int64(proto.Size(item.Value))
+	return &entry{
+		Item:  item,
+		hash:  string(item.Value.Hash()),
+		from:  item.Value.From(),
+		key:   replaceKey(item.Value),
+		price: item.Value.Fee / (size/1000 + 1),
+		size:  size,
+	}
+}
+
+// replaceKey 交易除手续费和签名以外的内容, 由发送者决定, 提高手续费重新签名的交易可以替换原来的交易
+func replaceKey(tx *types.Transaction) string {
+	copytx := tx.Clone()
+	copytx.Fee = 0
+	return string(copytx.Hash())
+}
+
+// less 队列中的排序, 价格高者优先, 同价格时间早者优先
+func (e *entry) less(other *entry) bool {
+	if e.price != other.price {
+		return e.price > other.price
+	}
+	return e.seq < other.seq
+}
+
+//Exist 是否存在, 已经被替换但是还没有清理的交易也认为存在
+func (cache *Queue) Exist(hash string) bool {
+	if _, ok := cache.txs[hash]; ok {
+		return true
+	}
+	_, ok := cache.dropped[hash]
+	return ok
+}
+
+//GetItem 获取数据通过 key
+func (cache *Queue) GetItem(hash string) (*mempool.Item, error) {
+	if e, ok := cache.txs[hash]; ok {
+		return e.Item, nil
+	}
+	if item, ok := cache.dropped[hash]; ok {
+		return item, nil
+	}
+	return nil, types.ErrNotFound
+}
+
+//Push 加入数据到队列
+func (cache *Queue) Push(item *mempool.Item) error {
+	e := newEntry(item)
+	if cache.Exist(e.hash) {
+		return types.ErrTxExist
+	}
+	acc := cache.accounts[e.from]
+	if acc != nil {
+		if old, ok := acc.txs[e.key]; ok {
+			if !cache.isBumped(old.Value.Fee, item.Value.Fee) {
+				return ErrReplaceUnderpriced
+			}
+			cache.drop(old)
+			cache.insert(e)
+			return nil
+		}
+		if int64(len(acc.txs)) >= cache.subConfig.MaxTxPerAccount {
+			return types.ErrManyTx
+		}
+	}
+	if int64(len(cache.txs)) >= cache.subConfig.PoolCacheSize {
+		victim := cache.victim()
+		if victim == nil || e.price <= victim.price {
+			return types.ErrMemFull
+		}
+		cache.drop(victim)
+	}
+	cache.insert(e)
+	return nil
+}
+
+func (cache *Queue) isBumped(oldFee, newFee int64) bool {
+	return newFee > oldFee && newFee*100 >= oldFee*(100+cache.subConfig.PriceBumpPercent)
+}
+
+// victim 队列满时挤出的交易, 取价格最低的交易, 同价格时优先挤出占用位置最多的账户最后进入的交易.
+// 只需要比较每个账户堆顶的交易
+func (cache *Queue) victim() *entry {
+	var victim *entry
+	for _, acc := range cache.accounts {
+		e := acc.worst[0]
+		if victim == nil || e.price < victim.price {
+			victim = e
+			continue
+		}
+		if e.price != victim.price {
+			continue
+		}
+		n, m := len(acc.txs), len(cache.accounts[victim.from].txs)
+		if n > m || (n == m && e.seq > victim.seq) {
+			victim = e
+		}
+	}
+	return victim
+}
+
+func (cache *Queue) insert(e *entry) {
+	cache.seq++
+	e.seq = cache.seq
+	acc := cache.accounts[e.from]
+	if acc == nil {
+		acc = &account{txs: make(map[string]*entry), first: e.seq}
+		cache.accounts[e.from] = acc
+	}
+	acc.txs[e.key] = e
+	heap.Push(&acc.worst, e)
+	cache.txs[e.hash] = e
+	cache.bytes += e.size
+	cache.dirty = true
+}
+
+func (cache *Queue) delete(e *entry) {
+	delete(cache.txs, e.hash)
+	cache.bytes -= e.size
+	if acc := cache.accounts[e.from]; acc != nil {
+		delete(acc.txs, e.key)
+		heap.Remove(&acc.worst, e.index)
+		if len(acc.txs) == 0 {
+			delete(cache.accounts, e.from)
+		}
+	}
+	cache.dirty = true
+}
+
+// drop 交易移出队列, 等待mempool调用 Remove 清理
+func (cache *Queue) drop(e *entry) {
+	cache.delete(e)
+	cache.dropped[e.hash] = e.Item
+	cache.mu.Lock()
+	cache.pending = append(cache.pending, e.hash)
+	cache.mu.Unlock()
+}
+
+//Remove 删除数据
+func (cache *Queue) Remove(hash string) error {
+	if e, ok := cache.txs[hash]; ok {
+		cache.delete(e)
+		return nil
+	}
+	if _, ok := cache.dropped[hash]; ok {
+		delete(cache.dropped, hash)
+		return nil
+	}
+	return types.ErrNotFound
+}
+
+//Size 数据总数
+func (cache *Queue) Size() int {
+	return len(cache.txs)
+}
+
+//GetCacheBytes 队列中交易的总字节数
+func (cache *Queue) GetCacheBytes() int64 {
+	return cache.bytes
+}
+
+//Walk 按照打包顺序遍历, 同一价格的交易在账户之间轮流选取
+func (cache *Queue) Walk(count int, cb func(tx *mempool.Item) bool) {
+	if cache.dirty {
+		cache.order = cache.sortTxs()
+		cache.dirty = false
+	}
+	for i, e := range cache.order {
+		if count > 0 && i >= count {
+			return
+		}
+		if !cb(e.Item) {
+			return
+		}
+	}
+}
+
+func (cache *Queue) sortTxs() []*entry {
+	all := make([]*entry, 0, len(cache.txs))
+	for _, e := range cache.txs {
+		all = append(all, e)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].less(all[j]) })
+	order := make([]*entry, 0, len(all))
+	for start := 0; start < len(all); {
+		end := start + 1
+		for end < len(all) && all[end].price == all[start].price {
+			end++
+		}
+		order = append(order, cache.roundRobin(all[start:end])...)
+		start = end
+	}
+	return order
+}
+
+// roundRobin 同一价格的交易, 账户按照进入队列的先后轮流取一笔
+func (cache *Queue) roundRobin(level []*entry) []*entry {
+	var froms []string
+	queues := make(map[string][]*entry)
+	for _, e := range level {
+		if _, ok := queues[e.from]; !ok {
+			froms = append(froms, e.from)
+		}
+		queues[e.from] = append(queues[e.from], e)
+	}
+	if len(froms) == 1 {
+		return level
+	}
+	sort.SliceStable(froms, func(i, j int) bool {
+		return cache.accounts[froms[i]].first < cache.accounts[froms[j]].first
+	})
+	order := make([]*entry, 0, len(level))
+	for len(order) < len(level) {
+		for _, from := range froms {
+			if q := queues[from]; len(q) > 0 {
+				order = append(order, q[0])
+				queues[from] = q[1:]
+			}
+		}
+	}
+	return order
+}
+
+// GetProperFee 获取合适的手续费率,取前100的平均手续费率
+func (cache *Queue) GetProperFee() int64 {
+	if cache.Size() < 100 {
+		return cache.subConfig.ProperFee
+	}
+	var sumFeeRate int64
+	i := 0
+	cache.Walk(100, func(item *mempool.Item) bool {
+		//总单元费率的个数, 单个交易根据txsize/1000 + 1计算
+		unitFeeNum := proto.Size(item.Value)/1000 + 1
+		//交易组计算
+		if count := item.Value.GetGroupCount(); count > 0 {
+			unitFeeNum = int(count)
+			txs, err := item.Value.GetTxGroup()
+			if err == nil {
+				for _, tx := range txs.GetTxs() {
+					unitFeeNum += proto.Size(tx) / 1000
+				}
+			}
+		}
+		sumFeeRate += item.Value.Fee / int64(unitFeeNum)
+		i++
+		return true
+	})
+	return sumFeeRate / int64(i)
+}
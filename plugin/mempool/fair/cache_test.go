@@ -0,0 +1,209 @@
+package fair
+
+import (
+	"testing"
+
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/common/crypto"
+	cty "github.com/33cn/chain33/system/dapp/coins/types"
+	drivers "github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/33cn/chain33/system"
+)
+
+var (
+	v        = &cty.CoinsAction_Transfer{Transfer: &types.AssetsTransfer{Amount: 1e8}}
+	transfer = &cty.CoinsAction{Value: v, Ty: cty.CoinsActionTransfer}
+	_, keyA  = util.Genaddress()
+	_, keyB  = util.Genaddress()
+	_, keyC  = util.Genaddress()
+)
+
+func initEnv(size int64) *Queue {
+	if size == 0 {
+		size = 1000
+	}
+	_, sub := types.InitCfg("chain33.test.toml")
+	var subcfg subConfig
+	types.MustDecode(sub.Mempool["fair"], &subcfg)
+	subcfg.PoolCacheSize = size
+	cache := NewQueue(subcfg)
+	return cache
+}
+
+func newItem(priv crypto.PrivKey, fee, nonce int64) *drivers.Item {
+	//交易hash不包含签名, 转给自己使不同账户的交易hash不同
+	toAddr := address.PubKeyToAddress(priv.PubKey().Bytes()).String()
+	tx := &types.Transaction{Execer: []byte("coins"), Payload: types.Encode(transfer), Fee: fee, Nonce: nonce, To: toAddr}
+	tx.Sign(types.SECP256K1, priv)
+	return &drivers.Item{Value: tx, Priority: tx.Fee, EnterTime: types.Now().Unix()}
+}
+
+func walkAll(cache *Queue) []*drivers.Item {
+	var items []*drivers.Item
+	cache.Walk(0, func(item *drivers.Item) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+func TestPushAndRemove(t *testing.T) {
+	cache := initEnv(0)
+	item := newItem(keyA, 1000000, 1)
+	hash := string(item.Value.Hash())
+	assert.Nil(t, cache.Push(item))
+	assert.Equal(t, true, cache.Exist(hash))
+	it, err := cache.GetItem(hash)
+	assert.Nil(t, err)
+	assert.Equal(t, item, it)
+	assert.Equal(t, int64(proto.Size(item.Value)), cache.GetCacheBytes())
+	assert.Equal(t, types.ErrTxExist, cache.Push(item))
+
+	assert.Nil(t, cache.Remove(hash))
+	assert.Equal(t, 0, cache.Size())
+	assert.Equal(t, int64(0), cache.GetCacheBytes())
+	assert.Equal(t, types.ErrNotFound, cache.Remove(hash))
+	_, err = cache.GetItem(hash)
+	assert.Equal(t, types.ErrNotFound, err)
+}
+
+func TestMaxTxPerAccount(t *testing.T) {
+	cache := initEnv(0)
+	cache.subConfig.MaxTxPerAccount = 2
+	assert.Nil(t, cache.Push(newItem(keyA, 1000000, 1)))
+	assert.Nil(t, cache.Push(newItem(keyA, 1000000, 2)))
+	assert.Equal(t, types.ErrManyTx, cache.Push(newItem(keyA, 1000000, 3)))
+	//其他账户不受影响
+	assert.Nil(t, cache.Push(newItem(keyB, 1000000, 3)))
+	assert.Equal(t, 3, cache.Size())
+}
+
+func TestReplaceByFee(t *testing.T) {
+	cache := initEnv(0)
+	old := newItem(keyA, 1000000, 1)
+	assert.Nil(t, cache.Push(old))
+	//手续费提高不到10%
+	assert.Equal(t, ErrReplaceUnderpriced, cache.Push(newItem(keyA, 1050000, 1)))
+	assert.Equal(t, ErrReplaceUnderpriced, cache.Push(newItem(keyA, 900000, 1)))
+	//不同账户相同内容不是替换
+	assert.Nil(t, cache.Push(newItem(keyB, 1000000, 1)))
+	//内容不同不是替换
+	other := newItem(keyA, 1000000, 1)
+	other.Value.Expire = 100
+	other.Value.Sign(types.SECP256K1, keyA)
+	assert.Nil(t, cache.Push(other))
+	assert.Nil(t, cache.Remove(string(other.Value.Hash())))
+
+	bumped := newItem(keyA, 1100000, 1)
+	assert.Nil(t, cache.Push(bumped))
+	assert.Equal(t, 2, cache.Size())
+	oldHash := string(old.Value.Hash())
+	assert.Equal(t, []string{oldHash}, cache.TakeDropped())
+	assert.Equal(t, 0, len(cache.TakeDropped()))
+	//被替换的交易等待mempool清理, 不再参与打包
+	assert.Equal(t, true, cache.Exist(oldHash))
+	for _, item := range walkAll(cache) {
+		assert.NotEqual(t, old, item)
+	}
+	assert.Equal(t, int64(proto.Size(bumped.Value)+proto.Size(newItem(keyB, 1000000, 1).Value)), cache.GetCacheBytes())
+	assert.Nil(t, cache.Remove(oldHash))
+	assert.Equal(t, false, cache.Exist(oldHash))
+	assert.Equal(t, 2, cache.Size())
+}
+
+func TestMemFull(t *testing.T) {
+	cache := initEnv(2)
+	a1 := newItem(keyA, 1000000, 1)
+	a2 := newItem(keyA, 1000000, 2)
+	assert.Nil(t, cache.Push(a1))
+	assert.Nil(t, cache.Push(a2))
+	//同价格不能挤出
+	assert.Equal(t, types.ErrMemFull, cache.Push(newItem(keyB, 1000000, 1)))
+	//价格高的交易挤出价格最低的交易中最后进入的
+	b1 := newItem(keyB, 2000000, 1)
+	assert.Nil(t, cache.Push(b1))
+	assert.Equal(t, 2, cache.Size())
+	assert.Equal(t, []string{string(a2.Value.Hash())}, cache.TakeDropped())
+	assert.Equal(t, []*drivers.Item{b1, a1}, walkAll(cache))
+}
+
+func TestRoundRobin(t *testing.T) {
+	cache := initEnv(0)
+	a1 := newItem(keyA, 1000000, 1)
+	a2 := newItem(keyA, 1000000, 2)
+	a3 := newItem(keyA, 1000000, 3)
+	b1 := newItem(keyB, 1000000, 1)
+	c1 := newItem(keyC, 1000000, 1)
+	c2 := newItem(keyC, 1000000, 2)
+	high := newItem(keyA, 3000000, 4)
+	for _, item := range []*drivers.Item{a1, a2, a3, b1, c1, c2, high} {
+		assert.Nil(t, cache.Push(item))
+	}
+	//价格高者优先, 同价格在账户之间轮流
+	assert.Equal(t, []*drivers.Item{high, a1, b1, c1, a2, c2, a3}, walkAll(cache))
+
+	var items []*drivers.Item
+	cache.Walk(3, func(item *drivers.Item) bool {
+		items = append(items, item)
+		return true
+	})
+	assert.Equal(t, []*drivers.Item{high, a1, b1}, items)
+
+	assert.Nil(t, cache.Remove(string(b1.Value.Hash())))
+	assert.Equal(t, []*drivers.Item{high, a1, c1, a2, c2, a3}, walkAll(cache))
+}
+
+func TestGetProperFee(t *testing.T) {
+	cache := initEnv(0)
+	cache.subConfig.MaxTxPerAccount = 100
+	assert.Equal(t, cache.subConfig.ProperFee, cache.GetProperFee())
+	var sum int64
+	for i := 0; i < 100; i++ {
+		item := newItem(keyA, 1000000+int64(i)*1000, int64(i))
+		assert.Nil(t, cache.Push(item))
+		sum += item.Value.Fee / int64(proto.Size(item.Value)/1000+1)
+	}
+	assert.Equal(t, sum/100, cache.GetProperFee())
+}
+
+func TestVictim(t *testing.T) {
+	cache := initEnv(4)
+	a1 := newItem(keyA, 1000000, 1)
+	a2 := newItem(keyA, 3000000, 2)
+	a3 := newItem(keyA, 1000000, 3)
+	b1 := newItem(keyB, 1000000, 1)
+	for _, item := range []*drivers.Item{a1, a2, a3, b1} {
+		assert.Nil(t, cache.Push(item))
+	}
+	//同价格时挤出占用位置最多的账户最后进入的交易
+	assert.Nil(t, cache.Push(newItem(keyC, 2000000, 1)))
+	assert.Equal(t, []string{string(a3.Value.Hash())}, cache.TakeDropped())
+	assert.Nil(t, cache.Push(newItem(keyC, 2000000, 2)))
+	assert.Nil(t, cache.Push(newItem(keyC, 2000000, 3)))
+	assert.Equal(t, []string{string(a1.Value.Hash()), string(b1.Value.Hash())}, cache.TakeDropped())
+	//价格最高的交易不会被挤出
+	assert.Equal(t, types.ErrMemFull, cache.Push(newItem(keyB, 2000000, 2)))
+	assert.Equal(t, true, cache.Exist(string(a2.Value.Hash())))
+}
+
+func TestReplaceCleanAccountIndex(t *testing.T) {
+	cfg, sub := types.InitCfg("chain33.test.toml")
+	mem := New(cfg.Mempool, sub.Mempool["fair"]).(*Mempool)
+	old := newItem(keyA, 1000000, 1)
+	bumped := newItem(keyA, 1100000, 1)
+	assert.Nil(t, mem.PushTx(old.Value))
+	assert.Nil(t, mem.PushTx(bumped.Value))
+	mem.removeDropped()
+	addr := bumped.Value.From()
+	assert.Equal(t, int64(1), mem.TxNumOfAccount(addr))
+	assert.Equal(t, 1, mem.Size())
+	txs := mem.GetLatestTx()
+	assert.Equal(t, 1, len(txs))
+	assert.Equal(t, bumped.Value.Hash(), txs[0].Hash())
+	assert.Equal(t, false, mem.cache.Exist(string(old.Value.Hash())))
+}
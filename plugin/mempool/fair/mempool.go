@@ -0,0 +1,119 @@
+package fair
+
+import (
+	"github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/queue"
+	drivers "github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+)
+
+var flog = log15.New("module", "mempool.fair")
+
+//--------------------------------------------------------------------------------
+// Module Mempool
+
+const (
+	defaultMaxTxPerAccount  = 16
+	defaultPriceBumpPercent = 10
+)
+
+type subConfig struct {
+	PoolCacheSize int64 `json:"poolCacheSize"`
+	//每个账户最多排队的交易数, 需要小于mempool的maxTxNumPerAccount, 否则账户排满之后无法替换交易
+	MaxTxPerAccount int64 `json:"maxTxPerAccount"`
+	//替换同一账户的同一笔交易时, 手续费至少提高的百分比
+	PriceBumpPercent int64 `json:"priceBumpPercent"`
+	ProperFee        int64 `json:"properFee"`
+}
+
+func init() {
+	drivers.Reg("fair", New)
+}
+
+//New 创建fair cache 结构的 mempool
+func New(cfg *types.Mempool, sub []byte) queue.Module {
+	c := drivers.NewMempool(cfg)
+	var subcfg subConfig
+	types.MustDecode(sub, &subcfg)
+	if subcfg.PoolCacheSize == 0 {
+		subcfg.PoolCacheSize = cfg.PoolCacheSize
+	}
+	if subcfg.MaxTxPerAccount == 0 {
+		subcfg.MaxTxPerAccount = defaultMaxTxPerAccount
+	}
+	if subcfg.PriceBumpPercent == 0 {
+		subcfg.PriceBumpPercent = defaultPriceBumpPercent
+	}
+	if subcfg.ProperFee == 0 {
+		subcfg.ProperFee = cfg.MinTxFeeRate
+	}
+	cache := NewQueue(subcfg)
+	c.SetQueueCache(cache)
+	return &Mempool{Mempool: c, cache: cache}
+}
+
+// Mempool 被替换或者被挤出队列的交易在交易的回复之前从mempool中删除,
+// Push 时mempool已经加锁, 不能在队列中删除账户索引等信息
+type Mempool struct {
+	*drivers.Mempool
+	cache *Queue
+	recv  chan *queue.Message
+}
+
+// SetQueueClient 交易消息在这里等待mempool的回复, 其余消息转交给mempool
+func (mem *Mempool) SetQueueClient(client queue.Client) {
+	mem.recv = make(chan *queue.Message)
+	mem.Mempool.SetQueueClient(&proxyClient{Client: client, recv: mem.recv})
+	go mem.eventProcess(client)
+}
+
+func (mem *Mempool) eventProcess(client queue.Client) {
+	defer close(mem.recv)
+	for msg := range client.Recv() {
+		if msg.Ty == types.EventTx {
+			inner := client.NewMessage("mempool", types.EventTx, msg.GetData())
+			mem.recv <- inner
+			go mem.replyTx(client, msg, inner)
+			continue
+		}
+		//区块回滚时重新加入的交易也可能挤出其他交易
+		mem.recv <- msg
+		mem.removeDropped()
+	}
+	flog.Info("fair mempool event process quit")
+}
+
+func (mem *Mempool) replyTx(client queue.Client, msg, inner *queue.Message) {
+	resp, err := client.Wait(inner)
+	if err == queue.ErrIsQueueClosed {
+		return
+	}
+	mem.removeDropped()
+	msg.Reply(resp)
+}
+
+// removeDropped 删除被替换或者被挤出队列的交易, 同时清理账户索引等信息
+func (mem *Mempool) removeDropped() {
+	hashes := mem.cache.TakeDropped()
+	if len(hashes) == 0 {
+		return
+	}
+	list := &types.TxHashList{}
+	for _, hash := range hashes {
+		list.Hashes = append(list.Hashes, []byte(hash))
+	}
+	err := mem.RemoveTxs(list)
+	if err != nil {
+		flog.Error("removeDropped", "err", err)
+	}
+}
+
+// proxyClient mempool从这里接收转交的消息
+type proxyClient struct {
+	queue.Client
+	recv chan *queue.Message
+}
+
+func (client *proxyClient) Recv() chan *queue.Message {
+	return client.recv
+}
@@ -1,6 +1,7 @@
 package init
 
 import (
+	_ "github.com/33cn/plugin/plugin/mempool/fair"  //auto gen
 	_ "github.com/33cn/plugin/plugin/mempool/para"  //auto gen
 	_ "github.com/33cn/plugin/plugin/mempool/price" //auto gen
 	_ "github.com/33cn/plugin/plugin/mempool/score" //auto gen
@@ -0,0 +1,41 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commands
+
+import (
+	"github.com/33cn/chain33/rpc/jsonclient"
+	jty "github.com/33cn/plugin/plugin/mempool/journal/types"
+	"github.com/spf13/cobra"
+)
+
+// JournalCmd journal cmd register
+func JournalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "journal",
+		Short: "Query mempool journal and eviction",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		StatsCmd(),
+	)
+	return cmd
+}
+
+// StatsCmd get evicted and replayed tx counts of mempool
+func StatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Get evicted and replayed tx counts of mempool journal",
+		Run:   mempoolStats,
+	}
+	return cmd
+}
+
+func mempoolStats(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	var res jty.MempoolStats
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, jty.JournalX+".GetMempoolStats", nil, &res)
+	ctx.Run()
+}
@@ -0,0 +1,177 @@
+// Package journal 排队交易的日志和过期清理
+//
+// 排队的交易写入日志文件, 节点重启后重新检查并加入mempool; 后台定期清理超过Expire
+// 或者排队时间超过maxAge的交易, 并按照原因计数.
+//
+// 日志文件由记录组成: 类型(1字节) | 长度(uvarint) | 数据
+//
+//	添加: 进入队列的时间(int64) | 交易
+//	删除: 交易hash
+package journal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"sort"
+
+	drivers "github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+)
+
+const (
+	opAdd byte = 1
+	opDel byte = 2
+	// 记录的最大长度, 防止错误的文件导致分配过大的内存
+	maxRecordSize = 32 * 1024 * 1024
+	// 文件中的记录数超过队列中交易数的两倍加上这个数时重写文件
+	compactSlack = 1024
+)
+
+type journal struct {
+	path    string
+	file    *os.File
+	records int
+}
+
+// loadJournal 读取日志中还在排队的交易, 按进入队列的时间排序. 文件末尾不完整的记录会被忽略
+func loadJournal(path string) ([]*drivers.Item, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	items := make(map[string]*drivers.Item)
+	for {
+		op, data, err := readRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			jlog.Warn("loadJournal skip broken tail", "path", path, "err", err)
+			break
+		}
+		switch op {
+		case opAdd:
+			if len(data) < 8 {
+				continue
+			}
+			var tx types.Transaction
+			if err := types.Decode(data[8:], &tx); err != nil {
+				jlog.Warn("loadJournal decode tx", "err", err)
+				continue
+			}
+			enterTime := int64(binary.BigEndian.Uint64(data[:8]))
+			items[string(tx.Hash())] = &drivers.Item{Value: &tx, Priority: tx.Fee, EnterTime: enterTime}
+		case opDel:
+			delete(items, string(data))
+		}
+	}
+	list := make([]*drivers.Item, 0, len(items))
+	for _, item := range items {
+		list = append(list, item)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].EnterTime < list[j].EnterTime })
+	return list, nil
+}
+
+func readRecord(r *bufio.Reader) (byte, []byte, error) {
+	op, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+	if size > maxRecordSize {
+		return 0, nil, types.ErrInvalidParam
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+	return op, data, nil
+}
+
+// openJournal 用items重写日志文件, 之后的记录追加到文件末尾
+func openJournal(path string, items []*drivers.Item) (*journal, error) {
+	j := &journal{path: path}
+	if err := j.rewrite(items); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (j *journal) rewrite(items []*drivers.Item) error {
+	tmp := j.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, item := range items {
+		if _, err := w.Write(addRecord(item)); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if j.file != nil {
+		j.file.Close()
+		j.file = nil
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return err
+	}
+	j.file, err = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	j.records = len(items)
+	return nil
+}
+
+func record(op byte, data []byte) []byte {
+	buf := make([]byte, 1+binary.MaxVarintLen64, 1+binary.MaxVarintLen64+len(data))
+	buf[0] = op
+	n := binary.PutUvarint(buf[1:], uint64(len(data)))
+	return append(buf[:1+n], data...)
+}
+
+func addRecord(item *drivers.Item) []byte {
+	data := make([]byte, 8, 8+types.Size(item.Value))
+	binary.BigEndian.PutUint64(data, uint64(item.EnterTime))
+	return record(opAdd, append(data, types.Encode(item.Value)...))
+}
+
+func (j *journal) add(item *drivers.Item) error {
+	j.records++
+	_, err := j.file.Write(addRecord(item))
+	return err
+}
+
+func (j *journal) del(hash string) error {
+	j.records++
+	_, err := j.file.Write(record(opDel, []byte(hash)))
+	return err
+}
+
+func (j *journal) close() error {
+	return j.file.Close()
+}
@@ -0,0 +1,156 @@
+package journal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/33cn/chain33/queue"
+	drivers "github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/mempool/estimate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newItem(nonce, expire, enterTime int64) *drivers.Item {
+	tx := &types.Transaction{Execer: []byte("coins"), Fee: 100000, Nonce: nonce, Expire: expire}
+	return &drivers.Item{Value: tx, Priority: tx.Fee, EnterTime: enterTime}
+}
+
+func newQueue(t *testing.T, path string) *Queue {
+	q, err := NewQueue(path, drivers.NewSimpleQueue(drivers.SubConfig{PoolCacheSize: 100}))
+	require.Nil(t, err)
+	return q
+}
+
+func hashes(items []*drivers.Item) []string {
+	var list []string
+	for _, item := range items {
+		list = append(list, string(item.Value.Hash()))
+	}
+	return list
+}
+
+func TestJournalReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mempool.journal")
+
+	q := newQueue(t, path)
+	assert.Equal(t, 0, len(q.takePending()))
+	now := types.Now().Unix()
+	item1, item2, item3 := newItem(1, 0, now-2), newItem(2, 0, now-3), newItem(3, 0, now-1)
+	assert.Nil(t, q.Push(item1))
+	assert.Nil(t, q.Push(item2))
+	assert.Nil(t, q.Push(item3))
+	assert.Equal(t, types.ErrTxExist, q.Push(item1))
+	assert.Nil(t, q.Remove(string(item1.Value.Hash())))
+	q.Close()
+
+	//末尾不完整的记录被忽略
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	require.Nil(t, err)
+	_, err = f.Write(addRecord(newItem(4, 0, now))[:10])
+	require.Nil(t, err)
+	f.Close()
+
+	q = newQueue(t, path)
+	pending := q.takePending()
+	assert.Equal(t, hashes([]*drivers.Item{item2, item3}), hashes(pending))
+	assert.Equal(t, item2.EnterTime, pending[0].EnterTime)
+	assert.Equal(t, 0, q.Size())
+
+	//重新检查失败的交易从日志中删除, 成功的重新加入队列
+	assert.Nil(t, q.Push(pending[1]))
+	q.settle(string(item3.Value.Hash()), true)
+	q.settle(string(item2.Value.Hash()), false)
+	q.Close()
+	q = newQueue(t, path)
+	assert.Equal(t, hashes([]*drivers.Item{item3}), hashes(q.takePending()))
+	q.Close()
+}
+
+func TestJournalCompact(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mempool.journal")
+
+	q := newQueue(t, path)
+	now := types.Now().Unix()
+	keep := newItem(0, 0, now)
+	assert.Nil(t, q.Push(keep))
+	for i := int64(1); i <= 2*compactSlack; i++ {
+		item := newItem(i, 0, now)
+		assert.Nil(t, q.Push(item))
+		assert.Nil(t, q.Remove(string(item.Value.Hash())))
+	}
+	assert.True(t, q.journal.records <= 2+compactSlack)
+	q.Close()
+	q = newQueue(t, path)
+	assert.Equal(t, hashes([]*drivers.Item{keep}), hashes(q.takePending()))
+	q.Close()
+}
+
+func TestScan(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	q := newQueue(t, "")
+	now := types.Now().Unix()
+	fresh := newItem(1, 0, now)
+	expired := newItem(2, 10, now)
+	aged := newItem(3, 0, now-100)
+	overflow := newItem(4, 0, now)
+	for _, item := range []*drivers.Item{fresh, expired, aged, overflow} {
+		assert.Nil(t, q.Push(item))
+	}
+	//队列满时被挤出的交易不会通过Remove删除
+	assert.Nil(t, q.QueueCache.Remove(string(overflow.Value.Hash())))
+
+	header := &types.Header{Height: 20, BlockTime: now}
+	exp, old, n := q.scan(cfg, header, 60)
+	assert.Equal(t, hashes([]*drivers.Item{expired}), exp)
+	assert.Equal(t, hashes([]*drivers.Item{aged}), old)
+	assert.Equal(t, 1, n)
+
+	exp, old, n = q.scan(cfg, header, 0)
+	assert.Equal(t, 1, len(exp))
+	assert.Equal(t, 0, len(old))
+	assert.Equal(t, 0, n)
+}
+
+func TestNewMempool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	cfg := &types.Mempool{PoolCacheSize: 100, MinTxFeeRate: 100000, MaxTxNumPerAccount: 100}
+	newMempool := func(jcfg Config) (queue.Module, error) {
+		qcache := drivers.NewSimpleQueue(drivers.SubConfig{PoolCacheSize: 100})
+		return NewMempool(cfg, jcfg, qcache, estimate.NewEstimator(cfg, qcache))
+	}
+
+	//没有配置交易日志和按时间清理时不包装
+	module, err := newMempool(Config{EvictInterval: 10})
+	require.Nil(t, err)
+	_, ok := module.(*estimate.Mempool)
+	assert.True(t, ok)
+
+	//日志文件是目录时打开失败, 启动时返回错误
+	_, err = newMempool(Config{JournalFile: dir})
+	assert.NotNil(t, err)
+
+	module, err = newMempool(Config{JournalFile: filepath.Join(dir, "mempool.journal")})
+	require.Nil(t, err)
+	mem := module.(*Mempool)
+	assert.NotNil(t, mem.queue.journal)
+	assert.Nil(t, mem.base.PushTx(newItem(1, 0, 0).Value))
+	assert.Equal(t, 1, mem.base.Size())
+	mem.queue.Close()
+
+	module, err = newMempool(Config{MaxAge: 60})
+	require.Nil(t, err)
+	mem = module.(*Mempool)
+	assert.Nil(t, mem.queue.journal)
+}
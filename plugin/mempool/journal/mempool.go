@@ -0,0 +1,239 @@
+package journal
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/queue"
+	drivers "github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/mempool/estimate"
+	jty "github.com/33cn/plugin/plugin/mempool/journal/types"
+)
+
+var jlog = log15.New("module", "mempool.journal")
+
+// 默认的清理间隔(秒)
+const defaultEvictInterval = 10
+
+// Config 交易日志和过期清理的配置, JournalFile和MaxAge都没有配置时mempool不做包装
+type Config struct {
+	// 交易日志文件, 为空时不保存排队的交易
+	JournalFile string `json:"journalFile"`
+	// 交易排队的最长时间(秒), 为0时不按时间清理. mempool本身会清理排队超过10分钟的交易
+	MaxAge int64 `json:"maxAge"`
+	// 清理过期交易的间隔(秒)
+	EvictInterval int64 `json:"evictInterval"`
+}
+
+// counters 清理和重新加入的交易计数
+type counters struct {
+	// 超过Expire被清理
+	Expired int64
+	// 排队时间超过MaxAge被清理
+	Aged int64
+	// 队列满时被挤出
+	Overflow int64
+	// 重启后重新加入mempool
+	Replayed int64
+	// 重启后重新检查失败
+	ReplayFailed int64
+}
+
+// Mempool 支持交易日志和过期清理的mempool
+type Mempool struct {
+	queue.Module
+	base   *drivers.Mempool
+	queue  *Queue
+	cfg    Config
+	stats  counters
+	recv   chan *queue.Message
+	done   chan struct{}
+	wg     sync.WaitGroup
+	closed int32
+}
+
+// NewMempool 创建支持手续费估计的mempool, qcache为排队策略, est为排队策略使用的手续费估计.
+// 配置了交易日志或者按时间清理时, 再包装交易日志和过期清理, 交易日志打开失败时返回错误
+func NewMempool(cfg *types.Mempool, jcfg Config, qcache drivers.QueueCache, est *estimate.Estimator) (queue.Module, error) {
+	base := drivers.NewMempool(cfg)
+	module := estimate.New(base, est)
+	if jcfg.JournalFile == "" && jcfg.MaxAge <= 0 {
+		base.SetQueueCache(qcache)
+		return module, nil
+	}
+	q, err := NewQueue(jcfg.JournalFile, qcache)
+	if err != nil {
+		return nil, err
+	}
+	base.SetQueueCache(q)
+	return New(jcfg, base, q, module), nil
+}
+
+// New 包装mempool, qcache需要已经设置给base, module为base或者对base的包装
+func New(cfg Config, base *drivers.Mempool, qcache *Queue, module queue.Module) *Mempool {
+	if cfg.EvictInterval <= 0 {
+		cfg.EvictInterval = defaultEvictInterval
+	}
+	return &Mempool{
+		Module: module,
+		base:   base,
+		queue:  qcache,
+		cfg:    cfg,
+		done:   make(chan struct{}),
+	}
+}
+
+// SetQueueClient 计数的消息在这里处理, 其余消息转交给mempool, 同时开始重新加入交易和清理
+func (mem *Mempool) SetQueueClient(client queue.Client) {
+	mem.recv = make(chan *queue.Message)
+	mem.Module.SetQueueClient(&proxyClient{Client: client, recv: mem.recv})
+	mem.wg.Add(2)
+	go mem.eventProcess(client)
+	go mem.evictLoop(client)
+	//等待检查的交易不能取消, 不计入wg, 关闭之后不再发送
+	go mem.replay(client)
+}
+
+// Close 关闭mempool和交易日志
+func (mem *Mempool) Close() {
+	if !atomic.CompareAndSwapInt32(&mem.closed, 0, 1) {
+		return
+	}
+	close(mem.done)
+	mem.Module.Close()
+	mem.wg.Wait()
+	mem.queue.Close()
+}
+
+// Stats 清理和重新加入的交易计数
+func (mem *Mempool) Stats() *jty.MempoolStats {
+	return &jty.MempoolStats{
+		Expired:      atomic.LoadInt64(&mem.stats.Expired),
+		Aged:         atomic.LoadInt64(&mem.stats.Aged),
+		Overflow:     atomic.LoadInt64(&mem.stats.Overflow),
+		Replayed:     atomic.LoadInt64(&mem.stats.Replayed),
+		ReplayFailed: atomic.LoadInt64(&mem.stats.ReplayFailed),
+	}
+}
+
+func (mem *Mempool) eventProcess(client queue.Client) {
+	defer mem.wg.Done()
+	defer close(mem.recv)
+	for msg := range client.Recv() {
+		if msg.Ty == jty.EventGetMempoolStats {
+			msg.Reply(client.NewMessage("", jty.EventReplyMempoolStats, mem.Stats()))
+			continue
+		}
+		mem.recv <- msg
+	}
+}
+
+func (mem *Mempool) evictLoop(client queue.Client) {
+	defer mem.wg.Done()
+	ticker := time.NewTicker(time.Duration(mem.cfg.EvictInterval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			mem.evict(client.GetConfig())
+		case <-mem.done:
+			return
+		}
+	}
+}
+
+// evict 清理过期的交易, mempool还没有获取到区块高度时不处理
+func (mem *Mempool) evict(cfg *types.Chain33Config) {
+	header := mem.base.GetHeader()
+	if header == nil {
+		return
+	}
+	expired, aged, overflow := mem.queue.scan(cfg, header, mem.cfg.MaxAge)
+	var hashes [][]byte
+	for _, hash := range append(expired, aged...) {
+		hashes = append(hashes, []byte(hash))
+	}
+	if len(hashes) > 0 {
+		mem.base.RemoveTxs(&types.TxHashList{Hashes: hashes})
+	}
+	atomic.AddInt64(&mem.stats.Expired, int64(len(expired)))
+	atomic.AddInt64(&mem.stats.Aged, int64(len(aged)))
+	atomic.AddInt64(&mem.stats.Overflow, int64(overflow))
+	if len(hashes) > 0 || overflow > 0 {
+		jlog.Info("mempool evict", "height", header.GetHeight(), "expired", len(expired), "aged", len(aged), "overflow", overflow)
+	}
+}
+
+// replay 重启前排队的交易按进入队列的顺序重新检查并加入mempool
+func (mem *Mempool) replay(client queue.Client) {
+	pending := mem.queue.takePending()
+	if len(pending) == 0 {
+		return
+	}
+	jlog.Info("mempool replay", "txs", len(pending))
+	for _, item := range pending {
+		for {
+			if atomic.LoadInt32(&mem.closed) == 1 {
+				return
+			}
+			err := sendTx(client, item.Value)
+			//mempool还没有同步完成时等待
+			if err == types.ErrNotSync {
+				time.Sleep(time.Second)
+				continue
+			}
+			ok := err == nil || err == types.ErrTxExist
+			if ok {
+				atomic.AddInt64(&mem.stats.Replayed, 1)
+			} else {
+				jlog.Debug("mempool replay", "hash", common.ToHex(item.Value.Hash()), "err", err)
+				atomic.AddInt64(&mem.stats.ReplayFailed, 1)
+			}
+			mem.queue.settle(string(item.Value.Hash()), ok)
+			break
+		}
+	}
+	stats := mem.Stats()
+	jlog.Info("mempool replay done", "replayed", stats.Replayed, "failed", stats.ReplayFailed)
+}
+
+func sendTx(client queue.Client, tx *types.Transaction) error {
+	msg := client.NewMessage("mempool", types.EventTx, tx)
+	err := client.Send(msg, true)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Wait(msg)
+	if err != nil {
+		return err
+	}
+	reply, ok := resp.GetData().(*types.Reply)
+	if !ok {
+		return types.ErrTypeAsset
+	}
+	if !reply.GetIsOk() {
+		switch string(reply.GetMsg()) {
+		case types.ErrNotSync.Error():
+			return types.ErrNotSync
+		case types.ErrTxExist.Error():
+			return types.ErrTxExist
+		}
+		return errors.New(string(reply.GetMsg()))
+	}
+	return nil
+}
+
+// proxyClient mempool从这里接收转交的消息
+type proxyClient struct {
+	queue.Client
+	recv chan *queue.Message
+}
+
+func (client *proxyClient) Recv() chan *queue.Message {
+	return client.recv
+}
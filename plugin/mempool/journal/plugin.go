@@ -0,0 +1,24 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package journal
+
+import (
+	"github.com/33cn/chain33/pluginmgr"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/mempool/journal/commands"
+	"github.com/33cn/plugin/plugin/mempool/journal/rpc"
+	jty "github.com/33cn/plugin/plugin/mempool/journal/types"
+)
+
+func init() {
+	pluginmgr.Register(&pluginmgr.PluginBase{
+		Name:     jty.JournalX,
+		ExecName: jty.JournalX,
+		//mempool没有执行器, 只注册获取交易日志计数的rpc和命令行
+		Exec: func(name string, cfg *types.Chain33Config, sub []byte) {},
+		Cmd:  commands.JournalCmd,
+		RPC:  rpc.Init,
+	})
+}
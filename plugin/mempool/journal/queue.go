@@ -0,0 +1,203 @@
+package journal
+
+import (
+	"sync"
+
+	drivers "github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+)
+
+// Queue 给排队队列加锁并记录队列中的交易, 开启日志时同时写入文件
+type Queue struct {
+	mu sync.Mutex
+	drivers.QueueCache
+	//队列中的交易, 队列满时被挤出的交易在清理时才会删除
+	items   map[string]*drivers.Item
+	journal *journal
+	//重启前还在排队的交易, 等待重新检查
+	pending     []*drivers.Item
+	pendingHash map[string]*drivers.Item
+}
+
+// NewQueue 包装排队队列, path为空时不写日志文件
+func NewQueue(path string, qcache drivers.QueueCache) (*Queue, error) {
+	q := &Queue{QueueCache: qcache, items: make(map[string]*drivers.Item)}
+	if path == "" {
+		return q, nil
+	}
+	pending, err := loadJournal(path)
+	if err != nil {
+		return nil, err
+	}
+	//重新检查之前保留在日志中, 中途退出时不会丢失
+	q.journal, err = openJournal(path, pending)
+	if err != nil {
+		return nil, err
+	}
+	q.pending = pending
+	q.pendingHash = make(map[string]*drivers.Item)
+	for _, item := range pending {
+		q.pendingHash[string(item.Value.Hash())] = item
+	}
+	return q, nil
+}
+
+// takePending 取出重启前还在排队的交易, 按进入队列的时间排序
+func (q *Queue) takePending() []*drivers.Item {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	pending := q.pending
+	q.pending = nil
+	return pending
+}
+
+// settle 重新检查完成, 失败的交易从日志中删除
+func (q *Queue) settle(hash string, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.pendingHash, hash)
+	if _, exist := q.items[hash]; exist || ok {
+		return
+	}
+	q.writeDel(hash)
+}
+
+func (q *Queue) writeAdd(item *drivers.Item) {
+	if q.journal == nil {
+		return
+	}
+	if err := q.journal.add(item); err != nil {
+		jlog.Error("journal add", "err", err)
+	}
+	q.compact()
+}
+
+func (q *Queue) writeDel(hash string) {
+	if q.journal == nil {
+		return
+	}
+	if err := q.journal.del(hash); err != nil {
+		jlog.Error("journal del", "err", err)
+	}
+	q.compact()
+}
+
+func (q *Queue) compact() {
+	if q.journal.records <= 2*(len(q.items)+len(q.pendingHash))+compactSlack {
+		return
+	}
+	items := make([]*drivers.Item, 0, len(q.items)+len(q.pendingHash))
+	for _, item := range q.items {
+		items = append(items, item)
+	}
+	//还没有重新检查的交易需要保留
+	for hash, item := range q.pendingHash {
+		if _, ok := q.items[hash]; !ok {
+			items = append(items, item)
+		}
+	}
+	if err := q.journal.rewrite(items); err != nil {
+		jlog.Error("journal rewrite", "err", err)
+	}
+}
+
+// scan 找出超过Expire或者排队时间超过maxAge的交易, 同时删除已经被挤出队列的交易
+func (q *Queue) scan(cfg *types.Chain33Config, header *types.Header, maxAge int64) (expired, aged []string, overflow int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := types.Now().Unix()
+	for hash, item := range q.items {
+		switch {
+		case !q.QueueCache.Exist(hash):
+			delete(q.items, hash)
+			q.writeDel(hash)
+			overflow++
+		case item.Value.IsExpire(cfg, header.GetHeight(), header.GetBlockTime()):
+			expired = append(expired, hash)
+		case maxAge > 0 && now-item.EnterTime >= maxAge:
+			aged = append(aged, hash)
+		}
+	}
+	return expired, aged, overflow
+}
+
+// Close 关闭日志文件, 之后的变化不再写入
+func (q *Queue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.journal == nil {
+		return
+	}
+	if err := q.journal.close(); err != nil {
+		jlog.Error("journal close", "err", err)
+	}
+	q.journal = nil
+}
+
+//Exist 是否存在
+func (q *Queue) Exist(hash string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.QueueCache.Exist(hash)
+}
+
+//GetItem 获取数据通过 key
+func (q *Queue) GetItem(hash string) (*drivers.Item, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.QueueCache.GetItem(hash)
+}
+
+//Push 加入数据到队列
+func (q *Queue) Push(item *drivers.Item) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	err := q.QueueCache.Push(item)
+	if err != nil {
+		return err
+	}
+	hash := string(item.Value.Hash())
+	q.items[hash] = item
+	q.writeAdd(item)
+	return nil
+}
+
+//Remove 删除数据
+func (q *Queue) Remove(hash string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	err := q.QueueCache.Remove(hash)
+	if _, ok := q.items[hash]; ok {
+		delete(q.items, hash)
+		q.writeDel(hash)
+	}
+	return err
+}
+
+//Size 数据总数
+func (q *Queue) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.QueueCache.Size()
+}
+
+//Walk 遍历队列, cb中不能再调用队列的方法
+func (q *Queue) Walk(count int, cb func(tx *drivers.Item) bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.QueueCache.Walk(count, cb)
+}
+
+//GetProperFee 获取合适的手续费率
+func (q *Queue) GetProperFee() int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.QueueCache.GetProperFee()
+}
+
+//GetCacheBytes 队列中交易的总字节数
+func (q *Queue) GetCacheBytes() int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.QueueCache.GetCacheBytes()
+}
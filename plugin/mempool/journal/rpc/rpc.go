@@ -0,0 +1,40 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/33cn/chain33/types"
+	jty "github.com/33cn/plugin/plugin/mempool/journal/types"
+)
+
+// GetMempoolStats mempool交易日志和清理的计数, mempool需要配置journalFile或者maxAge
+func (c *channelClient) GetMempoolStats(ctx context.Context, req *types.ReqNil) (*jty.MempoolStats, error) {
+	msg := c.client.NewMessage("mempool", jty.EventGetMempoolStats, req)
+	err := c.client.Send(msg, true)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Wait(msg)
+	if err != nil {
+		return nil, err
+	}
+	stats, ok := resp.GetData().(*jty.MempoolStats)
+	if !ok {
+		return nil, types.ErrTypeAsset
+	}
+	return stats, nil
+}
+
+// GetMempoolStats mempool清理和重新加入的交易计数
+func (c *Jrpc) GetMempoolStats(req *types.ReqNil, result *interface{}) error {
+	data, err := c.cli.GetMempoolStats(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	*result = data
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	jty "github.com/33cn/plugin/plugin/mempool/journal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMempoolStats(t *testing.T) {
+	q := queue.New("channel")
+	defer q.Close()
+	stats := &jty.MempoolStats{Expired: 1, Aged: 2, Overflow: 3, Replayed: 4, ReplayFailed: 5}
+	//模拟mempool模块, 没有配置交易日志时不支持查询计数
+	go func() {
+		cli := q.Client()
+		cli.Sub("mempool")
+		for msg := range cli.Recv() {
+			if msg.Ty == jty.EventGetMempoolStats {
+				msg.Reply(cli.NewMessage("", jty.EventReplyMempoolStats, stats))
+				continue
+			}
+			msg.ReplyErr("mempool", types.ErrActionNotSupport)
+		}
+	}()
+	jrpc := &Jrpc{cli: &channelClient{client: q.Client()}}
+
+	reply, err := jrpc.cli.GetMempoolStats(context.Background(), &types.ReqNil{})
+	require.Nil(t, err)
+	assert.Equal(t, stats, reply)
+	var result interface{}
+	err = jrpc.GetMempoolStats(&types.ReqNil{}, &result)
+	require.Nil(t, err)
+	assert.Equal(t, stats, result)
+}
@@ -0,0 +1,16 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+// JournalX 交易日志的插件名, 只注册rpc和命令行
+const JournalX = "journal"
+
+// mempool把chain33没有定义的消息交给排队策略包装的模块, 下面的消息只由配置了交易日志或者过期清理的mempool处理
+const (
+	// EventGetMempoolStats 获取mempool交易日志和清理的计数, 回复为MempoolStats
+	EventGetMempoolStats = 2031 + iota
+	// EventReplyMempoolStats 计数的回复
+	EventReplyMempoolStats
+)
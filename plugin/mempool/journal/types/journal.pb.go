@@ -0,0 +1,209 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: journal.proto
+
+package types
+
+import (
+	context "context"
+	fmt "fmt"
+	math "math"
+
+	types "github.com/33cn/chain33/types"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// MempoolStats 交易日志重新加入和过期清理的交易计数
+type MempoolStats struct {
+	//超过Expire被清理
+	Expired int64 `protobuf:"varint,1,opt,name=expired,proto3" json:"expired,omitempty"`
+	//排队时间超过maxAge被清理
+	Aged int64 `protobuf:"varint,2,opt,name=aged,proto3" json:"aged,omitempty"`
+	//队列满时被挤出
+	Overflow int64 `protobuf:"varint,3,opt,name=overflow,proto3" json:"overflow,omitempty"`
+	//重启后重新加入mempool
+	Replayed int64 `protobuf:"varint,4,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	//重启后重新检查失败
+	ReplayFailed         int64    `protobuf:"varint,5,opt,name=replayFailed,proto3" json:"replayFailed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MempoolStats) Reset()         { *m = MempoolStats{} }
+func (m *MempoolStats) String() string { return proto.CompactTextString(m) }
+func (*MempoolStats) ProtoMessage()    {}
+func (*MempoolStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04fd98cceb1b9191, []int{0}
+}
+
+func (m *MempoolStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MempoolStats.Unmarshal(m, b)
+}
+func (m *MempoolStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MempoolStats.Marshal(b, m, deterministic)
+}
+func (m *MempoolStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MempoolStats.Merge(m, src)
+}
+func (m *MempoolStats) XXX_Size() int {
+	return xxx_messageInfo_MempoolStats.Size(m)
+}
+func (m *MempoolStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_MempoolStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MempoolStats proto.InternalMessageInfo
+
+func (m *MempoolStats) GetExpired() int64 {
+	if m != nil {
+		return m.Expired
+	}
+	return 0
+}
+
+func (m *MempoolStats) GetAged() int64 {
+	if m != nil {
+		return m.Aged
+	}
+	return 0
+}
+
+func (m *MempoolStats) GetOverflow() int64 {
+	if m != nil {
+		return m.Overflow
+	}
+	return 0
+}
+
+func (m *MempoolStats) GetReplayed() int64 {
+	if m != nil {
+		return m.Replayed
+	}
+	return 0
+}
+
+func (m *MempoolStats) GetReplayFailed() int64 {
+	if m != nil {
+		return m.ReplayFailed
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*MempoolStats)(nil), "types.MempoolStats")
+}
+
+func init() {
+	proto.RegisterFile("journal.proto", fileDescriptor_04fd98cceb1b9191)
+}
+
+var fileDescriptor_04fd98cceb1b9191 = []byte{
+	// 194 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcd, 0xca, 0x2f, 0x2d,
+	0xca, 0x4b, 0xcc, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2d, 0xa9, 0x2c, 0x48, 0x2d,
+	0x96, 0xe2, 0x49, 0xce, 0xcf, 0xcd, 0xcd, 0xcf, 0x83, 0x08, 0x2a, 0xcd, 0x60, 0xe4, 0xe2, 0xf1,
+	0x4d, 0xcd, 0x2d, 0xc8, 0xcf, 0xcf, 0x09, 0x2e, 0x49, 0x2c, 0x29, 0x16, 0x92, 0xe0, 0x62, 0x4f,
+	0xad, 0x28, 0xc8, 0x2c, 0x4a, 0x4d, 0x91, 0x60, 0x54, 0x60, 0xd4, 0x60, 0x0e, 0x82, 0x71, 0x85,
+	0x84, 0xb8, 0x58, 0x12, 0xd3, 0x53, 0x53, 0x24, 0x98, 0xc0, 0xc2, 0x60, 0xb6, 0x90, 0x14, 0x17,
+	0x47, 0x7e, 0x59, 0x6a, 0x51, 0x5a, 0x4e, 0x7e, 0xb9, 0x04, 0x33, 0x58, 0x1c, 0xce, 0x07, 0xc9,
+	0x15, 0xa5, 0x16, 0xe4, 0x24, 0x56, 0xa6, 0xa6, 0x48, 0xb0, 0x40, 0xe4, 0x60, 0x7c, 0x21, 0x25,
+	0x2e, 0x1e, 0x08, 0xdb, 0x2d, 0x31, 0x33, 0x27, 0x35, 0x45, 0x82, 0x15, 0x2c, 0x8f, 0x22, 0x66,
+	0xe4, 0xc4, 0xc5, 0x0e, 0xf5, 0x80, 0x90, 0x39, 0x17, 0xbf, 0x7b, 0x6a, 0x09, 0x8a, 0x3b, 0x79,
+	0xf5, 0xc0, 0xde, 0xd1, 0x0b, 0x4a, 0x2d, 0xf4, 0xcb, 0xcc, 0x91, 0x12, 0x86, 0x72, 0x91, 0xd5,
+	0x28, 0x31, 0x24, 0xb1, 0x81, 0x7d, 0x69, 0x0c, 0x18, 0x00, 0x48, 0xdc, 0x00, 0xb7, 0x0b, 0x01,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// JournalClient is the client API for Journal service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type JournalClient interface {
+	GetMempoolStats(ctx context.Context, in *types.ReqNil, opts ...grpc.CallOption) (*MempoolStats, error)
+}
+
+type journalClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJournalClient(cc grpc.ClientConnInterface) JournalClient {
+	return &journalClient{cc}
+}
+
+func (c *journalClient) GetMempoolStats(ctx context.Context, in *types.ReqNil, opts ...grpc.CallOption) (*MempoolStats, error) {
+	out := new(MempoolStats)
+	err := c.cc.Invoke(ctx, "/types.journal/GetMempoolStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JournalServer is the server API for Journal service.
+type JournalServer interface {
+	GetMempoolStats(context.Context, *types.ReqNil) (*MempoolStats, error)
+}
+
+// UnimplementedJournalServer can be embedded to have forward compatible implementations.
+type UnimplementedJournalServer struct {
+}
+
+func (*UnimplementedJournalServer) GetMempoolStats(ctx context.Context, req *types.ReqNil) (*MempoolStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMempoolStats not implemented")
+}
+
+func RegisterJournalServer(s *grpc.Server, srv JournalServer) {
+	s.RegisterService(&_Journal_serviceDesc, srv)
+}
+
+func _Journal_GetMempoolStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.ReqNil)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JournalServer).GetMempoolStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.journal/GetMempoolStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JournalServer).GetMempoolStats(ctx, req.(*types.ReqNil))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Journal_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.journal",
+	HandlerType: (*JournalServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMempoolStats",
+			Handler:    _Journal_GetMempoolStats_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "journal.proto",
+}
@@ -1,33 +1,72 @@
 package para
 
 import (
-	"context"
 	"sync/atomic"
+	"time"
 
 	"sync"
 
+	"github.com/33cn/chain33/common"
 	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/queue"
-	"github.com/33cn/chain33/rpc/grpcclient"
 	"github.com/33cn/chain33/types"
+	"google.golang.org/grpc/status"
 )
 
 var mlog = log.New("module", "mempool.para")
 var topic = "mempool"
 
+const (
+	defaultPoolCacheSize    = 10240
+	defaultTimeout          = 5000
+	defaultRetryInterval    = 1000
+	defaultMaxRetryInterval = 60000
+	// 发送到主链之后超过这个时间(秒)还没有打包的交易不再保留, 同mempool的过期时间
+	sentExpire = 600
+)
+
 //Mempool mempool 基础类
 type Mempool struct {
-	key         string
-	wg          sync.WaitGroup
-	client      queue.Client
-	mainGrpcCli types.Chain33Client
-	isclose     int32
+	key     string
+	wg      sync.WaitGroup
+	client  queue.Client
+	subCfg  subConfig
+	main    *mainClients
+	mainErr error
+	cache   *txCache
+	done    chan struct{}
+	isclose int32
 }
 
 //NewMempool 新建mempool 实例
 func NewMempool(cfg *types.Mempool) *Mempool {
+	return newMempool(cfg, subConfig{})
+}
+
+func newMempool(cfg *types.Mempool, subcfg subConfig) *Mempool {
+	if subcfg.PoolCacheSize == 0 && cfg != nil {
+		subcfg.PoolCacheSize = cfg.PoolCacheSize
+	}
+	if subcfg.PoolCacheSize == 0 {
+		subcfg.PoolCacheSize = defaultPoolCacheSize
+	}
+	if subcfg.Timeout == 0 {
+		subcfg.Timeout = defaultTimeout
+	}
+	if subcfg.RetryInterval == 0 {
+		subcfg.RetryInterval = defaultRetryInterval
+	}
+	if subcfg.MaxRetryInterval == 0 {
+		subcfg.MaxRetryInterval = defaultMaxRetryInterval
+	}
+	if subcfg.MaxRetryInterval < subcfg.RetryInterval {
+		subcfg.MaxRetryInterval = subcfg.RetryInterval
+	}
 	pool := &Mempool{}
 	pool.key = topic
+	pool.subCfg = subcfg
+	pool.cache = newTxCache(int(subcfg.PoolCacheSize))
+	pool.done = make(chan struct{})
 	return pool
 }
 
@@ -35,8 +74,11 @@ func NewMempool(cfg *types.Mempool) *Mempool {
 func (mem *Mempool) SetQueueClient(client queue.Client) {
 	mem.client = client
 	mem.client.Sub(mem.key)
-	mem.setMainGrpcCli(client.GetConfig())
-	mem.wg.Add(1)
+	if mem.main == nil {
+		mem.setMainGrpcCli(client.GetConfig())
+	}
+	mem.wg.Add(2)
+	go mem.resendLoop()
 	go func() {
 		defer mem.wg.Done()
 		for msg := range client.Recv() {
@@ -45,12 +87,25 @@ func (mem *Mempool) SetQueueClient(client queue.Client) {
 			switch msg.Ty {
 			case types.EventTx:
 				mlog.Info("Receive msg from para mempool")
-				tx := msg.GetData().(*types.Transaction)
-				reply, err = mem.mainGrpcCli.SendTransaction(context.Background(), tx)
+				reply, err = mem.sendTx(msg.GetData().(*types.Transaction))
 			case types.EventGetProperFee:
-				reply, err = mem.mainGrpcCli.GetProperFee(context.Background(), &types.ReqProperFee{})
+				reply, err = mem.getProperFee()
+			case types.EventGetMempool:
+				reply = &types.ReplyTxList{Txs: mem.cache.txs()}
+			case types.EventGetMempoolSize:
+				reply = &types.MempoolSize{Size: int64(mem.cache.count())}
+			case types.EventGetLastMempool:
+				reply = &types.ReplyTxList{Txs: mem.cache.lastTxs()}
+			case types.EventGetAddrTxs:
+				reply = mem.cache.accTxs(msg.GetData().(*types.ReqAddrs))
+			case types.EventAddBlock:
+				//打包到平行链区块中的交易不再保留, 不需要回复
+				mem.cache.removeTxs(msg.GetData().(*types.BlockDetail).Block.Txs)
+				continue
+			case types.EventDelBlock:
+				continue
 			default:
-				msg.Reply(client.NewMessage(mem.key, types.EventReply, types.ErrActionNotSupport))
+				err = types.ErrActionNotSupport
 			}
 			if err != nil {
 				msg.Reply(client.NewMessage(mem.key, types.EventReply, err))
@@ -63,11 +118,107 @@ func (mem *Mempool) SetQueueClient(client queue.Client) {
 
 func (mem *Mempool) setMainGrpcCli(cfg *types.Chain33Config) {
 	if cfg != nil && cfg.IsPara() {
-		grpcCli, err := grpcclient.NewMainChainClient(cfg, "")
+		main, err := newMainClients(cfg, mem.subCfg.MainChainGrpcAddrs, time.Duration(mem.subCfg.Timeout)*time.Millisecond)
 		if err != nil {
-			panic(err)
+			//主链地址配置错误时不退出, 发送交易时返回错误
+			mlog.Error("setMainGrpcCli", "err", err)
+			mem.mainErr = err
+			return
+		}
+		mem.main = main
+	}
+}
+
+// checkMain 主链客户端创建失败时返回创建时的错误
+func (mem *Mempool) checkMain() error {
+	if mem.main != nil {
+		return nil
+	}
+	if mem.mainErr != nil {
+		return mem.mainErr
+	}
+	return types.ErrActionNotSupport
+}
+
+// sendTx 已经有排队的交易或者主链不可用时在本地排队并返回ErrTxQueued, 主链返回的错误直接返回
+func (mem *Mempool) sendTx(tx *types.Transaction) (*types.Reply, error) {
+	if err := mem.checkMain(); err != nil {
+		return nil, err
+	}
+	if mem.cache.exist(string(tx.Hash())) {
+		return nil, types.ErrTxExist
+	}
+	if mem.cache.queuedSize() == 0 {
+		reply, err := mem.main.sendTx(tx)
+		if err != ErrMainChainUnavailable {
+			if err == nil {
+				mem.cache.pushSent(tx)
+			}
+			return reply, err
+		}
+	}
+	if err := mem.cache.pushQueued(tx); err != nil {
+		return nil, err
+	}
+	mlog.Info("main chain unavailable, tx queued", "hash", common.ToHex(tx.Hash()), "queued", mem.cache.queuedSize())
+	return nil, ErrTxQueued
+}
+
+func (mem *Mempool) getProperFee() (*types.ReplyProperFee, error) {
+	if err := mem.checkMain(); err != nil {
+		return nil, err
+	}
+	return mem.main.getProperFee(&types.ReqProperFee{})
+}
+
+// resendLoop 按照排队的顺序发送交易, 主链不可用时发送间隔翻倍直到最大间隔
+func (mem *Mempool) resendLoop() {
+	defer mem.wg.Done()
+	interval := time.Duration(mem.subCfg.RetryInterval) * time.Millisecond
+	maxInterval := time.Duration(mem.subCfg.MaxRetryInterval) * time.Millisecond
+	wait := interval
+	for {
+		select {
+		case <-mem.done:
+			return
+		case <-time.After(wait):
+		}
+		mem.cache.expireSent(sentExpire)
+		if mem.resend() {
+			wait = interval
+			continue
+		}
+		wait *= 2
+		if wait > maxInterval {
+			wait = maxInterval
+		}
+		mlog.Info("main chain unavailable, resend later", "queued", mem.cache.queuedSize(), "wait", wait)
+	}
+}
+
+// resend 发送所有排队的交易, 主链不可用时返回false
+func (mem *Mempool) resend() bool {
+	if mem.main == nil {
+		return true
+	}
+	for {
+		if atomic.LoadInt32(&mem.isclose) == 1 {
+			return true
+		}
+		tx := mem.cache.top()
+		if tx == nil {
+			return true
+		}
+		_, err := mem.main.sendTx(tx)
+		if err == ErrMainChainUnavailable {
+			return false
+		}
+		if err == nil || status.Convert(err).Message() == types.ErrTxExist.Error() {
+			mem.cache.markSent(tx)
+			continue
 		}
-		mem.mainGrpcCli = grpcCli
+		mlog.Error("main chain reject queued tx", "hash", common.ToHex(tx.Hash()), "err", err)
+		mem.cache.drop(tx)
 	}
 }
 
@@ -79,6 +230,7 @@ func (mem *Mempool) Close() {
 	if !atomic.CompareAndSwapInt32(&mem.isclose, 0, 1) {
 		return
 	}
+	close(mem.done)
 	if mem.client != nil {
 		mem.client.Close()
 	}
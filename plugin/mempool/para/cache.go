@@ -0,0 +1,205 @@
+package para
+
+import (
+	"sync"
+
+	"github.com/33cn/chain33/common/listmap"
+	"github.com/33cn/chain33/types"
+)
+
+// 最近收到的交易数, 同mempool的maxTxLast
+const maxTxLast = 10
+
+type sentTx struct {
+	tx       *types.Transaction
+	sendTime int64
+}
+
+// txCache 本节点收到的交易, 包括等待发送到主链的交易和已经发送但是还没有打包到平行链区块的交易
+type txCache struct {
+	mu     sync.Mutex
+	size   int
+	queued *listmap.ListMap
+	sent   *listmap.ListMap
+	last   []*types.Transaction
+}
+
+func newTxCache(size int) *txCache {
+	return &txCache{
+		size:   size,
+		queued: listmap.New(),
+		sent:   listmap.New(),
+	}
+}
+
+func (cache *txCache) exist(hash string) bool {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.queued.Exist(hash) || cache.sent.Exist(hash)
+}
+
+// makeRoom 缓存满时删除最早发送的交易, 等待发送的交易不删除
+func (cache *txCache) makeRoom() error {
+	if cache.queued.Size()+cache.sent.Size() < cache.size {
+		return nil
+	}
+	if cache.sent.Size() == 0 {
+		return types.ErrMemFull
+	}
+	top := cache.sent.GetTop().(*sentTx)
+	cache.sent.Remove(string(top.tx.Hash()))
+	return nil
+}
+
+func (cache *txCache) pushLast(tx *types.Transaction) {
+	cache.last = append(cache.last, tx)
+	if len(cache.last) > maxTxLast {
+		cache.last = cache.last[1:]
+	}
+}
+
+// pushQueued 主链不可用时交易在本地排队
+func (cache *txCache) pushQueued(tx *types.Transaction) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	hash := string(tx.Hash())
+	if cache.queued.Exist(hash) || cache.sent.Exist(hash) {
+		return types.ErrTxExist
+	}
+	if err := cache.makeRoom(); err != nil {
+		return err
+	}
+	cache.queued.Push(hash, tx)
+	cache.pushLast(tx)
+	return nil
+}
+
+// pushSent 直接发送成功的交易
+func (cache *txCache) pushSent(tx *types.Transaction) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	hash := string(tx.Hash())
+	if cache.sent.Exist(hash) {
+		return
+	}
+	cache.makeRoom()
+	cache.sent.Push(hash, &sentTx{tx: tx, sendTime: types.Now().Unix()})
+	cache.pushLast(tx)
+}
+
+func (cache *txCache) queuedSize() int {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.queued.Size()
+}
+
+// top 最早排队的交易
+func (cache *txCache) top() *types.Transaction {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.queued.Size() == 0 {
+		return nil
+	}
+	return cache.queued.GetTop().(*types.Transaction)
+}
+
+// markSent 排队的交易发送成功
+func (cache *txCache) markSent(tx *types.Transaction) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	hash := string(tx.Hash())
+	if cache.queued.Remove(hash) == nil {
+		return
+	}
+	cache.sent.Push(hash, &sentTx{tx: tx, sendTime: types.Now().Unix()})
+}
+
+// drop 主链拒绝的排队交易
+func (cache *txCache) drop(tx *types.Transaction) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.queued.Remove(string(tx.Hash()))
+}
+
+// removeTxs 已经打包到平行链区块中的交易
+func (cache *txCache) removeTxs(txs []*types.Transaction) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for _, tx := range txs {
+		hash := string(tx.Hash())
+		cache.queued.Remove(hash)
+		cache.sent.Remove(hash)
+	}
+}
+
+// expireSent 发送之后超过expire秒还没有打包的交易不再保留
+func (cache *txCache) expireSent(expire int64) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	now := types.Now().Unix()
+	var hashes []string
+	cache.sent.Walk(func(value interface{}) bool {
+		sent := value.(*sentTx)
+		if now-sent.sendTime < expire {
+			return false
+		}
+		hashes = append(hashes, string(sent.tx.Hash()))
+		return true
+	})
+	for _, hash := range hashes {
+		cache.sent.Remove(hash)
+	}
+}
+
+// txs 等待发送和已经发送的交易
+func (cache *txCache) txs() []*types.Transaction {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	txs := make([]*types.Transaction, 0, cache.queued.Size()+cache.sent.Size())
+	cache.queued.Walk(func(value interface{}) bool {
+		txs = append(txs, value.(*types.Transaction))
+		return true
+	})
+	cache.sent.Walk(func(value interface{}) bool {
+		txs = append(txs, value.(*sentTx).tx)
+		return true
+	})
+	return txs
+}
+
+func (cache *txCache) count() int {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.queued.Size() + cache.sent.Size()
+}
+
+func (cache *txCache) lastTxs() []*types.Transaction {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return append([]*types.Transaction{}, cache.last...)
+}
+
+func (cache *txCache) accTxs(addrs *types.ReqAddrs) *types.TransactionDetails {
+	accs := make(map[string]bool)
+	for _, addr := range addrs.GetAddrs() {
+		accs[addr] = true
+	}
+	details := &types.TransactionDetails{}
+	for _, tx := range cache.txs() {
+		from := tx.From()
+		if !accs[from] {
+			continue
+		}
+		amount, err := tx.Amount()
+		if err != nil {
+			amount = 0
+		}
+		details.Txs = append(details.Txs, &types.TransactionDetail{
+			Tx:         tx,
+			Amount:     amount,
+			Fromaddr:   from,
+			ActionName: tx.ActionName(),
+		})
+	}
+	return details
+}
@@ -0,0 +1,237 @@
+package para
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeMain 模拟主链节点, down为true时连接失败
+type fakeMain struct {
+	types.Chain33Client
+	mu     sync.Mutex
+	down   bool
+	reject bool
+	txs    [][]byte
+}
+
+func (m *fakeMain) setDown(down bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.down = down
+}
+
+func (m *fakeMain) sent() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.txs)
+}
+
+func (m *fakeMain) SendTransaction(ctx context.Context, tx *types.Transaction, opts ...grpc.CallOption) (*types.Reply, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.down {
+		return nil, status.Error(codes.Unavailable, "connection refused")
+	}
+	if m.reject {
+		return nil, status.Error(codes.Unknown, types.ErrNoBalance.Error())
+	}
+	m.txs = append(m.txs, tx.Hash())
+	return &types.Reply{IsOk: true, Msg: tx.Hash()}, nil
+}
+
+func (m *fakeMain) GetProperFee(ctx context.Context, req *types.ReqProperFee, opts ...grpc.CallOption) (*types.ReplyProperFee, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.down {
+		return nil, status.Error(codes.Unavailable, "connection refused")
+	}
+	return &types.ReplyProperFee{ProperFee: 100000}, nil
+}
+
+func newTestMains(n int) ([]*fakeMain, *mainClients) {
+	var fakes []*fakeMain
+	m := &mainClients{timeout: time.Second}
+	for i := 0; i < n; i++ {
+		fake := &fakeMain{}
+		fakes = append(fakes, fake)
+		m.addrs = append(m.addrs, "fake")
+		m.clients = append(m.clients, fake)
+	}
+	return fakes, m
+}
+
+func newTestTx(t *testing.T, nonce int64) *types.Transaction {
+	c, err := crypto.New(types.GetSignName("", types.SECP256K1))
+	require.Nil(t, err)
+	priv, err := c.GenKey()
+	require.Nil(t, err)
+	tx := &types.Transaction{Execer: []byte("user.p.test.none"), Payload: []byte("none"), Fee: 100000, Nonce: nonce}
+	tx.Sign(types.SECP256K1, priv)
+	return tx
+}
+
+func newTestMempool(subcfg subConfig, main *mainClients) (*Mempool, queue.Client) {
+	q := queue.New("channel")
+	q.SetConfig(types.NewChain33Config(types.GetDefaultCfgstring()))
+	mem := newMempool(nil, subcfg)
+	mem.main = main
+	mem.SetQueueClient(q.Client())
+	return mem, q.Client()
+}
+
+func sendMsg(t *testing.T, client queue.Client, ty int64, data interface{}) (interface{}, error) {
+	msg := client.NewMessage("mempool", ty, data)
+	require.Nil(t, client.Send(msg, true))
+	resp, err := client.Wait(msg)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetData(), nil
+}
+
+func TestMainFailover(t *testing.T) {
+	fakes, main := newTestMains(3)
+	fakes[0].setDown(true)
+	fakes[1].setDown(true)
+	tx := newTestTx(t, 1)
+	_, err := main.sendTx(tx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, main.current)
+	assert.Equal(t, 1, fakes[2].sent())
+
+	//当前节点不可用时从下一个节点开始
+	fakes[0].setDown(false)
+	fakes[2].setDown(true)
+	_, err = main.getProperFee(&types.ReqProperFee{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, main.current)
+
+	fakes[0].setDown(true)
+	_, err = main.sendTx(tx)
+	assert.Equal(t, ErrMainChainUnavailable, err)
+	assert.Equal(t, 0, main.current)
+}
+
+func TestQueueWhenMainDown(t *testing.T) {
+	fakes, main := newTestMains(2)
+	mem, client := newTestMempool(subConfig{RetryInterval: 10, MaxRetryInterval: 20}, main)
+	defer mem.Close()
+
+	tx1, tx2, tx3 := newTestTx(t, 1), newTestTx(t, 2), newTestTx(t, 3)
+	reply, err := sendMsg(t, client, types.EventTx, tx1)
+	assert.Nil(t, err)
+	assert.True(t, reply.(*types.Reply).IsOk)
+	_, err = sendMsg(t, client, types.EventTx, tx1)
+	assert.Equal(t, types.ErrTxExist, err)
+
+	//主链不可用时交易在本地排队, 之后的交易也排队保证顺序
+	fakes[0].setDown(true)
+	fakes[1].setDown(true)
+	_, err = sendMsg(t, client, types.EventTx, tx2)
+	assert.Equal(t, ErrTxQueued, err)
+	fakes[1].setDown(false)
+	_, err = sendMsg(t, client, types.EventTx, tx3)
+	assert.Equal(t, ErrTxQueued, err)
+
+	reply, err = sendMsg(t, client, types.EventGetMempoolSize, &types.ReqNil{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), reply.(*types.MempoolSize).Size)
+	reply, err = sendMsg(t, client, types.EventGetLastMempool, &types.ReqNil{})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(reply.(*types.ReplyTxList).Txs))
+	reply, err = sendMsg(t, client, types.EventGetAddrTxs, &types.ReqAddrs{Addrs: []string{tx2.From()}})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(reply.(*types.TransactionDetails).Txs))
+
+	//主链恢复之后按顺序发送
+	for i := 0; i < 100 && mem.cache.queuedSize() > 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, 0, mem.cache.queuedSize())
+	fakes[1].mu.Lock()
+	assert.Equal(t, [][]byte{tx2.Hash(), tx3.Hash()}, fakes[1].txs)
+	fakes[1].mu.Unlock()
+
+	//打包到区块中的交易删除
+	client.Send(client.NewMessage("mempool", types.EventAddBlock, &types.BlockDetail{Block: &types.Block{Txs: []*types.Transaction{tx1, tx2}}}), false)
+	reply, err = sendMsg(t, client, types.EventGetMempool, &types.ReqGetMempool{})
+	assert.Nil(t, err)
+	txs := reply.(*types.ReplyTxList).Txs
+	require.Equal(t, 1, len(txs))
+	assert.Equal(t, tx3.Hash(), txs[0].Hash())
+
+	reply, err = sendMsg(t, client, types.EventGetProperFee, &types.ReqProperFee{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(100000), reply.(*types.ReplyProperFee).ProperFee)
+	_, err = sendMsg(t, client, types.EventGetMempoolSize+1000, &types.ReqNil{})
+	assert.Equal(t, types.ErrActionNotSupport, err)
+}
+
+func TestResendReject(t *testing.T) {
+	fakes, main := newTestMains(1)
+	mem := newMempool(nil, subConfig{})
+	mem.main = main
+	tx1, tx2 := newTestTx(t, 1), newTestTx(t, 2)
+	fakes[0].setDown(true)
+	_, err := mem.sendTx(tx1)
+	assert.Equal(t, ErrTxQueued, err)
+	_, err = mem.sendTx(tx2)
+	assert.Equal(t, ErrTxQueued, err)
+	assert.False(t, mem.resend())
+	assert.Equal(t, 2, mem.cache.queuedSize())
+
+	//主链拒绝的交易不再保留
+	fakes[0].setDown(false)
+	fakes[0].reject = true
+	assert.True(t, mem.resend())
+	assert.Equal(t, 0, mem.cache.count())
+
+	_, err = mem.sendTx(tx1)
+	assert.Equal(t, types.ErrNoBalance.Error(), status.Convert(err).Message())
+	assert.Equal(t, 0, mem.cache.count())
+}
+
+func TestMainConfigError(t *testing.T) {
+	mem := newMempool(nil, subConfig{})
+	tx := newTestTx(t, 1)
+	_, err := mem.sendTx(tx)
+	assert.Equal(t, types.ErrActionNotSupport, err)
+
+	//主链客户端创建失败时返回创建时的错误, 不再panic
+	mem.mainErr = ErrMainChainUnavailable
+	_, err = mem.sendTx(tx)
+	assert.Equal(t, ErrMainChainUnavailable, err)
+	_, err = mem.getProperFee()
+	assert.Equal(t, ErrMainChainUnavailable, err)
+	assert.Equal(t, []string{"a:1", "b:2"}, trimAddrs([]string{" a:1", "", "b:2 "}))
+}
+
+func TestCacheFull(t *testing.T) {
+	cache := newTxCache(2)
+	tx1, tx2, tx3 := newTestTx(t, 1), newTestTx(t, 2), newTestTx(t, 3)
+	cache.pushSent(tx1)
+	assert.Nil(t, cache.pushQueued(tx2))
+	//缓存满时删除最早发送的交易
+	assert.Nil(t, cache.pushQueued(tx3))
+	assert.False(t, cache.exist(string(tx1.Hash())))
+	//只剩等待发送的交易时不再接收
+	assert.Equal(t, types.ErrMemFull, cache.pushQueued(tx1))
+	assert.Equal(t, types.ErrTxExist, cache.pushQueued(tx2))
+
+	cache.markSent(tx2)
+	assert.Equal(t, 1, cache.queuedSize())
+	assert.Equal(t, tx3, cache.top())
+	cache.expireSent(0)
+	assert.Equal(t, 1, cache.count())
+}
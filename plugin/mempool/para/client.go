@@ -0,0 +1,113 @@
+package para
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/rpc/grpcclient"
+	"github.com/33cn/chain33/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrMainChainUnavailable 所有的主链节点都不可用
+	ErrMainChainUnavailable = errors.New("ErrMainChainUnavailable")
+	// ErrTxQueued 交易没有发送到主链, 只在本地排队, 主链恢复之后按顺序发送
+	ErrTxQueued = errors.New("ErrTxQueued")
+)
+
+// 平行链共识没有配置主链地址时的默认地址, 同grpcclient
+const defaultMainChainGrpcAddr = "127.0.0.1:8802"
+
+// mainClients 多个主链节点, 当前节点不可用时依次切换到下一个节点
+type mainClients struct {
+	mu      sync.Mutex
+	addrs   []string
+	clients []types.Chain33Client
+	current int
+	timeout time.Duration
+}
+
+func newMainClients(cfg *types.Chain33Config, addrs []string, timeout time.Duration) (*mainClients, error) {
+	addrs = trimAddrs(addrs)
+	if len(addrs) == 0 {
+		remote := types.Conf(cfg, "config.consensus.sub.para").GStr("ParaRemoteGrpcClient")
+		addrs = trimAddrs(strings.Split(remote, ","))
+	}
+	if len(addrs) == 0 {
+		addrs = []string{defaultMainChainGrpcAddr}
+	}
+	m := &mainClients{addrs: addrs, timeout: timeout}
+	for _, addr := range addrs {
+		cli, err := grpcclient.NewMainChainClient(cfg, addr)
+		if err != nil {
+			return nil, err
+		}
+		m.clients = append(m.clients, cli)
+	}
+	return m, nil
+}
+
+func trimAddrs(list []string) (addrs []string) {
+	for _, addr := range list {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// isUnavailable 连接失败或者超时, 其余的错误是主链返回的错误
+func isUnavailable(err error) bool {
+	code := status.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
+
+// call 从当前节点开始调用, 节点不可用时切换到下一个, 所有节点都不可用时返回ErrMainChainUnavailable
+func (m *mainClients) call(f func(ctx context.Context, cli types.Chain33Client) error) error {
+	m.mu.Lock()
+	start := m.current
+	m.mu.Unlock()
+	for i := 0; i < len(m.clients); i++ {
+		index := (start + i) % len(m.clients)
+		ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+		err := f(ctx, m.clients[index])
+		cancel()
+		if isUnavailable(err) {
+			mlog.Error("main chain node unavailable", "addr", m.addrs[index], "err", err)
+			continue
+		}
+		if index != start {
+			mlog.Info("switch main chain node", "addr", m.addrs[index])
+			m.mu.Lock()
+			m.current = index
+			m.mu.Unlock()
+		}
+		return err
+	}
+	return ErrMainChainUnavailable
+}
+
+func (m *mainClients) sendTx(tx *types.Transaction) (*types.Reply, error) {
+	var reply *types.Reply
+	err := m.call(func(ctx context.Context, cli types.Chain33Client) error {
+		var err error
+		reply, err = cli.SendTransaction(ctx, tx)
+		return err
+	})
+	return reply, err
+}
+
+func (m *mainClients) getProperFee(req *types.ReqProperFee) (*types.ReplyProperFee, error) {
+	var reply *types.ReplyProperFee
+	err := m.call(func(ctx context.Context, cli types.Chain33Client) error {
+		var err error
+		reply, err = cli.GetProperFee(ctx, req)
+		return err
+	})
+	return reply, err
+}
@@ -9,11 +9,27 @@ import (
 //--------------------------------------------------------------------------------
 // Module Mempool
 
+type subConfig struct {
+	// 主链grpc地址, 当前节点不可用时依次切换, 为空时使用平行链共识的ParaRemoteGrpcClient
+	MainChainGrpcAddrs []string `json:"mainChainGrpcAddrs"`
+	// 本地最多保存的交易数, 包括排队和已经发送还没有打包的交易
+	PoolCacheSize int64 `json:"poolCacheSize"`
+	// 调用主链的超时时间(毫秒)
+	Timeout int64 `json:"timeout"`
+	// 主链不可用时重新发送的初始间隔(毫秒), 每次失败翻倍直到最大间隔
+	RetryInterval    int64 `json:"retryInterval"`
+	MaxRetryInterval int64 `json:"maxRetryInterval"`
+}
+
 func init() {
 	drivers.Reg("para", New)
 }
 
-//New 创建price cache 结构的 mempool
+//New 创建para mempool, 交易转发到主链
 func New(cfg *types.Mempool, sub []byte) queue.Module {
-	return NewMempool(cfg)
+	var subcfg subConfig
+	if sub != nil {
+		types.MustDecode(sub, &subcfg)
+	}
+	return newMempool(cfg, subcfg)
 }
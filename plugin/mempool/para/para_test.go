@@ -9,6 +9,7 @@ import (
 	paratest "github.com/33cn/plugin/plugin/dapp/paracross/testnode"
 	"github.com/33cn/plugin/plugin/mempool/para"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	_ "github.com/33cn/chain33/system"
 	_ "github.com/33cn/plugin/plugin"
@@ -44,7 +45,9 @@ func TestParaNodeMempool(t *testing.T) {
 	hash := mockpara.Para.SendTx(tx)
 	assert.Equal(t, tx.Hash(), hash)
 
-	_, err := mockpara.Para.GetAPI().GetMempool(&types.ReqGetMempool{})
-	assert.Equal(t, err, types.ErrActionNotSupport)
-	t.Log(err)
+	//发送到主链的交易在打包到平行链区块之前保留在本地
+	reply, err := mockpara.Para.GetAPI().GetMempool(&types.ReqGetMempool{})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(reply.GetTxs()))
+	assert.Equal(t, tx.Hash(), reply.GetTxs()[0].Hash())
 }
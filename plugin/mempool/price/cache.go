@@ -3,6 +3,7 @@ package price
 import (
 	"github.com/33cn/chain33/common/skiplist"
 	"github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/plugin/plugin/mempool/estimate"
 	"github.com/golang/protobuf/proto"
 )
 
@@ -10,6 +11,8 @@ import (
 type Queue struct {
 	*skiplist.Queue
 	subConfig subConfig
+	//根据最近区块和排队交易估计手续费, 为空时按原来的方式计算
+	est *estimate.Estimator
 }
 
 type priceScore struct {
@@ -72,6 +75,11 @@ func (cache *Queue) Walk(count int, cb func(tx *mempool.Item) bool) {
 
 // GetProperFee 获取合适的手续费率,取前100的平均手续费率
 func (cache *Queue) GetProperFee() int64 {
+	if cache.est != nil {
+		if fee := cache.est.ProperFee(); fee > 0 {
+			return fee
+		}
+	}
 	var sumFeeRate int64
 	var properFeeRate int64
 	if cache.Size() < 100 {
@@ -1,8 +1,13 @@
 package price
 
 import (
+	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/33cn/chain33/common"
 	"github.com/33cn/chain33/common/address"
@@ -12,6 +17,10 @@ import (
 	"github.com/33cn/chain33/types"
 	"github.com/33cn/chain33/util"
 	"github.com/33cn/chain33/util/testnode"
+	"github.com/33cn/plugin/plugin/mempool/estimate"
+	ety "github.com/33cn/plugin/plugin/mempool/estimate/types"
+	"github.com/33cn/plugin/plugin/mempool/journal"
+	jty "github.com/33cn/plugin/plugin/mempool/journal/types"
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 
@@ -180,6 +189,13 @@ func TestGetProperFee(t *testing.T) {
 	feeRate2 := item4.Value.Fee / int64(proto.Size(item4.Value)/1000+1)
 	t.Log(feeRate1, feeRate2)
 	assert.Equal(t, (feeRate1*98+feeRate2+1200000)/100, properFee)
+
+	//记录区块之后按照3个区块内打包需要的费率, 满区块打包的最低费率是下限
+	cache.est = estimate.NewEstimator(&types.Mempool{MinTxFeeRate: 100000}, cache)
+	assert.Equal(t, properFee, cache.GetProperFee())
+	cache.est.AddBlock(1, []*types.Transaction{tx4, tx4}, 2)
+	assert.Equal(t, cache.est.Estimate(2).Normal, cache.GetProperFee())
+	assert.True(t, cache.GetProperFee() >= estimate.FeeRate(tx4))
 }
 
 func TestRealNodeMempool(t *testing.T) {
@@ -252,3 +268,69 @@ func TestRealNodeMempool(t *testing.T) {
 	assert.Equal(t, len(peer.Peers), 0)
 	//assert.Equal(t, peer.Peers[0].MempoolSize, int32(0))
 }
+
+func TestFeeEstimate(t *testing.T) {
+	mock33 := testnode.New("chain33.test.toml", nil)
+	defer mock33.Close()
+	mock33.Listen()
+	mock33.WaitHeight(0)
+	mock33.SendHot()
+	mock33.WaitHeight(1)
+	client := mock33.GetClient()
+	msg := client.NewMessage("mempool", ety.EventGetFeeEstimate, &types.ReqNil{})
+	assert.Nil(t, client.Send(msg, true))
+	resp, err := client.Wait(msg)
+	assert.Nil(t, err)
+	reply := resp.GetData().(*ety.ReplyFeeEstimate)
+	minFee := client.GetConfig().GetMinTxFeeRate()
+	assert.Equal(t, int64(1), reply.Height)
+	assert.Equal(t, minFee, reply.Fast)
+	assert.Equal(t, minFee, reply.Normal)
+	assert.Equal(t, minFee, reply.Slow)
+	//mempool的合适手续费使用同一个估计
+	fee, err := mock33.GetAPI().GetProperFee(&types.ReqProperFee{})
+	assert.Nil(t, err)
+	assert.Equal(t, minFee, fee.ProperFee)
+}
+
+func TestJournalReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mempool.journal")
+	str := strings.Replace(types.ReadFile("chain33.test.toml"), "[mempool.sub.price]\n",
+		"[mempool.sub.price]\njournalFile=\""+path+"\"\n", 1)
+	cfg := types.NewChain33Config(str)
+
+	//重启前排队的交易, 手续费过低的交易重新检查失败, 创世地址的私钥同testnode
+	qcache, err := journal.NewQueue(path, NewQueue(subConfig{PoolCacheSize: 10}))
+	assert.Nil(t, err)
+	addr, _ := util.Genaddress()
+	priv := util.TestPrivkeyList[1]
+	good := util.CreateCoinsTx(cfg, priv, addr, types.Coin)
+	bad := util.CreateCoinsTx(cfg, priv, addr, 2*types.Coin)
+	bad.Fee = 1
+	bad.Sign(types.SECP256K1, priv)
+	assert.Nil(t, qcache.Push(&drivers.Item{Value: good, Priority: good.Fee, EnterTime: types.Now().Unix()}))
+	assert.Nil(t, qcache.Push(&drivers.Item{Value: bad, Priority: bad.Fee, EnterTime: types.Now().Unix()}))
+	qcache.Close()
+
+	//重新启动后交易重新加入mempool并被打包
+	mock33 := testnode.NewWithConfig(cfg, nil)
+	defer mock33.Close()
+	for i := 0; i < 100; i++ {
+		if _, err = mock33.GetAPI().QueryTx(&types.ReqHash{Hash: good.Hash()}); err == nil {
+			break
+		}
+		time.Sleep(time.Second / 10)
+	}
+	assert.Nil(t, err)
+	client := mock33.GetClient()
+	msg := client.NewMessage("mempool", jty.EventGetMempoolStats, &types.ReqNil{})
+	assert.Nil(t, client.Send(msg, true))
+	resp, err := client.Wait(msg)
+	assert.Nil(t, err)
+	stats := resp.GetData().(*jty.MempoolStats)
+	assert.Equal(t, int64(1), stats.Replayed)
+	assert.Equal(t, int64(1), stats.ReplayFailed)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/33cn/chain33/common/skiplist"
 	"github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/plugin/plugin/mempool/estimate"
 	"github.com/golang/protobuf/proto"
 )
 
@@ -12,6 +13,8 @@ import (
 type Queue struct {
 	*skiplist.Queue
 	subConfig subConfig
+	//根据最近区块和排队交易估计手续费, 为空时按原来的方式计算
+	est *estimate.Estimator
 }
 
 type scoreScore struct {
@@ -83,6 +86,11 @@ func (cache *Queue) Walk(count int, cb func(value *mempool.Item) bool) {
 
 // GetProperFee 获取合适的手续费
 func (cache *Queue) GetProperFee() int64 {
+	if cache.est != nil {
+		if fee := cache.est.ProperFee(); fee > 0 {
+			return fee
+		}
+	}
 	var sumScore int64
 	var properFeerate int64
 	if cache.Size() == 0 {
@@ -13,6 +13,7 @@ import (
 	"github.com/33cn/chain33/types"
 	"github.com/33cn/chain33/util"
 	"github.com/33cn/chain33/util/testnode"
+	"github.com/33cn/plugin/plugin/mempool/estimate"
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 
@@ -243,4 +244,10 @@ func TestGetProperFee(t *testing.T) {
 	properFee := ((score3+score4)/2 + time.Now().Unix()*cache.subConfig.TimeParam) * int64(100) /
 		(cache.subConfig.PriceConstant * cache.subConfig.PricePower)
 	assert.Equal(t, int64(1), properFee/cache.GetProperFee())
+
+	//记录区块之后按照3个区块内打包需要的费率, 满区块打包的最低费率是下限
+	cache.est = estimate.NewEstimator(&types.Mempool{MinTxFeeRate: 100000}, cache)
+	cache.est.AddBlock(1, []*types.Transaction{tx4, tx4}, 2)
+	assert.Equal(t, cache.est.Estimate(2).Normal, cache.GetProperFee())
+	assert.True(t, cache.GetProperFee() >= estimate.FeeRate(tx4))
 }
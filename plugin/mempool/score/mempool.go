@@ -4,6 +4,8 @@ import (
 	"github.com/33cn/chain33/queue"
 	drivers "github.com/33cn/chain33/system/mempool"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/mempool/estimate"
+	"github.com/33cn/plugin/plugin/mempool/journal"
 )
 
 //--------------------------------------------------------------------------------
@@ -15,6 +17,8 @@ type subConfig struct {
 	PriceConstant int64 `json:"priceConstant"`
 	PricePower    int64 `json:"pricePower"`
 	ProperFee     int64 `json:"properFee"`
+	//交易日志和过期清理
+	journal.Config
 }
 
 func init() {
@@ -23,7 +27,6 @@ func init() {
 
 //New 创建score cache 结构的 mempool
 func New(cfg *types.Mempool, sub []byte) queue.Module {
-	c := drivers.NewMempool(cfg)
 	var subcfg subConfig
 	types.MustDecode(sub, &subcfg)
 	if subcfg.PoolCacheSize == 0 {
@@ -32,6 +35,11 @@ func New(cfg *types.Mempool, sub []byte) queue.Module {
 	if subcfg.ProperFee == 0 {
 		subcfg.ProperFee = cfg.MinTxFeeRate
 	}
-	c.SetQueueCache(NewQueue(subcfg))
-	return c
+	qcache := NewQueue(subcfg)
+	qcache.est = estimate.NewEstimator(cfg, qcache)
+	mem, err := journal.NewMempool(cfg, subcfg.Config, qcache, qcache.est)
+	if err != nil {
+		panic(err)
+	}
+	return mem
 }
@@ -270,6 +270,30 @@ func (a *AddrBook) loadDb() bool {
 
 }
 
+// saveBanList 保存禁止连接的节点和截止时间
+func (a *AddrBook) saveBanList(bans map[string]int64) {
+	jsonBytes, err := json.Marshal(bans)
+	if err != nil {
+		log.Error("saveBanList", "err", err)
+		return
+	}
+	if err = a.bookDb.Set([]byte(banListTag), jsonBytes); err != nil {
+		log.Error("saveBanList", "err", err)
+	}
+}
+
+func (a *AddrBook) loadBanList() map[string]int64 {
+	bans := make(map[string]int64)
+	value, err := a.bookDb.Get([]byte(banListTag))
+	if err != nil || len(value) == 0 {
+		return bans
+	}
+	if err = json.Unmarshal(value, &bans); err != nil {
+		log.Error("loadBanList", "err", err)
+	}
+	return bans
+}
+
 // Save saves the book.
 func (a *AddrBook) Save() {
 	a.saveToDb()
@@ -58,6 +58,13 @@ const (
 const (
 	addrkeyTag = "addrs"
 	privKeyTag = "privkey"
+	banListTag = "banlist"
+)
+
+// 节点评分, 分数降到-DefaultBanThreshold时禁止连接DefaultBanTime秒
+const (
+	DefaultBanThreshold = 100
+	DefaultBanTime      = 3600
 )
 
 //TTL
@@ -244,10 +244,12 @@ func (d *DownloadJob) syncDownloadBlock(peer *Peer, inv *pb.Inventory, bchan cha
 	//主动取消grpc流, 即时释放资源
 	defer cancel()
 	beg := pb.Now()
+	node := d.p2pcli.network.node
 	resp, err := peer.mconn.gcli.GetData(ctx, &p2pdata, grpc.FailFast(true))
 	P2pComm.CollectPeerStat(err, peer)
 	if err != nil {
 		log.Error("syncDownloadBlock", "GetData err", err.Error())
+		node.scoreGetDataErr(peer.Addr(), err)
 		return err
 	}
 	defer func() {
@@ -257,14 +259,21 @@ func (d *DownloadJob) syncDownloadBlock(peer *Peer, inv *pb.Inventory, bchan cha
 	invData, err := resp.Recv()
 	if err != nil && err != io.EOF {
 		log.Error("syncDownloadBlock", "RecvData err", err.Error())
+		node.scoreGetDataErr(peer.Addr(), err)
 		return err
 	}
 	//返回单个数据条目
 	if invData == nil || len(invData.Items) != 1 {
+		node.scorePeer(peer.Addr(), scoreGetDataFailed, "invalid GetData reply")
 		return fmt.Errorf("InvalidRecvData")
 	}
 
 	block := invData.Items[0].GetBlock()
+	if block == nil || block.GetHeight() != inv.GetHeight() {
+		node.scorePeer(peer.Addr(), scoreInvalidBlock, "invalid block")
+		return fmt.Errorf("InvalidRecvBlock")
+	}
+	node.scorePeer(peer.Addr(), scoreBlockDelivered, "block delivered")
 	log.Debug("download", "frompeer", peer.Addr(), "blockheight", inv.GetHeight(), "blockSize", block.Size())
 	bchan <- &pb.BlockPid{Pid: peer.GetPeerName(), Block: block} //加入到输出通道
 	return nil
@@ -5,10 +5,6 @@
 package gossip
 
 import (
-	"bytes"
-	"io"
-	"net/http"
-	"strings"
 	"time"
 
 	"github.com/33cn/chain33/p2p/utils"
@@ -62,41 +58,6 @@ func (n *Node) monitorErrPeer() {
 	}
 }
 
-func (n *Node) getAddrFromGithub() {
-	if !n.nodeInfo.cfg.UseGithub {
-		return
-	}
-	//从github 上下载种子节点文件
-	res, err := http.Get("https://raw.githubusercontent.com/chainseed/seeds/master/bty.txt")
-	if err != nil {
-		log.Error("getAddrFromGithub", "http.Get", err.Error())
-		return
-	}
-
-	bf := new(bytes.Buffer)
-	_, err = io.Copy(bf, res.Body)
-	if err != nil {
-		log.Error("getAddrFromGithub", "io.Copy", err.Error())
-		return
-	}
-
-	fileContent := bf.String()
-	st := strings.TrimSpace(fileContent)
-	strs := strings.Split(st, "\n")
-	log.Info("getAddrFromGithub", "download file", fileContent)
-	for _, linestr := range strs {
-		pidaddr := strings.Split(linestr, "@")
-		if len(pidaddr) == 2 {
-			addr := pidaddr[1]
-			if n.Has(addr) || n.nodeInfo.blacklist.Has(addr) {
-				return
-			}
-			n.pubsub.FIFOPub(addr, "addr")
-
-		}
-	}
-}
-
 // getAddrFromOnline gets the address list from the online node
 func (n *Node) getAddrFromOnline() {
 	ticker := time.NewTicker(GetAddrFromOnlineInterval)
@@ -238,9 +199,9 @@ func (n *Node) getAddrFromAddrBook() {
 			log.Debug("GetAddrFromOnLine", "loop", "done")
 			return
 		}
-		//12个循环后， 则去github下载
+		//12个循环后， 则从种子来源获取
 		if tickerTimes > 12 && n.Size() == 0 {
-			n.getAddrFromGithub()
+			n.getAddrFromSeedSources()
 			tickerTimes = 0
 		}
 
@@ -555,6 +516,7 @@ func (n *Node) monitorBlackList() {
 		}
 
 		<-ticker.C
+		n.nodeInfo.scores.decay()
 		badPeers := n.nodeInfo.blacklist.GetBadPeers()
 		now := types.Now().Unix()
 		for badPeer, intime := range badPeers {
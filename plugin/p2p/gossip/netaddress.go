@@ -14,6 +14,7 @@ import (
 	pb "github.com/33cn/chain33/types"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 )
 
@@ -140,8 +141,8 @@ func isCompressSupport(err error) bool {
 	return true
 }
 
-// DialTimeout dial timeout
-func (na *NetAddress) DialTimeout(version int32) (*grpc.ClientConn, error) {
+// DialTimeout dial timeout, creds为nil时不加密
+func (na *NetAddress) DialTimeout(version int32, creds credentials.TransportCredentials) (*grpc.ClientConn, error) {
 	ch := make(chan grpc.ServiceConfig, 1)
 	ch <- P2pComm.GrpcConfig()
 
@@ -151,8 +152,12 @@ func (na *NetAddress) DialTimeout(version int32) (*grpc.ClientConn, error) {
 	cliparm.PermitWithoutStream = true //启动keepalive 进行检查
 	keepaliveOp := grpc.WithKeepaliveParams(cliparm)
 	timeoutOp := grpc.WithTimeout(time.Second * 3)
+	secureOp := grpc.WithInsecure()
+	if creds != nil {
+		secureOp = grpc.WithTransportCredentials(creds)
+	}
 	log.Debug("NetAddress", "Dial", na.String())
-	conn, err := grpc.Dial(na.String(), grpc.WithInsecure(),
+	conn, err := grpc.Dial(na.String(), secureOp,
 		grpc.WithDefaultCallOptions(grpc.UseCompressor("gzip")), grpc.WithServiceConfig(ch), keepaliveOp, timeoutOp)
 	if err != nil {
 		log.Debug("grpc DialCon", "did not connect", err, "addr", na.String())
@@ -173,7 +178,7 @@ func (na *NetAddress) DialTimeout(version int32) (*grpc.ClientConn, error) {
 		ch2 := make(chan grpc.ServiceConfig, 1)
 		ch2 <- P2pComm.GrpcConfig()
 		log.Debug("NetAddress", "Dial with unCompressor", na.String())
-		conn, err = grpc.Dial(na.String(), grpc.WithInsecure(), grpc.WithServiceConfig(ch2), keepaliveOp, timeoutOp)
+		conn, err = grpc.Dial(na.String(), secureOp, grpc.WithServiceConfig(ch2), keepaliveOp, timeoutOp)
 
 	}
 
@@ -82,6 +82,9 @@ type Node struct {
 	pubsub     *pubsub.PubSub
 	chainCfg   *types.Chain33Config
 	p2pMgr     *p2p.Manager
+	//种子节点来源, dns, http或者本地文件
+	seedSources []seedSource
+	txSignChan  chan *peerTx
 }
 
 // SetQueueClient return client for nodeinfo
@@ -98,6 +101,7 @@ func NewNode(mgr *p2p.Manager, mcfg *subConfig) (*Node, error) {
 		cacheBound: make(map[string]*Peer),
 		pubsub:     pubsub.NewPubSub(10200),
 		p2pMgr:     mgr,
+		txSignChan: make(chan *peerTx, txSignQueueSize),
 	}
 	node.listenPort = 13802
 	if mcfg.Port != 0 && mcfg.Port <= 65535 && mcfg.Port > 1024 {
@@ -119,7 +123,11 @@ func NewNode(mgr *p2p.Manager, mcfg *subConfig) (*Node, error) {
 	for _, seed := range mcfg.Seeds {
 		node.cfgSeeds.Store(seed, "cfg")
 	}
+	if len(mcfg.AllowPeers) > 0 && !mcfg.EnableTLS {
+		return nil, fmt.Errorf("allowPeers need enableTLS")
+	}
 	node.nodeInfo = NewNodeInfo(cfg.GetModuleConfig().P2P, mcfg)
+	node.initSeedSources()
 	if mcfg.ServerStart {
 		node.server = newListener(protocol, node)
 	}
@@ -157,7 +165,7 @@ func (n *Node) doNat() {
 	}
 	testExaddr := fmt.Sprintf("%v:%v", n.nodeInfo.GetExternalAddr().IP.String(), n.listenPort)
 	log.Info("TestNetAddr", "testExaddr", testExaddr)
-	if len(P2pComm.AddrRouteble([]string{testExaddr}, n.nodeInfo.channelVersion)) != 0 {
+	if len(P2pComm.AddrRouteble([]string{testExaddr}, n.nodeInfo.channelVersion, n.nodeInfo.dialCreds())) != 0 {
 		log.Info("node outside")
 		n.nodeInfo.SetNetSide(true)
 		if netexaddr, err := NewNetAddressString(testExaddr); err == nil {
@@ -353,6 +361,9 @@ func (n *Node) monitor() {
 	go n.monitorFilter()
 	go n.monitorPeers()
 	go n.nodeReBalance()
+	for i := 0; i < txSignWorkers; i++ {
+		go n.monitorTxSign()
+	}
 }
 
 func (n *Node) needMore() bool {
@@ -433,7 +444,7 @@ func (n *Node) natMapPort() {
 		time.Sleep(time.Second)
 	}
 	var err error
-	if len(P2pComm.AddrRouteble([]string{n.nodeInfo.GetExternalAddr().String()}, n.nodeInfo.channelVersion)) != 0 { //判断能否连通要映射的端口
+	if len(P2pComm.AddrRouteble([]string{n.nodeInfo.GetExternalAddr().String()}, n.nodeInfo.channelVersion, n.nodeInfo.dialCreds())) != 0 { //判断能否连通要映射的端口
 		log.Info("natMapPort", "addr", "routeble")
 		p2pcli := NewNormalP2PCli() //检查要映射的IP地址是否已经被映射成功
 		ok := p2pcli.CheckSelf(n.nodeInfo.GetExternalAddr().String(), n.nodeInfo)
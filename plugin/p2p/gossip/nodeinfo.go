@@ -26,6 +26,8 @@ type NodeInfo struct {
 	cfg            *subConfig
 	client         queue.Client
 	blacklist      *BlackList
+	scores         *peerScores
+	tls            *nodeTLS
 	peerInfos      *PeerInfos
 	addrBook       *AddrBook // known peers
 	natDone        int32
@@ -48,6 +50,11 @@ func NewNodeInfo(p2pCfg *types.P2P, subCfg *subConfig) *NodeInfo {
 	nodeInfo.externalAddr = new(NetAddress)
 	nodeInfo.listenAddr = new(NetAddress)
 	nodeInfo.addrBook = NewAddrBook(p2pCfg, subCfg)
+	nodeInfo.scores = newPeerScores(subCfg.BanThreshold, subCfg.BanTime)
+	nodeInfo.loadBans()
+	if subCfg.EnableTLS {
+		nodeInfo.tls = newNodeTLS(nodeInfo.addrBook.GetPrivPubKey, subCfg.AllowPeers)
+	}
 	nodeInfo.channelVersion = utils.CalcChannelVersion(subCfg.Channel, VERSION)
 	return nodeInfo
 }
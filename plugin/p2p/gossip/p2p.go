@@ -45,7 +45,7 @@ type subConfig struct {
 	FixedSeed bool `protobuf:"varint,4,opt,name=fixedSeed" json:"fixedSeed,omitempty"`
 	// 是否使用内置的种子节点
 	InnerSeedEnable bool `protobuf:"varint,5,opt,name=innerSeedEnable" json:"innerSeedEnable,omitempty"`
-	// 是否使用Github获取种子节点
+	// 已废弃, 使用SeedSources配置种子来源
 	UseGithub bool `protobuf:"varint,6,opt,name=useGithub" json:"useGithub,omitempty"`
 	// 是否作为服务端，对外提供服务
 	ServerStart bool `protobuf:"varint,7,opt,name=serverStart" json:"serverStart,omitempty"`
@@ -59,6 +59,18 @@ type subConfig struct {
 	Channel int32 `protobuf:"varint,11,opt,name=channel" json:"channel,omitempty"`
 	//区块轻广播的最低打包交易数, 大于该值时区块内交易采用短哈希广播
 	MinLtBlockTxNum int32 `protobuf:"varint,12,opt,name=minLtBlockTxNum" json:"minLtBlockTxNum,omitempty"`
+	//节点评分降到负的该值时禁止连接
+	BanThreshold int32 `protobuf:"varint,13,opt,name=banThreshold" json:"banThreshold,omitempty"`
+	//禁止连接的时间, 单位秒
+	BanTime int64 `protobuf:"varint,14,opt,name=banTime" json:"banTime,omitempty"`
+	//节点之间使用tls加密连接, 证书和节点密钥绑定, 所有节点需要一致
+	EnableTLS bool `protobuf:"varint,15,opt,name=enableTLS" json:"enableTLS,omitempty"`
+	//只允许这些节点公钥(hex)连接, 为空时不限制, 需要开启enableTLS
+	AllowPeers []string `protobuf:"bytes,16,rep,name=allowPeers" json:"allowPeers,omitempty"`
+	//种子节点来源, 支持dns://域名, http(s)://地址, file://路径, http地址中的{channel}替换为p2p频道
+	SeedSources []string `protobuf:"bytes,17,rep,name=seedSources" json:"seedSources,omitempty"`
+	//种子列表签名的公钥(hex), 未配置时只使用本地文件来源
+	SeedPubkey string `protobuf:"bytes,18,opt,name=seedPubkey" json:"seedPubkey,omitempty"`
 	//指定p2p类型, 支持gossip, dht
 }
 
@@ -103,6 +115,12 @@ func New(mgr *p2p.Manager, subCfg []byte) p2p.IP2P {
 	if mcfg.MinLtBlockTxNum <= 0 {
 		mcfg.MinLtBlockTxNum = DefaultMinLtBlockTxNum
 	}
+	if mcfg.BanThreshold <= 0 {
+		mcfg.BanThreshold = DefaultBanThreshold
+	}
+	if mcfg.BanTime <= 0 {
+		mcfg.BanTime = DefaultBanTime
+	}
 
 	log.Info("p2p", "Channel", mcfg.Channel, "Version", VERSION, "IsTest", cfg.IsTestNet())
 	if mcfg.InnerBounds == 0 {
@@ -4,7 +4,9 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net"
+	"path/filepath"
 	"sort"
 	"sync/atomic"
 	"time"
@@ -362,7 +364,7 @@ func testGrpcStreamConns(t *testing.T, p2p *P2p) {
 
 func testP2pComm(t *testing.T, p2p *P2p) {
 
-	addrs := P2pComm.AddrRouteble([]string{"localhost:53802"}, utils.CalcChannelVersion(testChannel, VERSION))
+	addrs := P2pComm.AddrRouteble([]string{"localhost:53802"}, utils.CalcChannelVersion(testChannel, VERSION), nil)
 	t.Log(addrs)
 	i32 := P2pComm.BytesToInt32([]byte{0xff})
 	t.Log(i32)
@@ -430,6 +432,10 @@ func testRestart(t *testing.T, p2p *P2p) {
 
 func Test_p2p(t *testing.T) {
 	cfg := types.NewChain33Config(types.ReadFile("../../../chain33.toml"))
+	dir, err := ioutil.TempDir("", "gossipwallet")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	cfg.GetModuleConfig().Wallet.DbPath = filepath.Join(dir, "wallet")
 	q := queue.New("channel")
 	q.SetConfig(cfg)
 	go q.Start()
@@ -446,6 +452,14 @@ func Test_p2p(t *testing.T) {
 	testP2pComm(t, p2p)
 	testAddrBook(t, p2p)
 	testRestart(t, p2p)
+
+
+	//通过EventPeerInfo查询节点评分
+	msg := p2p.client.NewMessage("p2p", types.EventPeerInfo, &ReqPeerScores{})
+	p2p.mgr.PubSub.Pub(msg, P2PTypeName)
+	reply, err := p2p.client.WaitTimeout(msg, time.Second*10)
+	assert.Nil(t, err)
+	assert.Equal(t, &ReplyPeerScores{Scores: p2p.GetPeerScores()}, reply.GetData())
 }
 
 func Test_AddDelStream(t *testing.T) {
@@ -20,6 +20,7 @@ import (
 	pb "github.com/33cn/chain33/types"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	pr "google.golang.org/grpc/peer"
 )
 
 type p2pEventFunc func(message *queue.Message, taskIndex int64)
@@ -264,9 +265,10 @@ func (m *Cli) SendVersion(peer *Peer, nodeinfo *NodeInfo) (string, error) {
 	}
 	addrfrom := nodeinfo.GetExternalAddr().String()
 
+	var p pr.Peer
 	resp, err := peer.mconn.gcli.Version2(context.Background(), &pb.P2PVersion{Version: nodeinfo.channelVersion, Service: int64(nodeinfo.ServiceTy()), Timestamp: pb.Now().Unix(),
 		AddrRecv: peer.Addr(), AddrFrom: addrfrom, Nonce: int64(rand.Int31n(102040)),
-		UserAgent: hex.EncodeToString(in.Sign.GetPubkey()), StartHeight: blockheight}, grpc.FailFast(true))
+		UserAgent: hex.EncodeToString(in.Sign.GetPubkey()), StartHeight: blockheight}, grpc.FailFast(true), grpc.Peer(&p))
 	log.Debug("SendVersion", "resp", resp, "addrfrom", addrfrom, "sendto", peer.Addr())
 	if err != nil {
 		log.Error("SendVersion", "Verson", err.Error(), "peer", peer.Addr())
@@ -277,6 +279,11 @@ func (m *Cli) SendVersion(peer *Peer, nodeinfo *NodeInfo) (string, error) {
 		return "", err
 	}
 
+	//对端返回的节点公钥需要和tls握手的公钥一致
+	if err = nodeinfo.checkPeerIdentity(p.AuthInfo, resp.GetUserAgent()); err != nil {
+		log.Error("SendVersion", "peer", peer.Addr(), "err", err)
+		return "", err
+	}
 	P2pComm.CollectPeerStat(err, peer)
 	log.Debug("SHOW VERSION BACK", "VersionBack", resp, "peer", peer.Addr())
 	_, ver := utils.DecodeChannelVersion(resp.GetVersion())
@@ -345,6 +352,12 @@ func (m *Cli) GetPeerInfo(msg *queue.Message, taskindex int64) {
 		log.Debug("GetPeerInfo", "task complete:", taskindex)
 	}()
 
+	//chain33的节点信息中没有评分, 评分通过gossip的请求类型单独查询.
+	//p2p管理模块把非P2PGetPeerReq的请求转发给第一个配置的p2p类型
+	if _, ok := msg.GetData().(*ReqPeerScores); ok {
+		msg.Reply(m.network.client.NewMessage("", pb.EventPeerList, &ReplyPeerScores{Scores: m.network.node.GetPeerScores()}))
+		return
+	}
 	peerinfo, err := m.getLocalPeerInfo()
 	if err != nil {
 		log.Error("GetPeerInfo", "p2p cli Err", err.Error())
@@ -561,7 +574,7 @@ func (m *Cli) GetNetInfo(msg *queue.Message, taskindex int64) {
 // CheckPeerNatOk check peer is ok or not
 func (m *Cli) CheckPeerNatOk(addr string, info *NodeInfo) bool {
 	//连接自己的地址信息做测试
-	return !(len(P2pComm.AddrRouteble([]string{addr}, info.channelVersion)) == 0)
+	return !(len(P2pComm.AddrRouteble([]string{addr}, info.channelVersion, info.dialCreds())) == 0)
 
 }
 
@@ -572,7 +585,7 @@ func (m *Cli) CheckSelf(addr string, nodeinfo *NodeInfo) bool {
 		log.Error("AddrRouteble", "NewNetAddressString", err.Error())
 		return false
 	}
-	conn, err := netaddr.DialTimeout(nodeinfo.channelVersion)
+	conn, err := netaddr.DialTimeout(nodeinfo.channelVersion, nodeinfo.dialCreds())
 	if err != nil {
 		return false
 	}
@@ -424,6 +424,10 @@ func (s *P2pserver) ServerStreamSend(in *pb.P2PPing, stream pb.P2Pgservice_Serve
 	var peerInfo *innerpeer
 	var reTry int32
 	peerName := hex.EncodeToString(in.GetSign().GetPubkey())
+	if err = s.checkStreamIdentity(stream.Context(), peerName); err != nil {
+		log.Error("ServerStreamSend", "peer", peerAddr, "err", err)
+		return err
+	}
 	//此处不能用IP:Port 作为key,因为存在内网多个节点共享一个IP的可能,用peerName 不会有这个问题
 	for ; peerInfo == nil || peerInfo.p2pversion == 0; peerInfo = s.getInBoundPeerInfo(peerName) {
 		time.Sleep(time.Second)
@@ -440,6 +444,9 @@ func (s *P2pserver) ServerStreamSend(in *pb.P2PPing, stream pb.P2Pgservice_Serve
 		if s.IsClose() {
 			return fmt.Errorf("node close")
 		}
+		if s.node.nodeInfo.blacklist.Has(peerIP) {
+			return fmt.Errorf("blacklist %v  no authorized", peerIP)
+		}
 		sendData, doSend := s.node.processSendP2P(data, peerInfo.p2pversion, peerName, peerInfo.addr)
 		if !doSend {
 			continue
@@ -478,6 +485,10 @@ func (s *P2pserver) ServerStreamRead(stream pb.P2Pgservice_ServerStreamReadServe
 			log.Error("ServerStreamRead", "Recv", err)
 			return err
 		}
+		//评分过低被禁止的节点断开已有的连接
+		if s.node.nodeInfo.blacklist.Has(peerIP) {
+			return fmt.Errorf("blacklist %v  no authorized", peerIP)
+		}
 
 		if s.node.processRecvP2P(in, peername, s.pubToStream, peeraddr) {
 
@@ -488,6 +499,7 @@ func (s *P2pserver) ServerStreamRead(stream pb.P2Pgservice_ServerStreamReadServe
 			innerpeer := s.getInBoundPeerInfo(peername)
 			channel, p2pVersion := utils.DecodeChannelVersion(ver.GetP2Pversion())
 			if !s.node.verifyP2PChannel(channel) {
+				s.node.scorePeer(peeraddr, scoreProtocol, "invalid p2p channel")
 				return pb.ErrP2PChannel
 			}
 			if innerpeer != nil {
@@ -516,11 +528,24 @@ func (s *P2pserver) ServerStreamRead(stream pb.P2Pgservice_ServerStreamReadServe
 			}
 			peername = hex.EncodeToString(ping.GetSign().GetPubkey())
 			peeraddr = fmt.Sprintf("%s:%v", peerIP, ping.GetPort())
+			//ping中签名的节点公钥需要和tls握手的公钥一致
+			if err = s.checkStreamIdentity(stream.Context(), peername); err != nil {
+				s.node.scorePeer(peeraddr, scoreProtocol, "peer identity mismatch")
+				return err
+			}
 			s.addInBoundPeerInfo(peername, innerpeer{addr: peeraddr, name: peername, timestamp: pb.Now().Unix()})
 		}
 	}
 }
 
+func (s *P2pserver) checkStreamIdentity(ctx context.Context, pubkey string) error {
+	p, ok := pr.FromContext(ctx)
+	if !ok {
+		return errPeerIdentity
+	}
+	return s.node.nodeInfo.checkPeerIdentity(p.AuthInfo, pubkey)
+}
+
 // CollectInPeers collect external network nodes of connect their own
 func (s *P2pserver) CollectInPeers(ctx context.Context, in *pb.P2PPing) (*pb.PeerList, error) {
 	log.Debug("CollectInPeers")
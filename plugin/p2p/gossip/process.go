@@ -74,6 +74,7 @@ func (n *Node) processRecvP2P(data *types.BroadCastData, pid string, pubPeerFunc
 	defer func() {
 		if r := recover(); r != nil {
 			log.Error("ProcessRecvP2P_Panic", "recvData", data, "peerAddr", peerAddr, "recoverErr", r)
+			n.scorePeer(peerAddr, scoreProtocol, "invalid p2p data")
 		}
 	}()
 	log.Debug("ProcessRecvP2P", "peerID", pid, "peerAddr", peerAddr)
@@ -206,11 +207,11 @@ func (n *Node) recvTx(tx *types.P2PTx, pid, peerAddr string) {
 		tx.Route = &types.P2PRoute{TTL: 1}
 	}
 	txHashFilter.Add(txHash, tx.GetRoute())
-
 	errs := n.postMempool(txHash, tx.GetTx())
 	if errs != nil {
 		log.Error("recvTx", "process post mempool EventTx msg Error", errs.Error())
 	}
+	n.checkTxSign(tx.GetTx(), peerAddr)
 
 }
 
@@ -250,6 +251,13 @@ func (n *Node) recvBlock(block *types.P2PBlock, pid, peerAddr string) {
 	if isDuplicate {
 		return
 	}
+	//交易根哈希不一致的区块不再发送到blockchain
+	if !bytes.Equal(block.Block.TxHash, merkle.CalcMerkleRoot(n.chainCfg, block.Block.Height, block.Block.Txs)) {
+		log.Debug("recvBlock:TxHashCheckFail", "height", block.GetBlock().GetHeight(), "peerAddr", peerAddr, "blockHash", blockHash)
+		n.scorePeer(peerAddr, scoreInvalidBlock, "invalid block")
+		return
+	}
+	n.scorePeer(peerAddr, scoreBlockDelivered, "block delivered")
 	//发送至blockchain执行
 	if err := n.postBlockChain(blockHash, pid, block.GetBlock()); err != nil {
 		log.Error("recvBlock", "send block to blockchain Error", err.Error())
@@ -327,6 +335,7 @@ func (n *Node) recvLtBlock(ltBlock *types.LightBlock, pid, peerAddr string, pubP
 		if bytes.Equal(block.TxHash, merkle.CalcMerkleRoot(n.chainCfg, block.Height, block.Txs)) {
 			log.Debug("recvLtBlock", "height", block.GetHeight(), "peerAddr", peerAddr,
 				"blockHash", blockHash, "block size(KB)", float32(ltBlock.Size)/1024)
+			n.scorePeer(peerAddr, scoreBlockDelivered, "block delivered")
 			//发送至blockchain执行
 			if err := n.postBlockChain(blockHash, pid, block); err != nil {
 				log.Error("recvLtBlock", "send block to blockchain Error", err.Error())
@@ -411,6 +420,11 @@ func (n *Node) recvQueryReply(rep *types.P2PBlockTxReply, pid, peerAddr string,
 	if !exist || block == nil {
 		return
 	}
+	//回复的交易和请求的索引不一致
+	if len(rep.TxIndices) != 0 && len(rep.TxIndices) != len(rep.Txs) {
+		n.scorePeer(peerAddr, scoreProtocol, "invalid block tx reply")
+		return
+	}
 	for i, idx := range rep.TxIndices {
 		block.Txs[idx] = rep.Txs[i]
 	}
@@ -425,6 +439,7 @@ func (n *Node) recvQueryReply(rep *types.P2PBlockTxReply, pid, peerAddr string,
 
 		log.Debug("recvQueryReplyBlock", "blockHeight", block.GetHeight(), "peerAddr", peerAddr,
 			"block size(KB)", float32(block.Size())/1024, "blockHash", rep.BlockHash)
+		n.scorePeer(peerAddr, scoreBlockDelivered, "block delivered")
 		//发送至blockchain执行
 		if err := n.postBlockChain(rep.BlockHash, pid, block); err != nil {
 			log.Error("recvQueryReplyBlock", "send block to blockchain Error", err.Error())
@@ -444,6 +459,10 @@ func (n *Node) recvQueryReply(rep *types.P2PBlockTxReply, pid, peerAddr string,
 		pubPeerFunc(query, pid)
 		block.Txs = nil
 		ltBlockCache.Add(rep.BlockHash, block, int64(block.Size()))
+	} else {
+		//区块所有的交易都由对端节点提供, 根哈希仍然不一致
+		log.Debug("recvQueryReplyBlock:TxHashCheckFail", "height", block.GetHeight(), "peerAddr", peerAddr, "blockHash", rep.BlockHash)
+		n.scorePeer(peerAddr, scoreInvalidBlock, "invalid block")
 	}
 }
 
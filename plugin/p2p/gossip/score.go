@@ -0,0 +1,230 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gossip
+
+import (
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 节点行为对应的评分变化
+const (
+	scoreInvalidBlock   = -50
+	scoreInvalidTx      = -10
+	scoreGetDataFailed  = -20
+	scoreTimeout        = -10
+	scoreProtocol       = -30
+	scoreBlockDelivered = 1
+	//奖励的最高分, 避免长期正常的节点作恶后很久才被禁止
+	maxPeerScore = 100
+)
+
+// 签名检查的协程数量和队列长度, 队列满时不再检查, 避免节点大量发送交易时占用过多资源
+const (
+	txSignWorkers   = 4
+	txSignQueueSize = 1024
+)
+
+// PeerScore 节点评分, BanUntil不为0时表示禁止连接的截止时间
+type PeerScore struct {
+	Addr     string `json:"addr"`
+	Score    int32  `json:"score"`
+	BanUntil int64  `json:"banUntil"`
+}
+
+// ReqPeerScores 查询节点评分, 作为EventPeerInfo的请求发送给gossip
+type ReqPeerScores struct{}
+
+// ReplyPeerScores 节点评分列表
+type ReplyPeerScores struct {
+	Scores []*PeerScore `json:"scores"`
+}
+
+// peerTx 待检查签名的交易和发送交易的节点
+type peerTx struct {
+	tx       *types.Transaction
+	peerAddr string
+}
+
+// peerScores 以节点地址(ip:port)记录评分和禁止连接的截止时间
+type peerScores struct {
+	mtx       sync.Mutex
+	scores    map[string]int32
+	bans      map[string]int64
+	threshold int32
+	banTime   int64
+}
+
+func newPeerScores(threshold int32, banTime int64) *peerScores {
+	return &peerScores{
+		scores:    make(map[string]int32),
+		bans:      make(map[string]int64),
+		threshold: threshold,
+		banTime:   banTime,
+	}
+}
+
+// add 修改评分, 降到-threshold时评分清零并返回禁止连接的截止时间
+func (ps *peerScores) add(addr string, delta int32) (score int32, banUntil int64) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	score = ps.scores[addr] + delta
+	if score > maxPeerScore {
+		score = maxPeerScore
+	}
+	if score > -ps.threshold {
+		ps.scores[addr] = score
+		return score, 0
+	}
+	delete(ps.scores, addr)
+	banUntil = types.Now().Unix() + ps.banTime
+	ps.bans[addr] = banUntil
+	return score, banUntil
+}
+
+// decay 扣分随时间恢复, 同时删除过期的禁止记录
+func (ps *peerScores) decay() {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	for addr, score := range ps.scores {
+		if score < 0 {
+			ps.scores[addr] = score + 1
+		}
+	}
+	now := types.Now().Unix()
+	for addr, until := range ps.bans {
+		if now >= until {
+			delete(ps.bans, addr)
+		}
+	}
+}
+
+func (ps *peerScores) setBan(addr string, until int64) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	ps.bans[addr] = until
+}
+
+func (ps *peerScores) getBans() map[string]int64 {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	bans := make(map[string]int64, len(ps.bans))
+	for addr, until := range ps.bans {
+		bans[addr] = until
+	}
+	return bans
+}
+
+// list 按地址排序的评分, 包括被禁止的节点
+func (ps *peerScores) list() []*PeerScore {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	var list []*PeerScore
+	for addr, score := range ps.scores {
+		list = append(list, &PeerScore{Addr: addr, Score: score, BanUntil: ps.bans[addr]})
+	}
+	for addr, until := range ps.bans {
+		if _, ok := ps.scores[addr]; !ok {
+			list = append(list, &PeerScore{Addr: addr, BanUntil: until})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Addr < list[j].Addr })
+	return list
+}
+
+// banAddr 地址和ip都加入黑名单, 对端节点换端口重新接入时同样被拒绝
+func (nf *NodeInfo) banAddr(addr string, until int64) {
+	deadline := until - types.Now().Unix()
+	if deadline <= 0 {
+		return
+	}
+	nf.blacklist.Add(addr, deadline)
+	if ip, _, err := net.SplitHostPort(addr); err == nil {
+		nf.blacklist.Add(ip, deadline)
+	}
+}
+
+// loadBans 恢复重启前禁止的节点
+func (nf *NodeInfo) loadBans() {
+	for addr, until := range nf.addrBook.loadBanList() {
+		if until > types.Now().Unix() {
+			nf.scores.setBan(addr, until)
+			nf.banAddr(addr, until)
+		}
+	}
+}
+
+// scorePeer 根据节点行为修改评分, 评分过低时断开连接并禁止一段时间
+func (n *Node) scorePeer(addr string, delta int32, reason string) {
+	if addr == "" {
+		return
+	}
+	score, banUntil := n.nodeInfo.scores.add(addr, delta)
+	log.Debug("scorePeer", "addr", addr, "delta", delta, "reason", reason, "score", score)
+	if banUntil == 0 {
+		return
+	}
+	log.Info("scorePeer", "ban peer", addr, "reason", reason, "until", banUntil)
+	n.nodeInfo.banAddr(addr, banUntil)
+	n.nodeInfo.addrBook.saveBanList(n.nodeInfo.scores.getBans())
+	if peer := n.GetRegisterPeer(addr); peer != nil {
+		n.destroyPeer(peer)
+		return
+	}
+	n.nodeInfo.addrBook.RemoveAddr(addr)
+}
+
+// scoreGetDataErr 请求数据超时和其他失败分别扣分
+func (n *Node) scoreGetDataErr(addr string, err error) {
+	if status.Code(err) == codes.DeadlineExceeded {
+		n.scorePeer(addr, scoreTimeout, "GetData timeout")
+		return
+	}
+	n.scorePeer(addr, scoreGetDataFailed, "GetData failed")
+}
+
+// GetPeerScores 获取节点的评分
+func (n *Node) GetPeerScores() []*PeerScore {
+	return n.nodeInfo.scores.list()
+}
+
+// GetPeerScores 获取节点的评分和禁止连接的截止时间
+func (network *P2p) GetPeerScores() []*PeerScore {
+	return network.node.GetPeerScores()
+}
+
+// checkTxSign 交易签名检查放入队列, 由固定数量的协程检查, 队列满时直接丢弃
+func (n *Node) checkTxSign(tx *types.Transaction, peerAddr string) {
+	select {
+	case n.txSignChan <- &peerTx{tx: tx, peerAddr: peerAddr}:
+	default:
+		log.Debug("checkTxSign", "queue full, skip peerAddr", peerAddr)
+	}
+}
+
+// monitorTxSign 签名错误的交易对发送的节点扣分
+func (n *Node) monitorTxSign() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case ptx := <-n.txSignChan:
+			if !ptx.tx.CheckSign() {
+				n.scorePeer(ptx.peerAddr, scoreInvalidTx, "invalid tx")
+			}
+		case <-ticker.C:
+			if n.isClose() {
+				log.Info("monitorTxSign", "loop", "done")
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gossip
+
+import (
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerScores(t *testing.T) {
+	ps := newPeerScores(100, 60)
+	score, until := ps.add("1.1.1.1:13802", scoreInvalidTx)
+	assert.Equal(t, int32(scoreInvalidTx), score)
+	assert.Equal(t, int64(0), until)
+	for i := 0; i < 200; i++ {
+		ps.add("2.2.2.2:13802", scoreBlockDelivered)
+	}
+	ps.decay()
+	list := ps.list()
+	require.Equal(t, 2, len(list))
+	assert.Equal(t, &PeerScore{Addr: "1.1.1.1:13802", Score: scoreInvalidTx + 1}, list[0])
+	assert.Equal(t, &PeerScore{Addr: "2.2.2.2:13802", Score: maxPeerScore}, list[1])
+
+	//降到-100时禁止连接, 评分清零
+	ps.add("1.1.1.1:13802", scoreInvalidBlock)
+	_, until = ps.add("1.1.1.1:13802", scoreInvalidBlock)
+	assert.True(t, until >= types.Now().Unix()+60)
+	list = ps.list()
+	assert.Equal(t, &PeerScore{Addr: "1.1.1.1:13802", BanUntil: until}, list[0])
+	assert.Equal(t, map[string]int64{"1.1.1.1:13802": until}, ps.getBans())
+
+	ps.setBan("3.3.3.3:13802", types.Now().Unix()-1)
+	ps.decay()
+	assert.Equal(t, 1, len(ps.getBans()))
+}
+
+func TestBanPersist(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gossipscore")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	p2pCfg := &types.P2P{DbPath: dir, Driver: "leveldb", DbCache: 4}
+	subCfg := &subConfig{BanThreshold: 50, BanTime: 3600}
+
+	node := &Node{outBound: make(map[string]*Peer), cacheBound: make(map[string]*Peer)}
+	node.nodeInfo = NewNodeInfo(p2pCfg, subCfg)
+	node.scorePeer("1.1.1.1:13802", scoreInvalidTx, "test")
+	assert.False(t, node.nodeInfo.blacklist.Has("1.1.1.1:13802"))
+	node.scorePeer("1.1.1.1:13802", scoreInvalidBlock, "test")
+	assert.True(t, node.nodeInfo.blacklist.Has("1.1.1.1:13802"))
+	//同一个ip其他端口的连接也被拒绝
+	assert.True(t, node.nodeInfo.blacklist.Has("1.1.1.1"))
+	scores := node.GetPeerScores()
+	require.Equal(t, 1, len(scores))
+	assert.True(t, scores[0].BanUntil > types.Now().Unix())
+	node.nodeInfo.addrBook.Close()
+
+	//重启后仍然禁止
+	nodeInfo := NewNodeInfo(p2pCfg, subCfg)
+	defer nodeInfo.addrBook.Close()
+	assert.True(t, nodeInfo.blacklist.Has("1.1.1.1:13802"))
+	assert.True(t, nodeInfo.blacklist.Has("1.1.1.1"))
+	assert.Equal(t, scores, nodeInfo.scores.list())
+}
+
+func TestInvalidTxScore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gossipscore")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	p2pCfg := &types.P2P{DbPath: dir, Driver: "leveldb", DbCache: 4}
+	node := &Node{outBound: make(map[string]*Peer), cacheBound: make(map[string]*Peer), txSignChan: make(chan *peerTx, 1)}
+	node.nodeInfo = NewNodeInfo(p2pCfg, &subConfig{BanThreshold: 50, BanTime: 3600})
+	defer node.nodeInfo.addrBook.Close()
+	tx := &types.Transaction{Execer: []byte("coins"), Payload: []byte("none"), Fee: 100000}
+	node.checkTxSign(tx, "1.1.1.1:13802")
+	//队列满时不再检查
+	node.checkTxSign(tx, "1.1.1.1:13802")
+	go node.monitorTxSign()
+	defer atomic.StoreInt32(&node.closed, 1)
+	for i := 0; i < 100 && len(node.GetPeerScores()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, []*PeerScore{{Addr: "1.1.1.1:13802", Score: scoreInvalidTx}}, node.GetPeerScores())
+}
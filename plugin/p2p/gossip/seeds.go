@@ -0,0 +1,305 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gossip
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+)
+
+var (
+	errSeedSource    = errors.New("ErrSeedSource")
+	errSeedSignature = errors.New("ErrSeedSignature")
+	errSeedTooLarge  = errors.New("ErrSeedTooLarge")
+)
+
+// 种子列表签名内容的前缀, 避免和其他签名数据混淆
+const seedSignPrefix = "chain33-gossip-seeds:"
+
+// 种子列表中签名所在行的前缀
+const seedSigTag = "sig="
+
+// http种子地址中的频道占位符, 不同网络使用不同的种子列表
+const seedChannelHolder = "{channel}"
+
+// 获取种子列表的超时时间
+var seedFetchTimeout = 10 * time.Second
+
+// http种子列表的最大长度, 超过时认为种子来源异常
+var maxSeedListSize int64 = 64 * 1024
+
+// dns查询, 测试时替换
+var (
+	lookupTXT  = net.LookupTXT
+	lookupHost = net.LookupHost
+)
+
+// seedList 种子节点地址和签名
+type seedList struct {
+	addrs []string
+	sig   []byte
+}
+
+// seedSource 种子节点来源
+type seedSource interface {
+	name() string
+	fetch() (*seedList, error)
+}
+
+// newSeedSource 根据地址前缀创建种子来源, 支持dns://, http://, https://, file://
+func newSeedSource(uri string, channel int32, port int32) (seedSource, error) {
+	switch {
+	case strings.HasPrefix(uri, "dns://"):
+		return &dnsSeedSource{domain: strings.TrimPrefix(uri, "dns://"), port: port}, nil
+	case strings.HasPrefix(uri, "http://"), strings.HasPrefix(uri, "https://"):
+		url := strings.Replace(uri, seedChannelHolder, strconv.Itoa(int(channel)), -1)
+		return &httpSeedSource{url: url}, nil
+	case strings.HasPrefix(uri, "file://"):
+		return &fileSeedSource{path: strings.TrimPrefix(uri, "file://")}, nil
+	}
+	return nil, fmt.Errorf("%v: %s", errSeedSource, uri)
+}
+
+// parseSeedList 每行一个地址, 格式为ip:port或者pid@ip:port, sig=开头的行为签名, #开头的行为注释
+func parseSeedList(content string) (*seedList, error) {
+	list := &seedList{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, seedSigTag) {
+			sig, err := hex.DecodeString(strings.TrimPrefix(line, seedSigTag))
+			if err != nil {
+				return nil, err
+			}
+			list.sig = sig
+			continue
+		}
+		list.addrs = append(list.addrs, seedAddr(line))
+	}
+	return list, nil
+}
+
+// seedAddr 兼容pid@ip:port格式
+func seedAddr(line string) string {
+	if index := strings.LastIndex(line, "@"); index >= 0 {
+		return line[index+1:]
+	}
+	return line
+}
+
+// seedSignData 签名内容包括频道和排序去重后的地址, 其他网络的种子列表不能直接使用
+func seedSignData(channel int32, addrs []string) []byte {
+	unique := make(map[string]bool)
+	var sorted []string
+	for _, addr := range addrs {
+		if !unique[addr] {
+			unique[addr] = true
+			sorted = append(sorted, addr)
+		}
+	}
+	sort.Strings(sorted)
+	return []byte(fmt.Sprintf("%s%d\n%s", seedSignPrefix, channel, strings.Join(sorted, "\n")))
+}
+
+// verify 验证种子列表的签名, pubkey为hex格式的secp256k1公钥
+func (s *seedList) verify(channel int32, pubkey string) error {
+	if len(s.sig) == 0 {
+		return errSeedSignature
+	}
+	pubBytes, err := hex.DecodeString(pubkey)
+	if err != nil {
+		return err
+	}
+	cr, err := crypto.New(types.GetSignName("", types.SECP256K1))
+	if err != nil {
+		return err
+	}
+	pub, err := cr.PubKeyFromBytes(pubBytes)
+	if err != nil {
+		return err
+	}
+	sig, err := cr.SignatureFromBytes(s.sig)
+	if err != nil {
+		return err
+	}
+	if !pub.VerifyBytes(seedSignData(channel, s.addrs), sig) {
+		return errSeedSignature
+	}
+	return nil
+}
+
+// SignSeedList 生成带签名的种子列表, 用于发布到http, 文件或者dns txt记录
+func SignSeedList(privKey string, channel int32, addrs []string) (string, error) {
+	privBytes, err := hex.DecodeString(privKey)
+	if err != nil {
+		return "", err
+	}
+	cr, err := crypto.New(types.GetSignName("", types.SECP256K1))
+	if err != nil {
+		return "", err
+	}
+	priv, err := cr.PrivKeyFromBytes(privBytes)
+	if err != nil {
+		return "", err
+	}
+	sig := priv.Sign(seedSignData(channel, addrs))
+	return strings.Join(addrs, "\n") + "\n" + seedSigTag + hex.EncodeToString(sig.Bytes()) + "\n", nil
+}
+
+// dnsSeedSource txt记录为地址或者签名, a记录为节点ip, 端口使用本节点的监听端口
+type dnsSeedSource struct {
+	domain string
+	port   int32
+}
+
+func (d *dnsSeedSource) name() string {
+	return "dns://" + d.domain
+}
+
+func (d *dnsSeedSource) fetch() (*seedList, error) {
+	records, err := lookupTXT(d.domain)
+	if err != nil {
+		return nil, err
+	}
+	list, err := parseSeedList(strings.Join(records, "\n"))
+	if err != nil {
+		return nil, err
+	}
+	hosts, err := lookupHost(d.domain)
+	if err != nil {
+		return nil, err
+	}
+	for _, host := range hosts {
+		list.addrs = append(list.addrs, net.JoinHostPort(host, strconv.Itoa(int(d.port))))
+	}
+	return list, nil
+}
+
+// httpSeedSource 从http地址下载种子列表
+type httpSeedSource struct {
+	url string
+}
+
+func (h *httpSeedSource) name() string {
+	return h.url
+}
+
+func (h *httpSeedSource) fetch() (*seedList, error) {
+	client := &http.Client{Timeout: seedFetchTimeout}
+	res, err := client.Get(h.url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http status %d", res.StatusCode)
+	}
+	//多读取一个字节用于判断是否超过长度限制
+	content, err := ioutil.ReadAll(io.LimitReader(res.Body, maxSeedListSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > maxSeedListSize {
+		return nil, errSeedTooLarge
+	}
+	return parseSeedList(string(content))
+}
+
+// fileSeedSource 从本地文件读取种子列表
+type fileSeedSource struct {
+	path string
+}
+
+func (f *fileSeedSource) name() string {
+	return "file://" + f.path
+}
+
+func (f *fileSeedSource) fetch() (*seedList, error) {
+	content, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+	return parseSeedList(string(content))
+}
+
+// initSeedSources 加载配置的种子来源, 配置错误的来源忽略
+func (n *Node) initSeedSources() {
+	cfg := n.nodeInfo.cfg
+	if cfg.UseGithub {
+		log.Warn("initSeedSources", "useGithub", "deprecated, use seedSources and seedPubkey instead")
+	}
+	for _, uri := range cfg.SeedSources {
+		source, err := newSeedSource(uri, cfg.Channel, cfg.Port)
+		if err != nil {
+			log.Error("initSeedSources", "source", uri, "err", err)
+			continue
+		}
+		n.seedSources = append(n.seedSources, source)
+	}
+	if len(n.seedSources) > 0 && cfg.SeedPubkey == "" {
+		log.Warn("initSeedSources", "seedPubkey", "not set, only local file sources are used")
+	}
+}
+
+// fetchSeeds 从一个来源获取并验证种子列表, 未配置公钥时只信任本地文件
+func (n *Node) fetchSeeds(source seedSource) ([]string, error) {
+	list, err := source.fetch()
+	if err != nil {
+		return nil, err
+	}
+	cfg := n.nodeInfo.cfg
+	if cfg.SeedPubkey == "" {
+		if _, ok := source.(*fileSeedSource); ok {
+			return list.addrs, nil
+		}
+		return nil, errSeedSignature
+	}
+	if err = list.verify(cfg.Channel, cfg.SeedPubkey); err != nil {
+		return nil, err
+	}
+	return list.addrs, nil
+}
+
+// getAddrFromSeedSources 种子地址加入地址簿, 未连接的地址发起连接
+func (n *Node) getAddrFromSeedSources() {
+	for _, source := range n.seedSources {
+		addrs, err := n.fetchSeeds(source)
+		if err != nil {
+			log.Error("getAddrFromSeedSources", "source", source.name(), "err", err)
+			continue
+		}
+		log.Info("getAddrFromSeedSources", "source", source.name(), "addrs", len(addrs))
+		for _, addr := range addrs {
+			netAddr, err := NewNetAddressString(addr)
+			if err != nil {
+				log.Debug("getAddrFromSeedSources", "addr", addr, "err", err)
+				continue
+			}
+			addr = netAddr.String()
+			if n.nodeInfo.blacklist.Has(addr) || n.nodeInfo.blacklist.Has(netAddr.IP.String()) ||
+				n.nodeInfo.addrBook.IsOurStringAddress(addr) {
+				continue
+			}
+			n.nodeInfo.addrBook.AddAddress(netAddr, nil)
+			if !n.Has(addr) {
+				n.pubsub.FIFOPub(addr, "addr")
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gossip
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/33cn/chain33/common/pubsub"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedList(t *testing.T) {
+	priv, pub := genTestNodeKey(t)
+	addrs := []string{"192.168.1.2:13802", "192.168.1.1:13802"}
+	content, err := SignSeedList(priv, 1, addrs)
+	require.Nil(t, err)
+	list, err := parseSeedList("#seeds\n" + content)
+	require.Nil(t, err)
+	assert.Equal(t, addrs, list.addrs)
+	assert.Nil(t, list.verify(1, pub))
+	//其他频道的种子列表
+	assert.Equal(t, errSeedSignature, list.verify(2, pub))
+	_, pub2 := genTestNodeKey(t)
+	assert.Equal(t, errSeedSignature, list.verify(1, pub2))
+
+	//增加地址后签名失效
+	list, err = parseSeedList(content + "10.0.0.1:13802\n")
+	require.Nil(t, err)
+	assert.Equal(t, errSeedSignature, list.verify(1, pub))
+	//兼容pid@ip:port格式, 没有签名
+	list, err = parseSeedList("abc@192.168.1.1:13802\n")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"192.168.1.1:13802"}, list.addrs)
+	assert.Equal(t, errSeedSignature, list.verify(1, pub))
+}
+
+func TestSeedSources(t *testing.T) {
+	priv, pub := genTestNodeKey(t)
+	content, err := SignSeedList(priv, 3, []string{"192.168.1.1:13802"})
+	require.Nil(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/seeds/3.txt" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, content)
+	}))
+	defer srv.Close()
+	source, err := newSeedSource(srv.URL+"/seeds/{channel}.txt", 3, 13802)
+	require.Nil(t, err)
+	list, err := source.fetch()
+	require.Nil(t, err)
+	assert.Nil(t, list.verify(3, pub))
+	source, err = newSeedSource(srv.URL+"/seeds/{channel}.txt", 4, 13802)
+	require.Nil(t, err)
+	_, err = source.fetch()
+	assert.NotNil(t, err)
+	//超过长度限制的种子列表
+	defer func(size int64) { maxSeedListSize = size }(maxSeedListSize)
+	maxSeedListSize = int64(len(content) - 1)
+	source, err = newSeedSource(srv.URL+"/seeds/{channel}.txt", 3, 13802)
+	require.Nil(t, err)
+	_, err = source.fetch()
+	assert.Equal(t, errSeedTooLarge, err)
+	maxSeedListSize = int64(len(content))
+	_, err = source.fetch()
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "gossipseeds")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "seeds.txt")
+	require.Nil(t, ioutil.WriteFile(path, []byte(content), 0600))
+	source, err = newSeedSource("file://"+path, 3, 13802)
+	require.Nil(t, err)
+	list, err = source.fetch()
+	require.Nil(t, err)
+	assert.Nil(t, list.verify(3, pub))
+
+	//a记录使用监听端口, txt记录中带有签名
+	dnsContent, err := SignSeedList(priv, 3, []string{"10.0.0.1:13802", "192.168.1.1:13802"})
+	require.Nil(t, err)
+	defer func(txt, host func(string) ([]string, error)) {
+		lookupTXT, lookupHost = txt, host
+	}(lookupTXT, lookupHost)
+	lookupTXT = func(string) ([]string, error) {
+		return strings.Split(strings.TrimSpace(strings.Replace(dnsContent, "10.0.0.1:13802\n", "", 1)), "\n"), nil
+	}
+	lookupHost = func(string) ([]string, error) { return []string{"10.0.0.1"}, nil }
+	source, err = newSeedSource("dns://seed.example.com", 3, 13802)
+	require.Nil(t, err)
+	list, err = source.fetch()
+	require.Nil(t, err)
+	assert.Equal(t, []string{"192.168.1.1:13802", "10.0.0.1:13802"}, list.addrs)
+	assert.Nil(t, list.verify(3, pub))
+
+	_, err = newSeedSource("ftp://seed.example.com", 3, 13802)
+	assert.NotNil(t, err)
+}
+
+func TestGetAddrFromSeedSources(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gossipseeds")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	priv, pub := genTestNodeKey(t)
+	content, err := SignSeedList(priv, 0, []string{"192.168.1.1:13802", "192.168.1.2:13802", "192.168.1.3:13802"})
+	require.Nil(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, content)
+	}))
+	defer srv.Close()
+	path := filepath.Join(dir, "seeds.txt")
+	require.Nil(t, ioutil.WriteFile(path, []byte("192.168.1.4:13802\n"), 0600))
+
+	p2pCfg := &types.P2P{DbPath: dir, Driver: "leveldb", DbCache: 4}
+	subCfg := &subConfig{BanThreshold: 50, BanTime: 3600, SeedSources: []string{srv.URL, "file://" + path}}
+	node := &Node{outBound: make(map[string]*Peer), cacheBound: make(map[string]*Peer), pubsub: pubsub.NewPubSub(10)}
+	node.nodeInfo = NewNodeInfo(p2pCfg, subCfg)
+	defer node.nodeInfo.addrBook.Close()
+	node.initSeedSources()
+	require.Equal(t, 2, len(node.seedSources))
+
+	//未配置公钥时只使用本地文件
+	node.getAddrFromSeedSources()
+	assert.Equal(t, []string{"192.168.1.4:13802"}, node.nodeInfo.addrBook.GetAddrs())
+
+	subCfg.SeedPubkey = pub
+	node.nodeInfo.blacklist.Add("192.168.1.2", 3600)
+	node.getAddrFromSeedSources()
+	addrs := node.nodeInfo.addrBook.GetAddrs()
+	assert.Equal(t, 3, len(addrs))
+	for _, addr := range []string{"192.168.1.1:13802", "192.168.1.3:13802", "192.168.1.4:13802"} {
+		assert.Contains(t, addrs, addr)
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gossip
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	"google.golang.org/grpc/credentials"
+)
+
+var (
+	errPeerIdentity   = errors.New("ErrPeerIdentity")
+	errPeerNotAllowed = errors.New("ErrPeerNotAllowed")
+	errNodeKeyNotSet  = errors.New("ErrNodeKeyNotSet")
+)
+
+// tls证书中记录节点公钥和签名的扩展项, 只在gossip节点之间使用
+var nodeKeyExtensionID = asn1.ObjectIdentifier{2, 25, 330033001, 1}
+
+// 节点私钥对tls证书公钥的签名前缀, 避免和其他签名数据混淆
+const nodeKeySignPrefix = "chain33-gossip-tls:"
+
+// nodeKeyBinding 节点公钥和节点私钥对证书公钥的签名
+type nodeKeyBinding struct {
+	Pubkey    []byte
+	Signature []byte
+}
+
+// nodeTLS tls证书使用临时生成的ecdsa密钥, 证书中带有节点secp256k1私钥的签名,
+// 握手时双方验证签名, 对端节点的身份和节点公钥绑定
+type nodeTLS struct {
+	mtx sync.Mutex
+	//获取节点当前的密钥对, 节点密钥可能在启动之后重新设置
+	nodeKey func() (string, string)
+	//允许连接的节点公钥, 为空时不限制
+	allowPeers map[string]bool
+	privKey    string
+	cert       *tls.Certificate
+}
+
+func newNodeTLS(nodeKey func() (string, string), allowPeers []string) *nodeTLS {
+	t := &nodeTLS{nodeKey: nodeKey, allowPeers: make(map[string]bool)}
+	for _, pub := range allowPeers {
+		t.allowPeers[strings.ToLower(pub)] = true
+	}
+	return t
+}
+
+// certificate 节点密钥变化时重新生成证书
+func (t *nodeTLS) certificate() (*tls.Certificate, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	privKey, _ := t.nodeKey()
+	if privKey == "" {
+		return nil, errNodeKeyNotSet
+	}
+	if t.cert != nil && t.privKey == privKey {
+		return t.cert, nil
+	}
+	cert, err := genNodeCert(privKey)
+	if err != nil {
+		return nil, err
+	}
+	t.privKey = privKey
+	t.cert = cert
+	return cert, nil
+}
+
+func genNodeCert(privKey string) (*tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	spki, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	cr, err := crypto.New(types.GetSignName("", types.SECP256K1))
+	if err != nil {
+		return nil, err
+	}
+	privBytes, err := hex.DecodeString(privKey)
+	if err != nil {
+		return nil, err
+	}
+	priv, err := cr.PrivKeyFromBytes(privBytes)
+	if err != nil {
+		return nil, err
+	}
+	binding, err := asn1.Marshal(nodeKeyBinding{
+		Pubkey:    priv.PubKey().Bytes(),
+		Signature: priv.Sign(append([]byte(nodeKeySignPrefix), spki...)).Bytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:    serial,
+		Subject:         pkix.Name{CommonName: hex.EncodeToString(priv.PubKey().Bytes())},
+		NotBefore:       now.Add(-time.Hour),
+		NotAfter:        now.Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		ExtraExtensions: []pkix.Extension{{Id: nodeKeyExtensionID, Value: binding}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
+
+// certPubkey 验证证书中的节点签名, 返回节点公钥
+func certPubkey(cert *x509.Certificate) ([]byte, error) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(nodeKeyExtensionID) {
+			continue
+		}
+		var binding nodeKeyBinding
+		if _, err := asn1.Unmarshal(ext.Value, &binding); err != nil {
+			return nil, err
+		}
+		cr, err := crypto.New(types.GetSignName("", types.SECP256K1))
+		if err != nil {
+			return nil, err
+		}
+		pub, err := cr.PubKeyFromBytes(binding.Pubkey)
+		if err != nil {
+			return nil, err
+		}
+		sig, err := cr.SignatureFromBytes(binding.Signature)
+		if err != nil {
+			return nil, err
+		}
+		if !pub.VerifyBytes(append([]byte(nodeKeySignPrefix), cert.RawSubjectPublicKeyInfo...), sig) {
+			return nil, errPeerIdentity
+		}
+		return binding.Pubkey, nil
+	}
+	return nil, errPeerIdentity
+}
+
+// verify 握手时验证对端证书, 证书的签名由握手过程验证, 这里验证节点签名和允许连接的节点
+func (t *nodeTLS) verify(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return errPeerIdentity
+	}
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return errPeerIdentity
+	}
+	pub, err := certPubkey(cert)
+	if err != nil {
+		return err
+	}
+	if len(t.allowPeers) > 0 && !t.allowPeers[hex.EncodeToString(pub)] {
+		log.Error("tls verify", "peer not allowed", hex.EncodeToString(pub))
+		return errPeerNotAllowed
+	}
+	return nil
+}
+
+func (t *nodeTLS) serverConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return t.certificate()
+		},
+		ClientAuth:            tls.RequireAnyClientCert,
+		VerifyPeerCertificate: t.verify,
+	}
+}
+
+func (t *nodeTLS) clientConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return t.certificate()
+		},
+		//节点证书是自签名的, 不使用CA验证, 由verify验证节点签名
+		InsecureSkipVerify:    true,
+		VerifyPeerCertificate: t.verify,
+	}
+}
+
+// peerIdentity 从tls连接信息获取对端的节点公钥
+func peerIdentity(authInfo credentials.AuthInfo) (string, bool) {
+	info, ok := authInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return "", false
+	}
+	pub, err := certPubkey(info.State.PeerCertificates[0])
+	if err != nil {
+		return "", false
+	}
+	return hex.EncodeToString(pub), true
+}
+
+// serverCreds 未开启tls时返回nil
+func (nf *NodeInfo) serverCreds() credentials.TransportCredentials {
+	if nf == nil || nf.tls == nil {
+		return nil
+	}
+	return credentials.NewTLS(nf.tls.serverConfig())
+}
+
+// dialCreds 未开启tls时返回nil
+func (nf *NodeInfo) dialCreds() credentials.TransportCredentials {
+	if nf == nil || nf.tls == nil {
+		return nil
+	}
+	return credentials.NewTLS(nf.tls.clientConfig())
+}
+
+// checkPeerIdentity 开启tls时, 对端声明的节点公钥需要和tls握手的公钥一致
+func (nf *NodeInfo) checkPeerIdentity(authInfo credentials.AuthInfo, pubkey string) error {
+	if nf == nil || nf.tls == nil {
+		return nil
+	}
+	if pub, ok := peerIdentity(authInfo); !ok || pub != strings.ToLower(pubkey) {
+		return errPeerIdentity
+	}
+	return nil
+}
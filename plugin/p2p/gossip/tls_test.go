@@ -0,0 +1,165 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gossip
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+)
+
+func genTestNodeKey(t *testing.T) (string, string) {
+	priv, pub, err := P2pComm.GenPrivPubkey()
+	require.Nil(t, err)
+	return hex.EncodeToString(priv), hex.EncodeToString(pub)
+}
+
+// tlsHandshake 在本地tcp连接上握手, 返回双方看到的对端节点公钥
+func tlsHandshake(t *testing.T, server, client *nodeTLS) (string, string, error, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer ln.Close()
+	type result struct {
+		pub string
+		err error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			resCh <- result{err: err}
+			return
+		}
+		defer conn.Close()
+		srv := tls.Server(conn, server.serverConfig())
+		if err = srv.Handshake(); err != nil {
+			resCh <- result{err: err}
+			return
+		}
+		pub, _ := peerIdentity(credentials.TLSInfo{State: srv.ConnectionState()})
+		resCh <- result{pub: pub}
+		//等待客户端读取完成
+		srv.Write([]byte{1})
+	}()
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	require.Nil(t, err)
+	cli := tls.Client(conn, client.clientConfig())
+	cerr := cli.Handshake()
+	var cpub string
+	if cerr == nil {
+		cpub, _ = peerIdentity(credentials.TLSInfo{State: cli.ConnectionState()})
+		//tls1.3客户端握手完成时服务端可能还在验证客户端证书, 读取一次等待服务端完成
+		buf := make([]byte, 1)
+		cli.Read(buf)
+	}
+	//客户端握手失败时关闭连接, 服务端不再等待
+	conn.Close()
+	res := <-resCh
+	return res.pub, cpub, res.err, cerr
+}
+
+func TestTLSHandshake(t *testing.T) {
+	priv1, pub1 := genTestNodeKey(t)
+	priv2, pub2 := genTestNodeKey(t)
+	key1 := func() (string, string) { return priv1, pub1 }
+	key2 := func() (string, string) { return priv2, pub2 }
+
+	spub, cpub, serr, cerr := tlsHandshake(t, newNodeTLS(key1, nil), newNodeTLS(key2, nil))
+	require.Nil(t, serr)
+	require.Nil(t, cerr)
+	assert.Equal(t, pub2, spub)
+	assert.Equal(t, pub1, cpub)
+
+	//服务端只允许节点1连接
+	_, _, serr, _ = tlsHandshake(t, newNodeTLS(key1, []string{pub1}), newNodeTLS(key2, nil))
+	assert.Equal(t, errPeerNotAllowed, serr)
+	spub, _, serr, cerr = tlsHandshake(t, newNodeTLS(key1, []string{pub2}), newNodeTLS(key2, []string{pub1}))
+	require.Nil(t, serr)
+	require.Nil(t, cerr)
+	assert.Equal(t, pub2, spub)
+
+	//未设置节点密钥时无法握手
+	empty := func() (string, string) { return "", "" }
+	_, _, _, cerr = tlsHandshake(t, newNodeTLS(key1, nil), newNodeTLS(empty, nil))
+	assert.NotNil(t, cerr)
+}
+
+func TestTLSCertificate(t *testing.T) {
+	priv1, pub1 := genTestNodeKey(t)
+	priv2, pub2 := genTestNodeKey(t)
+	privKey, pubKey := priv1, pub1
+	nt := newNodeTLS(func() (string, string) { return privKey, pubKey }, nil)
+	cert, err := nt.certificate()
+	require.Nil(t, err)
+	x509Cert, err := x509.ParseCertificate(cert.Certificate[0])
+	require.Nil(t, err)
+	pub, err := certPubkey(x509Cert)
+	require.Nil(t, err)
+	assert.Equal(t, pub1, hex.EncodeToString(pub))
+	assert.Nil(t, nt.verify(cert.Certificate, nil))
+
+	//节点密钥不变时复用证书, 重新设置后更换证书
+	cert2, err := nt.certificate()
+	require.Nil(t, err)
+	assert.True(t, cert == cert2)
+	privKey, pubKey = priv2, pub2
+	cert2, err = nt.certificate()
+	require.Nil(t, err)
+	assert.False(t, cert == cert2)
+
+	//把节点签名复制到其他密钥的证书中, 验证失败
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	var binding []byte
+	for _, ext := range x509Cert.Extensions {
+		if ext.Id.Equal(nodeKeyExtensionID) {
+			binding = ext.Value
+		}
+	}
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().Add(time.Hour),
+		ExtraExtensions: []pkix.Extension{{Id: nodeKeyExtensionID, Value: binding}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+	assert.Equal(t, errPeerIdentity, nt.verify([][]byte{der}, nil))
+
+	//没有节点签名的证书
+	template.ExtraExtensions = nil
+	der, err = x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+	assert.Equal(t, errPeerIdentity, nt.verify([][]byte{der}, nil))
+}
+
+func TestCheckPeerIdentity(t *testing.T) {
+	nf := &NodeInfo{}
+	assert.Nil(t, nf.checkPeerIdentity(nil, "any"))
+
+	priv1, pub1 := genTestNodeKey(t)
+	nf.tls = newNodeTLS(func() (string, string) { return priv1, pub1 }, nil)
+	assert.Equal(t, errPeerIdentity, nf.checkPeerIdentity(nil, pub1))
+	cert, err := nf.tls.certificate()
+	require.Nil(t, err)
+	x509Cert, err := x509.ParseCertificate(cert.Certificate[0])
+	require.Nil(t, err)
+	info := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{x509Cert}}}
+	assert.Nil(t, nf.checkPeerIdentity(info, pub1))
+	_, pub2 := genTestNodeKey(t)
+	assert.Equal(t, errPeerIdentity, nf.checkPeerIdentity(info, pub2))
+}
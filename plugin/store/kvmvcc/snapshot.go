@@ -0,0 +1,52 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccdb
+
+import (
+	"io"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/plugin/plugin/store/snapshot"
+)
+
+// ExportSnapshot export all kvs at statehash to w, the latest statehash is used when statehash is nil,
+// blocks fills the blocks of the header when it is not nil
+func (mvccs *KVMVCCStore) ExportSnapshot(statehash []byte, w io.Writer, blocks snapshot.BlockLoader) (*snapshot.Header, error) {
+	hash, version, err := snapshot.ResolveVersion(mvccs.mvcc, statehash)
+	if err != nil {
+		return nil, err
+	}
+	header := &snapshot.Header{StateHash: hash, Height: version}
+	if blocks != nil {
+		if err := blocks(header); err != nil {
+			return nil, err
+		}
+	}
+	sw, err := snapshot.NewWriter(w, header)
+	if err != nil {
+		return nil, err
+	}
+	err = snapshot.ExportMVCC(mvccs.GetDB(), mvccs.mvcc, version, sw)
+	if err != nil {
+		return nil, err
+	}
+	klog.Info("KVMVCCStore ExportSnapshot", "hash", common.ToHex(hash), "height", version, "kvs", sw.Count())
+	return header, sw.Close()
+}
+
+// ImportSnapshot import the snapshot into an empty store, blocks can be executed from the next height
+func (mvccs *KVMVCCStore) ImportSnapshot(r io.Reader) (*snapshot.Header, error) {
+	sr, err := snapshot.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	count, err := snapshot.ImportMVCC(mvccs.GetDB(), mvccs.mvcc, mvccs.enableMVCCIter, sr)
+	if err != nil {
+		return nil, err
+	}
+	header := sr.Header()
+	klog.Info("KVMVCCStore ImportSnapshot", "hash", common.ToHex(header.StateHash), "height", header.Height, "kvs", count)
+	return header, nil
+}
@@ -0,0 +1,92 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccdb
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	storeCfg, sub := newStoreCfgIter(dir)
+	store := New(storeCfg, sub, nil).(*KVMVCCStore)
+	defer store.Close()
+
+	hash := drivers.EmptyRoot[:]
+	var hashes [][]byte
+	for i := 0; i < 5; i++ {
+		kv := []*types.KeyValue{
+			{Key: []byte(fmt.Sprintf("k%d", i)), Value: []byte(fmt.Sprintf("v%d", i))},
+			{Key: []byte("k"), Value: []byte(fmt.Sprintf("v%d", i))},
+		}
+		hash, err = store.Set(&types.StoreSet{StateHash: hash, KV: kv, Height: int64(i)}, true)
+		require.NoError(t, err)
+		hashes = append(hashes, hash)
+	}
+
+	//导出历史高度, 头部中的区块信息由调用者填充
+	var buf bytes.Buffer
+	blocks := func(header *snapshot.Header) error {
+		header.TotalDifficulty = []byte{1}
+		return nil
+	}
+	header, err := store.ExportSnapshot(hashes[2], &buf, blocks)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), header.Height)
+	require.Equal(t, hashes[2], header.StateHash)
+
+	//导出最新高度
+	var latest bytes.Buffer
+	header, err = store.ExportSnapshot(nil, &latest, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), header.Height)
+	require.Equal(t, hashes[4], header.StateHash)
+
+	dir2, err := ioutil.TempDir("", "example")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir2)
+	storeCfg2, sub2 := newStoreCfgIter(dir2)
+	store2 := New(storeCfg2, sub2, nil).(*KVMVCCStore)
+	defer store2.Close()
+
+	header, err = store2.ImportSnapshot(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), header.Height)
+	require.Equal(t, []byte{1}, header.TotalDifficulty)
+	keys := [][]byte{[]byte("k0"), []byte("k2"), []byte("k3"), []byte("k")}
+	values := store2.Get(&types.StoreGet{StateHash: hashes[2], Keys: keys})
+	require.Equal(t, [][]byte{[]byte("v0"), []byte("v2"), nil, []byte("v2")}, values)
+
+	//不能重复导入
+	_, err = store2.ImportSnapshot(bytes.NewReader(latest.Bytes()))
+	require.Equal(t, snapshot.ErrStoreNotEmpty, err)
+
+	//从下一个高度继续执行
+	kv := []*types.KeyValue{{Key: []byte("k"), Value: []byte("v3")}}
+	hash, err = store2.MemSet(&types.StoreSet{StateHash: hashes[2], KV: kv, Height: 3}, true)
+	require.NoError(t, err)
+	_, err = store2.Commit(&types.ReqHash{Hash: hash})
+	require.NoError(t, err)
+	values = store2.Get(&types.StoreGet{StateHash: hash, Keys: keys})
+	require.Equal(t, [][]byte{[]byte("v0"), []byte("v2"), nil, []byte("v3")}, values)
+
+	var got []string
+	store2.IterateRangeByStateHash(hash, []byte("k"), nil, true, func(key, value []byte) bool {
+		got = append(got, string(key)+"="+string(value))
+		return false
+	})
+	require.Equal(t, []string{"k=v3", "k0=v0", "k1=v1", "k2=v2"}, got)
+}
@@ -0,0 +1,41 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commands
+
+import (
+	"github.com/33cn/chain33/rpc/jsonclient"
+	kty "github.com/33cn/plugin/plugin/store/kvmvccmavl/types"
+	"github.com/spf13/cobra"
+)
+
+// KvmvccmavlCmd kvmvccmavl cmd register
+func KvmvccmavlCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kvmvccmavl",
+		Short: "Query kvmvccmavl store",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		StatusCmd(),
+	)
+	return cmd
+}
+
+// StatusCmd get kvmvccmavl store status
+func StatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Get fork height and background job status of kvmvccmavl store",
+		Run:   storeStatus,
+	}
+	return cmd
+}
+
+func storeStatus(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	var res kty.StoreStatus
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, kty.KvmvccmavlX+".GetStoreStatus", nil, &res)
+	ctx.Run()
+}
@@ -0,0 +1,71 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccmavl
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// jobs 存储实例的后台任务, 关闭存储时通知所有任务退出并等待
+type jobs struct {
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+	quit   chan struct{}
+	closed bool
+}
+
+func newJobs() *jobs {
+	return &jobs{quit: make(chan struct{})}
+}
+
+// run 启动一个后台任务, 关闭之后不再启动新的任务
+func (j *jobs) run(f func()) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closed {
+		return
+	}
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+		f()
+	}()
+}
+
+// quitting 后台任务在每一批数据之间检查, 收到退出通知后尽快返回
+func (j *jobs) quitting() bool {
+	select {
+	case <-j.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+func (j *jobs) stop() {
+	j.mu.Lock()
+	if !j.closed {
+		j.closed = true
+		close(j.quit)
+	}
+	j.mu.Unlock()
+	j.wg.Wait()
+}
+
+// flag 后台任务和区块执行同时访问的状态
+type flag int32
+
+func (f *flag) get() bool {
+	return atomic.LoadInt32((*int32)(f)) == 1
+}
+
+func (f *flag) set(v bool) {
+	var i int32
+	if v {
+		i = 1
+	}
+	atomic.StoreInt32((*int32)(f), i)
+}
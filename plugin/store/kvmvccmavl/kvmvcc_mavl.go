@@ -9,7 +9,6 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"sync"
 	"sync/atomic"
 
 	"time"
@@ -21,29 +20,23 @@ import (
 	drivers "github.com/33cn/chain33/system/store"
 	mavl "github.com/33cn/chain33/system/store/mavl/db"
 	"github.com/33cn/chain33/types"
+	kty "github.com/33cn/plugin/plugin/store/kvmvccmavl/types"
 	lru "github.com/hashicorp/golang-lru"
 )
 
 var (
 	kmlog = log.New("module", "kvmvccMavl")
 	// ErrStateHashLost ...
-	ErrStateHashLost         = errors.New("ErrStateHashLost")
-	kvmvccMavlFork     int64 = 200 * 10000
-	isDelMavlData            = false
-	delMavlDataHeight        = kvmvccMavlFork + 10000
-	delMavlDataState   int32
-	wg                 sync.WaitGroup
-	quit               bool
-	isPrunedMavl       bool                       // 是否是被裁剪过的 mavl
-	delPrunedMavlState int32 = delPrunedMavlStart // Upgrade时候删除pruned mavl的状态
-	isCompactDelMavl   bool                       // 是否对删除mavl后压缩
+	ErrStateHashLost = errors.New("ErrStateHashLost")
 )
 
 const (
-	cacheSize         = 2048 //可以缓存2048个roothash, height对
-	batchDataSize     = 1024 * 1024 * 1
-	delMavlStateStart = 1
-	delMavlStateEnd   = 0
+	cacheSize     = 2048 //可以缓存2048个roothash, height对
+	batchDataSize = 1024 * 1024 * 1
+	// 没有配置时的默认分叉高度
+	defaultKvmvccMavlFork int64 = 200 * 10000
+	// 分叉之后再执行这么多个区块开始删除mavl数据
+	delMavlDataDelay = 10000
 
 	delPrunedMavlStart    = 0
 	delPrunedMavlStarting = 1
@@ -75,6 +68,16 @@ type KVmMavlStore struct {
 	*KVMVCCStore
 	*MavlStore
 	cache *lru.Cache
+
+	kvmvccMavlFork    int64
+	delMavlDataHeight int64
+	isPrunedMavl      bool // 是否是被裁剪过的 mavl
+	jobs              *jobs
+	// 以下状态由后台任务修改
+	isDelMavlData      flag
+	isCompactDelMavl   flag  // 是否对删除mavl后压缩
+	delMavlDataState   flag  // 正在删除或者压缩mavl
+	delPrunedMavlState int32 // Upgrade时候删除pruned mavl的状态
 }
 
 type subKVMVCCConfig struct {
@@ -143,33 +146,39 @@ func New(cfg *types.Store, sub []byte, chain33cfg *types.Chain33Config) queue.Mo
 		panic("new KVmMavlStore fail")
 	}
 
-	kvms = &KVmMavlStore{bs, NewKVMVCC(&subKVMVCCcfg, bs.GetDB()),
-		NewMavl(&subMavlcfg, bs.GetDB()), cache}
+	kvmvcc := NewKVMVCC(&subKVMVCCcfg, bs.GetDB())
+	kvms = &KVmMavlStore{
+		BaseStore:      bs,
+		KVMVCCStore:    kvmvcc,
+		MavlStore:      NewMavl(&subMavlcfg, bs.GetDB()),
+		cache:          cache,
+		kvmvccMavlFork: defaultKvmvccMavlFork,
+		jobs:           kvmvcc.jobs,
+	}
 	// 查询是否已经删除mavl
 	_, err = bs.GetDB().Get(genDelMavlKey(mvccPrefix))
 	if err == nil {
-		isDelMavlData = true
+		kvms.isDelMavlData.set(true)
 	}
 	// 查询是否已经压缩过
 	_, err = bs.GetDB().Get(genCompactDelMavlKey(mvccPrefix))
 	if err == nil {
-		isCompactDelMavl = true
+		kvms.isCompactDelMavl.set(true)
 	}
 	// 查询是否是删除裁剪版mavl
-	isPrunedMavl = isPrunedMavlDB(bs.GetDB())
+	kvms.isPrunedMavl = isPrunedMavlDB(bs.GetDB())
 	// 读取fork高度
 	if chain33cfg != nil {
-		kvmvccMavlFork = chain33cfg.GetDappFork("store-kvmvccmavl", "ForkKvmvccmavl")
+		kvms.kvmvccMavlFork = chain33cfg.GetDappFork("store-kvmvccmavl", "ForkKvmvccmavl")
 	}
-	delMavlDataHeight = kvmvccMavlFork + 10000
+	kvms.delMavlDataHeight = kvms.kvmvccMavlFork + delMavlDataDelay
 	bs.SetChild(kvms)
 	return kvms
 }
 
 // Close the KVmMavlStore module
 func (kvmMavls *KVmMavlStore) Close() {
-	quit = true
-	wg.Wait()
+	kvmMavls.jobs.stop()
 	kvmMavls.KVMVCCStore.Close()
 	kvmMavls.MavlStore.Close()
 	kvmMavls.BaseStore.Close()
@@ -178,7 +187,7 @@ func (kvmMavls *KVmMavlStore) Close() {
 
 // Set kvs with statehash to KVmMavlStore
 func (kvmMavls *KVmMavlStore) Set(datas *types.StoreSet, sync bool) ([]byte, error) {
-	if datas.Height < kvmvccMavlFork {
+	if datas.Height < kvmMavls.kvmvccMavlFork {
 		hash, err := kvmMavls.MavlStore.Set(datas, sync)
 		if err != nil {
 			return hash, err
@@ -202,7 +211,7 @@ func (kvmMavls *KVmMavlStore) Set(datas *types.StoreSet, sync bool) ([]byte, err
 	// 仅仅做kvmvcc
 	var hash []byte
 	var err error
-	if kvmMavls.kvmvccCfg.EnableEmptyBlockHandle && datas.Height == kvmvccMavlFork { // kvmvccMavlFork高度下前一个区块需要映射
+	if kvmMavls.kvmvccCfg.EnableEmptyBlockHandle && datas.Height == kvmMavls.kvmvccMavlFork { // kvmvccMavlFork高度下前一个区块需要映射
 		hash, err = kvmMavls.KVMVCCStore.SetRdm(datas, nil, sync)
 	} else {
 		hash, err = kvmMavls.KVMVCCStore.Set(datas, nil, sync)
@@ -234,7 +243,7 @@ func (kvmMavls *KVmMavlStore) Get(datas *types.StoreGet) [][]byte {
 
 // MemSet set kvs to the mem of KVmMavlStore module and return the StateHash
 func (kvmMavls *KVmMavlStore) MemSet(datas *types.StoreSet, sync bool) ([]byte, error) {
-	if datas.Height < kvmvccMavlFork {
+	if datas.Height < kvmMavls.kvmvccMavlFork {
 		hash, err := kvmMavls.MavlStore.MemSet(datas, sync)
 		if err != nil {
 			return hash, err
@@ -259,7 +268,7 @@ func (kvmMavls *KVmMavlStore) MemSet(datas *types.StoreSet, sync bool) ([]byte,
 	// 仅仅做kvmvcc
 	var hash []byte
 	var err error
-	if kvmMavls.kvmvccCfg.EnableEmptyBlockHandle && datas.Height == kvmvccMavlFork { // kvmvccMavlFork高度下前一个区块需要映射
+	if kvmMavls.kvmvccCfg.EnableEmptyBlockHandle && datas.Height == kvmMavls.kvmvccMavlFork { // kvmvccMavlFork高度下前一个区块需要映射
 		hash, err = kvmMavls.KVMVCCStore.MemSetRdm(datas, nil, sync)
 	} else {
 		hash, err = kvmMavls.KVMVCCStore.MemSet(datas, nil, sync)
@@ -268,20 +277,21 @@ func (kvmMavls *KVmMavlStore) MemSet(datas *types.StoreSet, sync bool) ([]byte,
 		kvmMavls.cache.Add(string(hash), datas.Height)
 	}
 	// 删除Mavl数据
-	if datas.Height > delMavlDataHeight && !isDelMavlData && !isDelMavling() {
+	if datas.Height > kvmMavls.delMavlDataHeight && !kvmMavls.isDelMavlData.get() && !kvmMavls.delMavlDataState.get() {
 		// 达到该高度时候，将全局的memTree以及tkCloseCache释放掉
 		mavl.ReleaseGlobalMem()
-		wg.Add(1)
-		go DelMavl(kvmMavls.GetDB())
+		kvmMavls.delMavlDataState.set(true)
+		kvmMavls.jobs.run(kvmMavls.DelMavl)
 	}
 	// 对删除的mavl进行压缩
-	if isDelMavlData && !isCompactDelMavl && !isDelMavling() {
-		go CompactDelMavl(kvmMavls.GetDB())
-		if datas.Height > delMavlDataHeight && datas.Height < delMavlDataHeight*2 {
+	if kvmMavls.isDelMavlData.get() && !kvmMavls.isCompactDelMavl.get() && !kvmMavls.delMavlDataState.get() {
+		kvmMavls.delMavlDataState.set(true)
+		go kvmMavls.CompactDelMavl()
+		if datas.Height > kvmMavls.delMavlDataHeight && datas.Height < kvmMavls.delMavlDataHeight*2 {
 			// 出于对区块链安全的角度阻塞执行区块压缩之发生在固定高度区间内
 			count := 0
 			for {
-				if quit || isCompactDelMavl {
+				if kvmMavls.jobs.quitting() || kvmMavls.isCompactDelMavl.get() {
 					break
 				}
 				if count%100 == 0 {
@@ -298,7 +308,7 @@ func (kvmMavls *KVmMavlStore) MemSet(datas *types.StoreSet, sync bool) ([]byte,
 // Commit kvs in the mem of KVmMavlStore module to state db and return the StateHash
 func (kvmMavls *KVmMavlStore) Commit(req *types.ReqHash) ([]byte, error) {
 	if value, ok := kvmMavls.cache.Get(string(req.Hash)); ok {
-		if value.(int64) < kvmvccMavlFork {
+		if value.(int64) < kvmMavls.kvmvccMavlFork {
 			hash, err := kvmMavls.MavlStore.Commit(req)
 			if err != nil {
 				return hash, err
@@ -314,7 +324,7 @@ func (kvmMavls *KVmMavlStore) Commit(req *types.ReqHash) ([]byte, error) {
 // Rollback kvs in the mem of KVmMavlStore module and return the StateHash
 func (kvmMavls *KVmMavlStore) Rollback(req *types.ReqHash) ([]byte, error) {
 	if value, ok := kvmMavls.cache.Get(string(req.Hash)); ok {
-		if value.(int64) < kvmvccMavlFork {
+		if value.(int64) < kvmMavls.kvmvccMavlFork {
 			hash, err := kvmMavls.MavlStore.Rollback(req)
 			if err != nil {
 				return hash, err
@@ -342,7 +352,7 @@ func (kvmMavls *KVmMavlStore) Rollback(req *types.ReqHash) ([]byte, error) {
 
 // IterateRangeByStateHash travel with Prefix by StateHash  to get the latest version kvs.
 func (kvmMavls *KVmMavlStore) IterateRangeByStateHash(statehash []byte, start []byte, end []byte, ascending bool, fn func(key, value []byte) bool) {
-	if value, ok := kvmMavls.cache.Get(string(statehash)); ok && value.(int64) < kvmvccMavlFork {
+	if value, ok := kvmMavls.cache.Get(string(statehash)); ok && value.(int64) < kvmMavls.kvmvccMavlFork {
 		kvmMavls.MavlStore.IterateRangeByStateHash(statehash, start, end, ascending, fn)
 		return
 	}
@@ -361,16 +371,21 @@ func (kvmMavls *KVmMavlStore) ProcEvent(msg *queue.Message) {
 	if msg == nil {
 		return
 	}
-	msg.ReplyErr("KVmMavlStore", types.ErrActionNotSupport)
+	switch msg.Ty {
+	case kty.EventStoreGetStatus:
+		msg.Reply(queue.NewMessage(0, "", kty.EventStoreGetStatusReply, kvmMavls.Status()))
+	default:
+		msg.ReplyErr("KVmMavlStore", types.ErrActionNotSupport)
+	}
 }
 
 // MemSetUpgrade set kvs to the mem of KVmMavlStore module  not cache the tree and return the StateHash
 func (kvmMavls *KVmMavlStore) MemSetUpgrade(datas *types.StoreSet, sync bool) ([]byte, error) {
-	if datas.Height < kvmvccMavlFork {
+	if datas.Height < kvmMavls.kvmvccMavlFork {
 		var hash []byte
 		var err error
 
-		if isPrunedMavl {
+		if kvmMavls.isPrunedMavl {
 			hash, err = kvmMavls.MavlStore.MemSet(datas, sync)
 			if err != nil {
 				return hash, err
@@ -402,7 +417,7 @@ func (kvmMavls *KVmMavlStore) MemSetUpgrade(datas *types.StoreSet, sync bool) ([
 	// 仅仅做kvmvcc
 	var hash []byte
 	var err error
-	if kvmMavls.kvmvccCfg.EnableEmptyBlockHandle && datas.Height == kvmvccMavlFork { // kvmvccMavlFork高度下前一个区块需要映射
+	if kvmMavls.kvmvccCfg.EnableEmptyBlockHandle && datas.Height == kvmMavls.kvmvccMavlFork { // kvmvccMavlFork高度下前一个区块需要映射
 		hash, err = kvmMavls.KVMVCCStore.MemSetRdm(datas, nil, sync)
 	} else {
 		hash, err = kvmMavls.KVMVCCStore.MemSet(datas, nil, sync)
@@ -417,11 +432,11 @@ func (kvmMavls *KVmMavlStore) MemSetUpgrade(datas *types.StoreSet, sync bool) ([
 func (kvmMavls *KVmMavlStore) CommitUpgrade(req *types.ReqHash) ([]byte, error) {
 	var hash []byte
 	var err error
-	if isPrunedMavl {
+	if kvmMavls.isPrunedMavl {
 		hash, err = kvmMavls.Commit(req)
-		if isNeedDelPrunedMavl() {
-			wg.Add(1)
-			go deletePrunedMavl(kvmMavls.GetDB())
+		if kvmMavls.isNeedDelPrunedMavl() {
+			kvmMavls.setDelPrunedMavl(delPrunedMavlStarting)
+			kvmMavls.jobs.run(kvmMavls.deletePrunedMavl)
 		}
 	} else {
 		hash, err = kvmMavls.KVMVCCStore.CommitUpgrade(req)
@@ -431,7 +446,7 @@ func (kvmMavls *KVmMavlStore) CommitUpgrade(req *types.ReqHash) ([]byte, error)
 
 // Del set kvs to nil with StateHash
 func (kvmMavls *KVmMavlStore) Del(req *types.StoreDel) ([]byte, error) {
-	if req.Height < kvmvccMavlFork {
+	if req.Height < kvmMavls.kvmvccMavlFork {
 		hash, err := kvmMavls.MavlStore.Del(req)
 		if err != nil {
 			return hash, err
@@ -465,32 +480,36 @@ func (kvmMavls *KVmMavlStore) Del(req *types.StoreDel) ([]byte, error) {
 }
 
 // DelMavl 数据库中mavl数据清除
-// 达到kvmvccMavlFork + 100000 后触发清除
-func DelMavl(db dbm.DB) {
-	defer wg.Done()
-	setDelMavl(delMavlStateStart)
-	defer setDelMavl(delMavlStateEnd)
+// 达到kvmvccMavlFork + 10000 后触发清除, 关闭存储时中止, 下次达到条件时重新开始
+func (kvmMavls *KVmMavlStore) DelMavl() {
+	kvmMavls.delMavlDataState.set(true)
+	defer kvmMavls.delMavlDataState.set(false)
 	prefix := ""
 	for {
 		kmlog.Debug("start once del mavl")
 		var loop bool
-		loop, prefix = delMavlData(db, prefix)
+		loop, prefix = kvmMavls.delMavlData(prefix)
 		if !loop {
 			break
 		}
 		kmlog.Debug("end once del mavl")
-		time.Sleep(time.Second * 1)
+		select {
+		case <-kvmMavls.jobs.quit:
+			return
+		case <-time.After(time.Second * 1):
+		}
 	}
 }
 
-func delMavlData(db dbm.DB, prefix string) (bool, string) {
+func (kvmMavls *KVmMavlStore) delMavlData(prefix string) (bool, string) {
+	db := kvmMavls.GetDB()
 	it := db.Iterator([]byte(prefix), types.EmptyValue, false)
 	defer it.Close()
 	batch := db.NewBatch(false)
 	count := 0
 	const onceCount = 50
 	for it.Rewind(); it.Valid(); it.Next() {
-		if quit {
+		if kvmMavls.jobs.quitting() {
 			return false, ""
 		}
 		if !bytes.HasPrefix(it.Key(), mvccPrefix) { // 将非mvcc的mavl数据全部删除
@@ -510,7 +529,7 @@ func delMavlData(db dbm.DB, prefix string) (bool, string) {
 	}
 	batch.Set(genDelMavlKey(mvccPrefix), []byte(""))
 	dbm.MustWrite(batch)
-	isDelMavlData = true
+	kvmMavls.isDelMavlData.set(true)
 	kmlog.Info("DelMavl success")
 	return false, ""
 }
@@ -520,23 +539,18 @@ func genDelMavlKey(prefix []byte) []byte {
 	return []byte(fmt.Sprintf("%s%s", string(prefix), delMavl))
 }
 
-func isDelMavling() bool {
-	return atomic.LoadInt32(&delMavlDataState) == 1
-}
-
-func setDelMavl(state int32) {
-	atomic.StoreInt32(&delMavlDataState, state)
-}
-
-func CompactDelMavl(db dbm.DB) {
-	setDelMavl(delMavlStateStart)
-	defer setDelMavl(delMavlStateEnd)
+// CompactDelMavl 删除mavl之后压缩数据库
+// 压缩不能中途取消, 关闭存储时不等待, 数据库关闭后压缩返回错误, 下次启动时重新压缩
+func (kvmMavls *KVmMavlStore) CompactDelMavl() {
+	kvmMavls.delMavlDataState.set(true)
+	defer kvmMavls.delMavlDataState.set(false)
+	db := kvmMavls.GetDB()
 	// 开始进行压缩处理
 	kmlog.Info("start compact db")
 	err := db.CompactRange(nil, nil)
-	if err == nil {
+	if err == nil && !kvmMavls.jobs.quitting() {
 		db.Set(genCompactDelMavlKey(mvccPrefix), []byte(""))
-		isCompactDelMavl = true
+		kvmMavls.isCompactDelMavl.set(true)
 	}
 	kmlog.Info("end compact db", "error", err)
 }
@@ -546,12 +560,12 @@ func genCompactDelMavlKey(prefix []byte) []byte {
 	return []byte(fmt.Sprintf("%s%s", string(prefix), key))
 }
 
-func isNeedDelPrunedMavl() bool {
-	return atomic.LoadInt32(&delPrunedMavlState) == 0
+func (kvmMavls *KVmMavlStore) isNeedDelPrunedMavl() bool {
+	return atomic.LoadInt32(&kvmMavls.delPrunedMavlState) == delPrunedMavlStart
 }
 
-func setDelPrunedMavl(state int32) {
-	atomic.StoreInt32(&delPrunedMavlState, state)
+func (kvmMavls *KVmMavlStore) setDelPrunedMavl(state int32) {
+	atomic.StoreInt32(&kvmMavls.delPrunedMavlState, state)
 }
 
 func isPrunedMavlDB(db dbm.DB) bool {
@@ -567,15 +581,15 @@ func isPrunedMavlDB(db dbm.DB) bool {
 	return isCommit
 }
 
-func deletePrunedMavl(db dbm.DB) {
-	defer wg.Done()
-	setDelPrunedMavl(delPrunedMavlStarting)
-	defer setDelPrunedMavl(delPruneMavlEnd)
+// deletePrunedMavl 关闭存储时中止, 状态回到未开始, 下次升级时重新删除
+func (kvmMavls *KVmMavlStore) deletePrunedMavl() {
+	kvmMavls.setDelPrunedMavl(delPrunedMavlStarting)
 	prefixS := []string{hashNodePrefix, leafNodePrefix, leafKeyCountPrefix, oldLeafKeyCountPrefix}
 	for _, str := range prefixS {
 		for {
-			stat := deletePrunedMavlData(db, str)
+			stat := kvmMavls.deletePrunedMavlData(str)
 			if stat == 0 {
+				kvmMavls.setDelPrunedMavl(delPrunedMavlStart)
 				return
 			} else if stat == 1 {
 				break
@@ -584,9 +598,11 @@ func deletePrunedMavl(db dbm.DB) {
 			}
 		}
 	}
+	kvmMavls.setDelPrunedMavl(delPruneMavlEnd)
 }
 
-func deletePrunedMavlData(db dbm.DB, prefix string) (status int) {
+func (kvmMavls *KVmMavlStore) deletePrunedMavlData(prefix string) (status int) {
+	db := kvmMavls.GetDB()
 	it := db.Iterator([]byte(prefix), nil, false)
 	defer it.Close()
 	count := 0
@@ -594,7 +610,7 @@ func deletePrunedMavlData(db dbm.DB, prefix string) (status int) {
 	if it.Rewind() && it.Valid() {
 		batch := db.NewBatch(false)
 		for it.Next(); it.Valid(); it.Next() { //第一个不做删除
-			if quit {
+			if kvmMavls.jobs.quitting() {
 				return 0 // quit
 			}
 			batch.Delete(it.Key())
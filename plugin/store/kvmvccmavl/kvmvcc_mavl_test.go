@@ -56,10 +56,7 @@ func TestKvmvccMavlSetGet(t *testing.T) {
 	store := New(storeCfg, nil, nil).(*KVmMavlStore)
 	assert.NotNil(t, store)
 
-	kvmvccMavlFork = 50
-	defer func() {
-		kvmvccMavlFork = 200 * 10000
-	}()
+	store.kvmvccMavlFork = 50
 	hash := drivers.EmptyRoot[:]
 	for i := 0; i < 100; i++ {
 		var kvs []*types.KeyValue
@@ -89,10 +86,7 @@ func TestKvmvccMavlMemSet(t *testing.T) {
 	store := New(storeCfg, nil, nil).(*KVmMavlStore)
 	assert.NotNil(t, store)
 
-	kvmvccMavlFork = 50
-	defer func() {
-		kvmvccMavlFork = 200 * 10000
-	}()
+	store.kvmvccMavlFork = 50
 	hash := drivers.EmptyRoot[:]
 	for i := 0; i < 100; i++ {
 		var kvs []*types.KeyValue
@@ -127,10 +121,7 @@ func TestKvmvccMavlMemSetUpgrade(t *testing.T) {
 	store := New(storeCfg, nil, nil).(*KVmMavlStore)
 	assert.NotNil(t, store)
 
-	kvmvccMavlFork = 50
-	defer func() {
-		kvmvccMavlFork = 200 * 10000
-	}()
+	store.kvmvccMavlFork = 50
 	hash := drivers.EmptyRoot[:]
 	for i := 0; i < 1; i++ {
 		var kvs []*types.KeyValue
@@ -183,10 +174,7 @@ func TestKvmvccMavlCommit(t *testing.T) {
 
 	// 设置分叉高度
 	forkHeight := 100
-	kvmvccMavlFork = int64(forkHeight)
-	defer func() {
-		kvmvccMavlFork = 200 * 10000
-	}()
+	store.kvmvccMavlFork = int64(forkHeight)
 	frontHash := make([]byte, 0, 32)
 	var hash []byte
 	for i := 0; i < 200; i++ {
@@ -254,10 +242,7 @@ func TestKvmvccMavlRollback(t *testing.T) {
 	assert.Equal(t, types.ErrHashNotFound.Error(), err.Error())
 
 	// 分叉之后
-	kvmvccMavlFork = 1
-	defer func() {
-		kvmvccMavlFork = 200 * 10000
-	}()
+	store.kvmvccMavlFork = 1
 
 	hash, err = store.MemSet(datas, true)
 	assert.Nil(t, err)
@@ -310,10 +295,7 @@ func TestKvmvccdbRollbackBatch(t *testing.T) {
 	copy(hash1, hash)
 	store.Commit(req)
 	// 设置分叉高度
-	kvmvccMavlFork = 50
-	defer func() {
-		kvmvccMavlFork = 200 * 10000
-	}()
+	store.kvmvccMavlFork = 50
 	for i := 1; i <= 202; i++ {
 		kvset = nil
 		datas1 := &types.StoreSet{StateHash: hash1, KV: datas.KV, Height: datas.Height + int64(i)}
@@ -460,10 +442,7 @@ func TestIterateRangeByStateHash(t *testing.T) {
 	assert.Equal(t, int64(340000000000), resp.Amount)
 
 	// 设置分叉高度
-	kvmvccMavlFork = 5
-	defer func() {
-		kvmvccMavlFork = 200 * 10000
-	}()
+	store.kvmvccMavlFork = 5
 	fmt.Println("---test case1-2 ---")
 	firstForkHash := drivers.EmptyRoot[:]
 	for i := 1; i <= 10; i++ {
@@ -483,7 +462,7 @@ func TestIterateRangeByStateHash(t *testing.T) {
 		assert.Nil(t, err)
 		req := &types.ReqHash{Hash: hash1}
 		store.Commit(req)
-		if int(kvmvccMavlFork) == i {
+		if int(store.kvmvccMavlFork) == i {
 			firstForkHash = hash1
 		}
 	}
@@ -572,11 +551,10 @@ func TestDelMavlData(t *testing.T) {
 	db.Set([]byte("key11"), []byte("value11"))
 	db.Set([]byte("key22"), []byte("value22"))
 
-	quit = false
 	prefix := ""
 	for {
 		var loop bool
-		loop, prefix = delMavlData(db, prefix)
+		loop, prefix = store.delMavlData(prefix)
 		if !loop {
 			break
 		}
@@ -614,7 +592,7 @@ func TestCompactDelMavl(t *testing.T) {
 	for i := 0; i < 100; i++ {
 		db.Set([]byte(GetRandomString(MaxKeylenth)), []byte(fmt.Sprintf("v%d", i)))
 	}
-	CompactDelMavl(db)
+	store.CompactDelMavl()
 	_, err = db.Get(genCompactDelMavlKey(mvccPrefix))
 	assert.NoError(t, err)
 }
@@ -664,7 +642,7 @@ func TestPruning(t *testing.T) {
 		hashes = append(hashes, hash)
 	}
 
-	pruningMVCC(store.GetDB(), 99, &KVMCCCConfig{PruneHeight: 10})
+	kvmvccStore.pruningMVCC(99)
 
 	//check
 	getDatas := &types.StoreGet{
@@ -760,18 +738,18 @@ func TestDeletePrunedMavl(t *testing.T) {
 	store := New(storeCfg, nil, nil).(*KVmMavlStore)
 	assert.NotNil(t, store)
 
-	deletePrunedMavlData(store.GetDB(), hashNodePrefix)
+	store.deletePrunedMavlData(hashNodePrefix)
 	store.GetDB().Set([]byte(fmt.Sprintln(hashNodePrefix, "123")), []byte("v1"))
 
 	//测试只有一条数据时候, 则不做删除
-	deletePrunedMavlData(store.GetDB(), hashNodePrefix)
+	store.deletePrunedMavlData(hashNodePrefix)
 	v1, err := store.GetDB().Get([]byte(fmt.Sprintln(hashNodePrefix, "123")))
 	require.NoError(t, err)
 	require.Equal(t, v1, []byte("v1"))
 
 	//测试再加入一条数据，即两条时候
 	store.GetDB().Set([]byte(fmt.Sprintln(hashNodePrefix, "123")), []byte("v1"))
-	deletePrunedMavlData(store.GetDB(), hashNodePrefix)
+	store.deletePrunedMavlData(hashNodePrefix)
 
 	v1, err = store.GetDB().Get([]byte(fmt.Sprintln(hashNodePrefix, "456")))
 	require.Error(t, err)
@@ -780,9 +758,10 @@ func TestDeletePrunedMavl(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, v2, []byte("v1"))
 
-	wg.Add(1)
-	go deletePrunedMavl(store.GetDB())
-	wg.Wait()
+	store.setDelPrunedMavl(delPrunedMavlStarting)
+	store.jobs.run(store.deletePrunedMavl)
+	store.Close()
+	require.Equal(t, int32(delPruneMavlEnd), store.delPrunedMavlState)
 }
 
 func TestEmptyBlock(t *testing.T) {
@@ -969,10 +948,7 @@ func TestEmptyBlockForkSet(t *testing.T) {
 	}
 
 	// kvmvccMavlFork = 5 加一个分叉高度测试
-	kvmvccMavlFork = 5
-	defer func() {
-		kvmvccMavlFork = 200 * 10000
-	}()
+	store.kvmvccMavlFork = 5
 
 	datas := &types.StoreSet{
 		StateHash: drivers.EmptyRoot[:],
@@ -1028,10 +1004,7 @@ func TestEmptyBlockForkMemSet(t *testing.T) {
 	}
 
 	// kvmvccMavlFork = 5 加一个分叉高度测试
-	kvmvccMavlFork = 5
-	defer func() {
-		kvmvccMavlFork = 200 * 10000
-	}()
+	store.kvmvccMavlFork = 5
 
 	datas := &types.StoreSet{
 		StateHash: drivers.EmptyRoot[:],
@@ -1076,10 +1049,7 @@ func benchmarkGet(b *testing.B, isResetForkHeight bool) {
 	assert.NotNil(b, store)
 
 	if isResetForkHeight {
-		kvmvccMavlFork = 0
-		defer func() {
-			kvmvccMavlFork = 200 * 10000
-		}()
+		store.kvmvccMavlFork = 0
 	}
 
 	var kv []*types.KeyValue
@@ -1125,17 +1095,14 @@ func benchmarkStoreGetKvs4N(b *testing.B, isResetForkHeight bool) {
 	defer os.RemoveAll(dir) // clean up
 	os.RemoveAll(dir)       //删除已存在目录
 
-	if isResetForkHeight {
-		kvmvccMavlFork = 0
-		defer func() {
-			kvmvccMavlFork = 200 * 10000
-		}()
-	}
-
 	var storeCfg = newStoreCfg(dir)
 	store := New(storeCfg, nil, nil).(*KVmMavlStore)
 	assert.NotNil(b, store)
 
+	if isResetForkHeight {
+		store.kvmvccMavlFork = 0
+	}
+
 	var kv []*types.KeyValue
 	var key string
 	var value string
@@ -1185,10 +1152,7 @@ func benchmarkStoreGetKvsForNN(b *testing.B, isResetForkHeight bool) {
 	assert.NotNil(b, store)
 
 	if isResetForkHeight {
-		kvmvccMavlFork = 0
-		defer func() {
-			kvmvccMavlFork = 200 * 10000
-		}()
+		store.kvmvccMavlFork = 0
 	}
 
 	var kv []*types.KeyValue
@@ -1255,10 +1219,7 @@ func benchmarkStoreGetKvsFor10000(b *testing.B, isResetForkHeight bool) {
 	assert.NotNil(b, store)
 
 	if isResetForkHeight {
-		kvmvccMavlFork = 0
-		defer func() {
-			kvmvccMavlFork = 200 * 10000
-		}()
+		store.kvmvccMavlFork = 0
 	}
 
 	var kv []*types.KeyValue
@@ -1330,10 +1291,7 @@ func benchmarkGetIter(b *testing.B, isResetForkHeight bool) {
 	assert.NotNil(b, store)
 
 	if isResetForkHeight {
-		kvmvccMavlFork = 0
-		defer func() {
-			kvmvccMavlFork = 200 * 10000
-		}()
+		store.kvmvccMavlFork = 0
 	}
 
 	var kv []*types.KeyValue
@@ -1384,10 +1342,7 @@ func benchmarkSet(b *testing.B, isResetForkHeight bool) {
 	b.Log(dir)
 
 	if isResetForkHeight {
-		kvmvccMavlFork = 0
-		defer func() {
-			kvmvccMavlFork = 200 * 10000
-		}()
+		store.kvmvccMavlFork = 0
 	}
 
 	var kv []*types.KeyValue
@@ -1430,10 +1385,7 @@ func benchmarkStoreSet(b *testing.B, isResetForkHeight bool) {
 	assert.NotNil(b, store)
 
 	if isResetForkHeight {
-		kvmvccMavlFork = 0
-		defer func() {
-			kvmvccMavlFork = 200 * 10000
-		}()
+		store.kvmvccMavlFork = 0
 	}
 
 	var kv []*types.KeyValue
@@ -1476,10 +1428,7 @@ func benchmarkSetIter(b *testing.B, isResetForkHeight bool) {
 	b.Log(dir)
 
 	if isResetForkHeight {
-		kvmvccMavlFork = 0
-		defer func() {
-			kvmvccMavlFork = 200 * 10000
-		}()
+		store.kvmvccMavlFork = 0
 	}
 
 	var kv []*types.KeyValue
@@ -1522,10 +1471,7 @@ func benchmarkMemSet(b *testing.B, isResetForkHeight bool) {
 	assert.NotNil(b, store)
 
 	if isResetForkHeight {
-		kvmvccMavlFork = 0
-		defer func() {
-			kvmvccMavlFork = 200 * 10000
-		}()
+		store.kvmvccMavlFork = 0
 	}
 
 	var kv []*types.KeyValue
@@ -1566,10 +1512,7 @@ func benchmarkStoreMemSet(b *testing.B, isResetForkHeight bool) {
 	assert.NotNil(b, store)
 
 	if isResetForkHeight {
-		kvmvccMavlFork = 0
-		defer func() {
-			kvmvccMavlFork = 200 * 10000
-		}()
+		store.kvmvccMavlFork = 0
 	}
 
 	var kv []*types.KeyValue
@@ -1614,10 +1557,7 @@ func benchmarkCommit(b *testing.B, isResetForkHeight bool) {
 	assert.NotNil(b, store)
 
 	if isResetForkHeight {
-		kvmvccMavlFork = 0
-		defer func() {
-			kvmvccMavlFork = 200 * 10000
-		}()
+		store.kvmvccMavlFork = 0
 	}
 	var kv []*types.KeyValue
 	var key string
@@ -1664,10 +1604,7 @@ func benchmarkStoreCommit(b *testing.B, isResetForkHeight bool) {
 	assert.NotNil(b, store)
 
 	if isResetForkHeight {
-		kvmvccMavlFork = 0
-		defer func() {
-			kvmvccMavlFork = 200 * 10000
-		}()
+		store.kvmvccMavlFork = 0
 	}
 
 	var kv []*types.KeyValue
@@ -1718,10 +1655,7 @@ func benchmarkIterMemSet(b *testing.B, isResetForkHeight bool) {
 	assert.NotNil(b, store)
 
 	if isResetForkHeight {
-		kvmvccMavlFork = 0
-		defer func() {
-			kvmvccMavlFork = 200 * 10000
-		}()
+		store.kvmvccMavlFork = 0
 	}
 
 	var kv []*types.KeyValue
@@ -1761,10 +1695,7 @@ func benchmarkIterCommit(b *testing.B, isResetForkHeight bool) {
 	assert.NotNil(b, store)
 
 	if isResetForkHeight {
-		kvmvccMavlFork = 0
-		defer func() {
-			kvmvccMavlFork = 200 * 10000
-		}()
+		store.kvmvccMavlFork = 0
 	}
 
 	var kv []*types.KeyValue
@@ -8,7 +8,6 @@ import (
 	"bytes"
 	"fmt"
 	"strconv"
-	"sync/atomic"
 
 	"time"
 
@@ -20,19 +19,12 @@ import (
 )
 
 const (
-	pruningStateStart  = 1
-	pruningStateEnd    = 0
 	onceScanCount      = 10000 // 单次扫描数目
 	onceCount          = 1000  // 容器长度
 	levelPruningHeight = 100 * 10000
 	defaultPruneHeight = 10000 // 每个10000裁剪一次
 )
 
-var (
-	pruningState int32
-	batch        dbm.Batch
-)
-
 var (
 	//同common/db中的mvcc相关的定义保持一致
 	mvccPrefix = []byte(".-mvcc-.")
@@ -61,11 +53,14 @@ type KVMVCCStore struct {
 	kvsetmap  map[string][]*types.KeyValue
 	sync      bool
 	kvmvccCfg *KVMCCCConfig
+	// 升级时复用的batch
+	upgradeBatch dbm.Batch
+	jobs         *jobs
+	pruning      flag
 }
 
 // NewKVMVCC construct KVMVCCStore module
 func NewKVMVCC(sub *subKVMVCCConfig, db dbm.DB) *KVMVCCStore {
-	var kvs *KVMVCCStore
 	if sub == nil {
 		panic("sub is nil memory")
 	}
@@ -78,10 +73,11 @@ func NewKVMVCC(sub *subKVMVCCConfig, db dbm.DB) *KVMVCCStore {
 		PruneHeight:            sub.PruneHeight,
 		EnableEmptyBlockHandle: sub.EnableEmptyBlockHandle,
 	}
+	kvs := &KVMVCCStore{db: db, kvsetmap: make(map[string][]*types.KeyValue), kvmvccCfg: kvmvccCfg, jobs: newJobs()}
 	if kvmvccCfg.EnableMVCCIter {
-		kvs = &KVMVCCStore{db, dbm.NewMVCCIter(db), make(map[string][]*types.KeyValue), false, kvmvccCfg}
+		kvs.mvcc = dbm.NewMVCCIter(db)
 	} else {
-		kvs = &KVMVCCStore{db, dbm.NewMVCC(db), make(map[string][]*types.KeyValue), false, kvmvccCfg}
+		kvs.mvcc = dbm.NewMVCC(db)
 	}
 	return kvs
 }
@@ -148,11 +144,11 @@ func (mvccs *KVMVCCStore) MemSet(datas *types.StoreSet, hash []byte, sync bool)
 	mvccs.sync = sync
 	// 进行裁剪
 	if mvccs.kvmvccCfg != nil && mvccs.kvmvccCfg.EnableMVCCPrune &&
-		!isPruning() && mvccs.kvmvccCfg.PruneHeight != 0 &&
+		!mvccs.pruning.get() && mvccs.kvmvccCfg.PruneHeight != 0 &&
 		datas.Height%int64(mvccs.kvmvccCfg.PruneHeight) == 0 &&
 		datas.Height/int64(mvccs.kvmvccCfg.PruneHeight) > 1 {
-		wg.Add(1)
-		go pruning(mvccs.db, datas.Height, mvccs.kvmvccCfg)
+		height := datas.Height
+		mvccs.jobs.run(func() { mvccs.pruningMVCC(height) })
 	}
 	return hash, nil
 }
@@ -182,9 +178,10 @@ func (mvccs *KVMVCCStore) CommitUpgrade(req *types.ReqHash) ([]byte, error) {
 		return nil, types.ErrHashNotFound
 	}
 	//kmlog.Debug("KVMVCCStore Commit saveKVSets", "hash", common.ToHex(req.Hash))
-	if batch == nil {
-		batch = mvccs.db.NewBatch(mvccs.sync)
+	if mvccs.upgradeBatch == nil {
+		mvccs.upgradeBatch = mvccs.db.NewBatch(mvccs.sync)
 	}
+	batch := mvccs.upgradeBatch
 	batch.Reset()
 	kvset := mvccs.kvsetmap[string(req.Hash)]
 	for i := 0; i < len(kvset); i++ {
@@ -420,11 +417,11 @@ func (mvccs *KVMVCCStore) MemSetRdm(datas *types.StoreSet, mavlHash []byte, sync
 
 	// 进行裁剪
 	if mvccs.kvmvccCfg != nil && mvccs.kvmvccCfg.EnableMVCCPrune &&
-		!isPruning() && mvccs.kvmvccCfg.PruneHeight != 0 &&
+		!mvccs.pruning.get() && mvccs.kvmvccCfg.PruneHeight != 0 &&
 		datas.Height%int64(mvccs.kvmvccCfg.PruneHeight) == 0 &&
 		datas.Height/int64(mvccs.kvmvccCfg.PruneHeight) > 1 {
-		wg.Add(1)
-		go pruning(mvccs.db, datas.Height, mvccs.kvmvccCfg)
+		height := datas.Height
+		mvccs.jobs.run(func() { mvccs.pruningMVCC(height) })
 	}
 	return hash, nil
 }
@@ -453,21 +450,18 @@ func calcRdmKey(hash []byte, height int64) []byte {
 
 /*裁剪-------------------------------------------*/
 
-func pruning(db dbm.DB, height int64, KVmvccCfg *KVMCCCConfig) {
-	defer wg.Done()
-	pruningMVCC(db, height, KVmvccCfg)
-}
-
-func pruningMVCC(db dbm.DB, height int64, KVmvccCfg *KVMCCCConfig) {
-	setPruning(pruningStateStart)
-	defer setPruning(pruningStateEnd)
+func (mvccs *KVMVCCStore) pruningMVCC(height int64) {
+	mvccs.pruning.set(true)
+	defer mvccs.pruning.set(false)
 	start := time.Now()
-	pruningFirst(db, height, KVmvccCfg)
+	mvccs.pruningFirst(height)
 	end := time.Now()
 	kmlog.Debug("pruningMVCC", "height", height, "cost", end.Sub(start))
 }
 
-func pruningFirst(db dbm.DB, curHeight int64, KVmvccCfg *KVMCCCConfig) {
+func (mvccs *KVMVCCStore) pruningFirst(curHeight int64) {
+	db := mvccs.db
+	KVmvccCfg := mvccs.kvmvccCfg
 	it := db.Iterator(mvccData, nil, true)
 	defer it.Close()
 
@@ -475,7 +469,7 @@ func pruningFirst(db dbm.DB, curHeight int64, KVmvccCfg *KVMCCCConfig) {
 	count := 0
 	batch := db.NewBatch(true)
 	for it.Rewind(); it.Valid(); it.Next() {
-		if quit {
+		if mvccs.jobs.quitting() {
 			//该处退出
 			return
 		}
@@ -557,11 +551,3 @@ func pad(version int64) []byte {
 	s := fmt.Sprintf("%020d", version)
 	return []byte(s)
 }
-
-func isPruning() bool {
-	return atomic.LoadInt32(&pruningState) == 1
-}
-
-func setPruning(state int32) {
-	atomic.StoreInt32(&pruningState, state)
-}
@@ -0,0 +1,24 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccmavl
+
+import (
+	"github.com/33cn/chain33/pluginmgr"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/kvmvccmavl/commands"
+	"github.com/33cn/plugin/plugin/store/kvmvccmavl/rpc"
+	kty "github.com/33cn/plugin/plugin/store/kvmvccmavl/types"
+)
+
+func init() {
+	pluginmgr.Register(&pluginmgr.PluginBase{
+		Name:     kty.KvmvccmavlX,
+		ExecName: kty.KvmvccmavlX,
+		//存储没有执行器, 只注册查询状态的rpc和命令行
+		Exec: func(name string, cfg *types.Chain33Config, sub []byte) {},
+		Cmd:  commands.KvmvccmavlCmd,
+		RPC:  rpc.Init,
+	})
+}
@@ -0,0 +1,40 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/33cn/chain33/types"
+	kty "github.com/33cn/plugin/plugin/store/kvmvccmavl/types"
+)
+
+// GetStoreStatus kvmvccmavl存储的分叉高度和后台任务状态, 其他存储返回ErrActionNotSupport
+func (c *channelClient) GetStoreStatus(ctx context.Context, req *types.ReqNil) (*kty.StoreStatus, error) {
+	msg := c.client.NewMessage("store", kty.EventStoreGetStatus, req)
+	err := c.client.Send(msg, true)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Wait(msg)
+	if err != nil {
+		return nil, err
+	}
+	status, ok := resp.GetData().(*kty.StoreStatus)
+	if !ok {
+		return nil, types.ErrTypeAsset
+	}
+	return status, nil
+}
+
+// GetStoreStatus kvmvccmavl存储的分叉高度和后台任务状态
+func (c *Jrpc) GetStoreStatus(req *types.ReqNil, result *interface{}) error {
+	data, err := c.cli.GetStoreStatus(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	*result = data
+	return nil
+}
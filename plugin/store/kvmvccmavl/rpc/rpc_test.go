@@ -0,0 +1,43 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	kty "github.com/33cn/plugin/plugin/store/kvmvccmavl/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStoreStatus(t *testing.T) {
+	q := queue.New("channel")
+	defer q.Close()
+	status := &kty.StoreStatus{ForkHeight: 5, DelMavlDataHeight: 10005, MavlDeleted: true}
+	//模拟store模块, 其他存储不支持查询状态
+	go func() {
+		cli := q.Client()
+		cli.Sub("store")
+		for msg := range cli.Recv() {
+			if msg.Ty == kty.EventStoreGetStatus {
+				msg.Reply(cli.NewMessage("", kty.EventStoreGetStatusReply, status))
+				continue
+			}
+			msg.ReplyErr("store", types.ErrActionNotSupport)
+		}
+	}()
+	jrpc := &Jrpc{cli: &channelClient{client: q.Client()}}
+
+	reply, err := jrpc.cli.GetStoreStatus(context.Background(), &types.ReqNil{})
+	require.Nil(t, err)
+	assert.Equal(t, status, reply)
+	var result interface{}
+	err = jrpc.GetStoreStatus(&types.ReqNil{}, &result)
+	require.Nil(t, err)
+	assert.Equal(t, status, result)
+}
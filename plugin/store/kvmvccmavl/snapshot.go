@@ -0,0 +1,83 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccmavl
+
+import (
+	"errors"
+	"io"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/plugin/plugin/store/snapshot"
+)
+
+var (
+	// ErrSnapshotBeforeFork 快照高度在kvmvccMavlFork之前, 导入后缺少mavl数据
+	ErrSnapshotBeforeFork = errors.New("ErrSnapshotBeforeFork")
+)
+
+// ExportSnapshot 导出状态根对应的所有kv, 状态根为空时导出最新的状态, blocks不为空时在头部写入区块
+func (kvmMavls *KVmMavlStore) ExportSnapshot(statehash []byte, w io.Writer, blocks snapshot.BlockLoader) (*snapshot.Header, error) {
+	hash := statehash
+	if len(statehash) > 0 && kvmMavls.kvmvccCfg.EnableEmptyBlockHandle {
+		mvccHash, err := kvmMavls.KVMVCCStore.GetFirstHashRdm(statehash)
+		if err == nil {
+			hash = mvccHash
+		}
+	}
+	hash, version, err := snapshot.ResolveVersion(kvmMavls.mvcc, hash)
+	if err != nil {
+		return nil, err
+	}
+	//头部记录区块中的状态根, 导入后可以直接作为下一个区块的前一个状态根
+	if len(statehash) > 0 {
+		hash = statehash
+	}
+	header := &snapshot.Header{StateHash: hash, Height: version}
+	if blocks != nil {
+		if err := blocks(header); err != nil {
+			return nil, err
+		}
+	}
+	sw, err := snapshot.NewWriter(w, header)
+	if err != nil {
+		return nil, err
+	}
+	err = snapshot.ExportMVCC(kvmMavls.GetDB(), kvmMavls.mvcc, version, sw)
+	if err != nil {
+		return nil, err
+	}
+	kmlog.Info("KVmMavlStore ExportSnapshot", "hash", common.ToHex(hash), "height", version, "kvs", sw.Count())
+	return header, sw.Close()
+}
+
+// ImportSnapshot 把快照导入到空的存储中, 之后从快照的下一个高度开始执行区块
+// 只支持kvmvccMavlFork之后的快照, 导入后不再需要mavl数据
+func (kvmMavls *KVmMavlStore) ImportSnapshot(r io.Reader) (*snapshot.Header, error) {
+	sr, err := snapshot.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	header := sr.Header()
+	if header.Height < kvmMavls.kvmvccMavlFork {
+		return nil, ErrSnapshotBeforeFork
+	}
+	count, err := snapshot.ImportMVCC(kvmMavls.GetDB(), kvmMavls.mvcc, kvmMavls.kvmvccCfg.EnableMVCCIter, sr)
+	if err != nil {
+		return nil, err
+	}
+	//没有mavl数据, 标记为已经删除并压缩, 避免后续区块触发删除mavl
+	batch := kvmMavls.GetDB().NewBatch(true)
+	batch.Set(genDelMavlKey(mvccPrefix), []byte(""))
+	batch.Set(genCompactDelMavlKey(mvccPrefix), []byte(""))
+	err = batch.Write()
+	if err != nil {
+		return nil, err
+	}
+	kvmMavls.isDelMavlData.set(true)
+	kvmMavls.isCompactDelMavl.set(true)
+	kvmMavls.cache.Add(string(header.StateHash), header.Height)
+	kmlog.Info("KVmMavlStore ImportSnapshot", "hash", common.ToHex(header.StateHash), "height", header.Height, "kvs", count)
+	return header, nil
+}
@@ -0,0 +1,86 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccmavl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	sub, err := json.Marshal(&subConfig{EnableMVCCIter: true, EnableEmptyBlockHandle: true})
+	require.NoError(t, err)
+	store := New(newStoreCfg(dir), sub, nil).(*KVmMavlStore)
+
+	store.kvmvccMavlFork = 3
+
+	hash := drivers.EmptyRoot[:]
+	var hashes [][]byte
+	for i := 0; i < 6; i++ {
+		kv := []*types.KeyValue{
+			{Key: []byte(fmt.Sprintf("k%d", i)), Value: []byte(fmt.Sprintf("v%d", i))},
+			{Key: []byte("k"), Value: []byte(fmt.Sprintf("v%d", i))},
+		}
+		hash, err = store.Set(&types.StoreSet{StateHash: hash, KV: kv, Height: int64(i)}, true)
+		require.NoError(t, err)
+		hashes = append(hashes, hash)
+	}
+
+	//分叉之前的快照可以导出, 但是不能导入
+	var before bytes.Buffer
+	header, err := store.ExportSnapshot(hashes[1], &before, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), header.Height)
+	require.Equal(t, hashes[1], header.StateHash)
+
+	var buf bytes.Buffer
+	header, err = store.ExportSnapshot(hashes[4], &buf, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), header.Height)
+	store.Close()
+
+	dir2, err := ioutil.TempDir("", "example")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir2)
+	store2 := New(newStoreCfg(dir2), sub, nil).(*KVmMavlStore)
+	defer store2.Close()
+	store2.kvmvccMavlFork = 3
+
+	_, err = store2.ImportSnapshot(bytes.NewReader(before.Bytes()))
+	require.Equal(t, ErrSnapshotBeforeFork, err)
+
+	header, err = store2.ImportSnapshot(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, hashes[4], header.StateHash)
+	require.True(t, store2.isDelMavlData.get())
+	require.True(t, store2.isCompactDelMavl.get())
+	keys := [][]byte{[]byte("k0"), []byte("k4"), []byte("k5"), []byte("k")}
+	values := store2.Get(&types.StoreGet{StateHash: hashes[4], Keys: keys})
+	require.Equal(t, [][]byte{[]byte("v0"), []byte("v4"), nil, []byte("v4")}, values)
+
+	//从下一个高度继续执行, 状态根和原来的节点一致
+	kv := []*types.KeyValue{
+		{Key: []byte("k5"), Value: []byte("v5")},
+		{Key: []byte("k"), Value: []byte("v5")},
+	}
+	hash, err = store2.MemSet(&types.StoreSet{StateHash: hashes[4], KV: kv, Height: 5}, true)
+	require.NoError(t, err)
+	require.Equal(t, hashes[5], hash)
+	_, err = store2.Commit(&types.ReqHash{Hash: hash})
+	require.NoError(t, err)
+	values = store2.Get(&types.StoreGet{StateHash: hash, Keys: keys})
+	require.Equal(t, [][]byte{[]byte("v0"), []byte("v4"), []byte("v5"), []byte("v5")}, values)
+}
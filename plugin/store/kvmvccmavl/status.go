@@ -0,0 +1,28 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccmavl
+
+import (
+	"sync/atomic"
+
+	kty "github.com/33cn/plugin/plugin/store/kvmvccmavl/types"
+)
+
+// Status 获取存储实例的状态
+func (kvmMavls *KVmMavlStore) Status() *kty.StoreStatus {
+	delPruned := atomic.LoadInt32(&kvmMavls.delPrunedMavlState)
+	return &kty.StoreStatus{
+		ForkHeight:           kvmMavls.kvmvccMavlFork,
+		DelMavlDataHeight:    kvmMavls.delMavlDataHeight,
+		PrunedMavl:           kvmMavls.isPrunedMavl,
+		MavlDeleted:          kvmMavls.isDelMavlData.get(),
+		MavlCompacted:        kvmMavls.isCompactDelMavl.get(),
+		DelMavlRunning:       kvmMavls.delMavlDataState.get(),
+		DelPrunedMavlRunning: delPruned == delPrunedMavlStarting,
+		DelPrunedMavlDone:    delPruned == delPruneMavlEnd,
+		MvccPruning:          kvmMavls.KVMVCCStore.pruning.get(),
+		Closed:               kvmMavls.jobs.quitting(),
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccmavl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/33cn/chain33/queue"
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+	kty "github.com/33cn/plugin/plugin/store/kvmvccmavl/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T, fork int64) (*KVmMavlStore, func()) {
+	dir, err := ioutil.TempDir("", "example")
+	require.NoError(t, err)
+	store := New(newStoreCfg(dir), nil, nil).(*KVmMavlStore)
+	store.kvmvccMavlFork = fork
+	store.delMavlDataHeight = fork + delMavlDataDelay
+	return store, func() {
+		store.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func setBlocks(t *testing.T, store *KVmMavlStore, n int) [][]byte {
+	hash := drivers.EmptyRoot[:]
+	var hashes [][]byte
+	for i := 0; i < n; i++ {
+		kv := []*types.KeyValue{{Key: []byte(fmt.Sprintf("k%d", i)), Value: []byte(fmt.Sprintf("v%d", i))}}
+		var err error
+		hash, err = store.Set(&types.StoreSet{StateHash: hash, KV: kv, Height: int64(i)}, true)
+		require.NoError(t, err)
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+func TestInstanceState(t *testing.T) {
+	//不同分叉高度的两个存储互不影响
+	store1, close1 := newTestStore(t, 5)
+	store2, close2 := newTestStore(t, defaultKvmvccMavlFork)
+	defer close2()
+
+	hashes1 := setBlocks(t, store1, 10)
+	hashes2 := setBlocks(t, store2, 10)
+	require.Equal(t, hashes1[:5], hashes2[:5])
+	require.NotEqual(t, hashes1[5], hashes2[5])
+	require.Equal(t, int64(5), store1.Status().ForkHeight)
+	require.Equal(t, defaultKvmvccMavlFork, store2.Status().ForkHeight)
+
+	for i := 0; i < 20; i++ {
+		store1.GetDB().Set([]byte(fmt.Sprintf("mavl%d", i)), []byte("v"))
+	}
+	store1.DelMavl()
+	require.True(t, store1.Status().MavlDeleted)
+	require.False(t, store2.Status().MavlDeleted)
+
+	//关闭一个存储之后, 另一个存储的后台任务不受影响
+	close1()
+	require.True(t, store1.Status().Closed)
+	require.False(t, store2.Status().Closed)
+	store2.GetDB().Set([]byte("mavl"), []byte("v"))
+	store2.DelMavl()
+	require.True(t, store2.Status().MavlDeleted)
+	_, err := store2.GetDB().Get([]byte("mavl"))
+	require.Error(t, err)
+}
+
+func TestParallelStores(t *testing.T) {
+	for i := 0; i < 4; i++ {
+		fork := int64(i * 3)
+		t.Run(fmt.Sprintf("fork%d", fork), func(t *testing.T) {
+			t.Parallel()
+			store, closeStore := newTestStore(t, fork)
+			defer closeStore()
+			hashes := setBlocks(t, store, 12)
+			values := store.Get(&types.StoreGet{StateHash: hashes[11], Keys: [][]byte{[]byte("k0"), []byte("k11")}})
+			require.Equal(t, [][]byte{[]byte("v0"), []byte("v11")}, values)
+			require.Equal(t, fork, store.Status().ForkHeight)
+		})
+	}
+}
+
+func TestCancelJobs(t *testing.T) {
+	store, closeStore := newTestStore(t, 0)
+	defer closeStore()
+
+	for i := 0; i < 100; i++ {
+		store.GetDB().Set([]byte(fmt.Sprintf("%s%d", hashNodePrefix, i)), []byte("v"))
+	}
+	//关闭之后删除任务立即退出, 状态回到未开始
+	store.jobs.stop()
+	require.True(t, store.Status().Closed)
+	store.setDelPrunedMavl(delPrunedMavlStarting)
+	store.deletePrunedMavl()
+	status := store.Status()
+	require.False(t, status.DelPrunedMavlRunning)
+	require.False(t, status.DelPrunedMavlDone)
+	_, err := store.GetDB().Get([]byte(fmt.Sprintf("%s%d", hashNodePrefix, 99)))
+	require.NoError(t, err)
+
+	store.delMavlData("")
+	require.False(t, store.Status().MavlDeleted)
+
+	//关闭之后不再启动新的任务
+	started := false
+	store.jobs.run(func() { started = true })
+	store.jobs.stop()
+	require.False(t, started)
+}
+
+func TestGetStoreStatus(t *testing.T) {
+	store, closeStore := newTestStore(t, 5)
+	defer closeStore()
+	q := queue.New("channel")
+	client := q.Client()
+
+	msg := client.NewMessage("store", kty.EventStoreGetStatus, &types.ReqNil{})
+	store.ProcEvent(msg)
+	resp, err := client.Wait(msg)
+	require.NoError(t, err)
+	status := resp.GetData().(*kty.StoreStatus)
+	require.Equal(t, int64(5), status.ForkHeight)
+	require.Equal(t, int64(5+delMavlDataDelay), status.DelMavlDataHeight)
+	require.False(t, status.MavlDeleted)
+	require.False(t, status.Closed)
+}
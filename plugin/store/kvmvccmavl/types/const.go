@@ -0,0 +1,16 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+// KvmvccmavlX kvmvccmavl存储的插件名, 只注册rpc和命令行
+const KvmvccmavlX = "kvmvccmavl"
+
+// store模块把chain33没有定义的消息交给存储的ProcEvent, 下面的消息只由kvmvccmavl存储处理
+const (
+	// EventStoreGetStatus 获取kvmvccmavl存储的分叉高度和后台任务状态, 回复为StoreStatus
+	EventStoreGetStatus = 2011 + iota
+	// EventStoreGetStatusReply 存储状态的回复
+	EventStoreGetStatusReply
+)
@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: kvmvccmavl.proto
+
+package types
+
+import (
+	context "context"
+	fmt "fmt"
+	math "math"
+
+	types "github.com/33cn/chain33/types"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// StoreStatus kvmvccmavl存储的分叉高度和后台任务状态
+type StoreStatus struct {
+	ForkHeight        int64 `protobuf:"varint,1,opt,name=forkHeight,proto3" json:"forkHeight,omitempty"`
+	DelMavlDataHeight int64 `protobuf:"varint,2,opt,name=delMavlDataHeight,proto3" json:"delMavlDataHeight,omitempty"`
+	//数据库中是裁剪版的mavl, 升级时需要删除
+	PrunedMavl bool `protobuf:"varint,3,opt,name=prunedMavl,proto3" json:"prunedMavl,omitempty"`
+	//mavl数据已经删除
+	MavlDeleted bool `protobuf:"varint,4,opt,name=mavlDeleted,proto3" json:"mavlDeleted,omitempty"`
+	//删除mavl之后已经压缩数据库
+	MavlCompacted bool `protobuf:"varint,5,opt,name=mavlCompacted,proto3" json:"mavlCompacted,omitempty"`
+	//正在删除mavl数据或者压缩数据库
+	DelMavlRunning bool `protobuf:"varint,6,opt,name=delMavlRunning,proto3" json:"delMavlRunning,omitempty"`
+	//正在删除裁剪版的mavl
+	DelPrunedMavlRunning bool `protobuf:"varint,7,opt,name=delPrunedMavlRunning,proto3" json:"delPrunedMavlRunning,omitempty"`
+	//裁剪版的mavl已经删除
+	DelPrunedMavlDone bool `protobuf:"varint,8,opt,name=delPrunedMavlDone,proto3" json:"delPrunedMavlDone,omitempty"`
+	//正在裁剪mvcc的历史版本
+	MvccPruning bool `protobuf:"varint,9,opt,name=mvccPruning,proto3" json:"mvccPruning,omitempty"`
+	//存储已经关闭, 后台任务不再执行
+	Closed               bool     `protobuf:"varint,10,opt,name=closed,proto3" json:"closed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoreStatus) Reset()         { *m = StoreStatus{} }
+func (m *StoreStatus) String() string { return proto.CompactTextString(m) }
+func (*StoreStatus) ProtoMessage()    {}
+func (*StoreStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9782979fd5374d0f, []int{0}
+}
+
+func (m *StoreStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoreStatus.Unmarshal(m, b)
+}
+func (m *StoreStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoreStatus.Marshal(b, m, deterministic)
+}
+func (m *StoreStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreStatus.Merge(m, src)
+}
+func (m *StoreStatus) XXX_Size() int {
+	return xxx_messageInfo_StoreStatus.Size(m)
+}
+func (m *StoreStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreStatus proto.InternalMessageInfo
+
+func (m *StoreStatus) GetForkHeight() int64 {
+	if m != nil {
+		return m.ForkHeight
+	}
+	return 0
+}
+
+func (m *StoreStatus) GetDelMavlDataHeight() int64 {
+	if m != nil {
+		return m.DelMavlDataHeight
+	}
+	return 0
+}
+
+func (m *StoreStatus) GetPrunedMavl() bool {
+	if m != nil {
+		return m.PrunedMavl
+	}
+	return false
+}
+
+func (m *StoreStatus) GetMavlDeleted() bool {
+	if m != nil {
+		return m.MavlDeleted
+	}
+	return false
+}
+
+func (m *StoreStatus) GetMavlCompacted() bool {
+	if m != nil {
+		return m.MavlCompacted
+	}
+	return false
+}
+
+func (m *StoreStatus) GetDelMavlRunning() bool {
+	if m != nil {
+		return m.DelMavlRunning
+	}
+	return false
+}
+
+func (m *StoreStatus) GetDelPrunedMavlRunning() bool {
+	if m != nil {
+		return m.DelPrunedMavlRunning
+	}
+	return false
+}
+
+func (m *StoreStatus) GetDelPrunedMavlDone() bool {
+	if m != nil {
+		return m.DelPrunedMavlDone
+	}
+	return false
+}
+
+func (m *StoreStatus) GetMvccPruning() bool {
+	if m != nil {
+		return m.MvccPruning
+	}
+	return false
+}
+
+func (m *StoreStatus) GetClosed() bool {
+	if m != nil {
+		return m.Closed
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*StoreStatus)(nil), "types.StoreStatus")
+}
+
+func init() {
+	proto.RegisterFile("kvmvccmavl.proto", fileDescriptor_9782979fd5374d0f)
+}
+
+var fileDescriptor_9782979fd5374d0f = []byte{
+	// 281 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xd1, 0x4a, 0xc3, 0x30,
+	0x14, 0x86, 0x9d, 0x75, 0x75, 0x9e, 0xb9, 0xa1, 0x41, 0x24, 0xec, 0x42, 0xca, 0x10, 0xd9, 0x85,
+	0xf4, 0x62, 0xe2, 0x13, 0xac, 0xa0, 0x37, 0x8a, 0x74, 0x4f, 0x10, 0xd3, 0xe3, 0x2c, 0x4b, 0x93,
+	0xda, 0xa6, 0x05, 0x9f, 0xc0, 0xd7, 0x96, 0x1c, 0xa3, 0xad, 0xce, 0xcb, 0xf3, 0xfd, 0x5f, 0xd3,
+	0x73, 0xf8, 0xe1, 0x64, 0xdb, 0x16, 0xad, 0x94, 0x85, 0x68, 0x55, 0x5c, 0x56, 0xc6, 0x1a, 0x36,
+	0xb4, 0xef, 0x25, 0xd6, 0xb3, 0x63, 0x69, 0x8a, 0xc2, 0xe8, 0x2f, 0x38, 0xff, 0x08, 0x60, 0xbc,
+	0xb6, 0xa6, 0xc2, 0xb5, 0x15, 0xb6, 0xa9, 0xd9, 0x05, 0xc0, 0x8b, 0xa9, 0xb6, 0xf7, 0x98, 0x6f,
+	0x5e, 0x2d, 0x1f, 0x44, 0x83, 0x45, 0x90, 0xf6, 0x08, 0xbb, 0x86, 0xd3, 0x0c, 0xd5, 0x83, 0x68,
+	0x55, 0x22, 0xac, 0xf0, 0xda, 0x3e, 0x69, 0xbb, 0x81, 0x7b, 0xad, 0xac, 0x1a, 0x8d, 0x99, 0xe3,
+	0x3c, 0x88, 0x06, 0x8b, 0x51, 0xda, 0x23, 0x2c, 0x82, 0xb1, 0x5b, 0x30, 0x41, 0x85, 0x16, 0x33,
+	0x7e, 0x40, 0x42, 0x1f, 0xb1, 0x4b, 0x98, 0xb8, 0x71, 0x65, 0x8a, 0x52, 0x48, 0xe7, 0x0c, 0xc9,
+	0xf9, 0x0d, 0xd9, 0x15, 0x4c, 0xfd, 0xcf, 0xd3, 0x46, 0xeb, 0x5c, 0x6f, 0x78, 0x48, 0xda, 0x1f,
+	0xca, 0x96, 0x70, 0x96, 0xa1, 0x7a, 0xfa, 0x59, 0xe0, 0xdb, 0x3e, 0x24, 0xfb, 0xdf, 0xcc, 0x5f,
+	0xdc, 0xf1, 0xc4, 0x68, 0xe4, 0x23, 0xfa, 0x60, 0x37, 0xa0, 0x8b, 0x5a, 0x29, 0x1d, 0x75, 0x0f,
+	0x1f, 0xf9, 0x8b, 0x3a, 0xc4, 0xce, 0x21, 0x94, 0xca, 0xd4, 0x98, 0x71, 0xa0, 0xd0, 0x4f, 0xcb,
+	0x15, 0x40, 0x57, 0x19, 0xbb, 0x85, 0xe9, 0x1d, 0xda, 0x7e, 0x33, 0x93, 0x98, 0xfa, 0x8b, 0x53,
+	0x7c, 0x7b, 0xcc, 0xd5, 0x8c, 0xf9, 0xb1, 0xa7, 0xcc, 0xf7, 0x9e, 0x43, 0x6a, 0xf5, 0xe6, 0x73,
+	0x00, 0x55, 0x0f, 0xc5, 0x70, 0xfe, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// KvmvccmavlClient is the client API for Kvmvccmavl service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type KvmvccmavlClient interface {
+	GetStoreStatus(ctx context.Context, in *types.ReqNil, opts ...grpc.CallOption) (*StoreStatus, error)
+}
+
+type kvmvccmavlClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKvmvccmavlClient(cc grpc.ClientConnInterface) KvmvccmavlClient {
+	return &kvmvccmavlClient{cc}
+}
+
+func (c *kvmvccmavlClient) GetStoreStatus(ctx context.Context, in *types.ReqNil, opts ...grpc.CallOption) (*StoreStatus, error) {
+	out := new(StoreStatus)
+	err := c.cc.Invoke(ctx, "/types.kvmvccmavl/GetStoreStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KvmvccmavlServer is the server API for Kvmvccmavl service.
+type KvmvccmavlServer interface {
+	GetStoreStatus(context.Context, *types.ReqNil) (*StoreStatus, error)
+}
+
+// UnimplementedKvmvccmavlServer can be embedded to have forward compatible implementations.
+type UnimplementedKvmvccmavlServer struct {
+}
+
+func (*UnimplementedKvmvccmavlServer) GetStoreStatus(ctx context.Context, req *types.ReqNil) (*StoreStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStoreStatus not implemented")
+}
+
+func RegisterKvmvccmavlServer(s *grpc.Server, srv KvmvccmavlServer) {
+	s.RegisterService(&_Kvmvccmavl_serviceDesc, srv)
+}
+
+func _Kvmvccmavl_GetStoreStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.ReqNil)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KvmvccmavlServer).GetStoreStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.kvmvccmavl/GetStoreStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KvmvccmavlServer).GetStoreStatus(ctx, req.(*types.ReqNil))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Kvmvccmavl_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.kvmvccmavl",
+	HandlerType: (*KvmvccmavlServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStoreStatus",
+			Handler:    _Kvmvccmavl_GetStoreStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kvmvccmavl.proto",
+}
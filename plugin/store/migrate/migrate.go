@@ -0,0 +1,288 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package migrate 在不同的store驱动之间离线迁移状态, 需要在节点停止时运行
+//
+// kvmvcc, kvmvccmavl通过状态快照读写, 可以迁移任意保留的状态根,
+// mpt通过IterateRangeByStateHash读取, kvdb没有状态根, 直接读取整个数据库.
+// 写入mpt和kvdb时状态根由新的驱动重新计算, 和原来的状态根不同,
+// 写入kvmvcc, kvmvccmavl时沿用原来的状态根, 之后可以继续执行区块.
+package migrate
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	log "github.com/33cn/chain33/common/log/log15"
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot"
+)
+
+var mlog = log.New("module", "store.migrate")
+
+const (
+	// 每次写入和校验的kv数量
+	batchCount = 1000
+)
+
+var (
+	// ErrStateHashRequired 源驱动无法确定要迁移的状态根
+	ErrStateHashRequired = errors.New("ErrMigrateStateHashRequired")
+	// ErrVerify 迁移之后重新读取的值和源不一致
+	ErrVerify = errors.New("ErrMigrateVerify")
+	// ErrStoreNotEmpty 只能迁移到空的存储中
+	ErrStoreNotEmpty = errors.New("ErrMigrateStoreNotEmpty")
+)
+
+// Store 迁移需要的store接口, plugin/store下的驱动都实现了这些方法
+type Store interface {
+	Get(datas *types.StoreGet) [][]byte
+	Set(datas *types.StoreSet, sync bool) ([]byte, error)
+	IterateRangeByStateHash(statehash []byte, start []byte, end []byte, ascending bool, fn func(key, value []byte) bool)
+	GetDB() dbm.DB
+}
+
+type exporter interface {
+	ExportSnapshot(statehash []byte, w io.Writer, blocks snapshot.BlockLoader) (*snapshot.Header, error)
+}
+
+type importer interface {
+	ImportSnapshot(r io.Reader) (*snapshot.Header, error)
+}
+
+// Options 迁移参数
+type Options struct {
+	// 源驱动和目标驱动的名称, kvdb需要直接读写数据库
+	From string
+	To   string
+	// 要迁移的状态根, kvmvcc, kvmvccmavl为空时迁移最新的状态
+	StateHash []byte
+	// 状态根对应的高度, kvmvcc, kvmvccmavl从快照中获取
+	Height int64
+}
+
+// Result 迁移结果
+type Result struct {
+	// 源状态根和高度
+	StateHash []byte
+	Height    int64
+	// 目标驱动中的状态根
+	NewStateHash []byte
+	Count        uint64
+}
+
+// Migrate 把源驱动中状态根下的所有kv写入空的目标驱动, 写入后逐个key重新读取校验
+func Migrate(src, dst Store, opts *Options) (*Result, error) {
+	result := &Result{StateHash: opts.StateHash, Height: opts.Height}
+	var err error
+	if imp, ok := dst.(importer); ok {
+		err = importState(src, imp, opts, result)
+	} else {
+		err = setState(src, dst, opts, result)
+	}
+	if err != nil {
+		return nil, err
+	}
+	mlog.Info("migrate state", "stateHash", common.ToHex(result.StateHash), "height", result.Height,
+		"newStateHash", common.ToHex(result.NewStateHash), "kvs", result.Count)
+	err = Verify(src, dst, opts, result.NewStateHash)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// importState 目标驱动支持快照时, 通过快照格式沿用原来的状态根
+func importState(src Store, dst importer, opts *Options, result *Result) error {
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		header, err := dst.ImportSnapshot(pr)
+		if err == nil {
+			result.NewStateHash = header.StateHash
+		}
+		//导入失败时让写入端退出
+		pr.CloseWithError(err)
+		done <- err
+	}()
+	var w *snapshot.Writer
+	newWriter := func() error {
+		if len(result.StateHash) == 0 {
+			return ErrStateHashRequired
+		}
+		var err error
+		w, err = snapshot.NewWriter(pw, &snapshot.Header{StateHash: result.StateHash, Height: result.Height})
+		return err
+	}
+	err := walkSource(src, opts, result, func(kvs []*types.KeyValue) error {
+		if w == nil {
+			if err := newWriter(); err != nil {
+				return err
+			}
+		}
+		for _, kv := range kvs {
+			if err := w.Add(kv.Key, kv.Value); err != nil {
+				return err
+			}
+		}
+		result.Count += uint64(len(kvs))
+		return nil
+	})
+	if err == nil && w == nil {
+		err = newWriter()
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	pw.CloseWithError(err)
+	//导入失败时写入端的错误只是管道关闭, 优先返回导入的错误
+	if importErr := <-done; importErr != nil {
+		return importErr
+	}
+	return err
+}
+
+// setState 目标驱动按批次写入, 每一批在上一批的状态根上继续写入
+func setState(src, dst Store, opts *Options, result *Result) error {
+	//kvdb没有状态根, 已有的数据会混在迁移的状态中
+	if opts.To == "kvdb" {
+		it := dst.GetDB().Iterator(nil, nil, false)
+		empty := !it.Rewind() || !it.Valid()
+		it.Close()
+		if !empty {
+			return ErrStoreNotEmpty
+		}
+	}
+	root := drivers.EmptyRoot[:]
+	err := walkSource(src, opts, result, func(kvs []*types.KeyValue) error {
+		hash, err := dst.Set(&types.StoreSet{StateHash: root, KV: kvs, Height: result.Height}, true)
+		if err != nil {
+			return err
+		}
+		root = hash
+		result.Count += uint64(len(kvs))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	result.NewStateHash = root
+	return nil
+}
+
+// walkSource 按批次读取源驱动的状态, 并记录实际的状态根和高度
+func walkSource(src Store, opts *Options, result *Result, fn func([]*types.KeyValue) error) error {
+	exp, ok := src.(exporter)
+	if !ok {
+		if opts.From != "kvdb" && len(opts.StateHash) == 0 {
+			return ErrStateHashRequired
+		}
+		return walk(src, opts, fn)
+	}
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		_, err := exp.ExportSnapshot(opts.StateHash, pw, nil)
+		pw.CloseWithError(err)
+		done <- err
+	}()
+	r, err := snapshot.NewReader(pr)
+	if err == nil {
+		//快照头部中是实际导出的状态根和高度
+		result.StateHash, result.Height = r.Header().StateHash, r.Header().Height
+		for {
+			var kvs []*types.KeyValue
+			kvs, err = r.Next()
+			if err == io.EOF {
+				err = nil
+				break
+			}
+			if err != nil {
+				break
+			}
+			if err = fn(kvs); err != nil {
+				break
+			}
+		}
+	}
+	//读取失败时让导出端退出
+	pr.CloseWithError(err)
+	exportErr := <-done
+	if err != nil {
+		return err
+	}
+	return exportErr
+}
+
+// walk 按批次读取不支持快照的源驱动
+func walk(src Store, opts *Options, fn func([]*types.KeyValue) error) error {
+	var kvs []*types.KeyValue
+	var err error
+	add := func(key, value []byte) bool {
+		//保留第一个错误, 后续的批次不再写入
+		if err != nil {
+			return true
+		}
+		if len(value) == 0 {
+			return false
+		}
+		kvs = append(kvs, &types.KeyValue{Key: common.CopyBytes(key), Value: common.CopyBytes(value)})
+		if len(kvs) >= batchCount {
+			err = fn(kvs)
+			kvs = nil
+		}
+		return err != nil
+	}
+	if opts.From == "kvdb" {
+		//kvdb没有状态根, 数据库中只有最新的状态
+		it := src.GetDB().Iterator(nil, nil, false)
+		for it.Rewind(); it.Valid(); it.Next() {
+			if add(it.Key(), it.Value()) {
+				break
+			}
+		}
+		if err == nil {
+			err = it.Error()
+		}
+		it.Close()
+	} else {
+		src.IterateRangeByStateHash(opts.StateHash, nil, nil, true, add)
+	}
+	if err != nil {
+		return err
+	}
+	if len(kvs) > 0 {
+		return fn(kvs)
+	}
+	return nil
+}
+
+// Verify 重新读取源驱动中的每个key, 检查目标驱动中状态根下的值是否一致
+func Verify(src, dst Store, opts *Options, newStateHash []byte) error {
+	var count uint64
+	err := walkSource(src, opts, &Result{}, func(kvs []*types.KeyValue) error {
+		keys := make([][]byte, len(kvs))
+		for i, kv := range kvs {
+			keys[i] = kv.Key
+		}
+		values := dst.Get(&types.StoreGet{StateHash: newStateHash, Keys: keys})
+		for i, kv := range kvs {
+			if !bytes.Equal(kv.Value, values[i]) {
+				mlog.Error("verify migrated state", "key", common.ToHex(kv.Key), "value", common.ToHex(kv.Value), "got", common.ToHex(values[i]))
+				return ErrVerify
+			}
+		}
+		count += uint64(len(kvs))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	mlog.Info("verify migrated state", "newStateHash", common.ToHex(newStateHash), "kvs", count)
+	return nil
+}
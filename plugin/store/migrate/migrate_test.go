@@ -0,0 +1,170 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/kvmvccmavl"
+	"github.com/33cn/plugin/plugin/store/snapshot"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/33cn/plugin/plugin/store/init"
+)
+
+type testStore interface {
+	Store
+	Close()
+}
+
+func newStore(t *testing.T, driver string) (testStore, func()) {
+	dir, err := ioutil.TempDir("", "example")
+	require.NoError(t, err)
+	create, err := drivers.Load(driver)
+	require.NoError(t, err)
+	sub, err := json.Marshal(map[string]interface{}{"enableMVCCIter": true})
+	require.NoError(t, err)
+	cfg := &types.Store{Name: driver, Driver: "leveldb", DbPath: dir, DbCache: 100}
+	store := create(cfg, sub, nil).(testStore)
+	return store, func() {
+		store.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// setBlocks 写入n个高度, 每个高度修改公共的key并新增一个key, 一共2500个key以覆盖多个批次
+func setBlocks(t *testing.T, store Store, n int) [][]byte {
+	hash := drivers.EmptyRoot[:]
+	var hashes [][]byte
+	for i := 0; i < n; i++ {
+		kv := []*types.KeyValue{{Key: []byte("common"), Value: []byte(fmt.Sprintf("v%d", i))}}
+		for j := 0; j < 2500/n; j++ {
+			kv = append(kv, &types.KeyValue{Key: []byte(fmt.Sprintf("key-%d-%d", i, j)), Value: []byte(fmt.Sprintf("value-%d-%d", i, j))})
+		}
+		var err error
+		hash, err = store.Set(&types.StoreSet{StateHash: hash, KV: kv, Height: int64(i)}, true)
+		require.NoError(t, err)
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+func TestMigrate(t *testing.T) {
+	cases := []struct {
+		from, to string
+	}{
+		{"kvmvcc", "mpt"},
+		{"mpt", "kvmvcc"},
+		{"kvdb", "kvmvcc"},
+		{"kvmvccmavl", "kvdb"},
+		{"kvmvcc", "kvmvccmavl"},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.from+"-"+c.to, func(t *testing.T) {
+			src, closeSrc := newStore(t, c.from)
+			defer closeSrc()
+			dst, closeDst := newStore(t, c.to)
+			defer closeDst()
+
+			hashes := setBlocks(t, src, 5)
+			opts := &Options{From: c.from, To: c.to}
+			if c.from == "mpt" || c.from == "kvdb" {
+				opts.StateHash, opts.Height = hashes[4], 4
+			}
+			if c.to == "kvmvccmavl" {
+				//kvmvccmavl只能导入分叉之后的状态
+				_, err := Migrate(src, dst, opts)
+				require.Equal(t, kvmvccmavl.ErrSnapshotBeforeFork, err)
+				return
+			}
+			result, err := Migrate(src, dst, opts)
+			require.NoError(t, err)
+			require.Equal(t, hashes[4], result.StateHash)
+			require.Equal(t, int64(4), result.Height)
+			require.Equal(t, uint64(2501), result.Count)
+
+			keys := [][]byte{[]byte("common"), []byte("key-0-0"), []byte("key-4-499")}
+			values := dst.Get(&types.StoreGet{StateHash: result.NewStateHash, Keys: keys})
+			require.Equal(t, [][]byte{[]byte("v4"), []byte("value-0-0"), []byte("value-4-499")}, values)
+
+			if c.to == "kvmvcc" {
+				//沿用原来的状态根, 可以继续写入下一个高度
+				require.Equal(t, hashes[4], result.NewStateHash)
+				kv := []*types.KeyValue{{Key: []byte("common"), Value: []byte("v5")}}
+				_, err = dst.Set(&types.StoreSet{StateHash: hashes[4], KV: kv, Height: 5}, true)
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMigrateHistory(t *testing.T) {
+	src, closeSrc := newStore(t, "kvmvcc")
+	defer closeSrc()
+	dst, closeDst := newStore(t, "mpt")
+	defer closeDst()
+
+	hashes := setBlocks(t, src, 5)
+	result, err := Migrate(src, dst, &Options{From: "kvmvcc", To: "mpt", StateHash: hashes[2]})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.Height)
+	require.Equal(t, uint64(1501), result.Count)
+	values := dst.Get(&types.StoreGet{StateHash: result.NewStateHash, Keys: [][]byte{[]byte("common"), []byte("key-3-0")}})
+	require.Equal(t, [][]byte{[]byte("v2"), nil}, values)
+
+	//源和目标不一致时校验失败
+	err = Verify(src, dst, &Options{From: "kvmvcc", To: "mpt", StateHash: hashes[3]}, result.NewStateHash)
+	require.Equal(t, ErrVerify, err)
+}
+
+func TestMigrateErrors(t *testing.T) {
+	src, closeSrc := newStore(t, "mpt")
+	defer closeSrc()
+	dst, closeDst := newStore(t, "kvdb")
+	defer closeDst()
+	setBlocks(t, src, 5)
+
+	//mpt需要指定状态根
+	_, err := Migrate(src, dst, &Options{From: "mpt", To: "kvdb"})
+	require.Equal(t, ErrStateHashRequired, err)
+
+	//kvdb只能迁移到空的存储中
+	dst.GetDB().Set([]byte("exist"), []byte("value"))
+	_, err = Migrate(src, dst, &Options{From: "mpt", To: "kvdb", StateHash: []byte("hash")})
+	require.Equal(t, ErrStoreNotEmpty, err)
+
+	kvmvcc, closeKvmvcc := newStore(t, "kvmvcc")
+	defer closeKvmvcc()
+	setBlocks(t, kvmvcc, 1)
+	_, err = Migrate(kvmvcc, kvmvcc, &Options{From: "kvmvcc", To: "kvmvcc"})
+	require.Equal(t, snapshot.ErrStoreNotEmpty, err)
+}
+
+func TestWalkStopOnError(t *testing.T) {
+	src, closeSrc := newStore(t, "mpt")
+	defer closeSrc()
+	hashes := setBlocks(t, src, 5)
+
+	//一共3个批次, 倒数第二个批次失败之后最后一个批次成功也不能覆盖错误
+	errBatch := errors.New("batch failed")
+	var batches int
+	err := walk(src, &Options{From: "mpt", StateHash: hashes[4]}, func(kvs []*types.KeyValue) error {
+		batches++
+		if batches == 2 {
+			return errBatch
+		}
+		return nil
+	})
+	require.Equal(t, errBatch, err)
+	require.Equal(t, 2, batches)
+}
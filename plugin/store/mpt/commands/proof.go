@@ -0,0 +1,89 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/rpc/jsonclient"
+	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+	mty "github.com/33cn/plugin/plugin/store/mpt/types"
+	"github.com/spf13/cobra"
+)
+
+// MptCmd mpt cmd register
+func MptCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mpt",
+		Short: "Query mpt store",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		StateProofCmd(),
+	)
+	return cmd
+}
+
+// StateProofCmd get and verify state proof of keys in mpt store
+func StateProofCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proof",
+		Short: "Get values of keys with merkle proof from mpt store and verify them",
+		Run:   stateProof,
+	}
+	addStateProofFlags(cmd)
+	return cmd
+}
+
+func addStateProofFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("state_hash", "s", "", "state hash in block header")
+	cmd.MarkFlagRequired("state_hash")
+	cmd.Flags().StringSliceP("keys", "k", nil, "state keys, separated by comma")
+	cmd.MarkFlagRequired("keys")
+}
+
+// proofValue 验证之后的值, value为十六进制, 为空表示不存在
+type proofValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func stateProof(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	stateHash, _ := cmd.Flags().GetString("state_hash")
+	keys, _ := cmd.Flags().GetStringSlice("keys")
+	hash, err := common.FromHex(stateHash)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	var res mpt.StateProof
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, mty.MptX+".GetStateProof", &mty.ReqStateProof{StateHash: stateHash, Keys: keys}, &res)
+	ctx.SetResultCb(func(ret interface{}) (interface{}, error) {
+		proof := ret.(*mpt.StateProof)
+		//不信任节点, 用状态根验证返回的证明
+		if err := mpt.VerifyKVPairProof(hash, proof); err != nil {
+			return nil, err
+		}
+		if len(proof.Values) != len(keys) {
+			return nil, mpt.ErrProofValue
+		}
+		values := make([]*proofValue, 0, len(proof.Values))
+		for i, v := range proof.Values {
+			if string(v.Key) != keys[i] {
+				return nil, mpt.ErrProofValue
+			}
+			value := &proofValue{Key: keys[i]}
+			if v.Value != nil {
+				value.Value = common.ToHex(v.Value)
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	})
+	ctx.Run()
+}
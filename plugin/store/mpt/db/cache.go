@@ -0,0 +1,118 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"sync/atomic"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// NodeCache 多个Database共享的已落盘节点缓存
+// 同一个父状态根下的多个分叉读取相同的节点时, 只需要访问一次磁盘
+type NodeCache struct {
+	cache  *lru.Cache
+	hits   uint64
+	misses uint64
+}
+
+// NodeCacheStats 节点缓存的命中统计
+type NodeCacheStats struct {
+	Hits   uint64
+	Misses uint64
+	Len    int
+}
+
+// HitRate 命中率, 没有访问时为0
+func (s NodeCacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// NewNodeCache 创建最多缓存size个节点的共享缓存
+func NewNodeCache(size int) (*NodeCache, error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeCache{cache: cache}, nil
+}
+
+func (c *NodeCache) get(hash common.Hash) ([]byte, bool) {
+	if enc, ok := c.cache.Get(hash); ok {
+		atomic.AddUint64(&c.hits, 1)
+		return enc.([]byte), true
+	}
+	atomic.AddUint64(&c.misses, 1)
+	return nil, false
+}
+
+func (c *NodeCache) add(hash common.Hash, enc []byte) {
+	c.cache.Add(hash, enc)
+}
+
+// Remove 节点从数据库中删除时需要同时从缓存中删除
+func (c *NodeCache) Remove(hash common.Hash) {
+	c.cache.Remove(hash)
+}
+
+// Stats 返回缓存的命中统计
+func (c *NodeCache) Stats() NodeCacheStats {
+	return NodeCacheStats{
+		Hits:   atomic.LoadUint64(&c.hits),
+		Misses: atomic.LoadUint64(&c.misses),
+		Len:    c.cache.Len(),
+	}
+}
+
+// WrapBatch 记录batch中写入和删除的节点, 写入成功之后同步更新缓存
+func (c *NodeCache) WrapBatch(batch dbm.Batch) dbm.Batch {
+	return &cacheBatch{Batch: batch, cache: c, nodes: make(map[common.Hash][]byte)}
+}
+
+// cacheBatch 删除的节点记录为nil
+type cacheBatch struct {
+	dbm.Batch
+	cache *NodeCache
+	nodes map[common.Hash][]byte
+}
+
+func (b *cacheBatch) Set(key, value []byte) {
+	b.Batch.Set(key, value)
+	if len(key) == HashLength {
+		b.nodes[common.BytesToHash(key)] = value
+	}
+}
+
+func (b *cacheBatch) Delete(key []byte) {
+	b.Batch.Delete(key)
+	if len(key) == HashLength {
+		b.nodes[common.BytesToHash(key)] = nil
+	}
+}
+
+func (b *cacheBatch) Write() error {
+	if err := b.Batch.Write(); err != nil {
+		return err
+	}
+	for hash, enc := range b.nodes {
+		if enc == nil {
+			b.cache.Remove(hash)
+		} else {
+			b.cache.add(hash, enc)
+		}
+	}
+	return nil
+}
+
+func (b *cacheBatch) Reset() {
+	b.Batch.Reset()
+	b.nodes = make(map[common.Hash][]byte)
+}
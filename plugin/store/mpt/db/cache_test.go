@@ -0,0 +1,58 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeCache(t *testing.T) {
+	memdb, _ := dbm.NewGoMemDB("gomemdb", "", 128)
+	cache, err := NewNodeCache(1024)
+	assert.Nil(t, err)
+
+	triedb := NewDatabaseWithCache(memdb, cache)
+	triedb.EnableRefCount()
+	trie, err := New(common.Hash{}, triedb)
+	assert.Nil(t, err)
+	for i := 0; i < 100; i++ {
+		trie.Update([]byte(fmt.Sprintf("key-%04d", i)), bytes.Repeat([]byte{byte(i)}, 40))
+	}
+	root, err := trie.Commit(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, trie.Commit2Db(root, false))
+	// 提交的节点直接进入缓存
+	nodes := countNodes(memdb)
+	assert.Equal(t, nodes, cache.Stats().Len)
+
+	// 另一个Database读取相同的节点时命中缓存
+	trie2, err := New(root, NewDatabaseWithCache(memdb, cache))
+	assert.Nil(t, err)
+	for i := 0; i < 100; i++ {
+		value, err := trie2.TryGet([]byte(fmt.Sprintf("key-%04d", i)))
+		assert.Nil(t, err)
+		assert.Equal(t, bytes.Repeat([]byte{byte(i)}, 40), value)
+	}
+	stats := cache.Stats()
+	assert.Equal(t, uint64(0), stats.Misses)
+	assert.True(t, stats.Hits > 0)
+	assert.Equal(t, 1.0, stats.HitRate())
+
+	// 裁剪删除的节点同时从缓存中删除
+	batch := cache.WrapBatch(memdb.NewBatch(true))
+	deleted, err := ReleaseRoot(memdb, batch, root)
+	assert.Nil(t, err)
+	assert.Equal(t, nodes, deleted)
+	assert.Nil(t, batch.Write())
+	assert.Equal(t, 0, cache.Stats().Len)
+	_, err = New(root, NewDatabaseWithCache(memdb, cache))
+	assert.NotNil(t, err)
+}
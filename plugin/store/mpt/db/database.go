@@ -81,6 +81,9 @@ type Database struct {
 	nodesSize     float64 // Storage size of the nodes cache (exc. flushlist)
 	preimagesSize float64 // Storage size of the preimages cache
 
+	refCount bool       // Maintain on-disk node reference counts for pruning
+	cache    *NodeCache // Clean node cache shared between databases, may be nil
+
 	lock sync.RWMutex
 }
 
@@ -194,6 +197,14 @@ func NewDatabase(db dbm.DB) *Database {
 	}
 }
 
+// NewDatabaseWithCache creates a trie database which reads and writes persisted
+// nodes through a clean node cache shared with other databases.
+func NewDatabaseWithCache(db dbm.DB, cache *NodeCache) *Database {
+	database := NewDatabase(db)
+	database.cache = cache
+	return database
+}
+
 // DiskDB retrieves the persistent storage backing the trie database.
 //func (db *Database) DiskDB() DatabaseReader {
 //	return db.db
@@ -289,8 +300,8 @@ func (db *Database) node(hash common.Hash, cachegen uint16) node {
 	if node != nil {
 		return node.obj(hash, cachegen)
 	}
-	// Content unavailable in memory, attempt to retrieve from disk
-	enc, err := db.db.Get(hash[:])
+	// Content unavailable in memory, attempt to retrieve from clean cache or disk
+	enc, err := db.diskNode(hash)
 	if err != nil || enc == nil {
 		return nil
 	}
@@ -310,7 +321,23 @@ func (db *Database) Node(hash common.Hash) ([]byte, error) {
 	}
 	// Content unavailable in memory, attempt to retrieve from disk
 	//return db.diskdb.Get(hash[:])
-	return db.db.Get(hash[:])
+	return db.diskNode(hash)
+}
+
+// diskNode retrieves an encoded persisted node, consulting the shared clean
+// cache first if there is one.
+func (db *Database) diskNode(hash common.Hash) ([]byte, error) {
+	if db.cache == nil {
+		return db.db.Get(hash[:])
+	}
+	if enc, ok := db.cache.get(hash); ok {
+		return enc, nil
+	}
+	enc, err := db.db.Get(hash[:])
+	if err == nil && enc != nil {
+		db.cache.add(hash, enc)
+	}
+	return enc, err
 }
 
 // preimage retrieves a cached trie node pre-image from memory. If it cannot be
@@ -466,6 +493,9 @@ func (db *Database) Commit(node common.Hash, report bool) error {
 
 	// TODO 暂时在内部设置为同步状态
 	batch := db.db.NewBatch(true)
+	if db.cache != nil {
+		batch = db.cache.WrapBatch(batch)
+	}
 
 	// Move all of the accumulated preimages into a write batch
 	for hash, preimage := range db.preimages {
@@ -484,7 +514,16 @@ func (db *Database) Commit(node common.Hash, report bool) error {
 	}
 	// Move the trie itself into the batch, flushing if enough data is accumulated
 	nodes, storage := len(db.nodes), db.nodesSize
-	if err := db.commit(node, batch); err != nil {
+	var err error
+	if db.refCount {
+		refs := make(map[common.Hash]uint64)
+		if err = db.commitRef(node, batch, refs); err == nil {
+			writeRefs(batch, refs)
+		}
+	} else {
+		err = db.commit(node, batch)
+	}
+	if err != nil {
 		mptlog.Error("Failed to commit trie from trie database", "err", err)
 		db.lock.RUnlock()
 		return err
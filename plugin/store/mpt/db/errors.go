@@ -21,6 +21,7 @@
 package mpt
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/33cn/chain33/common"
@@ -37,3 +38,12 @@ type MissingNodeError struct {
 func (err *MissingNodeError) Error() string {
 	return fmt.Sprintf("missing trie node %x (path %x)", err.NodeHash, err.Path)
 }
+
+// ErrRefCount is returned when a stored node reference count can not be decoded.
+var ErrRefCount = errors.New("mpt: bad node reference count")
+
+// ErrProofStateHash is returned when a state proof is checked against a different state hash.
+var ErrProofStateHash = errors.New("mpt: proof state hash mismatch")
+
+// ErrProofValue is returned when a proven value differs from the value in the proof.
+var ErrProofValue = errors.New("mpt: proof value mismatch")
@@ -0,0 +1,150 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: proof.proto
+
+package mpt
+
+import (
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/golang/protobuf/proto"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// StateProof 一组key在同一个状态根下的值和默克尔证明
+type StateProof struct {
+	StateHash []byte        `protobuf:"bytes,1,opt,name=stateHash,proto3" json:"stateHash,omitempty"`
+	Values    []*ProofValue `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	// 证明路径上的节点, 多个key共享的节点只出现一次
+	Nodes                [][]byte `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateProof) Reset()         { *m = StateProof{} }
+func (m *StateProof) String() string { return proto.CompactTextString(m) }
+func (*StateProof) ProtoMessage()    {}
+func (*StateProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_473d204b28f447f0, []int{0}
+}
+
+func (m *StateProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateProof.Unmarshal(m, b)
+}
+func (m *StateProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateProof.Marshal(b, m, deterministic)
+}
+func (m *StateProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateProof.Merge(m, src)
+}
+func (m *StateProof) XXX_Size() int {
+	return xxx_messageInfo_StateProof.Size(m)
+}
+func (m *StateProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateProof proto.InternalMessageInfo
+
+func (m *StateProof) GetStateHash() []byte {
+	if m != nil {
+		return m.StateHash
+	}
+	return nil
+}
+
+func (m *StateProof) GetValues() []*ProofValue {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *StateProof) GetNodes() [][]byte {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+// ProofValue value为空表示状态根下不存在这个key
+type ProofValue struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProofValue) Reset()         { *m = ProofValue{} }
+func (m *ProofValue) String() string { return proto.CompactTextString(m) }
+func (*ProofValue) ProtoMessage()    {}
+func (*ProofValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_473d204b28f447f0, []int{1}
+}
+
+func (m *ProofValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProofValue.Unmarshal(m, b)
+}
+func (m *ProofValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProofValue.Marshal(b, m, deterministic)
+}
+func (m *ProofValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProofValue.Merge(m, src)
+}
+func (m *ProofValue) XXX_Size() int {
+	return xxx_messageInfo_ProofValue.Size(m)
+}
+func (m *ProofValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProofValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProofValue proto.InternalMessageInfo
+
+func (m *ProofValue) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *ProofValue) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*StateProof)(nil), "mpt.StateProof")
+	proto.RegisterType((*ProofValue)(nil), "mpt.ProofValue")
+}
+
+func init() {
+	proto.RegisterFile("proof.proto", fileDescriptor_473d204b28f447f0)
+}
+
+var fileDescriptor_473d204b28f447f0 = []byte{
+	// 151 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2e, 0x28, 0xca, 0xcf,
+	0x4f, 0xd3, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0xce, 0x2d, 0x28, 0x51, 0xca, 0xe4, 0xe2,
+	0x0a, 0x2e, 0x49, 0x2c, 0x49, 0x0d, 0x00, 0x49, 0x08, 0xc9, 0x70, 0x71, 0x16, 0x83, 0x78, 0x1e,
+	0x89, 0xc5, 0x19, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x3c, 0x41, 0x08, 0x01, 0x21, 0x75, 0x2e, 0xb6,
+	0xb2, 0xc4, 0x9c, 0xd2, 0xd4, 0x62, 0x09, 0x26, 0x05, 0x66, 0x0d, 0x6e, 0x23, 0x7e, 0xbd, 0xdc,
+	0x82, 0x12, 0x3d, 0xb0, 0xce, 0x30, 0x90, 0x78, 0x10, 0x54, 0x5a, 0x48, 0x84, 0x8b, 0x35, 0x2f,
+	0x3f, 0x25, 0xb5, 0x58, 0x82, 0x59, 0x81, 0x59, 0x83, 0x27, 0x08, 0xc2, 0x51, 0x32, 0xe1, 0xe2,
+	0x42, 0xa8, 0x15, 0x12, 0xe0, 0x62, 0xce, 0x4e, 0xad, 0x84, 0x5a, 0x02, 0x62, 0x82, 0x74, 0x81,
+	0xf5, 0x4b, 0x30, 0x81, 0xc5, 0x20, 0x9c, 0x24, 0x36, 0xb0, 0x63, 0x8d, 0x01, 0x03, 0x00, 0xc4,
+	0x5c, 0x6f, 0x67, 0xbb, 0x00, 0x00, 0x00,
+}
@@ -229,6 +229,68 @@ func BenchmarkVerifyProof(b *testing.B) {
 	}
 }
 
+func TestKVPairProof(t *testing.T) {
+	memdb, _ := dbm.NewGoMemDB("gomemdb", "", 128)
+	trie, vals := randomTrie(200)
+	trie.db = NewDatabase(memdb)
+	root, err := trie.Commit(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := trie.Commit2Db(root, false); err != nil {
+		t.Fatal(err)
+	}
+	var keys [][]byte
+	for _, kv := range vals {
+		keys = append(keys, kv.k)
+		if len(keys) == 20 {
+			break
+		}
+	}
+	missing := randBytes(32)
+	keys = append(keys, missing)
+
+	proof, err := GetKVPairProof(memdb, root[:], keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proof.Values) != len(keys) {
+		t.Fatalf("proof values %d, want %d", len(proof.Values), len(keys))
+	}
+	for i, value := range proof.Values[:len(keys)-1] {
+		if !bytes.Equal(value.Value, vals[string(keys[i])].v) {
+			t.Fatalf("proof value mismatch for key %x", keys[i])
+		}
+	}
+	if proof.Values[len(keys)-1].Value != nil {
+		t.Fatalf("missing key %x has value", missing)
+	}
+	if err := VerifyKVPairProof(root[:], proof); err != nil {
+		t.Fatalf("failed to verify proof: %v", err)
+	}
+
+	// 证明只能用于生成它的状态根
+	if err := VerifyKVPairProof(randBytes(32), proof); err != ErrProofStateHash {
+		t.Fatalf("expected ErrProofStateHash, got %v", err)
+	}
+	// 篡改值或者声明不存在的key存在
+	proof.Values[0].Value = randBytes(20)
+	if err := VerifyKVPairProof(root[:], proof); err != ErrProofValue {
+		t.Fatalf("expected ErrProofValue, got %v", err)
+	}
+	proof.Values[0].Value = vals[string(keys[0])].v
+	proof.Values[len(keys)-1].Value = []byte("forged")
+	if err := VerifyKVPairProof(root[:], proof); err != ErrProofValue {
+		t.Fatalf("expected ErrProofValue, got %v", err)
+	}
+	proof.Values[len(keys)-1].Value = nil
+	// 缺少或者篡改证明节点
+	proof.Nodes = proof.Nodes[1:]
+	if err := VerifyKVPairProof(root[:], proof); err == nil {
+		t.Fatal("expected error for incomplete proof")
+	}
+}
+
 func randomTrie(n int) (*Trie, map[string]*kv) {
 	trie := new(Trie)
 	vals := make(map[string]*kv)
@@ -0,0 +1,140 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"encoding/binary"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+)
+
+// 节点引用计数
+// 开启引用计数之后写入的节点, 每被一个父节点引用或者作为状态根被引用一次, 计数加一,
+// 释放状态根时计数减一, 计数为0的节点从数据库中删除并级联释放它的子节点.
+// 开启之前写入的节点没有引用计数, 永远不会被删除.
+var refKeyPrefix = []byte("mpt-ref-")
+
+func refKey(hash common.Hash) []byte {
+	return append(append([]byte{}, refKeyPrefix...), hash[:]...)
+}
+
+// getRef 读取节点的引用计数, refs中缓存了本次修改但还没有写入数据库的计数
+func getRef(db dbm.KV, hash common.Hash, refs map[common.Hash]uint64) (uint64, error) {
+	if cnt, ok := refs[hash]; ok {
+		return cnt, nil
+	}
+	value, err := db.Get(refKey(hash))
+	if err == dbm.ErrNotFoundInDb || (err == nil && len(value) == 0) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	cnt, n := binary.Uvarint(value)
+	if n <= 0 {
+		return 0, ErrRefCount
+	}
+	return cnt, nil
+}
+
+func writeRefs(batch dbm.Batch, refs map[common.Hash]uint64) {
+	for hash, cnt := range refs {
+		if cnt == 0 {
+			batch.Delete(refKey(hash))
+			continue
+		}
+		buf := make([]byte, binary.MaxVarintLen64)
+		n := binary.PutUvarint(buf, cnt)
+		batch.Set(refKey(hash), buf[:n])
+	}
+}
+
+// EnableRefCount Commit时维护节点的引用计数, 状态根本身也增加一个引用
+func (db *Database) EnableRefCount() {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	db.refCount = true
+}
+
+// commitRef 和commit一样把缓存中的节点写入batch, 已经在数据库中的节点只增加引用计数
+func (db *Database) commitRef(hash common.Hash, batch dbm.Batch, refs map[common.Hash]uint64) error {
+	cnt, err := getRef(db.db, hash, refs)
+	if err != nil {
+		return err
+	}
+	if cnt > 0 {
+		refs[hash] = cnt + 1
+		return nil
+	}
+	// 不在缓存中又没有引用计数, 是开启引用计数之前写入的节点
+	node, ok := db.nodes[hash]
+	if !ok {
+		return nil
+	}
+	if _, err := db.db.Get(hash[:]); err == nil {
+		return nil
+	}
+	refs[hash] = 1
+	for _, child := range node.childs() {
+		if err := db.commitRef(child, batch, refs); err != nil {
+			return err
+		}
+	}
+	batch.Set(hash[:], node.proto())
+	return nil
+}
+
+// ReferenceRoot 给已经写入数据库的状态根增加一个引用, 用于固定历史状态
+func ReferenceRoot(db dbm.DB, batch dbm.Batch, root common.Hash) error {
+	refs := make(map[common.Hash]uint64)
+	cnt, err := getRef(db, root, refs)
+	if err != nil || cnt == 0 {
+		return err
+	}
+	refs[root] = cnt + 1
+	writeRefs(batch, refs)
+	return nil
+}
+
+// ReleaseRoot 释放状态根的一个引用, 把引用计数变为0的节点的删除操作写入batch, 返回删除的节点数
+func ReleaseRoot(db dbm.DB, batch dbm.Batch, root common.Hash) (int, error) {
+	refs := make(map[common.Hash]uint64)
+	deleted := 0
+	if err := releaseNode(db, batch, root, refs, &deleted); err != nil {
+		return 0, err
+	}
+	writeRefs(batch, refs)
+	return deleted, nil
+}
+
+func releaseNode(db dbm.DB, batch dbm.Batch, hash common.Hash, refs map[common.Hash]uint64, deleted *int) error {
+	cnt, err := getRef(db, hash, refs)
+	if err != nil || cnt == 0 {
+		return err
+	}
+	refs[hash] = cnt - 1
+	if cnt > 1 {
+		return nil
+	}
+	enc, err := db.Get(hash[:])
+	if err != nil {
+		return err
+	}
+	n, err := decodeNode(hash[:], enc, 0)
+	if err != nil {
+		return err
+	}
+	var children []common.Hash
+	gatherChildren(n, &children)
+	batch.Delete(hash[:])
+	*deleted++
+	for _, child := range children {
+		if err := releaseNode(db, batch, child, refs, deleted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func commitRefTrie(t *testing.T, memdb dbm.DB, root common.Hash, kvs map[string]string) common.Hash {
+	triedb := NewDatabase(memdb)
+	triedb.EnableRefCount()
+	trie, err := New(root, triedb)
+	assert.Nil(t, err)
+	for k, v := range kvs {
+		trie.Update([]byte(k), []byte(v))
+	}
+	newRoot, err := trie.Commit(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, trie.Commit2Db(newRoot, false))
+	return newRoot
+}
+
+func countNodes(memdb dbm.DB) int {
+	it := memdb.Iterator(nil, nil, false)
+	defer it.Close()
+	count := 0
+	for it.Rewind(); it.Valid(); it.Next() {
+		if len(it.Key()) == HashLength {
+			count++
+		}
+	}
+	return count
+}
+
+func releaseRoot(t *testing.T, memdb dbm.DB, root common.Hash) int {
+	batch := memdb.NewBatch(true)
+	deleted, err := ReleaseRoot(memdb, batch, root)
+	assert.Nil(t, err)
+	assert.Nil(t, batch.Write())
+	return deleted
+}
+
+func TestReleaseRoot(t *testing.T) {
+	memdb, _ := dbm.NewGoMemDB("gomemdb", "", 128)
+	kvs := make(map[string]string)
+	for i := 0; i < 200; i++ {
+		kvs[fmt.Sprintf("key-%04d", i)] = string(bytes.Repeat([]byte{byte(i)}, 40))
+	}
+	root1 := commitRefTrie(t, memdb, common.Hash{}, kvs)
+	assert.True(t, countNodes(memdb) > 0)
+
+	root2 := commitRefTrie(t, memdb, root1, map[string]string{"key-0001": "changed value which is long enough"})
+	assert.NotEqual(t, root1, root2)
+
+	// 释放旧的状态根只删除新状态根没有用到的节点
+	deleted := releaseRoot(t, memdb, root1)
+	assert.True(t, deleted > 0)
+	trie, err := New(root2, NewDatabase(memdb))
+	assert.Nil(t, err)
+	for k, v := range kvs {
+		if k == "key-0001" {
+			v = "changed value which is long enough"
+		}
+		value, err := trie.TryGet([]byte(k))
+		assert.Nil(t, err)
+		assert.Equal(t, v, string(value))
+	}
+	_, err = New(root1, NewDatabase(memdb))
+	assert.NotNil(t, err)
+
+	// 重复释放不会删除其他状态根的节点
+	assert.Equal(t, 0, releaseRoot(t, memdb, root1))
+
+	// 同一个状态根提交两次, 需要释放两次
+	nodes2 := countNodes(memdb)
+	assert.Equal(t, root2, commitRefTrie(t, memdb, root2, nil))
+	assert.Equal(t, 0, releaseRoot(t, memdb, root2))
+	assert.Equal(t, nodes2, countNodes(memdb))
+	assert.True(t, releaseRoot(t, memdb, root2) > 0)
+	assert.Equal(t, 0, countNodes(memdb))
+}
+
+func TestReferenceRoot(t *testing.T) {
+	memdb, _ := dbm.NewGoMemDB("gomemdb", "", 128)
+	kvs := make(map[string]string)
+	for i := 0; i < 50; i++ {
+		kvs[fmt.Sprintf("key-%04d", i)] = string(bytes.Repeat([]byte{byte(i)}, 40))
+	}
+	root := commitRefTrie(t, memdb, common.Hash{}, kvs)
+	batch := memdb.NewBatch(true)
+	assert.Nil(t, ReferenceRoot(memdb, batch, root))
+	assert.Nil(t, batch.Write())
+
+	assert.Equal(t, 0, releaseRoot(t, memdb, root))
+	_, err := New(root, NewDatabase(memdb))
+	assert.Nil(t, err)
+	assert.True(t, releaseRoot(t, memdb, root) > 0)
+	assert.Equal(t, 0, countNodes(memdb))
+}
+
+func TestReleaseLegacyRoot(t *testing.T) {
+	memdb, _ := dbm.NewGoMemDB("gomemdb", "", 128)
+	trie, _ := New(common.Hash{}, NewDatabase(memdb))
+	for i := 0; i < 50; i++ {
+		trie.Update([]byte(fmt.Sprintf("key-%04d", i)), bytes.Repeat([]byte{byte(i)}, 40))
+	}
+	root, err := trie.Commit(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, trie.Commit2Db(root, false))
+	nodes := countNodes(memdb)
+
+	// 开启引用计数之前写入的节点不会被删除
+	root2 := commitRefTrie(t, memdb, root, map[string]string{"key-0001": "changed value which is long enough"})
+	assert.Equal(t, 0, releaseRoot(t, memdb, root))
+	assert.True(t, releaseRoot(t, memdb, root2) > 0)
+	assert.Equal(t, nodes, countNodes(memdb))
+	_, err = New(root, NewDatabase(memdb))
+	assert.Nil(t, err)
+}
@@ -495,6 +495,11 @@ func NewEx(root common.Hash, db *Database) (*TrieEx, error) {
 	return trieEx, err
 }
 
+// Database 返回树使用的内存数据库, 在还没有提交的树上继续构造新树时共享节点
+func (t *TrieEx) Database() *Database {
+	return t.db
+}
+
 // Get returns the value for key stored in the trie
 func (t *TrieEx) Get(key []byte) []byte {
 	res, err := t.TryGet(key)
@@ -542,6 +547,37 @@ func (t *TrieEx) TryDelete(key []byte) error {
 	return t.Trie.TryDelete(key)
 }
 
+// Prove 生成一组key在当前状态根下的值和证明, 不存在的key生成不存在的证明
+func (t *TrieEx) Prove(keys [][]byte) (*StateProof, error) {
+	proofDb, err := dbm.NewGoMemDB("proof", "", 0)
+	if err != nil {
+		return nil, err
+	}
+	root := t.Hash()
+	proof := &StateProof{StateHash: root[:]}
+	for _, key := range keys {
+		value, err := t.TryGet(key)
+		if err != nil {
+			return nil, err
+		}
+		if enableSecure {
+			err = t.Trie.Prove(common.Sha3(key), 0, proofDb)
+		} else {
+			err = t.Trie.Prove(key, 0, proofDb)
+		}
+		if err != nil {
+			return nil, err
+		}
+		proof.Values = append(proof.Values, &ProofValue{Key: key, Value: value})
+	}
+	it := proofDb.Iterator(nil, nil, false)
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		proof.Nodes = append(proof.Nodes, it.ValueCopy())
+	}
+	return proof, nil
+}
+
 // Commit writes all nodes to the trie's memory database
 func (t *TrieEx) Commit(onleaf LeafCallback) (root common.Hash, err error) {
 	return t.Trie.Commit(onleaf)
@@ -597,16 +633,13 @@ func GetKVPair(db dbm.DB, storeGet *types.StoreGet) ([][]byte, error) {
 	return values, nil
 }
 
-// GetKVPairProof 获取指定k:v pair的proof证明
-func GetKVPairProof(db dbm.DB, roothash []byte, key []byte) []byte {
-	if enableSecure {
-		key = common.Sha3(key)
-	}
-	value, _, err := VerifyProof(common.BytesToHash(roothash), key, db)
-	if nil != err {
-		return nil
+// GetKVPairProof 获取一组key在状态根下的值和proof证明
+func GetKVPairProof(db dbm.DB, roothash []byte, keys [][]byte) (*StateProof, error) {
+	trie, err := NewEx(common.BytesToHash(roothash), NewDatabase(db))
+	if err != nil {
+		return nil, err
 	}
-	return value
+	return trie.Prove(keys)
 }
 
 // DelKVPair 剔除key对应的节点在本次tree中，返回新的roothash和key对应的value
@@ -639,13 +672,32 @@ func DelKVPair(db dbm.DB, storeDel *types.StoreGet) ([]byte, [][]byte, error) {
 	return hashByte, values, nil
 }
 
-// VerifyKVPairProof 验证KVPair 的证明
-func VerifyKVPairProof(db dbm.DB, roothash []byte, keyvalue types.KeyValue, proof []byte) bool {
-	if enableSecure {
-		keyvalue.Key = common.Sha3(keyvalue.Key)
+// VerifyKVPairProof 验证一组key在状态根下的值, 证明节点按照自身的hash索引, 不依赖提供证明的节点
+func VerifyKVPairProof(roothash []byte, proof *StateProof) error {
+	if proof == nil || !bytes.Equal(roothash, proof.StateHash) {
+		return ErrProofStateHash
+	}
+	proofDb, err := dbm.NewGoMemDB("proof", "", 0)
+	if err != nil {
+		return err
+	}
+	for _, node := range proof.Nodes {
+		proofDb.Set(common.Sha3(node), node)
 	}
-	_, _, err := VerifyProof(common.BytesToHash(roothash), keyvalue.Key, db)
-	return nil == err
+	for _, kv := range proof.Values {
+		key := kv.Key
+		if enableSecure {
+			key = common.Sha3(key)
+		}
+		value, _, err := VerifyProof(common.BytesToHash(roothash), key, proofDb)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(value, kv.Value) {
+			return ErrProofValue
+		}
+	}
+	return nil
 }
 
 // IterateRangeByStateHash 迭代实现功能； statehash：当前状态hash, start：开始查找的key, end: 结束的key, ascending：升序，降序, fn 迭代回调函数
@@ -665,6 +717,9 @@ func IterateRangeByStateHash(db dbm.DB, statehash, start, end []byte, ascending
 		it = NewIterator(di)
 	}
 	for it.Next() {
-		fn(it.Key, it.Value)
+		//回调返回true时停止迭代
+		if fn(it.Key, it.Value) {
+			return
+		}
 	}
 }
@@ -665,6 +665,9 @@ func IterateRangeByStateHash(db dbm.DB, statehash, start, end []byte, ascending
 		it = NewIterator(di)
 	}
 	for it.Next() {
-		fn(it.Key, it.Value)
+		//回调返回true时停止迭代
+		if fn(it.Key, it.Value) {
+			return
+		}
 	}
 }
@@ -5,6 +5,8 @@
 package mpt
 
 import (
+	"sync"
+
 	"github.com/33cn/chain33/common"
 	clog "github.com/33cn/chain33/common/log"
 	log "github.com/33cn/chain33/common/log/log15"
@@ -12,6 +14,7 @@ import (
 	drivers "github.com/33cn/chain33/system/store"
 	"github.com/33cn/chain33/types"
 	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+	mty "github.com/33cn/plugin/plugin/store/mpt/types"
 	lru "github.com/hashicorp/golang-lru"
 )
 
@@ -27,11 +30,51 @@ func DisableLog() {
 	mlog.SetHandler(log.DiscardHandler())
 }
 
+const (
+	// 默认最多保留的未提交树的数量
+	defaultMaxPendingTrees = 1000
+	// 默认共享节点缓存的节点数量
+	defaultNodeCacheSize = 100000
+	// 每隔多少个高度输出一次缓存统计
+	statsLogInterval = 1000
+)
+
 // Store mpt store struct
 type Store struct {
 	*drivers.BaseStore
-	trees map[string]*mpt.TrieEx
-	cache *lru.Cache
+	// 并行执行区块时多个协程同时访问未提交的树
+	mu        sync.RWMutex
+	trees     map[string]*memTree
+	maxTrees  int
+	cache     *lru.Cache
+	nodeCache *mpt.NodeCache
+	pruner    *pruner
+}
+
+// memTree 还没有提交的树和它所在的高度, 树本身不支持并发访问
+type memTree struct {
+	mu     sync.Mutex
+	tree   *mpt.TrieEx
+	height int64
+}
+
+// Stats mpt存储的缓存统计
+type Stats struct {
+	PendingTrees int
+	NodeCache    mpt.NodeCacheStats
+}
+
+type subConfig struct {
+	// 是否开启裁剪, 开启之后写入的节点维护引用计数, 超出保留范围的状态根在后台释放
+	EnableMptPrune bool `json:"enableMptPrune"`
+	// 保留最近多少个高度的状态根
+	PruneHeight int64 `json:"pruneHeight"`
+	// 最多保留多少个未提交的树, 超出时先淘汰高度最低的树
+	MaxPendingTrees int `json:"maxPendingTrees"`
+	// 多个状态根共享的节点缓存大小
+	NodeCacheSize int `json:"nodeCacheSize"`
+	// 固定的历史状态根(十六进制), 开启裁剪时不会被裁剪, 启动时取消不在配置中的固定
+	PinRoots []string `json:"pinRoots"`
 }
 
 func init() {
@@ -40,21 +83,87 @@ func init() {
 
 // New new mpt store module
 func New(cfg *types.Store, sub []byte, chain33cfg *types.Chain33Config) queue.Module {
+	var subcfg subConfig
+	if sub != nil {
+		types.MustDecode(sub, &subcfg)
+	}
+	if subcfg.MaxPendingTrees <= 0 {
+		subcfg.MaxPendingTrees = defaultMaxPendingTrees
+	}
+	if subcfg.NodeCacheSize <= 0 {
+		subcfg.NodeCacheSize = defaultNodeCacheSize
+	}
 	bs := drivers.NewBaseStore(cfg)
-	mpts := &Store{bs, make(map[string]*mpt.TrieEx), nil}
+	mpts := &Store{BaseStore: bs, trees: make(map[string]*memTree), maxTrees: subcfg.MaxPendingTrees}
 	mpts.cache, _ = lru.New(10)
+	mpts.nodeCache, _ = mpt.NewNodeCache(subcfg.NodeCacheSize)
+	if subcfg.EnableMptPrune {
+		mpts.pruner = newPruner(bs.GetDB(), subcfg.PruneHeight, mpts.nodeCache)
+		mpts.syncPinnedRoots(subcfg.PinRoots)
+	}
 	bs.SetChild(mpts)
 	return mpts
 }
 
+// syncPinnedRoots 固定配置中的状态根, 之前固定但是已经不在配置中的状态根取消固定
+func (mpts *Store) syncPinnedRoots(roots []string) {
+	pins := make(map[string]bool)
+	for _, root := range roots {
+		hash, err := common.FromHex(root)
+		if err != nil || len(hash) != common.Sha256Len {
+			mlog.Error("mpt pin root invalid", "root", root)
+			continue
+		}
+		err = mpts.PinRoot(hash)
+		if err != nil {
+			mlog.Error("mpt pin root", "root", root, "err", err)
+			continue
+		}
+		pins[string(hash)] = true
+	}
+	for _, hash := range mpts.PinnedRoots() {
+		if pins[string(hash)] {
+			continue
+		}
+		err := mpts.UnpinRoot(hash)
+		if err != nil {
+			mlog.Error("mpt unpin root", "root", common.ToHex(hash), "err", err)
+		}
+	}
+	mlog.Info("mpt pinned roots", "count", len(pins))
+}
+
 // Close close mpt store
 func (mpts *Store) Close() {
+	if mpts.pruner != nil {
+		mpts.pruner.close()
+	}
 	mpts.BaseStore.Close()
 	mlog.Info("store mavl closed")
 }
 
+func (mpts *Store) newDatabase() *mpt.Database {
+	db := mpt.NewDatabaseWithCache(mpts.GetDB(), mpts.nodeCache)
+	if mpts.pruner != nil {
+		db.EnableRefCount()
+	}
+	return db
+}
+
 // Set set k v to mpt store db; sync is true represent write sync
 func (mpts *Store) Set(datas *types.StoreSet, sync bool) ([]byte, error) {
+	if mpts.pruner != nil {
+		tree, hash, err := mpts.memSet(datas)
+		if err != nil {
+			return nil, err
+		}
+		err = mpts.pruner.commit(tree, hash, datas.Height)
+		if err != nil {
+			mlog.Error("mpt store error", "err", err)
+			return nil, err
+		}
+		return hash, nil
+	}
 	hash, err := mpt.SetKVPair(mpts.GetDB(), datas, sync)
 	if err != nil {
 		mlog.Error("mpt store error", "err", err)
@@ -65,27 +174,13 @@ func (mpts *Store) Set(datas *types.StoreSet, sync bool) ([]byte, error) {
 
 // Get get values by keys
 func (mpts *Store) Get(datas *types.StoreGet) [][]byte {
-	var tree *mpt.TrieEx
-	var err error
 	values := make([][]byte, len(datas.Keys))
-	search := string(datas.StateHash)
-	if data, ok := mpts.cache.Get(search); ok {
-		tree = data.(*mpt.TrieEx)
-	} else if data, ok := mpts.trees[search]; ok {
-		tree = data
-	} else {
-		tree, err = mpt.NewEx(common.BytesToHash(datas.StateHash), mpt.NewDatabase(mpts.GetDB()))
-		if nil != err {
-			mlog.Error("Store get can not find a trie")
-		}
-		if nil == err {
-			mpts.cache.Add(search, tree)
-		}
-		mlog.Debug("store mpt get tree", "err", err, "StateHash", common.ToHex(datas.StateHash))
-	}
+	data, err := mpts.getTree(datas.StateHash)
 	if err == nil {
+		data.mu.Lock()
+		defer data.mu.Unlock()
 		for i := 0; i < len(datas.Keys); i++ {
-			value, err := tree.TryGet(datas.Keys[i])
+			value, err := data.tree.TryGet(datas.Keys[i])
 			if nil == err {
 				values[i] = value
 			}
@@ -94,61 +189,146 @@ func (mpts *Store) Get(datas *types.StoreGet) [][]byte {
 	return values
 }
 
+// getTree 依次从缓存, 未提交的树和数据库中查找状态根对应的树
+func (mpts *Store) getTree(stateHash []byte) (*memTree, error) {
+	search := string(stateHash)
+	if data, ok := mpts.cache.Get(search); ok {
+		return data.(*memTree), nil
+	}
+	if data, ok := mpts.pendingTree(stateHash); ok {
+		return data, nil
+	}
+	tree, err := mpt.NewEx(common.BytesToHash(stateHash), mpt.NewDatabaseWithCache(mpts.GetDB(), mpts.nodeCache))
+	if nil != err {
+		mlog.Error("Store get can not find a trie")
+	}
+	data := &memTree{tree: tree}
+	if nil == err {
+		mpts.cache.Add(search, data)
+	}
+	mlog.Debug("store mpt get tree", "err", err, "StateHash", common.ToHex(stateHash))
+	return data, err
+}
+
+func (mpts *Store) pendingTree(stateHash []byte) (*memTree, bool) {
+	mpts.mu.RLock()
+	defer mpts.mu.RUnlock()
+	data, ok := mpts.trees[string(stateHash)]
+	return data, ok
+}
+
+// addTree 记录未提交的树, 超出上限时淘汰高度最低的树
+func (mpts *Store) addTree(hash []byte, data *memTree) {
+	mpts.mu.Lock()
+	defer mpts.mu.Unlock()
+	mpts.trees[string(hash)] = data
+	for len(mpts.trees) > mpts.maxTrees {
+		var oldest string
+		var height int64 = -1
+		for key, tree := range mpts.trees {
+			if key != string(hash) && (height < 0 || tree.height < height) {
+				oldest, height = key, tree.height
+			}
+		}
+		delete(mpts.trees, oldest)
+		mlog.Error("too many trees in cache, evict the lowest one", "height", height, "hash", common.ToHex([]byte(oldest)))
+	}
+}
+
+// removeTree 提交之后删除该树和所有更低高度的树, 这些树所在的分叉已经不会再被提交
+func (mpts *Store) removeTree(hash []byte, height int64) {
+	mpts.mu.Lock()
+	defer mpts.mu.Unlock()
+	delete(mpts.trees, string(hash))
+	for key, tree := range mpts.trees {
+		if tree.height < height {
+			delete(mpts.trees, key)
+		}
+	}
+}
+
+// Stats 返回未提交树的数量和共享节点缓存的命中情况
+func (mpts *Store) Stats() *Stats {
+	mpts.mu.RLock()
+	defer mpts.mu.RUnlock()
+	return &Stats{PendingTrees: len(mpts.trees), NodeCache: mpts.nodeCache.Stats()}
+}
+
 // MemSet set keys values to memcory mpt, return root hash and error
 func (mpts *Store) MemSet(datas *types.StoreSet, sync bool) ([]byte, error) {
-	var err error
-	var tree *mpt.TrieEx
-	tree, err = mpt.NewEx(common.BytesToHash(datas.StateHash), mpt.NewDatabase(mpts.GetDB()))
+	tree, hash, err := mpts.memSet(datas)
 	if err != nil {
-		mlog.Info("MemSet create a new trie", "err", err)
 		return nil, err
 	}
+	mpts.addTree(hash, &memTree{tree: tree, height: datas.Height})
+	return hash, nil
+}
+
+func (mpts *Store) memSet(datas *types.StoreSet) (*mpt.TrieEx, []byte, error) {
+	//父状态还没有提交时在它的内存数据库上构造新树, 兄弟分叉共享父状态的节点
+	db := mpts.newDatabase()
+	if parent, ok := mpts.pendingTree(datas.StateHash); ok {
+		db = parent.tree.Database()
+	}
+	tree, err := mpt.NewEx(common.BytesToHash(datas.StateHash), db)
+	if err != nil {
+		mlog.Info("MemSet create a new trie", "err", err)
+		return nil, nil, err
+	}
 	for i := 0; i < len(datas.KV); i++ {
 		tree.Update(datas.KV[i].Key, datas.KV[i].Value)
 	}
 	root, err := tree.Commit(nil)
 	if err != nil {
 		mlog.Error("MemSet Commit to memory trie fail")
-		return nil, err
+		return nil, nil, err
 	}
-	hash := root[:]
-	mpts.trees[string(hash)] = tree
-	if len(mpts.trees) > 1000 {
-		mlog.Error("too many trees in cache")
-	}
-	return hash, nil
+	return tree, root[:], nil
 }
 
 // Commit convert memcory mpt to storage db
 func (mpts *Store) Commit(req *types.ReqHash) ([]byte, error) {
-	tree, ok := mpts.trees[string(req.Hash)]
+	data, ok := mpts.pendingTree(req.Hash)
 	if !ok {
 		mlog.Error("store mpt commit", "err", types.ErrHashNotFound)
 		return nil, types.ErrHashNotFound
 	}
-	err := tree.Commit2Db(common.BytesToHash(req.Hash), true)
+	var err error
+	data.mu.Lock()
+	if mpts.pruner != nil {
+		err = mpts.pruner.commit(data.tree, req.Hash, data.height)
+	} else {
+		err = data.tree.Commit2Db(common.BytesToHash(req.Hash), true)
+	}
+	data.mu.Unlock()
 	if nil != err {
 		mlog.Error("store mpt commit", "err", types.ErrHashNotFound)
 		return nil, types.ErrDataBaseDamage
 	}
-	delete(mpts.trees, string(req.Hash))
+	mpts.removeTree(req.Hash, data.height)
+	if data.height%statsLogInterval == 0 {
+		stats := mpts.Stats()
+		mlog.Info("store mpt cache stats", "height", data.height, "pendingTrees", stats.PendingTrees,
+			"nodeCacheLen", stats.NodeCache.Len, "hits", stats.NodeCache.Hits, "misses", stats.NodeCache.Misses,
+			"hitRate", stats.NodeCache.HitRate())
+	}
 	return req.Hash, nil
 }
 
 // MemSetUpgrade set keys values to memcory mpt, return root hash and error
 func (mpts *Store) MemSetUpgrade(datas *types.StoreSet, sync bool) ([]byte, error) {
-	//not support
-	return nil, nil
+	return mpts.MemSet(datas, sync)
 }
 
 // CommitUpgrade convert memcory mpt to storage db
 func (mpts *Store) CommitUpgrade(req *types.ReqHash) ([]byte, error) {
-	//not support
-	return nil, nil
+	return mpts.Commit(req)
 }
 
 // Rollback 回退将缓存的mpt树删除掉
 func (mpts *Store) Rollback(req *types.ReqHash) ([]byte, error) {
+	mpts.mu.Lock()
+	defer mpts.mu.Unlock()
 	_, ok := mpts.trees[string(req.Hash)]
 	if !ok {
 		mlog.Error("store mavl rollback", "err", types.ErrHashNotFound)
@@ -158,10 +338,45 @@ func (mpts *Store) Rollback(req *types.ReqHash) ([]byte, error) {
 	return req.Hash, nil
 }
 
-// Del ...
+// Del 区块回滚时释放该高度的状态根, 只在开启裁剪时有效
 func (mpts *Store) Del(req *types.StoreDel) ([]byte, error) {
-	//not support
-	return nil, nil
+	if mpts.pruner == nil {
+		return req.StateHash, nil
+	}
+	mpts.cache.Remove(string(req.StateHash))
+	err := mpts.pruner.del(req.StateHash, req.Height)
+	if err != nil {
+		mlog.Error("store mpt del", "height", req.Height, "err", err)
+		return nil, err
+	}
+	return req.StateHash, nil
+}
+
+// PinRoot 固定一个历史状态根, 固定的状态根不会被裁剪, 比如回滚时需要用到的状态
+func (mpts *Store) PinRoot(hash []byte) error {
+	if mpts.pruner == nil {
+		return types.ErrNotSupport
+	}
+	if _, err := mpt.NewEx(common.BytesToHash(hash), mpt.NewDatabaseWithCache(mpts.GetDB(), mpts.nodeCache)); err != nil {
+		return types.ErrHashNotFound
+	}
+	return mpts.pruner.pin(hash)
+}
+
+// UnpinRoot 取消固定, 超出保留范围的状态根会在后台被裁剪
+func (mpts *Store) UnpinRoot(hash []byte) error {
+	if mpts.pruner == nil {
+		return types.ErrNotSupport
+	}
+	return mpts.pruner.unpin(hash)
+}
+
+// PinnedRoots 列出所有被固定的状态根
+func (mpts *Store) PinnedRoots() [][]byte {
+	if mpts.pruner == nil {
+		return nil
+	}
+	return mpts.pruner.pinnedRoots()
 }
 
 // IterateRangeByStateHash 迭代实现功能； statehash：当前状态hash, start：开始查找的key, end: 结束的key, ascending：升序，降序, fn 迭代回调函数
@@ -169,10 +384,25 @@ func (mpts *Store) IterateRangeByStateHash(statehash []byte, start []byte, end [
 	mpt.IterateRangeByStateHash(mpts.GetDB(), statehash, start, end, ascending, fn)
 }
 
-// ProcEvent not support message
+// ProcEvent 处理store模块没有定义的消息, 目前支持获取状态证明
 func (mpts *Store) ProcEvent(msg *queue.Message) {
 	if msg == nil {
 		return
 	}
-	msg.ReplyErr("Store", types.ErrActionNotSupport)
+	switch msg.Ty {
+	case mty.EventStoreGetProof:
+		req, ok := msg.GetData().(*types.StoreGet)
+		if !ok {
+			msg.Reply(queue.NewMessage(0, "", mty.EventStoreGetProofReply, types.ErrInvalidParam))
+			return
+		}
+		proof, err := mpts.GetProof(req)
+		if err != nil {
+			msg.Reply(queue.NewMessage(0, "", mty.EventStoreGetProofReply, err))
+			return
+		}
+		msg.Reply(queue.NewMessage(0, "", mty.EventStoreGetProofReply, proof))
+	default:
+		msg.ReplyErr("Store", types.ErrActionNotSupport)
+	}
 }
@@ -7,14 +7,18 @@ package mpt
 import (
 	"io/ioutil"
 	"os"
+	"sync"
 	"testing"
 
 	"fmt"
 	"time"
 
 	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/queue"
 	drivers "github.com/33cn/chain33/system/store"
 	"github.com/33cn/chain33/types"
+	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+	mty "github.com/33cn/plugin/plugin/store/mpt/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -115,7 +119,6 @@ func TestKvdbMemSet(t *testing.T) {
 }
 
 func TestKvmvccdbMemSetUpgrade(t *testing.T) {
-	// not support
 	dir, err := ioutil.TempDir("", "example")
 	assert.Nil(t, err)
 	defer os.RemoveAll(dir) // clean up
@@ -123,11 +126,16 @@ func TestKvmvccdbMemSetUpgrade(t *testing.T) {
 	var storeCfg = newStoreCfg(dir)
 	store := New(storeCfg, nil, nil).(*Store)
 	assert.NotNil(t, store)
-	store.MemSetUpgrade(nil, false)
+
+	kv := []*types.KeyValue{{Key: []byte("mk1"), Value: []byte("v1")}}
+	datas := &types.StoreSet{StateHash: drivers.EmptyRoot[:], KV: kv, Height: 0}
+	hash, err := store.MemSetUpgrade(datas, false)
+	assert.Nil(t, err)
+	values := store.Get(&types.StoreGet{StateHash: hash, Keys: [][]byte{[]byte("mk1")}})
+	assert.Equal(t, []byte("v1"), values[0])
 }
 
 func TestKvmvccdbCommitUpgrade(t *testing.T) {
-	// not support
 	dir, err := ioutil.TempDir("", "example")
 	assert.Nil(t, err)
 	defer os.RemoveAll(dir) // clean up
@@ -135,7 +143,16 @@ func TestKvmvccdbCommitUpgrade(t *testing.T) {
 	var storeCfg = newStoreCfg(dir)
 	store := New(storeCfg, nil, nil).(*Store)
 	assert.NotNil(t, store)
-	store.CommitUpgrade(nil)
+
+	kv := []*types.KeyValue{{Key: []byte("mk1"), Value: []byte("v1")}}
+	datas := &types.StoreSet{StateHash: drivers.EmptyRoot[:], KV: kv, Height: 0}
+	hash, err := store.MemSetUpgrade(datas, false)
+	assert.Nil(t, err)
+	actHash, err := store.CommitUpgrade(&types.ReqHash{Hash: hash})
+	assert.Nil(t, err)
+	assert.Equal(t, hash, actHash)
+	_, err = store.CommitUpgrade(&types.ReqHash{Hash: hash})
+	assert.Equal(t, types.ErrHashNotFound, err)
 }
 
 func TestKvdbRollback(t *testing.T) {
@@ -319,3 +336,250 @@ func BenchmarkCommit(b *testing.B) {
 	fmt.Println("mpt BenchmarkCommit cost time is", end.Sub(start), "num is", b.N)
 	b.StopTimer()
 }
+
+func TestPrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	os.RemoveAll(dir)       //删除已存在目录
+	var storeCfg = newStoreCfg(dir)
+	store := New(storeCfg, []byte(`{"enableMptPrune":true,"pruneHeight":2}`), nil).(*Store)
+	assert.NotNil(t, store)
+	defer store.Close()
+
+	key := []byte("key")
+	roots := make([][]byte, 0)
+	hash := drivers.EmptyRoot[:]
+	for i := 0; i < 6; i++ {
+		var kv []*types.KeyValue
+		kv = append(kv, &types.KeyValue{Key: key, Value: []byte(fmt.Sprintf("value-%d-%s", i, GetRandomString(64)))})
+		for j := 0; j < 20; j++ {
+			kv = append(kv, &types.KeyValue{Key: []byte(fmt.Sprintf("key-%d", j)), Value: []byte(GetRandomString(64))})
+		}
+		hash, err = store.MemSet(&types.StoreSet{StateHash: hash, KV: kv, Height: int64(i)}, true)
+		assert.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash})
+		assert.Nil(t, err)
+		roots = append(roots, hash)
+		if i == 1 {
+			assert.Nil(t, store.PinRoot(hash))
+		}
+	}
+	assert.Equal(t, [][]byte{roots[1]}, store.PinnedRoots())
+
+	exist := func(root []byte) bool {
+		store.cache.Purge()
+		return store.Get(&types.StoreGet{StateHash: root, Keys: [][]byte{key}})[0] != nil
+	}
+	// 后台裁剪高度不超过3的状态根, 被固定的状态根保留
+	for i := 0; i < 100 && exist(roots[3]); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.False(t, exist(roots[0]))
+	assert.True(t, exist(roots[1]))
+	assert.False(t, exist(roots[2]))
+	assert.False(t, exist(roots[3]))
+	assert.True(t, exist(roots[4]))
+	assert.True(t, exist(roots[5]))
+
+	assert.Nil(t, store.UnpinRoot(roots[1]))
+	assert.False(t, exist(roots[1]))
+	assert.Equal(t, types.ErrNotFound, store.UnpinRoot(roots[1]))
+
+	// 回滚区块时释放对应的状态根
+	_, err = store.Del(&types.StoreDel{StateHash: roots[5], Height: 5})
+	assert.Nil(t, err)
+	assert.False(t, exist(roots[5]))
+	assert.True(t, exist(roots[4]))
+}
+
+func TestPinRootsConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	var storeCfg = newStoreCfg(dir)
+	prune := `{"enableMptPrune":true,"pruneHeight":2}`
+	store := New(storeCfg, []byte(prune), nil).(*Store)
+
+	key := []byte("key")
+	roots := make([][]byte, 0)
+	hash := drivers.EmptyRoot[:]
+	commit := func(height int) {
+		kv := []*types.KeyValue{{Key: key, Value: []byte(fmt.Sprintf("value-%d", height))}}
+		hash, err = store.MemSet(&types.StoreSet{StateHash: hash, KV: kv, Height: int64(height)}, true)
+		assert.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash})
+		assert.Nil(t, err)
+		roots = append(roots, hash)
+	}
+	exist := func(root []byte) bool {
+		store.cache.Purge()
+		return store.Get(&types.StoreGet{StateHash: root, Keys: [][]byte{key}})[0] != nil
+	}
+	commit(0)
+	commit(1)
+	store.Close()
+
+	// 重启时固定配置中的状态根, 无效的配置被忽略
+	pin := fmt.Sprintf(`{"enableMptPrune":true,"pruneHeight":2,"pinRoots":["%s","0x01"]}`, common.ToHex(roots[1]))
+	store = New(storeCfg, []byte(pin), nil).(*Store)
+	assert.Equal(t, [][]byte{roots[1]}, store.PinnedRoots())
+	for i := 2; i < 6; i++ {
+		commit(i)
+	}
+	for i := 0; i < 100 && exist(roots[3]); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.False(t, exist(roots[0]))
+	assert.True(t, exist(roots[1]))
+	assert.False(t, exist(roots[3]))
+	store.Close()
+
+	// 移出配置后重启时取消固定, 已经超出保留范围的状态根被释放
+	store = New(storeCfg, []byte(prune), nil).(*Store)
+	defer store.Close()
+	assert.Equal(t, 0, len(store.PinnedRoots()))
+	assert.False(t, exist(roots[1]))
+	assert.True(t, exist(roots[5]))
+}
+
+// getStateProof 通过store模块获取状态证明
+func getStateProof(client queue.Client, stateHash []byte, keys [][]byte) (*mpt.StateProof, error) {
+	msg := client.NewMessage("store", mty.EventStoreGetProof, &types.StoreGet{StateHash: stateHash, Keys: keys})
+	err := client.Send(msg, true)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Wait(msg)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetData().(*mpt.StateProof), nil
+}
+
+func TestGetProof(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	os.RemoveAll(dir)       //删除已存在目录
+	var storeCfg = newStoreCfg(dir)
+	store := New(storeCfg, nil, nil).(*Store)
+	assert.NotNil(t, store)
+	q := queue.New("channel")
+	store.SetQueueClient(q.Client())
+	defer store.Close()
+
+	var kv []*types.KeyValue
+	for i := 0; i < 50; i++ {
+		kv = append(kv, &types.KeyValue{Key: []byte(fmt.Sprintf("key-%d", i)), Value: []byte(GetRandomString(32))})
+	}
+	hash, err := store.Set(&types.StoreSet{StateHash: drivers.EmptyRoot[:], KV: kv, Height: 0}, true)
+	assert.Nil(t, err)
+
+	keys := [][]byte{kv[0].Key, kv[10].Key, []byte("not-exist")}
+	proof, err := getStateProof(q.Client(), hash, keys)
+	assert.Nil(t, err)
+	assert.Nil(t, VerifyStateProof(hash, proof))
+	assert.Equal(t, 3, len(proof.Values))
+	assert.Equal(t, kv[0].Value, proof.Values[0].Value)
+	assert.Equal(t, kv[10].Value, proof.Values[1].Value)
+	assert.Nil(t, proof.Values[2].Value)
+
+	// 还没有提交的状态根也可以生成证明
+	kv2 := []*types.KeyValue{{Key: kv[0].Key, Value: []byte("changed")}}
+	hash2, err := store.MemSet(&types.StoreSet{StateHash: hash, KV: kv2, Height: 1}, true)
+	assert.Nil(t, err)
+	proof2, err := store.GetProof(&types.StoreGet{StateHash: hash2, Keys: keys[:1]})
+	assert.Nil(t, err)
+	assert.Nil(t, VerifyStateProof(hash2, proof2))
+	assert.Equal(t, []byte("changed"), proof2.Values[0].Value)
+	assert.NotNil(t, VerifyStateProof(hash, proof2))
+
+	_, err = getStateProof(q.Client(), common.Sha256([]byte("unknown")), keys)
+	assert.Equal(t, types.ErrHashNotFound, err)
+	_, err = getStateProof(q.Client(), hash, nil)
+	assert.Equal(t, types.ErrInvalidParam, err)
+}
+
+func TestPendingTrees(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	os.RemoveAll(dir)       //删除已存在目录
+	var storeCfg = newStoreCfg(dir)
+	store := New(storeCfg, []byte(`{"maxPendingTrees":3}`), nil).(*Store)
+	assert.NotNil(t, store)
+	defer store.Close()
+
+	memSet := func(parent []byte, height int64, value string) []byte {
+		kv := []*types.KeyValue{{Key: []byte("key"), Value: []byte(value)}, {Key: []byte(value), Value: []byte(value)}}
+		hash, err := store.MemSet(&types.StoreSet{StateHash: parent, KV: kv, Height: height}, true)
+		assert.Nil(t, err)
+		return hash
+	}
+	// 父状态还没有提交时也可以构造分叉
+	parent := memSet(drivers.EmptyRoot[:], 1, "parent")
+	fork1 := memSet(parent, 2, "fork1")
+	fork2 := memSet(parent, 2, "fork2")
+	assert.Equal(t, 3, store.Stats().PendingTrees)
+	assert.Equal(t, []byte("fork1"), store.Get(&types.StoreGet{StateHash: fork1, Keys: [][]byte{[]byte("key")}})[0])
+	assert.Equal(t, []byte("parent"), store.Get(&types.StoreGet{StateHash: fork2, Keys: [][]byte{[]byte("parent")}})[0])
+
+	// 超出上限时淘汰高度最低的树
+	fork3 := memSet(fork1, 3, "fork3")
+	assert.Equal(t, 3, store.Stats().PendingTrees)
+	_, err = store.Commit(&types.ReqHash{Hash: parent})
+	assert.Equal(t, types.ErrHashNotFound, err)
+
+	// 提交子状态时写入父状态的节点
+	_, err = store.Commit(&types.ReqHash{Hash: fork1})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, store.Stats().PendingTrees)
+	store.cache.Purge()
+	values := store.Get(&types.StoreGet{StateHash: fork1, Keys: [][]byte{[]byte("key"), []byte("parent"), []byte("fork2")}})
+	assert.Equal(t, [][]byte{[]byte("fork1"), []byte("parent"), nil}, values)
+
+	// 提交之后淘汰更低高度的分叉
+	_, err = store.Commit(&types.ReqHash{Hash: fork3})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, store.Stats().PendingTrees)
+	_, err = store.Commit(&types.ReqHash{Hash: fork2})
+	assert.Equal(t, types.ErrHashNotFound, err)
+	assert.True(t, store.Stats().NodeCache.Len > 0)
+}
+
+func TestConcurrentMemSet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	os.RemoveAll(dir)       //删除已存在目录
+	var storeCfg = newStoreCfg(dir)
+	store := New(storeCfg, nil, nil).(*Store)
+	assert.NotNil(t, store)
+	defer store.Close()
+
+	kv := []*types.KeyValue{{Key: []byte("key"), Value: []byte("value")}}
+	root, err := store.Set(&types.StoreSet{StateHash: drivers.EmptyRoot[:], KV: kv, Height: 0}, true)
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				kv := []*types.KeyValue{{Key: []byte(fmt.Sprintf("key-%d-%d", i, j)), Value: []byte("value")}}
+				hash, err := store.MemSet(&types.StoreSet{StateHash: root, KV: kv, Height: 1}, true)
+				assert.Nil(t, err)
+				values := store.Get(&types.StoreGet{StateHash: hash, Keys: [][]byte{[]byte("key"), kv[0].Key}})
+				assert.Equal(t, [][]byte{[]byte("value"), []byte("value")}, values)
+				if j%2 == 0 {
+					_, err = store.Rollback(&types.ReqHash{Hash: hash})
+					assert.Nil(t, err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 80, store.Stats().PendingTrees)
+}
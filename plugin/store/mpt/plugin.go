@@ -0,0 +1,24 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"github.com/33cn/chain33/pluginmgr"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/mpt/commands"
+	"github.com/33cn/plugin/plugin/store/mpt/rpc"
+	mty "github.com/33cn/plugin/plugin/store/mpt/types"
+)
+
+func init() {
+	pluginmgr.Register(&pluginmgr.PluginBase{
+		Name:     mty.MptX,
+		ExecName: mty.MptX,
+		//存储没有执行器, 只注册获取状态证明的rpc和命令行
+		Exec: func(name string, cfg *types.Chain33Config, sub []byte) {},
+		Cmd:  commands.MptCmd,
+		RPC:  rpc.Init,
+	})
+}
@@ -0,0 +1,35 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+)
+
+// GetProof 获取一组key在状态根下的值和证明, 状态根可以是还没有提交的树
+func (mpts *Store) GetProof(req *types.StoreGet) (*mpt.StateProof, error) {
+	if req == nil || len(req.Keys) == 0 {
+		return nil, types.ErrInvalidParam
+	}
+	data, err := mpts.getTree(req.StateHash)
+	if err != nil {
+		return nil, types.ErrHashNotFound
+	}
+	data.mu.Lock()
+	proof, err := data.tree.Prove(req.Keys)
+	data.mu.Unlock()
+	if err != nil {
+		mlog.Error("store mpt get proof", "StateHash", common.ToHex(req.StateHash), "err", err)
+		return nil, err
+	}
+	return proof, nil
+}
+
+// VerifyStateProof 用区块头中的状态根验证证明, 不需要访问数据库, 轻节点和平行链可以直接使用
+func VerifyStateProof(stateHash []byte, proof *mpt.StateProof) error {
+	return mpt.VerifyKVPairProof(stateHash, proof)
+}
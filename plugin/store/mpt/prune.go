@@ -0,0 +1,220 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+)
+
+const (
+	// 默认保留最近10000个高度的状态根
+	defaultPruneKeepHeight = 10000
+)
+
+var (
+	// mpt-root-{height}{roothash} 记录开启裁剪之后每个高度提交的状态根
+	rootKeyPrefix = []byte("mpt-root-")
+	// mpt-pin-{roothash} 记录被固定的状态根
+	pinKeyPrefix = []byte("mpt-pin-")
+)
+
+func rootKey(height int64, hash []byte) []byte {
+	key := make([]byte, 0, len(rootKeyPrefix)+8+len(hash))
+	key = append(key, rootKeyPrefix...)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(height))
+	key = append(key, buf[:]...)
+	return append(key, hash...)
+}
+
+func parseRootKey(key []byte) (int64, []byte) {
+	key = key[len(rootKeyPrefix):]
+	return int64(binary.BigEndian.Uint64(key[:8])), key[8:]
+}
+
+func pinKey(hash []byte) []byte {
+	return append(append([]byte{}, pinKeyPrefix...), hash...)
+}
+
+// pruner 维护最近的状态根, 在后台释放超出保留范围的状态根
+type pruner struct {
+	db         dbm.DB
+	cache      *mpt.NodeCache
+	keepHeight int64
+	// 提交和裁剪都会修改节点的引用计数, 需要互斥
+	mu     sync.Mutex
+	notify chan int64
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newPruner(db dbm.DB, keepHeight int64, cache *mpt.NodeCache) *pruner {
+	if keepHeight <= 0 {
+		keepHeight = defaultPruneKeepHeight
+	}
+	p := &pruner{
+		db:         db,
+		cache:      cache,
+		keepHeight: keepHeight,
+		notify:     make(chan int64, 1),
+		done:       make(chan struct{}),
+	}
+	p.wg.Add(1)
+	go p.loop()
+	return p
+}
+
+func (p *pruner) close() {
+	close(p.done)
+	p.wg.Wait()
+}
+
+func (p *pruner) loop() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.done:
+			return
+		case height := <-p.notify:
+			p.prune(height - p.keepHeight)
+		}
+	}
+}
+
+// commit 把状态根写入数据库并记录提交的高度
+// 先记录高度再写节点, 中途退出时只会留下一条释放时没有效果的记录
+func (p *pruner) commit(tree *mpt.TrieEx, root []byte, height int64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	err := p.db.SetSync(rootKey(height, root), []byte{})
+	if err != nil {
+		return err
+	}
+	err = tree.Commit2Db(common.BytesToHash(root), true)
+	if err != nil {
+		return err
+	}
+	//只保留最新的高度, 后台裁剪时处理所有更早的状态根
+	select {
+	case <-p.notify:
+	default:
+	}
+	p.notify <- height
+	return nil
+}
+
+// prune 释放所有高度不超过maxHeight的状态根
+func (p *pruner) prune(maxHeight int64) {
+	if maxHeight < 0 {
+		return
+	}
+	var keys [][]byte
+	it := p.db.Iterator(rootKeyPrefix, nil, false)
+	for it.Rewind(); it.Valid(); it.Next() {
+		if bytes.Compare(it.Key(), rootKey(maxHeight+1, nil)) >= 0 {
+			break
+		}
+		keys = append(keys, common.CopyBytes(it.Key()))
+	}
+	it.Close()
+
+	total := 0
+	for _, key := range keys {
+		select {
+		case <-p.done:
+			return
+		default:
+		}
+		height, root := parseRootKey(key)
+		deleted, err := p.release(key, root)
+		if err != nil {
+			mlog.Error("prune mpt root", "height", height, "root", common.ToHex(root), "err", err)
+			return
+		}
+		total += deleted
+	}
+	if len(keys) > 0 {
+		mlog.Info("prune mpt roots", "maxHeight", maxHeight, "roots", len(keys), "nodes", total)
+	}
+}
+
+// newBatch 删除节点时同步更新共享的节点缓存
+func (p *pruner) newBatch() dbm.Batch {
+	batch := p.db.NewBatch(true)
+	if p.cache != nil {
+		batch = p.cache.WrapBatch(batch)
+	}
+	return batch
+}
+
+// release 删除高度记录并释放对应状态根的引用
+func (p *pruner) release(key, root []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	//裁剪和区块回滚可能同时释放同一个高度
+	if _, err := p.db.Get(key); err != nil {
+		return 0, nil
+	}
+	batch := p.newBatch()
+	batch.Delete(key)
+	deleted, err := mpt.ReleaseRoot(p.db, batch, common.BytesToHash(root))
+	if err != nil {
+		return 0, err
+	}
+	return deleted, batch.Write()
+}
+
+// del 区块回滚时释放该高度的状态根
+func (p *pruner) del(root []byte, height int64) error {
+	_, err := p.release(rootKey(height, root), root)
+	return err
+}
+
+func (p *pruner) pin(root []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.db.Get(pinKey(root)); err == nil {
+		return nil
+	}
+	batch := p.newBatch()
+	batch.Set(pinKey(root), []byte{})
+	err := mpt.ReferenceRoot(p.db, batch, common.BytesToHash(root))
+	if err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (p *pruner) unpin(root []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.db.Get(pinKey(root)); err != nil {
+		return types.ErrNotFound
+	}
+	batch := p.newBatch()
+	batch.Delete(pinKey(root))
+	_, err := mpt.ReleaseRoot(p.db, batch, common.BytesToHash(root))
+	if err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (p *pruner) pinnedRoots() [][]byte {
+	var roots [][]byte
+	it := p.db.Iterator(pinKeyPrefix, nil, false)
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		roots = append(roots, common.CopyBytes(it.Key()[len(pinKeyPrefix):]))
+	}
+	return roots
+}
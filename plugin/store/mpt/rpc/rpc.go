@@ -0,0 +1,55 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"encoding/json"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+	mty "github.com/33cn/plugin/plugin/store/mpt/types"
+)
+
+// getStateProof 通过store模块获取一组key在状态根下的值和证明, 其他存储返回ErrActionNotSupport
+func (c *channelClient) getStateProof(req *mty.ReqStateProof) (*mpt.StateProof, error) {
+	stateHash, err := common.FromHex(req.StateHash)
+	if err != nil || len(stateHash) == 0 || len(req.Keys) == 0 {
+		return nil, types.ErrInvalidParam
+	}
+	keys := make([][]byte, len(req.Keys))
+	for i, key := range req.Keys {
+		keys[i] = []byte(key)
+	}
+	msg := c.client.NewMessage("store", mty.EventStoreGetProof, &types.StoreGet{StateHash: stateHash, Keys: keys})
+	err = c.client.Send(msg, true)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Wait(msg)
+	if err != nil {
+		return nil, err
+	}
+	proof, ok := resp.GetData().(*mpt.StateProof)
+	if !ok {
+		return nil, types.ErrTypeAsset
+	}
+	return proof, nil
+}
+
+// GetStateProof mpt存储中一组key在状态根下的值和证明, 可以用区块头中的状态根验证
+func (c *Jrpc) GetStateProof(req *mty.ReqStateProof, result *interface{}) error {
+	proof, err := c.cli.getStateProof(req)
+	if err != nil {
+		return err
+	}
+	var jsonmsg json.RawMessage
+	jsonmsg, err = types.PBToJSON(proof)
+	if err != nil {
+		return err
+	}
+	*result = jsonmsg
+	return nil
+}
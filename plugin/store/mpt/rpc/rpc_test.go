@@ -0,0 +1,55 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+	mty "github.com/33cn/plugin/plugin/store/mpt/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStateProof(t *testing.T) {
+	q := queue.New("channel")
+	defer q.Close()
+	hash := common.Sha256([]byte("state"))
+	proof := &mpt.StateProof{StateHash: hash, Values: []*mpt.ProofValue{{Key: []byte("key"), Value: []byte("value")}}}
+	//模拟store模块, 其他存储不支持证明
+	go func() {
+		cli := q.Client()
+		cli.Sub("store")
+		for msg := range cli.Recv() {
+			if msg.Ty == mty.EventStoreGetProof {
+				msg.Reply(cli.NewMessage("", mty.EventStoreGetProofReply, proof))
+				continue
+			}
+			msg.ReplyErr("store", types.ErrActionNotSupport)
+		}
+	}()
+	jrpc := &Jrpc{cli: &channelClient{client: q.Client()}}
+
+	req := &mty.ReqStateProof{StateHash: common.ToHex(hash), Keys: []string{"key"}}
+	reply, err := jrpc.cli.getStateProof(req)
+	require.Nil(t, err)
+	assert.Equal(t, proof, reply)
+	var result interface{}
+	err = jrpc.GetStateProof(req, &result)
+	require.Nil(t, err)
+	//字节按照十六进制编码
+	var decoded mpt.StateProof
+	require.Nil(t, types.JSONToPB(result.(json.RawMessage), &decoded))
+	assert.Equal(t, proof.Values[0].Value, decoded.Values[0].Value)
+
+	_, err = jrpc.cli.getStateProof(&mty.ReqStateProof{StateHash: "0xzz", Keys: []string{"key"}})
+	assert.Equal(t, types.ErrInvalidParam, err)
+	_, err = jrpc.cli.getStateProof(&mty.ReqStateProof{StateHash: common.ToHex(hash)})
+	assert.Equal(t, types.ErrInvalidParam, err)
+}
@@ -0,0 +1,27 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/rpc/types"
+)
+
+// Jrpc mpt jrpc interface
+type Jrpc struct {
+	cli *channelClient
+}
+
+type channelClient struct {
+	types.ChannelClient
+	//发送chain33接口没有包括的消息
+	client queue.Client
+}
+
+// Init mpt rpc register, 证明中的字节按十六进制编码, 只提供jrpc接口
+func Init(name string, s types.RPCServer) {
+	cli := &channelClient{client: s.GetQueueClient()}
+	cli.Init(name, s, &Jrpc{cli: cli}, nil)
+}
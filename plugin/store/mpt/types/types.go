@@ -0,0 +1,22 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+// MptX mpt存储的插件名, 只注册rpc和命令行
+const MptX = "mpt"
+
+// store模块把chain33没有定义的消息交给存储的ProcEvent, 下面的消息只由mpt存储处理
+const (
+	// EventStoreGetProof 获取一组key在状态根下的值和证明, 请求为types.StoreGet, 回复为db.StateProof
+	EventStoreGetProof = 2001 + iota
+	// EventStoreGetProofReply 状态证明的回复
+	EventStoreGetProofReply
+)
+
+// ReqStateProof 获取一组key在状态根下的值和证明, StateHash为十六进制
+type ReqStateProof struct {
+	StateHash string   `json:"stateHash"`
+	Keys      []string `json:"keys"`
+}
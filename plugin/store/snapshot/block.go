@@ -0,0 +1,125 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/33cn/chain33/blockchain"
+	"github.com/33cn/chain33/common/difficulty"
+	"github.com/33cn/chain33/common/version"
+	"github.com/33cn/chain33/types"
+)
+
+// BlockCount 快照中保存的区块数量, 节点启动时需要从数据库加载最新的InitBlockNum个区块头
+var BlockCount = blockchain.InitBlockNum + 1
+
+var (
+	// ErrBlockStateHash 快照高度的区块和快照的状态根不一致
+	ErrBlockStateHash = errors.New("ErrSnapshotBlockStateHash")
+	// ErrBlocks 快照中的区块不连续或者数量不足
+	ErrBlocks = errors.New("ErrSnapshotBlocks")
+	// ErrChainNotEmpty 区块只能导入到空的区块链数据库中
+	ErrChainNotEmpty = errors.New("ErrSnapshotChainNotEmpty")
+	// ErrBlockSequence 记录区块序列号的节点和平行链需要从0高度同步区块
+	ErrBlockSequence = errors.New("ErrSnapshotBlockSequence")
+)
+
+// LoadBlocks 从区块链数据库读取快照高度及之前的BlockCount个区块和快照高度的总难度
+func LoadBlocks(cfg *types.Chain33Config, store *blockchain.BlockStore) BlockLoader {
+	return func(header *Header) error {
+		start := header.Height - BlockCount + 1
+		if start < 0 {
+			start = 0
+		}
+		header.Blocks = nil
+		for height := start; height <= header.Height; height++ {
+			block, err := store.LoadBlockByHeight(height)
+			if err != nil {
+				return err
+			}
+			header.Blocks = append(header.Blocks, block)
+		}
+		tip := header.Blocks[len(header.Blocks)-1].GetBlock()
+		if !bytes.Equal(tip.GetStateHash(), header.StateHash) {
+			return ErrBlockStateHash
+		}
+		td, err := store.GetTdByBlockHash(tip.Hash(cfg))
+		if err != nil {
+			return err
+		}
+		header.TotalDifficulty = td.Bytes()
+		return nil
+	}
+}
+
+// CheckBlocks 检查快照中的区块从父区块开始连续, 并且最后一个区块是快照高度的区块
+func CheckBlocks(cfg *types.Chain33Config, header *Header) error {
+	count := header.Height + 1
+	if count > BlockCount {
+		count = BlockCount
+	}
+	if int64(len(header.Blocks)) != count || len(header.TotalDifficulty) == 0 {
+		return ErrBlocks
+	}
+	var parent []byte
+	for i, detail := range header.Blocks {
+		block := detail.GetBlock()
+		if block == nil || block.Height != header.Height-count+1+int64(i) {
+			return ErrBlocks
+		}
+		if parent != nil && !bytes.Equal(block.ParentHash, parent) {
+			return ErrBlocks
+		}
+		parent = block.Hash(cfg)
+	}
+	if !bytes.Equal(header.Blocks[count-1].GetBlock().GetStateHash(), header.StateHash) {
+		return ErrBlockStateHash
+	}
+	return nil
+}
+
+// SaveBlocks 把快照中的区块写入空的区块链数据库, 快照高度的区块作为最新区块,
+// 节点启动后从下一个高度开始同步. 只写入区块和总难度, 快照高度及之前的交易无法查询
+func SaveBlocks(cfg *types.Chain33Config, store *blockchain.BlockStore, header *Header) error {
+	mcfg := cfg.GetModuleConfig().BlockChain
+	if mcfg.IsRecordBlockSequence || mcfg.IsParaChain {
+		return ErrBlockSequence
+	}
+	if store.Height() != -1 {
+		return ErrChainNotEmpty
+	}
+	if err := CheckBlocks(cfg, header); err != nil {
+		return err
+	}
+	//从快照高度的总难度倒推之前每个区块的总难度
+	tds := make([]*big.Int, len(header.Blocks))
+	td := new(big.Int).SetBytes(header.TotalDifficulty)
+	for i := len(header.Blocks) - 1; i >= 0; i-- {
+		tds[i] = td
+		td = new(big.Int).Sub(td, difficulty.CalcWork(header.Blocks[i].GetBlock().Difficulty))
+	}
+	//所有区块在一个batch中写入, 最新高度为最后写入的区块
+	batch := store.NewBatch(true)
+	for i, block := range header.Blocks {
+		if _, err := store.SaveBlock(batch, block, -1); err != nil {
+			return err
+		}
+		if err := store.SaveTdByBlockHash(batch, block.GetBlock().Hash(cfg), tds[i]); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	//和新节点一致, 标记数据库已经是最新版本, 避免启动时从0高度重建索引和重新执行区块
+	err := store.SetUpgradeMeta(&types.UpgradeMeta{Version: version.GetLocalDBVersion()})
+	if err != nil {
+		return err
+	}
+	return store.SetStoreUpgradeMeta(&types.UpgradeMeta{Version: version.GetStoreDBVersion()})
+}
@@ -0,0 +1,100 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/33cn/chain33/blockchain"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestChain(cfg *types.Chain33Config) (*blockchain.BlockChain, func()) {
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	chain := blockchain.New(cfg)
+	chain.SetQueueClient(q.Client())
+	return chain, func() {
+		chain.Close()
+		q.Close()
+	}
+}
+
+func newTestChainConfig(dir string) *types.Chain33Config {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	mcfg := cfg.GetModuleConfig().BlockChain
+	mcfg.DbPath = dir
+	mcfg.IsRecordBlockSequence = false
+	return cfg
+}
+
+func TestSaveLoadBlocks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cfg := newTestChainConfig(dir)
+
+	var blocks []*types.BlockDetail
+	var parent []byte
+	for height := int64(0); height < 4; height++ {
+		block := &types.Block{
+			ParentHash: parent,
+			Height:     height,
+			StateHash:  []byte(fmt.Sprintf("hash%d", height)),
+			BlockTime:  height + 1,
+			Difficulty: cfg.GetP(height).PowLimitBits,
+		}
+		blocks = append(blocks, &types.BlockDetail{Block: block})
+		parent = block.Hash(cfg)
+	}
+	header := &Header{StateHash: []byte("hash3"), Height: 3, TotalDifficulty: []byte{0x10, 0x00}, Blocks: blocks}
+	require.NoError(t, CheckBlocks(cfg, header))
+
+	//区块不连续或者和状态根不一致
+	bad := *header
+	bad.Blocks = blocks[1:]
+	require.Equal(t, ErrBlocks, CheckBlocks(cfg, &bad))
+	bad.Blocks = []*types.BlockDetail{blocks[0], blocks[2], blocks[1], blocks[3]}
+	require.Equal(t, ErrBlocks, CheckBlocks(cfg, &bad))
+	bad = *header
+	bad.StateHash = []byte("hash2")
+	require.Equal(t, ErrBlockStateHash, CheckBlocks(cfg, &bad))
+
+	chain, closeChain := newTestChain(cfg)
+	require.NoError(t, SaveBlocks(cfg, chain.GetStore(), header))
+	closeChain()
+
+	//重新启动后最新区块是快照高度的区块
+	chain, closeChain = newTestChain(cfg)
+	defer closeChain()
+	store := chain.GetStore()
+	require.Equal(t, int64(3), chain.GetBlockHeight())
+	require.Equal(t, parent, store.LastHeader().Hash)
+	require.Equal(t, ErrChainNotEmpty, SaveBlocks(cfg, store, header))
+
+	//导出时读取的区块和总难度与导入的一致
+	loaded := &Header{StateHash: []byte("hash3"), Height: 3}
+	require.NoError(t, LoadBlocks(cfg, store)(loaded))
+	require.Equal(t, header.TotalDifficulty, loaded.TotalDifficulty)
+	require.Equal(t, len(blocks), len(loaded.Blocks))
+	for i := range blocks {
+		require.Equal(t, blocks[i].Block.Hash(cfg), loaded.Blocks[i].Block.Hash(cfg))
+	}
+	loaded = &Header{StateHash: []byte("hash3"), Height: 2}
+	require.Equal(t, ErrBlockStateHash, LoadBlocks(cfg, store)(loaded))
+
+	//头部中的区块随快照一起读写
+	data := writeSnapshot(t, header, nil)
+	h, _, err := readSnapshot(data)
+	require.NoError(t, err)
+	require.NoError(t, CheckBlocks(cfg, h))
+	require.True(t, bytes.Equal(parent, h.Blocks[3].Block.Hash(cfg)))
+}
@@ -0,0 +1,131 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+)
+
+var (
+	//同common/db中的mvcc相关的定义保持一致
+	mvccPrefix = []byte(".-mvcc-.")
+	mvccData   = append(mvccPrefix, []byte("d.")...)
+	mvccLast   = append(mvccPrefix, []byte("l.")...)
+)
+
+// 版本号固定为20位十进制数字
+const versionLen = 20
+
+// ResolveVersion 获取状态根对应的mvcc版本, 状态根为空时使用最新的版本
+func ResolveVersion(mvcc dbm.MVCC, stateHash []byte) ([]byte, int64, error) {
+	if len(stateHash) == 0 {
+		version, err := mvcc.GetMaxVersion()
+		if err != nil {
+			return nil, 0, err
+		}
+		stateHash, err = mvcc.GetVersionHash(version)
+		if err != nil {
+			return nil, 0, err
+		}
+		return stateHash, version, nil
+	}
+	version, err := mvcc.GetVersion(stateHash)
+	if err != nil {
+		return nil, 0, err
+	}
+	return stateHash, version, nil
+}
+
+// ExportMVCC 按照mvcc的版本索引导出version版本下所有的kv, 每个key取不超过version的最新值
+func ExportMVCC(db dbm.DB, mvcc dbm.MVCC, version int64, w *Writer) error {
+	it := db.Iterator(mvccData, nil, false)
+	defer it.Close()
+	var last []byte
+	for it.Rewind(); it.Valid(); it.Next() {
+		key, ok := cutDataKey(it.Key())
+		if !ok {
+			continue
+		}
+		//同一个key的多个版本相邻, 只需要处理一次
+		if last != nil && bytes.Equal(key, last) {
+			continue
+		}
+		last = append(last[:0], key...)
+		value, err := mvcc.GetV(key, version)
+		if err == types.ErrNotFound || err == types.ErrVersion {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := w.Add(key, value); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// cutDataKey 从 mvccData + key + "." + version 中取出key
+func cutDataKey(dataKey []byte) ([]byte, bool) {
+	if len(dataKey) < len(mvccData)+1+versionLen {
+		return nil, false
+	}
+	if dataKey[len(dataKey)-versionLen-1] != '.' {
+		return nil, false
+	}
+	if _, err := strconv.ParseInt(string(dataKey[len(dataKey)-versionLen:]), 10, 64); err != nil {
+		return nil, false
+	}
+	return dataKey[len(mvccData) : len(dataKey)-versionLen-1], true
+}
+
+// ImportMVCC 把快照中的kv写入快照高度对应的mvcc版本, 之后可以从下一个高度继续执行区块
+// 版本信息最后写入, 中途失败时存储中没有版本信息, 可以重新导入
+func ImportMVCC(db dbm.DB, mvcc dbm.MVCC, withLast bool, r *Reader) (uint64, error) {
+	if _, err := mvcc.GetMaxVersion(); err != types.ErrNotFound {
+		return 0, ErrStoreNotEmpty
+	}
+	header := r.Header()
+	var count uint64
+	for {
+		kvs, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return count, err
+		}
+		batch := db.NewBatch(false)
+		for _, kv := range kvs {
+			save, err := mvcc.GetSaveKV(kv.Key, kv.Value, header.Height)
+			if err != nil {
+				return count, err
+			}
+			batch.Set(save.Key, save.Value)
+			//开启mvcc迭代时需要同时写入最新值
+			if withLast {
+				batch.Set(append(append([]byte{}, mvccLast...), kv.Key...), kv.Value)
+			}
+		}
+		if err := batch.Write(); err != nil {
+			return count, err
+		}
+		count += uint64(len(kvs))
+	}
+	versions, err := mvcc.SetVersionKV(header.StateHash, header.Height)
+	if err != nil {
+		return count, err
+	}
+	batch := db.NewBatch(true)
+	for _, kv := range versions {
+		batch.Set(kv.Key, kv.Value)
+	}
+	return count, batch.Write()
+}
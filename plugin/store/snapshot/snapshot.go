@@ -0,0 +1,331 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package snapshot 状态快照文件, 用于新节点直接从某个高度的状态开始同步
+//
+// 文件格式:
+//
+//	头部: magic | 状态根长度(uvarint) | 状态根 | 高度(int64) | 总难度长度(uvarint) | 总难度 |
+//	      区块数量(uvarint) | 每个区块: 数据长度(uvarint) | types.BlockDetail
+//	分片: 数据长度(uint32) | types.LocalDBSet编码的kv列表 | 数据的sha256
+//	结尾: 0(uint32) | kv总数(uint64) | 头部和所有分片hash的sha256
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+)
+
+const (
+	// 每个分片最多包含的数据大小
+	chunkSize = 4 * 1024 * 1024
+	// 读取时允许的最大分片, 防止错误的文件导致分配过大的内存
+	maxChunkSize = 64 * 1024 * 1024
+	// 状态根和总难度的最大长度
+	maxHashSize = 64
+	// 头部中允许的最大区块数量
+	maxBlocks = 1 << 16
+)
+
+var magic = []byte("c33snap1")
+
+var (
+	// ErrBadMagic 不是快照文件
+	ErrBadMagic = errors.New("ErrSnapshotBadMagic")
+	// ErrChunkTooLarge 分片超过最大长度
+	ErrChunkTooLarge = errors.New("ErrSnapshotChunkTooLarge")
+	// ErrChunkHash 分片数据和hash不一致
+	ErrChunkHash = errors.New("ErrSnapshotChunkHash")
+	// ErrSnapshotHash 快照的kv总数或者总hash不一致
+	ErrSnapshotHash = errors.New("ErrSnapshotHash")
+	// ErrStoreNotEmpty 只能导入到空的存储中
+	ErrStoreNotEmpty = errors.New("ErrSnapshotStoreNotEmpty")
+)
+
+// Header 快照对应的状态根和高度
+type Header struct {
+	StateHash []byte
+	Height    int64
+	// 快照高度的区块总难度和该高度及之前的区块, 导入时写入区块链数据库,
+	// 只迁移状态时为空
+	TotalDifficulty []byte
+	Blocks          []*types.BlockDetail
+}
+
+// BlockLoader 导出时在写入头部之前填充头部中的区块
+type BlockLoader func(header *Header) error
+
+// Writer 把kv按分片写入快照文件
+type Writer struct {
+	w      *bufio.Writer
+	kvs    []*types.KeyValue
+	size   int
+	count  uint64
+	digest hash.Hash
+}
+
+// NewWriter 写入快照头部, 头部同时计入结尾的hash
+func NewWriter(w io.Writer, header *Header) (*Writer, error) {
+	bw := bufio.NewWriter(w)
+	digest := sha256.New()
+	if err := writeHeader(io.MultiWriter(bw, digest), header); err != nil {
+		return nil, err
+	}
+	return &Writer{w: bw, digest: digest}, nil
+}
+
+func writeHeader(w io.Writer, header *Header) error {
+	if _, err := w.Write(magic); err != nil {
+		return err
+	}
+	if err := writeBytes(w, header.StateHash); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, header.Height); err != nil {
+		return err
+	}
+	if err := writeBytes(w, header.TotalDifficulty); err != nil {
+		return err
+	}
+	if err := writeUvarint(w, uint64(len(header.Blocks))); err != nil {
+		return err
+	}
+	for _, block := range header.Blocks {
+		if err := writeBytes(w, types.Encode(block)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeUvarint(w io.Writer, x uint64) error {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, x)
+	_, err := w.Write(buf[:n])
+	return err
+}
+
+func writeBytes(w io.Writer, data []byte) error {
+	if err := writeUvarint(w, uint64(len(data))); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// Add 添加一个kv, 累计到分片大小时写入文件
+func (w *Writer) Add(key, value []byte) error {
+	w.kvs = append(w.kvs, &types.KeyValue{Key: common.CopyBytes(key), Value: common.CopyBytes(value)})
+	w.size += len(key) + len(value)
+	w.count++
+	if w.size >= chunkSize {
+		return w.flush()
+	}
+	return nil
+}
+
+// Count 已经写入的kv数量
+func (w *Writer) Count() uint64 {
+	return w.count
+}
+
+func (w *Writer) flush() error {
+	if len(w.kvs) == 0 {
+		return nil
+	}
+	data := types.Encode(&types.LocalDBSet{KV: w.kvs})
+	sum := sha256.Sum256(data)
+	if err := binary.Write(w.w, binary.BigEndian, uint32(len(data))); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(data); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(sum[:]); err != nil {
+		return err
+	}
+	w.digest.Write(sum[:])
+	w.kvs = nil
+	w.size = 0
+	return nil
+}
+
+// Close 写入最后一个分片和结尾, 不关闭底层的io.Writer
+func (w *Writer) Close() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+	if err := binary.Write(w.w, binary.BigEndian, uint32(0)); err != nil {
+		return err
+	}
+	if err := binary.Write(w.w, binary.BigEndian, w.count); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(w.digest.Sum(nil)); err != nil {
+		return err
+	}
+	return w.w.Flush()
+}
+
+// Reader 按分片读取快照文件, 每个分片读取时校验hash
+type Reader struct {
+	r      *bufio.Reader
+	header *Header
+	count  uint64
+	digest hash.Hash
+	done   bool
+}
+
+// NewReader 读取并检查快照头部, 头部是否被修改在读到结尾时校验
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	digest := sha256.New()
+	header, err := readHeader(&teeReader{r: br, digest: digest})
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{r: br, header: header, digest: digest}, nil
+}
+
+// teeReader 读取头部时同时计算hash
+type teeReader struct {
+	r      *bufio.Reader
+	digest hash.Hash
+}
+
+func (t *teeReader) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	t.digest.Write(p[:n])
+	return n, err
+}
+
+func (t *teeReader) ReadByte() (byte, error) {
+	b, err := t.r.ReadByte()
+	if err == nil {
+		t.digest.Write([]byte{b})
+	}
+	return b, err
+}
+
+func readHeader(r *teeReader) (*Header, error) {
+	buf := make([]byte, len(magic))
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(buf, magic) {
+		return nil, ErrBadMagic
+	}
+	header := &Header{}
+	var err error
+	if header.StateHash, err = readBytes(r, maxHashSize); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.BigEndian, &header.Height); err != nil {
+		return nil, err
+	}
+	if header.TotalDifficulty, err = readBytes(r, maxHashSize); err != nil {
+		return nil, err
+	}
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if count > maxBlocks {
+		return nil, ErrBadMagic
+	}
+	for i := uint64(0); i < count; i++ {
+		data, err := readBytes(r, maxChunkSize)
+		if err != nil {
+			return nil, err
+		}
+		var block types.BlockDetail
+		if err := types.Decode(data, &block); err != nil {
+			return nil, err
+		}
+		header.Blocks = append(header.Blocks, &block)
+	}
+	return header, nil
+}
+
+func readBytes(r *teeReader, max uint64) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if size > max {
+		return nil, ErrBadMagic
+	}
+	if size == 0 {
+		return nil, nil
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Header 快照的状态根和高度
+func (r *Reader) Header() *Header {
+	return r.header
+}
+
+// Next 读取下一个分片, 读到结尾并且校验通过之后返回io.EOF
+func (r *Reader) Next() ([]*types.KeyValue, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+	var size uint32
+	if err := binary.Read(r.r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, r.finish()
+	}
+	if size > maxChunkSize {
+		return nil, ErrChunkTooLarge
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return nil, err
+	}
+	var sum [sha256.Size]byte
+	if _, err := io.ReadFull(r.r, sum[:]); err != nil {
+		return nil, err
+	}
+	if sha256.Sum256(data) != sum {
+		return nil, ErrChunkHash
+	}
+	var kvs types.LocalDBSet
+	if err := types.Decode(data, &kvs); err != nil {
+		return nil, err
+	}
+	r.digest.Write(sum[:])
+	r.count += uint64(len(kvs.KV))
+	return kvs.KV, nil
+}
+
+func (r *Reader) finish() error {
+	var count uint64
+	if err := binary.Read(r.r, binary.BigEndian, &count); err != nil {
+		return err
+	}
+	sum := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(r.r, sum); err != nil {
+		return err
+	}
+	if count != r.count || !bytes.Equal(sum, r.digest.Sum(nil)) {
+		return ErrSnapshotHash
+	}
+	r.done = true
+	return io.EOF
+}
@@ -0,0 +1,176 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"testing"
+
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSnapshot(t *testing.T, header *Header, kvs []*types.KeyValue) []byte {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, header)
+	require.NoError(t, err)
+	for _, kv := range kvs {
+		require.NoError(t, w.Add(kv.Key, kv.Value))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func readSnapshot(data []byte) (*Header, []*types.KeyValue, error) {
+	r, err := NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	var kvs []*types.KeyValue
+	for {
+		chunk, err := r.Next()
+		if err == io.EOF {
+			return r.Header(), kvs, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		kvs = append(kvs, chunk...)
+	}
+}
+
+func TestWriterReader(t *testing.T) {
+	header := &Header{StateHash: []byte("statehash"), Height: 10}
+	var kvs []*types.KeyValue
+	for i := 0; i < 100; i++ {
+		kvs = append(kvs, &types.KeyValue{Key: []byte(fmt.Sprintf("k%03d", i)), Value: []byte(fmt.Sprintf("v%d", i))})
+	}
+	data := writeSnapshot(t, header, kvs)
+
+	h, read, err := readSnapshot(data)
+	require.NoError(t, err)
+	require.Equal(t, header, h)
+	require.Equal(t, len(kvs), len(read))
+	for i := range kvs {
+		require.Equal(t, kvs[i].Key, read[i].Key)
+		require.Equal(t, kvs[i].Value, read[i].Value)
+	}
+
+	//空快照
+	h, read, err = readSnapshot(writeSnapshot(t, header, nil))
+	require.NoError(t, err)
+	require.Equal(t, header, h)
+	require.Empty(t, read)
+
+	//修改分片中的数据
+	bad := append([]byte{}, data...)
+	bad[len(bad)-sha256.Size-8-4-sha256.Size-1] ^= 0xff
+	_, _, err = readSnapshot(bad)
+	require.Equal(t, ErrChunkHash, err)
+
+	//修改结尾的kv数量
+	bad = append([]byte{}, data...)
+	bad[len(bad)-sha256.Size-1] ^= 0xff
+	_, _, err = readSnapshot(bad)
+	require.Equal(t, ErrSnapshotHash, err)
+
+	//修改头部中的高度, 读到结尾时校验失败
+	bad = append([]byte{}, data...)
+	bad[len(magic)+1+len(header.StateHash)+7] ^= 0xff
+	_, _, err = readSnapshot(bad)
+	require.Equal(t, ErrSnapshotHash, err)
+
+	//截断的文件
+	_, _, err = readSnapshot(data[:len(data)/2])
+	require.Equal(t, io.ErrUnexpectedEOF, err)
+
+	bad = append([]byte{}, data...)
+	bad[0] = 'x'
+	_, _, err = readSnapshot(bad)
+	require.Equal(t, ErrBadMagic, err)
+}
+
+func TestExportImportMVCC(t *testing.T) {
+	db := dbm.NewDB("snapshot", "memdb", "", 100)
+	mvcc := dbm.NewMVCC(db)
+	var prev []byte
+	//k1每个高度都修改, k2只在高度0设置, k3在高度3才设置
+	for height := int64(0); height < 4; height++ {
+		kvs := []*types.KeyValue{{Key: []byte("k1"), Value: []byte(fmt.Sprintf("v1-%d", height))}}
+		if height == 0 {
+			kvs = append(kvs, &types.KeyValue{Key: []byte("k2"), Value: []byte("v2")})
+		}
+		if height == 3 {
+			kvs = append(kvs, &types.KeyValue{Key: []byte("k3"), Value: []byte("v3")})
+		}
+		hash := []byte(fmt.Sprintf("hash%d", height))
+		saved, err := mvcc.AddMVCC(kvs, hash, prev, height)
+		require.NoError(t, err)
+		batch := db.NewBatch(true)
+		for _, kv := range saved {
+			batch.Set(kv.Key, kv.Value)
+		}
+		require.NoError(t, batch.Write())
+		prev = hash
+	}
+
+	hash, version, err := ResolveVersion(mvcc, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hash3"), hash)
+	require.Equal(t, int64(3), version)
+
+	hash, version, err = ResolveVersion(mvcc, []byte("hash2"))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), version)
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, &Header{StateHash: hash, Height: version})
+	require.NoError(t, err)
+	require.NoError(t, ExportMVCC(db, mvcc, version, w))
+	require.NoError(t, w.Close())
+	require.Equal(t, uint64(2), w.Count())
+
+	_, kvs, err := readSnapshot(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []*types.KeyValue{
+		{Key: []byte("k1"), Value: []byte("v1-2")},
+		{Key: []byte("k2"), Value: []byte("v2")},
+	}, kvs)
+
+	newdb := dbm.NewDB("snapshot", "memdb", "", 100)
+	newmvcc := dbm.NewMVCCIter(newdb)
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	count, err := ImportMVCC(newdb, newmvcc, true, r)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), count)
+
+	version, err = newmvcc.GetVersion([]byte("hash2"))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), version)
+	value, err := newmvcc.GetV([]byte("k1"), version)
+	require.NoError(t, err)
+	require.Equal(t, []byte("v1-2"), value)
+	value, err = newmvcc.GetV([]byte("k2"), version)
+	require.NoError(t, err)
+	require.Equal(t, []byte("v2"), value)
+	value, err = newdb.Get(append(append([]byte{}, mvccLast...), []byte("k2")...))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v2"), value)
+
+	//只能导入到空的存储
+	r, err = NewReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	_, err = ImportMVCC(newdb, newmvcc, true, r)
+	require.Equal(t, ErrStoreNotEmpty, err)
+
+	//可以从下一个高度继续写入
+	_, err = newmvcc.AddMVCC([]*types.KeyValue{{Key: []byte("k1"), Value: []byte("v1-3")}}, []byte("hash3"), []byte("hash2"), 3)
+	require.NoError(t, err)
+}
@@ -0,0 +1,76 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// chain33-migrate 把状态从一个store驱动迁移到另一个store驱动并逐个key校验, 需要在节点停止时运行
+//
+//	chain33-migrate -from chain33.toml -to chain33.mpt.toml [-hash 0x...] [-height n]
+//
+// 源驱动为mpt, kvdb时需要指定状态根和高度
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/33cn/chain33/common"
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/migrate"
+
+	_ "github.com/33cn/plugin/plugin/store/init"
+)
+
+type store interface {
+	migrate.Store
+	Close()
+}
+
+func main() {
+	from := flag.String("from", "chain33.toml", "config file of the source store")
+	to := flag.String("to", "", "config file of the target store")
+	hash := flag.String("hash", "", "state hash to migrate, the latest state of kvmvcc and kvmvccmavl is used when empty")
+	height := flag.Int64("height", 0, "height of the state hash, required when the source is mpt or kvdb")
+	flag.Parse()
+
+	if *to == "" {
+		log.Fatalf("Usage: chain33-migrate -from chain33.toml -to target.toml [-hash statehash] [-height height]")
+	}
+	opts := &migrate.Options{Height: *height}
+	if *hash != "" {
+		var err error
+		opts.StateHash, err = common.FromHex(*hash)
+		if err != nil {
+			log.Fatalf("invalid hash %s: %v", *hash, err)
+		}
+	}
+
+	src, name := loadStore(*from)
+	defer src.Close()
+	opts.From = name
+	dst, name := loadStore(*to)
+	defer dst.Close()
+	opts.To = name
+
+	result, err := migrate.Migrate(src, dst, opts)
+	if err != nil {
+		log.Fatalf("migrate from %s to %s failed: %v", opts.From, opts.To, err)
+	}
+	fmt.Printf("Migrated %d kvs of state %s at height %d from %s to %s, new state hash %s.\n", result.Count,
+		common.ToHex(result.StateHash), result.Height, opts.From, opts.To, common.ToHex(result.NewStateHash))
+}
+
+func loadStore(configPath string) (store, string) {
+	cfg := types.NewChain33Config(types.ReadFile(configPath))
+	mcfg := cfg.GetModuleConfig().Store
+	create, err := drivers.Load(mcfg.Name)
+	if err != nil {
+		log.Fatalf("Unsupported store type %s: %v", mcfg.Name, err)
+	}
+	s, ok := create(mcfg, cfg.GetSubConfig().Store[mcfg.Name], cfg).(store)
+	if !ok {
+		log.Fatalf("store %s does not support migration", mcfg.Name)
+	}
+	return s, mcfg.Name
+}
@@ -0,0 +1,157 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// chain33-snapshot 导出和导入kvmvcc, kvmvccmavl存储的状态快照, 需要在节点停止时运行
+//
+// 快照中同时包含快照高度及之前的区块, 导入时写入空的区块链数据库, 节点启动后从下一个高度开始同步
+//
+//	chain33-snapshot -f chain33.toml [-hash 0x...] export snapshot.dat
+//	chain33-snapshot -f chain33.toml import snapshot.dat
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/33cn/chain33/blockchain"
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/queue"
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot"
+
+	_ "github.com/33cn/plugin/plugin/store/init"
+)
+
+type exporter interface {
+	ExportSnapshot(statehash []byte, w io.Writer, blocks snapshot.BlockLoader) (*snapshot.Header, error)
+}
+
+type importer interface {
+	ImportSnapshot(r io.Reader) (*snapshot.Header, error)
+}
+
+func main() {
+	configPath := flag.String("f", "chain33.toml", "config file")
+	hash := flag.String("hash", "", "state hash to export, the latest state is exported when empty")
+	flag.Parse()
+
+	if len(flag.Args()) != 2 {
+		log.Fatalf("Usage: chain33-snapshot [-f chain33.toml] [-hash statehash] export|import file")
+	}
+	cmd, file := flag.Arg(0), flag.Arg(1)
+
+	cfg := types.NewChain33Config(types.ReadFile(*configPath))
+	mcfg := cfg.GetModuleConfig().Store
+	create, err := drivers.Load(mcfg.Name)
+	if err != nil {
+		log.Fatalf("Unsupported store type %s: %v", mcfg.Name, err)
+	}
+	store := create(mcfg, cfg.GetSubConfig().Store[mcfg.Name], cfg)
+	defer store.Close()
+	chain, closeChain := openChain(cfg)
+	defer closeChain()
+
+	switch cmd {
+	case "export":
+		err = export(cfg, chain, store, *hash, file)
+	case "import":
+		err = load(cfg, chain, store, file)
+	default:
+		err = fmt.Errorf("unknown command %s", cmd)
+	}
+	if err != nil {
+		log.Fatalf("%s failed: %v", cmd, err)
+	}
+}
+
+// openChain 启动区块链模块, 通过其中的BlockStore读写区块链数据库
+func openChain(cfg *types.Chain33Config) (*blockchain.BlockChain, func()) {
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	chain := blockchain.New(cfg)
+	chain.SetQueueClient(q.Client())
+	return chain, func() {
+		chain.Close()
+		q.Close()
+	}
+}
+
+func export(cfg *types.Chain33Config, chain *blockchain.BlockChain, store queue.Module, hash string, file string) error {
+	s, ok := store.(exporter)
+	if !ok {
+		return fmt.Errorf("store does not support snapshot")
+	}
+	var statehash []byte
+	if hash != "" {
+		var err error
+		statehash, err = common.FromHex(hash)
+		if err != nil {
+			return err
+		}
+	} else {
+		//默认导出区块链最新区块的状态
+		last := chain.GetStore().LastBlock()
+		if last == nil {
+			return fmt.Errorf("blockchain is empty")
+		}
+		statehash = last.StateHash
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	header, err := s.ExportSnapshot(statehash, f, snapshot.LoadBlocks(cfg, chain.GetStore()))
+	if err != nil {
+		f.Close()
+		os.Remove(file)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	fmt.Printf("Exported state %s and %d blocks at height %d to %s.\n", common.ToHex(header.StateHash), len(header.Blocks), header.Height, file)
+	return f.Close()
+}
+
+// load 先导入状态, 读到结尾校验通过之后再写入区块,
+// 中途失败时需要清空数据目录重新导入
+func load(cfg *types.Chain33Config, chain *blockchain.BlockChain, store queue.Module, file string) error {
+	s, ok := store.(importer)
+	if !ok {
+		return fmt.Errorf("store does not support snapshot")
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	//导入状态之前先检查头部中的区块
+	r, err := snapshot.NewReader(f)
+	if err != nil {
+		return err
+	}
+	if err := snapshot.CheckBlocks(cfg, r.Header()); err != nil {
+		return err
+	}
+	if chain.GetStore().Height() != -1 {
+		return snapshot.ErrChainNotEmpty
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	header, err := s.ImportSnapshot(f)
+	if err != nil {
+		return err
+	}
+	if err := snapshot.SaveBlocks(cfg, chain.GetStore(), header); err != nil {
+		return err
+	}
+	fmt.Printf("Imported state %s and %d blocks at height %d, blocks can be synced from height %d.\n", common.ToHex(header.StateHash), len(header.Blocks), header.Height, header.Height+1)
+	return nil
+}